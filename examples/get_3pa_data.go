@@ -52,23 +52,26 @@ func main() {
 	fmt.Printf("Found %d players. Getting stats...\n", len(players))
 	fmt.Println()
 
+	var playerKeys []string
 	for i, player := range players {
 		if i >= 5 {
 			break
 		}
+		playerKeys = append(playerKeys, player.PlayerKey)
+	}
 
-		playerWithStats, err := client.GetPlayerStats(ctx, leagueKey, player.PlayerKey, 0)
-		if err != nil {
-			fmt.Printf("Error getting stats for %s: %v\n", player.Name.Full, err)
-			continue
-		}
+	playersWithStats, err := client.GetPlayersStats(ctx, leagueKey, playerKeys, 0)
+	if err != nil {
+		log.Fatalf("Error fetching player stats: %v", err)
+	}
 
-		if playerWithStats.PlayerStats == nil {
+	for _, player := range playersWithStats {
+		if player.PlayerStats == nil {
 			fmt.Printf("%s - No stats available\n", player.Name.Full)
 			continue
 		}
 
-		nbaStats, err := yahoo.ParseNBAStats(playerWithStats.PlayerStats.Stats)
+		nbaStats, err := yahoo.ParseNBAStats(player.PlayerStats.Stats)
 		if err != nil {
 			fmt.Printf("Error parsing stats for %s: %v\n", player.Name.Full, err)
 			continue
@@ -0,0 +1,228 @@
+// Package dialect lets the repositories, services and API cache run on
+// databases other than SQLite.
+//
+// Queries throughout the module are written for SQLite: ? placeholders,
+// booleans compared with 1, and CURRENT_TIMESTAMP for the current time,
+// which every supported database understands. A database opened with Open
+// rewrites placeholders, quotes reserved words and converts arguments for
+// its dialect as queries reach the driver, so code holding the *sql.DB
+// needs no changes. The few statements that cannot be written portably,
+// upserts and inserts that return the new row's ID, are built through the
+// Dialect For returns.
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Dialect covers the SQL differences between the supported databases.
+type Dialect interface {
+	// Name is the dialect's name, such as "postgres".
+	Name() string
+	// Rebind rewrites a query written for SQLite for the dialect: its
+	// placeholders and any identifiers the dialect needs quoted.
+	Rebind(query string) string
+	// Upsert returns an INSERT of columns into table that, when a row with
+	// the same key columns exists, sets the update columns to the new values
+	// instead, or leaves the row alone if update is empty. It uses ?
+	// placeholders.
+	Upsert(table string, columns, key, update []string) string
+	// InsertID runs an INSERT into a table with an id column and returns
+	// the new row's id.
+	InsertID(ctx context.Context, db Execer, query string, args ...interface{}) (int64, error)
+}
+
+// Execer is satisfied by both *sql.DB and *sql.Tx.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+var (
+	// SQLite is the dialect the module's queries are written in.
+	SQLite Dialect = sqlite{}
+	// Postgres uses $1-style placeholders and returns inserted IDs with
+	// RETURNING, since its drivers do not support LastInsertId. Boolean
+	// columns are INTEGER, as in SQLite, so Go bools are sent as 1 or 0.
+	Postgres Dialect = postgres{}
+	// MySQL covers MySQL and MariaDB. It keeps ? placeholders, quotes
+	// column names MySQL reserves with backticks, and upserts with ON
+	// DUPLICATE KEY UPDATE, which matches on any unique key rather than
+	// the key columns given.
+	MySQL Dialect = mysql{}
+)
+
+// mysqlReserved are the column names used by the module's queries that
+// MySQL reserves as keywords.
+var mysqlReserved = map[string]bool{
+	"rank": true,
+}
+
+// For returns the dialect of a database opened with Open, and SQLite for
+// any other database.
+func For(db *sql.DB) Dialect {
+	if db != nil {
+		if d, ok := db.Driver().(*rebindDriver); ok {
+			return d.dialect
+		}
+	}
+	return SQLite
+}
+
+type sqlite struct{}
+
+func (sqlite) Name() string { return "sqlite" }
+
+func (sqlite) Rebind(query string) string { return query }
+
+func (sqlite) Upsert(table string, columns, key, update []string) string {
+	return onConflictUpsert(table, columns, key, update)
+}
+
+func (sqlite) InsertID(ctx context.Context, db Execer, query string, args ...interface{}) (int64, error) {
+	return lastInsertID(ctx, db, query, args...)
+}
+
+type postgres struct{}
+
+func (postgres) Name() string { return "postgres" }
+
+// Rebind numbers the placeholders $1, $2 and so on, leaving question marks
+// inside quoted strings and identifiers alone.
+func (postgres) Rebind(query string) string {
+	if !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+		case ch == '?':
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteByte(ch)
+	}
+	return b.String()
+}
+
+func (postgres) Upsert(table string, columns, key, update []string) string {
+	return onConflictUpsert(table, columns, key, update)
+}
+
+func (postgres) InsertID(ctx context.Context, db Execer, query string, args ...interface{}) (int64, error) {
+	query = strings.TrimRight(strings.TrimSpace(query), ";") + " RETURNING id"
+	var id int64
+	err := db.QueryRowContext(ctx, query, args...).Scan(&id)
+	return id, err
+}
+
+type mysql struct{}
+
+func (mysql) Name() string { return "mysql" }
+
+// Rebind quotes reserved words used as column names with backticks. Words
+// followed by a parenthesis are function calls, such as RANK(), and are
+// left alone, as is anything inside quotes.
+func (mysql) Rebind(query string) string {
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+		case isIdentByte(ch) && (i == 0 || !isIdentByte(query[i-1])):
+			j := i
+			for j < len(query) && isIdentByte(query[j]) {
+				j++
+			}
+			word := query[i:j]
+			if mysqlReserved[strings.ToLower(word)] && !followedByParen(query[j:]) {
+				b.WriteString("`" + word + "`")
+			} else {
+				b.WriteString(word)
+			}
+			i = j - 1
+			continue
+		}
+		b.WriteByte(ch)
+	}
+	return b.String()
+}
+
+func isIdentByte(ch byte) bool {
+	return ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9'
+}
+
+func followedByParen(rest string) bool {
+	return strings.HasPrefix(strings.TrimLeft(rest, " \t\n"), "(")
+}
+
+// Upsert uses VALUES() rather than a row alias, which MariaDB lacks. With
+// nothing to update, the first key column is set to itself so that
+// duplicates are skipped without INSERT IGNORE hiding other errors.
+func (mysql) Upsert(table string, columns, key, update []string) string {
+	if len(update) == 0 {
+		return insertInto(table, columns) + fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s = %s", key[0], key[0])
+	}
+
+	sets := make([]string, len(update))
+	for i, col := range update {
+		sets[i] = fmt.Sprintf("%s = VALUES(%s)", col, col)
+	}
+	return insertInto(table, columns) + " ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+}
+
+func (mysql) InsertID(ctx context.Context, db Execer, query string, args ...interface{}) (int64, error) {
+	return lastInsertID(ctx, db, query, args...)
+}
+
+// onConflictUpsert builds the INSERT ... ON CONFLICT upsert SQLite and
+// Postgres share.
+func onConflictUpsert(table string, columns, key, update []string) string {
+	query := insertInto(table, columns) + " ON CONFLICT (" + strings.Join(key, ", ") + ")"
+	if len(update) == 0 {
+		return query + " DO NOTHING"
+	}
+
+	sets := make([]string, len(update))
+	for i, col := range update {
+		sets[i] = fmt.Sprintf("%s = excluded.%s", col, col)
+	}
+	return query + " DO UPDATE SET " + strings.Join(sets, ", ")
+}
+
+func insertInto(table string, columns []string) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), placeholders)
+}
+
+func lastInsertID(ctx context.Context, db Execer, query string, args ...interface{}) (int64, error) {
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
@@ -0,0 +1,179 @@
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestPostgresRebind(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT 1", "SELECT 1"},
+		{"SELECT * FROM t WHERE a = ? AND b = ?", "SELECT * FROM t WHERE a = $1 AND b = $2"},
+		{"SELECT '?' FROM t WHERE a = ?", "SELECT '?' FROM t WHERE a = $1"},
+		{`SELECT "a?" FROM t WHERE a = ? OR b = 'it''s?'`, `SELECT "a?" FROM t WHERE a = $1 OR b = 'it''s?'`},
+	}
+
+	for _, tt := range tests {
+		if got := Postgres.Rebind(tt.query); got != tt.want {
+			t.Errorf("Rebind(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+
+	if got := SQLite.Rebind("a = ?"); got != "a = ?" {
+		t.Errorf("SQLite should keep ? placeholders, got %q", got)
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	got := Postgres.Upsert("t", []string{"k", "a", "b"}, []string{"k"}, []string{"a", "b"})
+	want := "INSERT INTO t (k, a, b) VALUES (?, ?, ?) ON CONFLICT (k) DO UPDATE SET a = excluded.a, b = excluded.b"
+	if got != want {
+		t.Errorf("Upsert() = %q, want %q", got, want)
+	}
+
+	got = SQLite.Upsert("t", []string{"k"}, []string{"k"}, nil)
+	want = "INSERT INTO t (k) VALUES (?) ON CONFLICT (k) DO NOTHING"
+	if got != want {
+		t.Errorf("Upsert() = %q, want %q", got, want)
+	}
+}
+
+func TestMySQLRebind(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"SELECT rank FROM t WHERE a = ?", "SELECT `rank` FROM t WHERE a = ?"},
+		{"SELECT pr.rank, RANK() OVER (ORDER BY x) FROM power_rankings pr ORDER BY rank",
+			"SELECT pr.`rank`, RANK() OVER (ORDER BY x) FROM power_rankings pr ORDER BY `rank`"},
+		{"SELECT 'rank', `rank`, ranking FROM t", "SELECT 'rank', `rank`, ranking FROM t"},
+	}
+
+	for _, tt := range tests {
+		if got := MySQL.Rebind(tt.query); got != tt.want {
+			t.Errorf("Rebind(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestMySQLUpsert(t *testing.T) {
+	got := MySQL.Upsert("t", []string{"k", "a"}, []string{"k"}, []string{"a"})
+	want := "INSERT INTO t (k, a) VALUES (?, ?) ON DUPLICATE KEY UPDATE a = VALUES(a)"
+	if got != want {
+		t.Errorf("Upsert() = %q, want %q", got, want)
+	}
+
+	got = MySQL.Upsert("t", []string{"k"}, []string{"k"}, nil)
+	want = "INSERT INTO t (k) VALUES (?) ON DUPLICATE KEY UPDATE k = k"
+	if got != want {
+		t.Errorf("Upsert() = %q, want %q", got, want)
+	}
+}
+
+// TestOpen runs the Postgres dialect against SQLite, which accepts $1-style
+// placeholders and RETURNING, to check that queries are rewritten on their
+// way to the driver.
+func TestOpen(t *testing.T) {
+	db, err := Open(Postgres, "sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	defer db.Close()
+
+	if For(db) != Postgres {
+		t.Fatalf("For() = %s, want postgres", For(db).Name())
+	}
+
+	ctx := context.Background()
+	_, err = db.ExecContext(ctx, `CREATE TABLE t (id INTEGER PRIMARY KEY, name TEXT UNIQUE, active INTEGER)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := For(db)
+	id, err := d.InsertID(ctx, db, `INSERT INTO t (name, active) VALUES (?, ?)`, "a", true)
+	if err != nil {
+		t.Fatalf("InsertID() error = %v", err)
+	}
+	if id != 1 {
+		t.Errorf("InsertID() = %d, want 1", id)
+	}
+
+	upsert := d.Upsert("t", []string{"name", "active"}, []string{"name"}, []string{"active"})
+	if _, err := db.ExecContext(ctx, upsert, "a", false); err != nil {
+		t.Fatalf("upsert error = %v", err)
+	}
+
+	var active bool
+	var count int
+	err = db.QueryRowContext(ctx, `SELECT active, (SELECT COUNT(*) FROM t) FROM t WHERE name = ? AND active = ?`, "a", 0).Scan(&active, &count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if active || count != 1 {
+		t.Errorf("active, count = %v, %d; want the row updated in place", active, count)
+	}
+}
+
+// recordingDriver records the queries and arguments it is asked to run.
+type recordingDriver struct {
+	queries []string
+	args    [][]driver.Value
+}
+
+func (d *recordingDriver) Open(string) (driver.Conn, error) { return recordingConn{d}, nil }
+
+type recordingConn struct{ d *recordingDriver }
+
+func (c recordingConn) Prepare(query string) (driver.Stmt, error) {
+	c.d.queries = append(c.d.queries, query)
+	return recordingStmt{c.d}, nil
+}
+func (recordingConn) Close() error              { return nil }
+func (recordingConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+type recordingStmt struct{ d *recordingDriver }
+
+func (recordingStmt) Close() error  { return nil }
+func (recordingStmt) NumInput() int { return -1 }
+func (s recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.args = append(s.d.args, args)
+	return driver.RowsAffected(0), nil
+}
+func (recordingStmt) Query([]driver.Value) (driver.Rows, error) { return nil, driver.ErrSkip }
+
+func TestOpenRewritesQueries(t *testing.T) {
+	recorder := &recordingDriver{}
+	sql.Register("dialect-recording", recorder)
+
+	db, err := Open(Postgres, "dialect-recording", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(`UPDATE t SET a = ? WHERE b = ?`, true, "x"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(recorder.queries) != 1 || recorder.queries[0] != "UPDATE t SET a = $1 WHERE b = $2" {
+		t.Errorf("queries = %q", recorder.queries)
+	}
+	if len(recorder.args) != 1 || recorder.args[0][0] != int64(1) {
+		t.Errorf("Expected bools to be sent as integers, got %v", recorder.args)
+	}
+}
+
+func TestForUnwrapped(t *testing.T) {
+	if For(nil) != SQLite {
+		t.Errorf("For(nil) should default to SQLite")
+	}
+}
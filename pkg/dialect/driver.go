@@ -0,0 +1,172 @@
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+// Open opens a database with the registered driver driverName, such as
+// "pgx" or "postgres", whose queries are rewritten for d before they reach
+// the driver. The driver must already be registered, usually by a blank
+// import of its package.
+func Open(d Dialect, driverName, dsn string) (*sql.DB, error) {
+	probe, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	base := probe.Driver()
+	probe.Close()
+
+	wrapped := &rebindDriver{base: base, dialect: d}
+	var connector driver.Connector = dsnConnector{dsn: dsn, driver: base}
+	if dc, ok := base.(driver.DriverContext); ok {
+		if connector, err = dc.OpenConnector(dsn); err != nil {
+			return nil, err
+		}
+	}
+
+	return sql.OpenDB(&rebindConnector{base: connector, driver: wrapped}), nil
+}
+
+// rebindDriver wraps a driver so that its connections rewrite queries for
+// the dialect.
+type rebindDriver struct {
+	base    driver.Driver
+	dialect Dialect
+}
+
+func (d *rebindDriver) Open(name string) (driver.Conn, error) {
+	c, err := d.base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &rebindConn{Conn: c, dialect: d.dialect}, nil
+}
+
+type rebindConnector struct {
+	base   driver.Connector
+	driver *rebindDriver
+}
+
+func (c *rebindConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &rebindConn{Conn: conn, dialect: c.driver.dialect}, nil
+}
+
+func (c *rebindConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// dsnConnector connects drivers that do not implement driver.DriverContext.
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// rebindConn rewrites every query passed to the underlying connection and
+// forwards the optional driver interfaces it implements.
+type rebindConn struct {
+	driver.Conn
+	dialect Dialect
+}
+
+func (c *rebindConn) Prepare(query string) (driver.Stmt, error) {
+	return c.Conn.Prepare(c.dialect.Rebind(query))
+}
+
+func (c *rebindConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	query = c.dialect.Rebind(query)
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *rebindConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	if opts.Isolation != 0 || opts.ReadOnly {
+		return nil, errors.New("dialect: driver does not support transaction options")
+	}
+	return c.Conn.Begin()
+}
+
+func (c *rebindConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return q.QueryContext(ctx, c.dialect.Rebind(query), args)
+}
+
+func (c *rebindConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	return e.ExecContext(ctx, c.dialect.Rebind(query), args)
+}
+
+// CheckNamedValue converts arguments the dialect stores differently, then
+// lets the underlying driver, or database/sql, check the result.
+func (c *rebindConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if conv, ok := c.dialect.(argConverter); ok {
+		nv.Value = conv.convertArg(nv.Value)
+	}
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+func (c *rebindConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *rebindConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *rebindConn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+// argConverter is implemented by dialects that store some Go values
+// differently from SQLite.
+type argConverter interface {
+	convertArg(v interface{}) interface{}
+}
+
+// convertArg sends bools as integers, since boolean columns are INTEGER.
+func (postgres) convertArg(v interface{}) interface{} {
+	if b, ok := v.(bool); ok {
+		if b {
+			return int64(1)
+		}
+		return int64(0)
+	}
+	return v
+}
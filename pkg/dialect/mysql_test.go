@@ -0,0 +1,110 @@
+package dialect_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+)
+
+// TestMySQLIntegration runs repositories against the MySQL or MariaDB
+// database in MYSQL_TEST_DSN, such as
+// "user:pass@tcp(localhost:3306)/fantasy_test?parseTime=true". It creates
+// and drops its own tables, and is skipped when the variable is unset.
+func TestMySQLIntegration(t *testing.T) {
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN not set")
+	}
+
+	db, err := dialect.Open(dialect.MySQL, "mysql", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	schema := []string{
+		`CREATE TABLE fantasy_teams (
+			id               INTEGER PRIMARY KEY AUTO_INCREMENT,
+			league_id        INTEGER NOT NULL,
+			yahoo_team_id    VARCHAR(32) NOT NULL,
+			yahoo_team_key   VARCHAR(64) NOT NULL,
+			team_name        VARCHAR(255) NOT NULL,
+			manager_name     VARCHAR(255),
+			manager_guid     VARCHAR(64),
+			is_user_team     BOOLEAN DEFAULT 0,
+			wins             INTEGER DEFAULT 0,
+			losses           INTEGER DEFAULT 0,
+			ties             INTEGER DEFAULT 0,
+			rank             INTEGER DEFAULT 0,
+			points_for       DOUBLE DEFAULT 0,
+			points_against   DOUBLE DEFAULT 0,
+			number_of_moves  INTEGER DEFAULT 0,
+			number_of_trades INTEGER DEFAULT 0,
+			created_at       DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at       DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE trade_block (
+			id         INTEGER PRIMARY KEY AUTO_INCREMENT,
+			team_id    INTEGER NOT NULL,
+			player_id  INTEGER NOT NULL,
+			status     VARCHAR(32) NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE (team_id, player_id)
+		)`,
+	}
+	for _, stmt := range schema {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("failed to create schema: %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		db.ExecContext(ctx, `DROP TABLE fantasy_teams`)
+		db.ExecContext(ctx, `DROP TABLE trade_block`)
+	})
+
+	teams := repository.NewTeamRepository(db)
+	for i, name := range []string{"Second", "First"} {
+		team := &repository.FantasyTeam{
+			LeagueID:     1,
+			YahooTeamID:  name,
+			YahooTeamKey: "454.l.1.t." + name,
+			TeamName:     name,
+			IsUserTeam:   i == 0,
+			Rank:         2 - i,
+		}
+		if err := teams.Create(ctx, team); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if team.ID == 0 {
+			t.Errorf("Expected Create to set the team ID")
+		}
+	}
+
+	got, err := teams.GetByLeague(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetByLeague() error = %v", err)
+	}
+	if len(got) != 2 || got[0].TeamName != "First" || !got[1].IsUserTeam {
+		t.Errorf("Expected teams ordered by rank, got %+v", got)
+	}
+
+	block := repository.NewTradeBlockRepository(db)
+	if err := block.Set(ctx, 1, 10, repository.TradeBlockAvailable); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := block.Set(ctx, 1, 10, repository.TradeBlockUntouchable); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	entries, err := block.GetByTeam(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetByTeam() error = %v", err)
+	}
+	if len(entries) != 1 || entries[0].Status != repository.TradeBlockUntouchable {
+		t.Errorf("Expected Set to update the entry in place, got %+v", entries)
+	}
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// DraftResultRepository stores the picks of a league's completed draft in
+// the draft_results table. Cost is what an auction pick went for and is
+// NULL in snake drafts:
+//
+//	CREATE TABLE draft_results (
+//		league_id INTEGER NOT NULL REFERENCES fantasy_leagues(id),
+//		pick      INTEGER NOT NULL,
+//		round     INTEGER NOT NULL,
+//		team_id   INTEGER NOT NULL REFERENCES fantasy_teams(id),
+//		player_id INTEGER NOT NULL REFERENCES players(id),
+//		cost      INTEGER,
+//		PRIMARY KEY (league_id, pick)
+//	);
+type DraftResultRepository struct {
+	db *sql.DB
+}
+
+type DraftResult struct {
+	LeagueID int
+	Pick     int
+	Round    int
+	TeamID   int
+	PlayerID int
+	// Cost is zero for picks in snake drafts.
+	Cost int
+}
+
+func NewDraftResultRepository(db *sql.DB) *DraftResultRepository {
+	return &DraftResultRepository{db: db}
+}
+
+// ReplaceForLeague replaces the league's draft results with picks.
+func (r *DraftResultRepository) ReplaceForLeague(ctx context.Context, leagueID int, picks []DraftResult) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM draft_results WHERE league_id = ?`, leagueID); err != nil {
+		return fmt.Errorf("failed to clear draft results: %w", err)
+	}
+
+	query := `
+		INSERT INTO draft_results (league_id, pick, round, team_id, player_id, cost)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	for _, p := range picks {
+		var cost interface{}
+		if p.Cost > 0 {
+			cost = p.Cost
+		}
+		if _, err := tx.ExecContext(ctx, query, leagueID, p.Pick, p.Round, p.TeamID, p.PlayerID, cost); err != nil {
+			return fmt.Errorf("failed to save pick %d: %w", p.Pick, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *DraftResultRepository) GetByLeague(ctx context.Context, leagueID int) ([]*DraftResult, error) {
+	query := `
+		SELECT league_id, pick, round, team_id, player_id, COALESCE(cost, 0)
+		FROM draft_results
+		WHERE league_id = ?
+		ORDER BY pick
+	`
+
+	return r.query(ctx, query, leagueID)
+}
+
+func (r *DraftResultRepository) GetByTeam(ctx context.Context, teamID int) ([]*DraftResult, error) {
+	query := `
+		SELECT league_id, pick, round, team_id, player_id, COALESCE(cost, 0)
+		FROM draft_results
+		WHERE team_id = ?
+		ORDER BY pick
+	`
+
+	return r.query(ctx, query, teamID)
+}
+
+func (r *DraftResultRepository) query(ctx context.Context, query string, args ...interface{}) ([]*DraftResult, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var picks []*DraftResult
+	for rows.Next() {
+		p := &DraftResult{}
+		if err := rows.Scan(&p.LeagueID, &p.Pick, &p.Round, &p.TeamID, &p.PlayerID, &p.Cost); err != nil {
+			return nil, err
+		}
+		picks = append(picks, p)
+	}
+
+	return picks, rows.Err()
+}
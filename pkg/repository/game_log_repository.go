@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
+)
+
+// GameLogRepository stores players' stat lines for each fantasy week of a
+// season, in the player_game_logs table:
+//
+//	CREATE TABLE player_game_logs (
+//		player_id    INTEGER NOT NULL REFERENCES players(id),
+//		season       INTEGER NOT NULL,
+//		week         INTEGER NOT NULL,
+//		games_played INTEGER NOT NULL DEFAULT 0,
+//		minutes      REAL DEFAULT 0,
+//		fgm          INTEGER DEFAULT 0,
+//		fga          INTEGER DEFAULT 0,
+//		ftm          INTEGER DEFAULT 0,
+//		fta          INTEGER DEFAULT 0,
+//		tpm          INTEGER DEFAULT 0,
+//		points       INTEGER DEFAULT 0,
+//		rebounds     INTEGER DEFAULT 0,
+//		assists      INTEGER DEFAULT 0,
+//		steals       INTEGER DEFAULT 0,
+//		blocks       INTEGER DEFAULT 0,
+//		turnovers    INTEGER DEFAULT 0,
+//		updated_at   DATETIME DEFAULT CURRENT_TIMESTAMP,
+//		PRIMARY KEY (player_id, season, week)
+//	);
+type GameLogRepository struct {
+	db      dbtx
+	dialect dialect.Dialect
+}
+
+// GameLog is a player's stat totals for one week. Per-game averages divide
+// by GamesPlayed.
+type GameLog struct {
+	PlayerID    int
+	Season      int
+	Week        int
+	GamesPlayed int
+	Minutes     float64
+	FGM         int
+	FGA         int
+	FTM         int
+	FTA         int
+	TPM         int
+	Points      int
+	Rebounds    int
+	Assists     int
+	Steals      int
+	Blocks      int
+	Turnovers   int
+}
+
+func NewGameLogRepository(db *sql.DB) *GameLogRepository {
+	return &GameLogRepository{db: db, dialect: dialect.For(db)}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx.
+func (r *GameLogRepository) WithTx(tx *sql.Tx) *GameLogRepository {
+	return &GameLogRepository{db: tx, dialect: r.dialect}
+}
+
+// Save stores the logs, replacing any already stored for the same player
+// and week. Outside a transaction the logs are saved in one of their own.
+func (r *GameLogRepository) Save(ctx context.Context, logs []GameLog) error {
+	stats := []string{
+		"games_played", "minutes", "fgm", "fga", "ftm", "fta",
+		"tpm", "points", "rebounds", "assists", "steals", "blocks", "turnovers", "updated_at",
+	}
+	key := []string{"player_id", "season", "week"}
+	query := r.dialect.Upsert("player_game_logs", append(key, stats...), key, stats)
+
+	now := time.Now()
+	return inTx(ctx, r.db, func(tx dbtx) error {
+		for _, l := range logs {
+			_, err := tx.ExecContext(ctx, query,
+				l.PlayerID, l.Season, l.Week, l.GamesPlayed, l.Minutes, l.FGM, l.FGA, l.FTM, l.FTA,
+				l.TPM, l.Points, l.Rebounds, l.Assists, l.Steals, l.Blocks, l.Turnovers, now,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to save week %d log for player %d: %w", l.Week, l.PlayerID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// GetByPlayer returns the player's logs for the season, oldest week first.
+func (r *GameLogRepository) GetByPlayer(ctx context.Context, playerID, season int) ([]*GameLog, error) {
+	query := `
+		SELECT player_id, season, week, games_played, minutes, fgm, fga, ftm, fta,
+		       tpm, points, rebounds, assists, steals, blocks, turnovers
+		FROM player_game_logs
+		WHERE player_id = ? AND season = ?
+		ORDER BY week
+	`
+
+	return r.query(ctx, query, playerID, season)
+}
+
+// GetRecent returns the player's logs for the last weeks weeks of the
+// season they played in, oldest first.
+func (r *GameLogRepository) GetRecent(ctx context.Context, playerID, season, weeks int) ([]*GameLog, error) {
+	query := `
+		SELECT player_id, season, week, games_played, minutes, fgm, fga, ftm, fta,
+		       tpm, points, rebounds, assists, steals, blocks, turnovers
+		FROM (
+			SELECT * FROM player_game_logs
+			WHERE player_id = ? AND season = ? AND games_played > 0
+			ORDER BY week DESC
+			LIMIT ?
+		) recent
+		ORDER BY week
+	`
+
+	return r.query(ctx, query, playerID, season, weeks)
+}
+
+func (r *GameLogRepository) query(ctx context.Context, query string, args ...interface{}) ([]*GameLog, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var logs []*GameLog
+	for rows.Next() {
+		l := &GameLog{}
+		err := rows.Scan(
+			&l.PlayerID, &l.Season, &l.Week, &l.GamesPlayed, &l.Minutes, &l.FGM, &l.FGA, &l.FTM, &l.FTA,
+			&l.TPM, &l.Points, &l.Rebounds, &l.Assists, &l.Steals, &l.Blocks, &l.Turnovers,
+		)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, l)
+	}
+
+	return logs, rows.Err()
+}
@@ -4,11 +4,20 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
 )
 
+// LeagueRepository stores leagues in the fantasy_leagues table. Yahoo gives
+// a league a new key each season; the renew keys link the seasons together:
+//
+//	ALTER TABLE fantasy_leagues ADD COLUMN renew_league_key TEXT;
+//	ALTER TABLE fantasy_leagues ADD COLUMN renewed_league_key TEXT;
 type LeagueRepository struct {
-	db *sql.DB
+	db      dbtx
+	dialect dialect.Dialect
 }
 
 type League struct {
@@ -23,13 +32,22 @@ type League struct {
 	CurrentWeek      int
 	StartWeek        int
 	EndWeek          int
+	// RenewLeagueKey is the Yahoo league key of the prior season and
+	// RenewedLeagueKey that of the next; each is empty when there is none.
+	RenewLeagueKey   string
+	RenewedLeagueKey string
 	LastSyncedAt     *time.Time
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
 }
 
 func NewLeagueRepository(db *sql.DB) *LeagueRepository {
-	return &LeagueRepository{db: db}
+	return &LeagueRepository{db: db, dialect: dialect.For(db)}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx.
+func (r *LeagueRepository) WithTx(tx *sql.Tx) *LeagueRepository {
+	return &LeagueRepository{db: tx, dialect: r.dialect}
 }
 
 func (r *LeagueRepository) Create(ctx context.Context, league *League) error {
@@ -37,43 +55,58 @@ func (r *LeagueRepository) Create(ctx context.Context, league *League) error {
 		INSERT INTO fantasy_leagues (
 			yahoo_league_id, yahoo_game_key, league_name, season_year,
 			scoring_type, scoring_settings, num_teams, current_week,
-			start_week, end_week
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			start_week, end_week, renew_league_key, renewed_league_key
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	id, err := r.dialect.InsertID(ctx, r.db, query,
 		league.YahooLeagueID, league.YahooGameKey, league.LeagueName,
 		league.SeasonYear, league.ScoringType, league.ScoringSettings,
 		league.NumTeams, league.CurrentWeek, league.StartWeek, league.EndWeek,
+		league.RenewLeagueKey, league.RenewedLeagueKey,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create league: %w", err)
 	}
-
-	id, err := result.LastInsertId()
-	if err != nil {
-		return err
-	}
 	league.ID = int(id)
 
 	return nil
 }
 
 func (r *LeagueRepository) GetByYahooID(ctx context.Context, yahooLeagueID string) (*League, error) {
+	return r.getOne(ctx, `yahoo_league_id = ?`, yahooLeagueID)
+}
+
+func (r *LeagueRepository) GetByID(ctx context.Context, leagueID int) (*League, error) {
+	return r.getOne(ctx, `id = ?`, leagueID)
+}
+
+// GetByKey returns the league with the Yahoo league key, such as
+// "428.l.31". Unlike the league ID alone, the key tells seasons apart.
+func (r *LeagueRepository) GetByKey(ctx context.Context, leagueKey string) (*League, error) {
+	gameKey, leagueID, ok := strings.Cut(leagueKey, ".l.")
+	if !ok {
+		return nil, fmt.Errorf("invalid league key %q", leagueKey)
+	}
+	return r.getOne(ctx, `yahoo_game_key = ? AND yahoo_league_id = ?`, gameKey, leagueID)
+}
+
+func (r *LeagueRepository) getOne(ctx context.Context, where string, args ...interface{}) (*League, error) {
 	query := `
 		SELECT id, yahoo_league_id, yahoo_game_key, league_name, season_year,
 		       scoring_type, scoring_settings, num_teams, current_week,
-		       start_week, end_week, last_synced_at, created_at, updated_at
+		       start_week, end_week, COALESCE(renew_league_key, ''),
+		       COALESCE(renewed_league_key, ''), last_synced_at, created_at, updated_at
 		FROM fantasy_leagues
-		WHERE yahoo_league_id = ?
-	`
+		WHERE ` + where
 
 	league := &League{}
-	err := r.db.QueryRowContext(ctx, query, yahooLeagueID).Scan(
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
 		&league.ID, &league.YahooLeagueID, &league.YahooGameKey,
 		&league.LeagueName, &league.SeasonYear, &league.ScoringType,
 		&league.ScoringSettings, &league.NumTeams, &league.CurrentWeek,
-		&league.StartWeek, &league.EndWeek, &league.LastSyncedAt,
+		&league.StartWeek, &league.EndWeek, &league.RenewLeagueKey,
+		&league.RenewedLeagueKey, &league.LastSyncedAt,
 		&league.CreatedAt, &league.UpdatedAt,
 	)
 	if err != nil {
@@ -87,7 +120,8 @@ func (r *LeagueRepository) GetAll(ctx context.Context) ([]*League, error) {
 	query := `
 		SELECT id, yahoo_league_id, yahoo_game_key, league_name, season_year,
 		       scoring_type, scoring_settings, num_teams, current_week,
-		       start_week, end_week, last_synced_at, created_at, updated_at
+		       start_week, end_week, COALESCE(renew_league_key, ''),
+		       COALESCE(renewed_league_key, ''), last_synced_at, created_at, updated_at
 		FROM fantasy_leagues
 		ORDER BY created_at DESC
 	`
@@ -105,7 +139,8 @@ func (r *LeagueRepository) GetAll(ctx context.Context) ([]*League, error) {
 			&league.ID, &league.YahooLeagueID, &league.YahooGameKey,
 			&league.LeagueName, &league.SeasonYear, &league.ScoringType,
 			&league.ScoringSettings, &league.NumTeams, &league.CurrentWeek,
-			&league.StartWeek, &league.EndWeek, &league.LastSyncedAt,
+			&league.StartWeek, &league.EndWeek, &league.RenewLeagueKey,
+			&league.RenewedLeagueKey, &league.LastSyncedAt,
 			&league.CreatedAt, &league.UpdatedAt,
 		)
 		if err != nil {
@@ -129,3 +164,121 @@ func (r *LeagueRepository) Delete(ctx context.Context, leagueID int) error {
 	_, err := r.db.ExecContext(ctx, query, leagueID)
 	return err
 }
+
+// RosterSlot is a lineup position and how many of it each team in a league
+// has, stored in the league_roster_positions table:
+//
+//	CREATE TABLE league_roster_positions (
+//		league_id   INTEGER NOT NULL REFERENCES fantasy_leagues(id),
+//		position    TEXT NOT NULL,
+//		count       INTEGER NOT NULL,
+//		is_starting BOOLEAN NOT NULL,
+//		PRIMARY KEY (league_id, position)
+//	);
+type RosterSlot struct {
+	Position   string
+	Count      int
+	IsStarting bool
+}
+
+// SaveRosterPositions replaces the league's roster positions.
+func (r *LeagueRepository) SaveRosterPositions(ctx context.Context, leagueID int, slots []RosterSlot) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM league_roster_positions WHERE league_id = ?`, leagueID); err != nil {
+		return fmt.Errorf("failed to clear roster positions: %w", err)
+	}
+
+	query := `
+		INSERT INTO league_roster_positions (league_id, position, count, is_starting)
+		VALUES (?, ?, ?, ?)
+	`
+	for _, slot := range slots {
+		if _, err := r.db.ExecContext(ctx, query, leagueID, slot.Position, slot.Count, slot.IsStarting); err != nil {
+			return fmt.Errorf("failed to save roster position %s: %w", slot.Position, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *LeagueRepository) GetRosterPositions(ctx context.Context, leagueID int) ([]RosterSlot, error) {
+	query := `
+		SELECT position, count, is_starting
+		FROM league_roster_positions
+		WHERE league_id = ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slots []RosterSlot
+	for rows.Next() {
+		var slot RosterSlot
+		if err := rows.Scan(&slot.Position, &slot.Count, &slot.IsStarting); err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+
+	return slots, rows.Err()
+}
+
+// LeagueCategory is a stat category a league scores, stored in the
+// league_stat_categories table:
+//
+//	CREATE TABLE league_stat_categories (
+//		league_id       INTEGER NOT NULL REFERENCES fantasy_leagues(id),
+//		category        TEXT NOT NULL,
+//		lower_is_better BOOLEAN NOT NULL,
+//		PRIMARY KEY (league_id, category)
+//	);
+type LeagueCategory struct {
+	Name          string
+	LowerIsBetter bool
+}
+
+// SaveStatCategories replaces the league's stat categories.
+func (r *LeagueRepository) SaveStatCategories(ctx context.Context, leagueID int, categories []LeagueCategory) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM league_stat_categories WHERE league_id = ?`, leagueID); err != nil {
+		return fmt.Errorf("failed to clear stat categories: %w", err)
+	}
+
+	query := `
+		INSERT INTO league_stat_categories (league_id, category, lower_is_better)
+		VALUES (?, ?, ?)
+	`
+	for _, cat := range categories {
+		if _, err := r.db.ExecContext(ctx, query, leagueID, cat.Name, cat.LowerIsBetter); err != nil {
+			return fmt.Errorf("failed to save stat category %s: %w", cat.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *LeagueRepository) GetStatCategories(ctx context.Context, leagueID int) ([]LeagueCategory, error) {
+	query := `
+		SELECT category, lower_is_better
+		FROM league_stat_categories
+		WHERE league_id = ?
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var categories []LeagueCategory
+	for rows.Next() {
+		var cat LeagueCategory
+		if err := rows.Scan(&cat.Name, &cat.LowerIsBetter); err != nil {
+			return nil, err
+		}
+		categories = append(categories, cat)
+	}
+
+	return categories, rows.Err()
+}
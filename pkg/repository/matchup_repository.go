@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+)
+
+// MatchupRepository stores a league's weekly matchups as synced from the
+// Yahoo scoreboard, so history-based analyses can run without the API:
+//
+//	CREATE TABLE matchups (
+//		id              INTEGER PRIMARY KEY AUTOINCREMENT,
+//		league_id       INTEGER NOT NULL REFERENCES fantasy_leagues(id),
+//		week            INTEGER NOT NULL,
+//		week_start      TEXT,
+//		week_end        TEXT,
+//		status          TEXT NOT NULL,
+//		is_playoffs     BOOLEAN DEFAULT 0,
+//		is_consolation  BOOLEAN DEFAULT 0,
+//		is_tied         BOOLEAN DEFAULT 0,
+//		winner_team_key TEXT
+//	);
+//
+//	CREATE TABLE matchup_teams (
+//		matchup_id       INTEGER NOT NULL REFERENCES matchups(id),
+//		team_key         TEXT NOT NULL,
+//		name             TEXT,
+//		points           REAL,
+//		projected_points REAL,
+//		is_winner        BOOLEAN DEFAULT 0,
+//		PRIMARY KEY (matchup_id, team_key)
+//	);
+//
+//	CREATE TABLE matchup_stat_winners (
+//		matchup_id      INTEGER NOT NULL REFERENCES matchups(id),
+//		stat_id         INTEGER NOT NULL,
+//		stat_name       TEXT,
+//		winner_team_key TEXT,
+//		is_tied         BOOLEAN DEFAULT 0,
+//		PRIMARY KEY (matchup_id, stat_id)
+//	);
+type MatchupRepository struct {
+	db *sql.DB
+}
+
+func NewMatchupRepository(db *sql.DB) *MatchupRepository {
+	return &MatchupRepository{db: db}
+}
+
+// SaveWeek replaces the league's matchups for the week. statNames maps stat
+// IDs to the category names stored with each stat winner.
+func (r *MatchupRepository) SaveWeek(ctx context.Context, leagueID, week int, matchups []yahoo.Matchup, statNames map[int]string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := deleteWeek(ctx, tx, leagueID, week); err != nil {
+		return fmt.Errorf("failed to clear week %d matchups: %w", week, err)
+	}
+
+	matchupQuery := `
+		INSERT INTO matchups (
+			league_id, week, week_start, week_end, status,
+			is_playoffs, is_consolation, is_tied, winner_team_key
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	teamQuery := `
+		INSERT INTO matchup_teams (matchup_id, team_key, name, points, projected_points, is_winner)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	statQuery := `
+		INSERT INTO matchup_stat_winners (matchup_id, stat_id, stat_name, winner_team_key, is_tied)
+		VALUES (?, ?, ?, ?, ?)
+	`
+
+	for _, m := range matchups {
+		id, err := dialect.For(r.db).InsertID(ctx, tx, matchupQuery,
+			leagueID, week, m.WeekStart, m.WeekEnd, m.Status,
+			m.IsPlayoffs, m.IsConsolation, m.IsTied, m.WinnerTeamKey,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save week %d matchup: %w", week, err)
+		}
+
+		for _, t := range m.Teams {
+			_, err := tx.ExecContext(ctx, teamQuery, id, t.TeamKey, t.Name, t.Points, t.ProjectedPoints, t.IsWinner)
+			if err != nil {
+				return fmt.Errorf("failed to save matchup team %s: %w", t.TeamKey, err)
+			}
+		}
+		for _, sw := range m.StatWinners {
+			_, err := tx.ExecContext(ctx, statQuery, id, sw.StatID, statNames[sw.StatID], sw.WinnerTeamKey, sw.IsTied)
+			if err != nil {
+				return fmt.Errorf("failed to save stat winner %d: %w", sw.StatID, err)
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+func deleteWeek(ctx context.Context, tx *sql.Tx, leagueID, week int) error {
+	ids := `SELECT id FROM matchups WHERE league_id = ? AND week = ?`
+	for _, table := range []string{"matchup_teams", "matchup_stat_winners"} {
+		query := fmt.Sprintf(`DELETE FROM %s WHERE matchup_id IN (%s)`, table, ids)
+		if _, err := tx.ExecContext(ctx, query, leagueID, week); err != nil {
+			return err
+		}
+	}
+	_, err := tx.ExecContext(ctx, `DELETE FROM matchups WHERE league_id = ? AND week = ?`, leagueID, week)
+	return err
+}
+
+// GetWeek returns the league's stored matchups for the week, in the shape
+// Yahoo's scoreboard returns them.
+func (r *MatchupRepository) GetWeek(ctx context.Context, leagueID, week int) ([]yahoo.Matchup, error) {
+	query := `
+		SELECT id, week, COALESCE(week_start, ''), COALESCE(week_end, ''), status,
+		       is_playoffs, is_consolation, is_tied, COALESCE(winner_team_key, '')
+		FROM matchups
+		WHERE league_id = ? AND week = ?
+		ORDER BY id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID, week)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	var matchups []yahoo.Matchup
+	for rows.Next() {
+		var id int64
+		var m yahoo.Matchup
+		err := rows.Scan(&id, &m.Week, &m.WeekStart, &m.WeekEnd, &m.Status,
+			&m.IsPlayoffs, &m.IsConsolation, &m.IsTied, &m.WinnerTeamKey)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		matchups = append(matchups, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, id := range ids {
+		if matchups[i].Teams, err = r.getTeams(ctx, id); err != nil {
+			return nil, err
+		}
+		if matchups[i].StatWinners, err = r.getStatWinners(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return matchups, nil
+}
+
+func (r *MatchupRepository) getTeams(ctx context.Context, matchupID int64) ([]yahoo.MatchupTeam, error) {
+	query := `
+		SELECT team_key, COALESCE(name, ''), COALESCE(points, 0), COALESCE(projected_points, 0), is_winner
+		FROM matchup_teams
+		WHERE matchup_id = ?
+		ORDER BY team_key
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, matchupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var teams []yahoo.MatchupTeam
+	for rows.Next() {
+		var t yahoo.MatchupTeam
+		if err := rows.Scan(&t.TeamKey, &t.Name, &t.Points, &t.ProjectedPoints, &t.IsWinner); err != nil {
+			return nil, err
+		}
+		teams = append(teams, t)
+	}
+
+	return teams, rows.Err()
+}
+
+func (r *MatchupRepository) getStatWinners(ctx context.Context, matchupID int64) ([]yahoo.StatWinner, error) {
+	query := `
+		SELECT stat_id, COALESCE(winner_team_key, ''), is_tied
+		FROM matchup_stat_winners
+		WHERE matchup_id = ?
+		ORDER BY stat_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, matchupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var winners []yahoo.StatWinner
+	for rows.Next() {
+		var sw yahoo.StatWinner
+		if err := rows.Scan(&sw.StatID, &sw.WinnerTeamKey, &sw.IsTied); err != nil {
+			return nil, err
+		}
+		winners = append(winners, sw)
+	}
+
+	return winners, rows.Err()
+}
+
+// GetStatNames returns the category names stored with the league's stat
+// winners, by stat ID.
+func (r *MatchupRepository) GetStatNames(ctx context.Context, leagueID int) (map[int]string, error) {
+	query := `
+		SELECT DISTINCT msw.stat_id, msw.stat_name
+		FROM matchup_stat_winners msw
+		JOIN matchups m ON msw.matchup_id = m.id
+		WHERE m.league_id = ? AND msw.stat_name IS NOT NULL AND msw.stat_name != ''
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		names[id] = name
+	}
+
+	return names, rows.Err()
+}
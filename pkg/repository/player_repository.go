@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+)
+
+// PlayerRepository stores players as Yahoo reports them, in the players
+// table and the player_positions table joined to positions:
+//
+//	CREATE TABLE players (
+//		id                  INTEGER PRIMARY KEY AUTOINCREMENT,
+//		yahoo_player_key    TEXT NOT NULL UNIQUE,
+//		yahoo_player_id     TEXT,
+//		full_name           TEXT NOT NULL,
+//		editorial_team_abbr TEXT,
+//		is_active           BOOLEAN DEFAULT 1,
+//		updated_at          DATETIME DEFAULT CURRENT_TIMESTAMP
+//	);
+//
+//	CREATE TABLE positions (
+//		id   INTEGER PRIMARY KEY AUTOINCREMENT,
+//		code TEXT NOT NULL UNIQUE
+//	);
+//
+//	CREATE TABLE player_positions (
+//		player_id   INTEGER NOT NULL REFERENCES players(id),
+//		position_id INTEGER NOT NULL REFERENCES positions(id),
+//		is_primary  BOOLEAN DEFAULT 0,
+//		PRIMARY KEY (player_id, position_id)
+//	);
+type PlayerRepository struct {
+	db      dbtx
+	dialect dialect.Dialect
+}
+
+func NewPlayerRepository(db *sql.DB) *PlayerRepository {
+	return &PlayerRepository{db: db, dialect: dialect.For(db)}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx.
+func (r *PlayerRepository) WithTx(tx *sql.Tx) *PlayerRepository {
+	return &PlayerRepository{db: tx, dialect: r.dialect}
+}
+
+// UpsertFromYahoo creates the player or updates their name and team, and
+// replaces their eligible positions, returning the player's ID. The first
+// eligible position is the primary one.
+func (r *PlayerRepository) UpsertFromYahoo(ctx context.Context, player yahoo.Player) (int, error) {
+	if player.PlayerKey == "" {
+		return 0, fmt.Errorf("player has no player key")
+	}
+
+	var playerID int
+	err := inTx(ctx, r.db, func(tx dbtx) error {
+		columns := []string{"yahoo_player_id", "full_name", "editorial_team_abbr", "is_active", "updated_at"}
+		query := r.dialect.Upsert("players", append([]string{"yahoo_player_key"}, columns...), []string{"yahoo_player_key"}, columns)
+		_, err := tx.ExecContext(ctx, query,
+			player.PlayerKey, player.PlayerID, player.Name.Full, player.EditorialTeamAbbr, true, time.Now(),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to upsert player %s: %w", player.PlayerKey, err)
+		}
+
+		err = tx.QueryRowContext(ctx, `SELECT id FROM players WHERE yahoo_player_key = ?`, player.PlayerKey).Scan(&playerID)
+		if err != nil {
+			return err
+		}
+
+		if len(player.EligiblePositions) > 0 {
+			if err := r.replacePositions(ctx, tx, playerID, player.EligiblePositions); err != nil {
+				return fmt.Errorf("failed to save positions for player %s: %w", player.PlayerKey, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return playerID, nil
+}
+
+func (r *PlayerRepository) replacePositions(ctx context.Context, tx dbtx, playerID int, positions []string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM player_positions WHERE player_id = ?`, playerID); err != nil {
+		return err
+	}
+
+	positionQuery := r.dialect.Upsert("positions", []string{"code"}, []string{"code"}, nil)
+	playerPositionQuery := r.dialect.Upsert("player_positions",
+		[]string{"player_id", "position_id", "is_primary"}, []string{"player_id", "position_id"}, nil)
+
+	for i, code := range positions {
+		if _, err := tx.ExecContext(ctx, positionQuery, code); err != nil {
+			return err
+		}
+
+		var positionID int
+		if err := tx.QueryRowContext(ctx, `SELECT id FROM positions WHERE code = ?`, code).Scan(&positionID); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, playerPositionQuery, playerID, positionID, i == 0); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetEligiblePositions returns every player's eligible positions by player
+// ID, primary position first and the rest in alphabetical order.
+func (r *PlayerRepository) GetEligiblePositions(ctx context.Context) (map[int][]string, error) {
+	query := `
+		SELECT pp.player_id, pos.code
+		FROM player_positions pp
+		JOIN positions pos ON pp.position_id = pos.id
+		ORDER BY pp.player_id, pp.is_primary DESC, pos.code
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	eligible := make(map[int][]string)
+	for rows.Next() {
+		var playerID int
+		var position string
+		if err := rows.Scan(&playerID, &position); err != nil {
+			return nil, err
+		}
+		eligible[playerID] = append(eligible[playerID], position)
+	}
+
+	return eligible, rows.Err()
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ProjectionRepository reads the per-game projections stored in
+// player_projections for the players on a team's roster, and how many games
+// players have in a week:
+//
+//	CREATE TABLE player_weekly_games (
+//		league_id INTEGER NOT NULL REFERENCES fantasy_leagues(id),
+//		week      INTEGER NOT NULL,
+//		player_id INTEGER NOT NULL REFERENCES players(id),
+//		games     INTEGER NOT NULL,
+//		PRIMARY KEY (league_id, week, player_id)
+//	);
+type ProjectionRepository struct {
+	db *sql.DB
+}
+
+// Projection is a rostered player's per-game projection in a league.
+// Position is the player's primary position, or "F" when it is unknown.
+type Projection struct {
+	PlayerID int
+	Position string
+	FPG      float64
+	PTS      float64
+	REB      float64
+	AST      float64
+	STL      float64
+	BLK      float64
+	TO       float64
+	FGPct    float64
+	FTPct    float64
+	TPM      float64
+}
+
+func NewProjectionRepository(db *sql.DB) *ProjectionRepository {
+	return &ProjectionRepository{db: db}
+}
+
+// GetStarters returns the league's projections for the team's starters.
+// Players without a projection are left out.
+func (r *ProjectionRepository) GetStarters(ctx context.Context, leagueID, teamID int) ([]Projection, error) {
+	return r.getRostered(ctx, leagueID, teamID, "AND fr.is_starting = 1")
+}
+
+// GetRoster returns the league's projections for every player on the
+// team's roster, starters and bench alike. Players without a projection are
+// left out.
+func (r *ProjectionRepository) GetRoster(ctx context.Context, leagueID, teamID int) ([]Projection, error) {
+	return r.getRostered(ctx, leagueID, teamID, "")
+}
+
+func (r *ProjectionRepository) getRostered(ctx context.Context, leagueID, teamID int, filter string) ([]Projection, error) {
+	query := `
+		SELECT pp.player_id, pp.fpg, pp.proj_pts, pp.proj_reb, pp.proj_ast,
+		       pp.proj_stl, pp.proj_blk, pp.proj_to, pp.proj_fg_pct,
+		       pp.proj_ft_pct, pp.proj_3pm,
+		       COALESCE(pos.code, 'F') as position
+		FROM fantasy_rosters fr
+		JOIN player_projections pp ON fr.player_id = pp.player_id AND pp.league_id = ?
+		LEFT JOIN player_positions plp ON fr.player_id = plp.player_id AND plp.is_primary = 1
+		LEFT JOIN positions pos ON plp.position_id = pos.id
+		WHERE fr.team_id = ? ` + filter
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projections []Projection
+	for rows.Next() {
+		var p Projection
+		err := rows.Scan(
+			&p.PlayerID, &p.FPG, &p.PTS, &p.REB, &p.AST,
+			&p.STL, &p.BLK, &p.TO, &p.FGPct, &p.FTPct, &p.TPM, &p.Position,
+		)
+		if err != nil {
+			return nil, err
+		}
+		projections = append(projections, p)
+	}
+
+	return projections, rows.Err()
+}
+
+// GetWeeklyGames returns the number of games each player has in the week,
+// by player ID. Players without a row are left out.
+func (r *ProjectionRepository) GetWeeklyGames(ctx context.Context, leagueID, week int) (map[int]int, error) {
+	query := `SELECT player_id, games FROM player_weekly_games WHERE league_id = ? AND week = ?`
+
+	rows, err := r.db.QueryContext(ctx, query, leagueID, week)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	games := make(map[int]int)
+	for rows.Next() {
+		var playerID, n int
+		if err := rows.Scan(&playerID, &n); err != nil {
+			return nil, err
+		}
+		games[playerID] = n
+	}
+
+	return games, rows.Err()
+}
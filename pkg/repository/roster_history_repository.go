@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// RosterHistoryRepository records every change league syncs find in a
+// team's roster, in the roster_history table. Positions are the lineup
+// slots the player moved between; an add has no from_position and a drop
+// no to_position:
+//
+//	CREATE TABLE roster_history (
+//		id            INTEGER PRIMARY KEY AUTOINCREMENT,
+//		team_id       INTEGER NOT NULL REFERENCES fantasy_teams(id),
+//		player_id     INTEGER NOT NULL REFERENCES players(id),
+//		change_type   TEXT NOT NULL,
+//		from_position TEXT,
+//		to_position   TEXT,
+//		changed_at    DATETIME NOT NULL
+//	);
+type RosterHistoryRepository struct {
+	db dbtx
+}
+
+// RosterChangeType is what happened to a player on a team's roster.
+type RosterChangeType string
+
+const (
+	// RosterAdd is a player joining the team.
+	RosterAdd RosterChangeType = "add"
+	// RosterDrop is a player leaving the team.
+	RosterDrop RosterChangeType = "drop"
+	// RosterMove is a player changing lineup slots on the team.
+	RosterMove RosterChangeType = "move"
+)
+
+type RosterChange struct {
+	ID           int
+	TeamID       int
+	PlayerID     int
+	ChangeType   RosterChangeType
+	FromPosition string
+	ToPosition   string
+	// ChangedAt is when the sync that found the change ran, which is after
+	// the change itself was made on Yahoo.
+	ChangedAt time.Time
+}
+
+func NewRosterHistoryRepository(db *sql.DB) *RosterHistoryRepository {
+	return &RosterHistoryRepository{db: db}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx.
+func (r *RosterHistoryRepository) WithTx(tx *sql.Tx) *RosterHistoryRepository {
+	return &RosterHistoryRepository{db: tx}
+}
+
+func (r *RosterHistoryRepository) Record(ctx context.Context, changes []RosterChange) error {
+	query := `
+		INSERT INTO roster_history (team_id, player_id, change_type, from_position, to_position, changed_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	for _, c := range changes {
+		_, err := r.db.ExecContext(ctx, query,
+			c.TeamID, c.PlayerID, string(c.ChangeType), nullString(c.FromPosition), nullString(c.ToPosition), c.ChangedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to record %s of player %d: %w", c.ChangeType, c.PlayerID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetByPlayer returns the player's roster changes across all teams, newest
+// first, answering who dropped or added them and when.
+func (r *RosterHistoryRepository) GetByPlayer(ctx context.Context, playerID int) ([]*RosterChange, error) {
+	query := `
+		SELECT id, team_id, player_id, change_type, COALESCE(from_position, ''), COALESCE(to_position, ''), changed_at
+		FROM roster_history
+		WHERE player_id = ?
+		ORDER BY changed_at DESC, id DESC
+	`
+
+	return r.query(ctx, query, playerID)
+}
+
+// GetByTeam returns the team's roster changes since the time, newest first.
+func (r *RosterHistoryRepository) GetByTeam(ctx context.Context, teamID int, since time.Time) ([]*RosterChange, error) {
+	query := `
+		SELECT id, team_id, player_id, change_type, COALESCE(from_position, ''), COALESCE(to_position, ''), changed_at
+		FROM roster_history
+		WHERE team_id = ? AND changed_at >= ?
+		ORDER BY changed_at DESC, id DESC
+	`
+
+	return r.query(ctx, query, teamID, since)
+}
+
+func (r *RosterHistoryRepository) query(ctx context.Context, query string, args ...interface{}) ([]*RosterChange, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var changes []*RosterChange
+	for rows.Next() {
+		c := &RosterChange{}
+		var changeType string
+		if err := rows.Scan(&c.ID, &c.TeamID, &c.PlayerID, &changeType, &c.FromPosition, &c.ToPosition, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		c.ChangeType = RosterChangeType(changeType)
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
+
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
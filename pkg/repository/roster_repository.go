@@ -4,11 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
 )
 
 type RosterRepository struct {
-	db *sql.DB
+	db      dbtx
+	dialect dialect.Dialect
 }
 
 type RosterEntry struct {
@@ -25,7 +29,12 @@ type RosterEntry struct {
 }
 
 func NewRosterRepository(db *sql.DB) *RosterRepository {
-	return &RosterRepository{db: db}
+	return &RosterRepository{db: db, dialect: dialect.For(db)}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx.
+func (r *RosterRepository) WithTx(tx *sql.Tx) *RosterRepository {
+	return &RosterRepository{db: tx, dialect: r.dialect}
 }
 
 func (r *RosterRepository) Create(ctx context.Context, entry *RosterEntry) error {
@@ -36,7 +45,7 @@ func (r *RosterRepository) Create(ctx context.Context, entry *RosterEntry) error
 		) VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	id, err := r.dialect.InsertID(ctx, r.db, query,
 		entry.TeamID, entry.PlayerID, entry.RosterPosition,
 		entry.SelectedPosition, entry.IsStarting, entry.AcquisitionType,
 		entry.AcquisitionDate,
@@ -44,12 +53,34 @@ func (r *RosterRepository) Create(ctx context.Context, entry *RosterEntry) error
 	if err != nil {
 		return fmt.Errorf("failed to create roster entry: %w", err)
 	}
+	entry.ID = int(id)
+
+	return nil
+}
+
+// Upsert creates the roster entry or, when the player is already on the
+// team, updates their positions, leaving how they were acquired alone, and
+// sets entry.ID either way. It relies on each player appearing on a team
+// once:
+//
+//	CREATE UNIQUE INDEX idx_fantasy_rosters_team_player ON fantasy_rosters (team_id, player_id);
+func (r *RosterRepository) Upsert(ctx context.Context, entry *RosterEntry) error {
+	update := []string{"roster_position", "selected_position", "is_starting", "updated_at"}
+	columns := append([]string{"team_id", "player_id", "acquisition_type", "acquisition_date"}, update...)
+	query := r.dialect.Upsert("fantasy_rosters", columns, []string{"team_id", "player_id"}, update)
 
-	id, err := result.LastInsertId()
+	_, err := r.db.ExecContext(ctx, query,
+		entry.TeamID, entry.PlayerID, entry.AcquisitionType, entry.AcquisitionDate,
+		entry.RosterPosition, entry.SelectedPosition, entry.IsStarting, time.Now(),
+	)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to upsert roster entry: %w", err)
+	}
+
+	query = `SELECT id FROM fantasy_rosters WHERE team_id = ? AND player_id = ?`
+	if err := r.db.QueryRowContext(ctx, query, entry.TeamID, entry.PlayerID).Scan(&entry.ID); err != nil {
+		return fmt.Errorf("failed to get roster entry: %w", err)
 	}
-	entry.ID = int(id)
 
 	return nil
 }
@@ -92,6 +123,25 @@ func (r *RosterRepository) DeleteByTeam(ctx context.Context, teamID int) error {
 	return err
 }
 
+// DeleteOthers removes the team's roster entries for players not in
+// playerIDs, such as those dropped or traded away since the last sync.
+func (r *RosterRepository) DeleteOthers(ctx context.Context, teamID int, playerIDs []int) error {
+	if len(playerIDs) == 0 {
+		return r.DeleteByTeam(ctx, teamID)
+	}
+
+	args := make([]interface{}, 0, len(playerIDs)+1)
+	args = append(args, teamID)
+	for _, id := range playerIDs {
+		args = append(args, id)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(playerIDs)), ", ")
+	query := fmt.Sprintf(`DELETE FROM fantasy_rosters WHERE team_id = ? AND player_id NOT IN (%s)`, placeholders)
+
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
 func (r *RosterRepository) GetPlayerIDByYahooKey(ctx context.Context, yahooPlayerKey string) (int, error) {
 	query := `SELECT id FROM players WHERE yahoo_player_key = ?`
 	var playerID int
@@ -5,10 +5,21 @@ import (
 	"database/sql"
 	"fmt"
 	"time"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
 )
 
+// TeamRepository stores a league's teams in the fantasy_teams table. The
+// move and trade counts Yahoo reports for each team let syncs skip rosters
+// that have not changed since the last one, and the manager's GUID ties
+// their teams in different seasons together:
+//
+//	ALTER TABLE fantasy_teams ADD COLUMN number_of_moves INTEGER DEFAULT 0;
+//	ALTER TABLE fantasy_teams ADD COLUMN number_of_trades INTEGER DEFAULT 0;
+//	ALTER TABLE fantasy_teams ADD COLUMN manager_guid TEXT;
 type TeamRepository struct {
-	db *sql.DB
+	db      dbtx
+	dialect dialect.Dialect
 }
 
 type FantasyTeam struct {
@@ -18,6 +29,7 @@ type FantasyTeam struct {
 	YahooTeamKey  string
 	TeamName      string
 	ManagerName   string
+	ManagerGUID   string
 	IsUserTeam    bool
 	Wins          int
 	Losses        int
@@ -25,12 +37,21 @@ type FantasyTeam struct {
 	Rank          int
 	PointsFor     float64
 	PointsAgainst float64
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	// NumberOfMoves and NumberOfTrades are Yahoo's counts as of the last
+	// roster sync.
+	NumberOfMoves  int
+	NumberOfTrades int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 func NewTeamRepository(db *sql.DB) *TeamRepository {
-	return &TeamRepository{db: db}
+	return &TeamRepository{db: db, dialect: dialect.For(db)}
+}
+
+// WithTx returns a copy of the repository that runs its queries in tx.
+func (r *TeamRepository) WithTx(tx *sql.Tx) *TeamRepository {
+	return &TeamRepository{db: tx, dialect: r.dialect}
 }
 
 func (r *TeamRepository) Create(ctx context.Context, team *FantasyTeam) error {
@@ -41,7 +62,7 @@ func (r *TeamRepository) Create(ctx context.Context, team *FantasyTeam) error {
 		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := r.db.ExecContext(ctx, query,
+	id, err := r.dialect.InsertID(ctx, r.db, query,
 		team.LeagueID, team.YahooTeamID, team.YahooTeamKey, team.TeamName,
 		team.ManagerName, team.IsUserTeam, team.Wins, team.Losses, team.Ties,
 		team.Rank, team.PointsFor, team.PointsAgainst,
@@ -49,12 +70,37 @@ func (r *TeamRepository) Create(ctx context.Context, team *FantasyTeam) error {
 	if err != nil {
 		return fmt.Errorf("failed to create team: %w", err)
 	}
+	team.ID = int(id)
+
+	return nil
+}
+
+// Upsert creates the team or, when a team with the same Yahoo team key
+// exists, updates its name, manager, record, rank and move counts, and sets
+// team.ID either way. It relies on the key being unique:
+//
+//	CREATE UNIQUE INDEX idx_fantasy_teams_yahoo_team_key ON fantasy_teams (yahoo_team_key);
+func (r *TeamRepository) Upsert(ctx context.Context, team *FantasyTeam) error {
+	update := []string{
+		"team_name", "manager_name", "manager_guid", "is_user_team", "wins", "losses", "ties", "rank",
+		"number_of_moves", "number_of_trades", "updated_at",
+	}
+	columns := append([]string{"league_id", "yahoo_team_id", "yahoo_team_key"}, update...)
+	query := r.dialect.Upsert("fantasy_teams", columns, []string{"yahoo_team_key"}, update)
 
-	id, err := result.LastInsertId()
+	_, err := r.db.ExecContext(ctx, query,
+		team.LeagueID, team.YahooTeamID, team.YahooTeamKey, team.TeamName,
+		team.ManagerName, team.ManagerGUID, team.IsUserTeam, team.Wins, team.Losses, team.Ties,
+		team.Rank, team.NumberOfMoves, team.NumberOfTrades, time.Now(),
+	)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to upsert team %s: %w", team.YahooTeamKey, err)
+	}
+
+	err = r.db.QueryRowContext(ctx, `SELECT id FROM fantasy_teams WHERE yahoo_team_key = ?`, team.YahooTeamKey).Scan(&team.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get team %s: %w", team.YahooTeamKey, err)
 	}
-	team.ID = int(id)
 
 	return nil
 }
@@ -62,8 +108,9 @@ func (r *TeamRepository) Create(ctx context.Context, team *FantasyTeam) error {
 func (r *TeamRepository) GetByLeague(ctx context.Context, leagueID int) ([]*FantasyTeam, error) {
 	query := `
 		SELECT id, league_id, yahoo_team_id, yahoo_team_key, team_name,
-		       manager_name, is_user_team, wins, losses, ties, rank,
-		       points_for, points_against, created_at, updated_at
+		       manager_name, COALESCE(manager_guid, ''), is_user_team, wins, losses, ties, rank,
+		       points_for, points_against, number_of_moves, number_of_trades,
+		       created_at, updated_at
 		FROM fantasy_teams
 		WHERE league_id = ?
 		ORDER BY rank
@@ -80,9 +127,10 @@ func (r *TeamRepository) GetByLeague(ctx context.Context, leagueID int) ([]*Fant
 		team := &FantasyTeam{}
 		err := rows.Scan(
 			&team.ID, &team.LeagueID, &team.YahooTeamID, &team.YahooTeamKey,
-			&team.TeamName, &team.ManagerName, &team.IsUserTeam, &team.Wins,
+			&team.TeamName, &team.ManagerName, &team.ManagerGUID, &team.IsUserTeam, &team.Wins,
 			&team.Losses, &team.Ties, &team.Rank, &team.PointsFor,
-			&team.PointsAgainst, &team.CreatedAt, &team.UpdatedAt,
+			&team.PointsAgainst, &team.NumberOfMoves, &team.NumberOfTrades,
+			&team.CreatedAt, &team.UpdatedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -93,11 +141,34 @@ func (r *TeamRepository) GetByLeague(ctx context.Context, leagueID int) ([]*Fant
 	return teams, nil
 }
 
+// GetIDsByYahooKey maps the Yahoo team keys of the league's teams to their
+// IDs, for matching teams in Yahoo's scoreboards to stored ones.
+func (r *TeamRepository) GetIDsByYahooKey(ctx context.Context, leagueID int) (map[string]int, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, yahoo_team_key FROM fantasy_teams WHERE league_id = ?`, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	teamIDs := make(map[string]int)
+	for rows.Next() {
+		var id int
+		var key string
+		if err := rows.Scan(&id, &key); err != nil {
+			return nil, err
+		}
+		teamIDs[key] = id
+	}
+
+	return teamIDs, rows.Err()
+}
+
 func (r *TeamRepository) GetUserTeam(ctx context.Context, leagueID int) (*FantasyTeam, error) {
 	query := `
 		SELECT id, league_id, yahoo_team_id, yahoo_team_key, team_name,
-		       manager_name, is_user_team, wins, losses, ties, rank,
-		       points_for, points_against, created_at, updated_at
+		       manager_name, COALESCE(manager_guid, ''), is_user_team, wins, losses, ties, rank,
+		       points_for, points_against, number_of_moves, number_of_trades,
+		       created_at, updated_at
 		FROM fantasy_teams
 		WHERE league_id = ? AND is_user_team = 1
 	`
@@ -105,9 +176,10 @@ func (r *TeamRepository) GetUserTeam(ctx context.Context, leagueID int) (*Fantas
 	team := &FantasyTeam{}
 	err := r.db.QueryRowContext(ctx, query, leagueID).Scan(
 		&team.ID, &team.LeagueID, &team.YahooTeamID, &team.YahooTeamKey,
-		&team.TeamName, &team.ManagerName, &team.IsUserTeam, &team.Wins,
+		&team.TeamName, &team.ManagerName, &team.ManagerGUID, &team.IsUserTeam, &team.Wins,
 		&team.Losses, &team.Ties, &team.Rank, &team.PointsFor,
-		&team.PointsAgainst, &team.CreatedAt, &team.UpdatedAt,
+		&team.PointsAgainst, &team.NumberOfMoves, &team.NumberOfTrades,
+		&team.CreatedAt, &team.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
+)
+
+// TradeBlockRepository stores the players each team has put on the trade
+// block or marked untouchable, in the trade_block table:
+//
+//	CREATE TABLE trade_block (
+//		id         INTEGER PRIMARY KEY AUTOINCREMENT,
+//		team_id    INTEGER NOT NULL REFERENCES fantasy_teams(id),
+//		player_id  INTEGER NOT NULL REFERENCES players(id),
+//		status     TEXT NOT NULL,
+//		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+//		UNIQUE (team_id, player_id)
+//	);
+type TradeBlockRepository struct {
+	db *sql.DB
+}
+
+// TradeBlockStatus is how a team feels about trading a player.
+type TradeBlockStatus string
+
+const (
+	// TradeBlockAvailable marks a player the team is shopping.
+	TradeBlockAvailable TradeBlockStatus = "available"
+	// TradeBlockUntouchable marks a player the team will not trade.
+	TradeBlockUntouchable TradeBlockStatus = "untouchable"
+)
+
+type TradeBlockEntry struct {
+	ID        int
+	TeamID    int
+	PlayerID  int
+	Status    TradeBlockStatus
+	CreatedAt time.Time
+}
+
+func NewTradeBlockRepository(db *sql.DB) *TradeBlockRepository {
+	return &TradeBlockRepository{db: db}
+}
+
+// Set puts the player on the team's trade block or untouchable list,
+// replacing any earlier status.
+func (r *TradeBlockRepository) Set(ctx context.Context, teamID, playerID int, status TradeBlockStatus) error {
+	query := dialect.For(r.db).Upsert("trade_block",
+		[]string{"team_id", "player_id", "status"},
+		[]string{"team_id", "player_id"},
+		[]string{"status"},
+	)
+
+	if _, err := r.db.ExecContext(ctx, query, teamID, playerID, string(status)); err != nil {
+		return fmt.Errorf("failed to set trade block status: %w", err)
+	}
+	return nil
+}
+
+// Remove takes the player off both of the team's lists.
+func (r *TradeBlockRepository) Remove(ctx context.Context, teamID, playerID int) error {
+	query := `DELETE FROM trade_block WHERE team_id = ? AND player_id = ?`
+	_, err := r.db.ExecContext(ctx, query, teamID, playerID)
+	return err
+}
+
+func (r *TradeBlockRepository) GetByTeam(ctx context.Context, teamID int) ([]*TradeBlockEntry, error) {
+	query := `
+		SELECT id, team_id, player_id, status, created_at
+		FROM trade_block
+		WHERE team_id = ?
+		ORDER BY status, player_id
+	`
+
+	return r.query(ctx, query, teamID)
+}
+
+// GetByLeague returns the trade block entries of every team in the league.
+func (r *TradeBlockRepository) GetByLeague(ctx context.Context, leagueID int) ([]*TradeBlockEntry, error) {
+	query := `
+		SELECT tb.id, tb.team_id, tb.player_id, tb.status, tb.created_at
+		FROM trade_block tb
+		JOIN fantasy_teams ft ON tb.team_id = ft.id
+		WHERE ft.league_id = ?
+		ORDER BY tb.team_id, tb.status, tb.player_id
+	`
+
+	return r.query(ctx, query, leagueID)
+}
+
+func (r *TradeBlockRepository) query(ctx context.Context, query string, args ...interface{}) ([]*TradeBlockEntry, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*TradeBlockEntry
+	for rows.Next() {
+		entry := &TradeBlockEntry{}
+		var status string
+		err := rows.Scan(&entry.ID, &entry.TeamID, &entry.PlayerID, &status, &entry.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		entry.Status = TradeBlockStatus(status)
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// dbtx is the part of *sql.DB and *sql.Tx the repositories query through,
+// so that a repository returned by WithTx runs inside the caller's
+// transaction.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// inTx runs fn in a new transaction on db. When db is already a
+// transaction, fn runs in it and the caller that began it commits or rolls
+// back.
+func inTx(ctx context.Context, db dbtx, fn func(tx dbtx) error) error {
+	conn, ok := db.(*sql.DB)
+	if !ok {
+		return fn(db)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// analysisCategory is a category teams can be analyzed in.
+type analysisCategory struct {
+	Name string
+	// column is the player_projections column with the per-game
+	// projection.
+	column string
+	// average marks percentages, which are averaged over a team's starters
+	// rather than summed.
+	average       bool
+	lowerIsBetter bool
+}
+
+// projectedCategories are the categories player projections cover, which
+// are also the standard nine analyzed when a league's own are unknown.
+// Other league categories, such as double-doubles, are unsupported.
+var projectedCategories = []analysisCategory{
+	{Name: "PTS", column: "proj_pts"},
+	{Name: "REB", column: "proj_reb"},
+	{Name: "AST", column: "proj_ast"},
+	{Name: "STL", column: "proj_stl"},
+	{Name: "BLK", column: "proj_blk"},
+	{Name: "TO", column: "proj_to", lowerIsBetter: true},
+	{Name: "FG%", column: "proj_fg_pct", average: true},
+	{Name: "FT%", column: "proj_ft_pct", average: true},
+	{Name: "3PM", column: "proj_3pm"},
+}
+
+// defaultAnalysisCategories returns the nine standard categories.
+func defaultAnalysisCategories() []analysisCategory {
+	return projectedCategories
+}
+
+// analysisCategoriesFor returns the projected categories among a league's
+// stat categories, with the league's sort direction.
+// Categories without projections are left out. With none it returns the
+// standard nine.
+func analysisCategoriesFor(stored []repository.LeagueCategory) []analysisCategory {
+	var categories []analysisCategory
+	for _, lc := range stored {
+		for _, cat := range projectedCategories {
+			if strings.EqualFold(cat.Name, lc.Name) {
+				cat.lowerIsBetter = lc.LowerIsBetter
+				categories = append(categories, cat)
+				break
+			}
+		}
+	}
+	if len(categories) == 0 {
+		return defaultAnalysisCategories()
+	}
+	return categories
+}
+
+// unsupportedCategories returns the names of the league's stat categories
+// that player projections do not cover, which analyses leave out.
+func unsupportedCategories(stored []repository.LeagueCategory) []string {
+	var unsupported []string
+	for _, lc := range stored {
+		if !isProjectedCategory(lc.Name) {
+			unsupported = append(unsupported, lc.Name)
+		}
+	}
+	return unsupported
+}
+
+func isProjectedCategory(name string) bool {
+	for _, cat := range projectedCategories {
+		if strings.EqualFold(cat.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// leagueAnalysisCategories returns the categories to analyze the league
+// in, from its stored stat categories.
+func leagueAnalysisCategories(ctx context.Context, leagueRepo *repository.LeagueRepository, leagueID int) ([]analysisCategory, error) {
+	stored, err := leagueRepo.GetStatCategories(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	return analysisCategoriesFor(stored), nil
+}
+
+// getLeagueCategories returns the categories to analyze the league in.
+func (s *AnalysisService) getLeagueCategories(ctx context.Context, leagueID int) ([]analysisCategory, error) {
+	return leagueAnalysisCategories(ctx, s.leagueRepo, leagueID)
+}
+
+// leagueCategoriesFromSettings returns the league's scored categories.
+// Those player projections cover are named as projectedCategories names
+// them, and the rest by their Yahoo display name.
+func leagueCategoriesFromSettings(settings *yahoo.LeagueSettings) []repository.LeagueCategory {
+	scored := settings.ScoredCategories()
+	registry := yahoo.NewStatRegistry(scored)
+
+	names := make(map[int]string, len(projectedCategories))
+	for _, cat := range projectedCategories {
+		if statID, ok := registry.Lookup(cat.Name); ok {
+			names[statID] = cat.Name
+		}
+	}
+
+	categories := make([]repository.LeagueCategory, 0, len(scored))
+	for _, sc := range scored {
+		name, ok := names[sc.StatID]
+		if !ok {
+			name = sc.DisplayName
+		}
+		categories = append(categories, repository.LeagueCategory{
+			Name:          name,
+			LowerIsBetter: sc.SortOrder != 1,
+		})
+	}
+	return categories
+}
+
+// byCategory returns the totals keyed by category name.
+func (t TeamCategoryTotals) byCategory() map[string]float64 {
+	return CategoryProjections(t).byCategory()
+}
+
+// getTeamCategoryScores returns the team's saved z-score in each category.
+func (s *AnalysisService) getTeamCategoryScores(ctx context.Context, teamID int) (map[string]float64, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT category, zscore FROM team_category_scores WHERE team_id = ?`, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category scores: %w", err)
+	}
+	defer rows.Close()
+
+	scores := make(map[string]float64)
+	for rows.Next() {
+		var category string
+		var z float64
+		if err := rows.Scan(&category, &z); err != nil {
+			return nil, err
+		}
+		scores[category] = z
+	}
+
+	return scores, rows.Err()
+}
+
+// GetTeamAnalysis returns the team's analysis as AnalyzeAllTeams last saved
+// it: its score in every category and its three weakest and strongest,
+// along with the league categories it could not score.
+func (s *AnalysisService) GetTeamAnalysis(ctx context.Context, teamID int) (_ *TeamAnalysis, err error) {
+	ctx, span := startSpan(ctx, "AnalysisService.GetTeamAnalysis", attribute.Int("team.id", teamID))
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT ft.league_id,
+		       COALESCE(ta.weakest_cat_1, ''), COALESCE(ta.weakest_cat_2, ''), COALESCE(ta.weakest_cat_3, ''),
+		       COALESCE(ta.strongest_cat_1, ''), COALESCE(ta.strongest_cat_2, ''), COALESCE(ta.strongest_cat_3, '')
+		FROM team_analysis ta
+		JOIN fantasy_teams ft ON ta.team_id = ft.id
+		WHERE ta.team_id = ?
+	`
+
+	var leagueID int
+	var weak, strong [3]string
+	err = s.db.QueryRowContext(ctx, query, teamID).Scan(
+		&leagueID,
+		&weak[0], &weak[1], &weak[2],
+		&strong[0], &strong[1], &strong[2],
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := s.getTeamCategoryScores(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.leagueRepo.GetStatCategories(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &TeamAnalysis{
+		TeamID:                teamID,
+		CategoryScores:        scores,
+		UnsupportedCategories: unsupportedCategories(stored),
+	}
+	for _, cat := range weak {
+		if cat != "" {
+			analysis.WeakCategories = append(analysis.WeakCategories, CategoryScore{Category: cat, ZScore: scores[cat]})
+		}
+	}
+	for _, cat := range strong {
+		if cat != "" {
+			analysis.StrongCategories = append(analysis.StrongCategories, CategoryScore{Category: cat, ZScore: scores[cat]})
+		}
+	}
+
+	return analysis, nil
+}
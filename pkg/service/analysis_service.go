@@ -6,10 +6,33 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strings"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type AnalysisService struct {
-	db *sql.DB
+	db             *sql.DB
+	yahooClient    *yahoo.Client
+	playerRepo     *repository.PlayerRepository
+	teamRepo       *repository.TeamRepository
+	leagueRepo     *repository.LeagueRepository
+	matchupRepo    *repository.MatchupRepository
+	projectionRepo *repository.ProjectionRepository
+}
+
+// AnalysisServiceOption configures an AnalysisService.
+type AnalysisServiceOption func(*AnalysisService)
+
+// WithAnalysisYahooClient lets the analysis service read matchup results
+// from Yahoo, which power rankings need.
+func WithAnalysisYahooClient(client *yahoo.Client) AnalysisServiceOption {
+	return func(s *AnalysisService) {
+		s.yahooClient = client
+	}
 }
 
 type TeamAnalysis struct {
@@ -18,6 +41,9 @@ type TeamAnalysis struct {
 	WeakCategories   []CategoryScore
 	StrongCategories []CategoryScore
 	PositionNeeds    []string
+	// UnsupportedCategories are the league's categories player projections
+	// do not cover, such as double-doubles, which the scores leave out.
+	UnsupportedCategories []string
 }
 
 type CategoryScore struct {
@@ -37,34 +63,46 @@ type TeamCategoryTotals struct {
 	TPM   float64
 }
 
-func NewAnalysisService(db *sql.DB) *AnalysisService {
-	return &AnalysisService{db: db}
+func NewAnalysisService(db *sql.DB, opts ...AnalysisServiceOption) Analyzer {
+	s := &AnalysisService{
+		db:             db,
+		playerRepo:     repository.NewPlayerRepository(db),
+		teamRepo:       repository.NewTeamRepository(db),
+		leagueRepo:     repository.NewLeagueRepository(db),
+		matchupRepo:    repository.NewMatchupRepository(db),
+		projectionRepo: repository.NewProjectionRepository(db),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-func (s *AnalysisService) AnalyzeAllTeams(ctx context.Context, leagueID int) error {
+func (s *AnalysisService) AnalyzeAllTeams(ctx context.Context, leagueID int) (err error) {
+	ctx, span := startSpan(ctx, "AnalysisService.AnalyzeAllTeams", attribute.Int("league.id", leagueID))
+	defer func() { endSpan(span, err) }()
+
 	teams, err := s.getLeagueTeams(ctx, leagueID)
 	if err != nil {
 		return fmt.Errorf("failed to get teams: %w", err)
 	}
 
-	var teamTotals []struct {
-		TeamID int
-		Totals TeamCategoryTotals
+	categories, err := s.getLeagueCategories(ctx, leagueID)
+	if err != nil {
+		return fmt.Errorf("failed to get league categories: %w", err)
 	}
 
+	var allTotals []teamTotals
 	for _, teamID := range teams {
-		totals, err := s.calculateTeamCategoryTotals(ctx, teamID)
+		totals, err := s.calculateTeamCategoryTotals(ctx, teamID, categories)
 		if err != nil {
 			return fmt.Errorf("failed to calculate totals for team %d: %w", teamID, err)
 		}
-		teamTotals = append(teamTotals, struct {
-			TeamID int
-			Totals TeamCategoryTotals
-		}{teamID, totals})
+		allTotals = append(allTotals, teamTotals{TeamID: teamID, Totals: totals})
 	}
 
-	for _, team := range teamTotals {
-		analysis := s.analyzeTeam(team.TeamID, team.Totals, teamTotals)
+	for _, team := range allTotals {
+		analysis := s.analyzeTeam(team.TeamID, team.Totals, allTotals, categories)
 
 		positionNeeds, err := s.analyzePositionNeeds(ctx, team.TeamID)
 		if err != nil {
@@ -80,84 +118,78 @@ func (s *AnalysisService) AnalyzeAllTeams(ctx context.Context, leagueID int) err
 	return nil
 }
 
-func (s *AnalysisService) calculateTeamCategoryTotals(ctx context.Context, teamID int) (TeamCategoryTotals, error) {
+// teamTotals is a team's starters' totals in each analyzed category.
+type teamTotals struct {
+	TeamID int
+	Totals map[string]float64
+}
+
+// calculateTeamCategoryTotals sums the projections of the team's starters
+// in each category, averaging percentages.
+func (s *AnalysisService) calculateTeamCategoryTotals(ctx context.Context, teamID int, categories []analysisCategory) (map[string]float64, error) {
+	columns := make([]string, len(categories))
+	for i, cat := range categories {
+		aggregate := "SUM"
+		if cat.average {
+			aggregate = "AVG"
+		}
+		columns[i] = fmt.Sprintf("COALESCE(%s(pp.%s), 0)", aggregate, cat.column)
+	}
+
 	query := `
-		SELECT
-			SUM(proj_pts) as total_pts,
-			SUM(proj_reb) as total_reb,
-			SUM(proj_ast) as total_ast,
-			SUM(proj_stl) as total_stl,
-			SUM(proj_blk) as total_blk,
-			SUM(proj_to) as total_to,
-			AVG(proj_fg_pct) as avg_fg_pct,
-			AVG(proj_ft_pct) as avg_ft_pct,
-			SUM(proj_3pm) as total_3pm
+		SELECT ` + strings.Join(columns, ", ") + `
 		FROM fantasy_rosters fr
 		JOIN player_projections pp ON fr.player_id = pp.player_id
 		WHERE fr.team_id = ? AND fr.is_starting = 1
 	`
 
-	var totals TeamCategoryTotals
-	err := s.db.QueryRowContext(ctx, query, teamID).Scan(
-		&totals.PTS, &totals.REB, &totals.AST, &totals.STL,
-		&totals.BLK, &totals.TO, &totals.FGPct, &totals.FTPct, &totals.TPM,
-	)
-
-	return totals, err
-}
-
-func (s *AnalysisService) analyzeTeam(teamID int, totals TeamCategoryTotals, allTeams []struct {
-	TeamID int
-	Totals TeamCategoryTotals
-}) TeamAnalysis {
-	categories := map[string][]float64{
-		"PTS":   {},
-		"REB":   {},
-		"AST":   {},
-		"STL":   {},
-		"BLK":   {},
-		"TO":    {},
-		"FG%":   {},
-		"FT%":   {},
-		"3PM":   {},
+	values := make([]float64, len(categories))
+	dest := make([]interface{}, len(categories))
+	for i := range values {
+		dest[i] = &values[i]
 	}
-
-	for _, team := range allTeams {
-		categories["PTS"] = append(categories["PTS"], team.Totals.PTS)
-		categories["REB"] = append(categories["REB"], team.Totals.REB)
-		categories["AST"] = append(categories["AST"], team.Totals.AST)
-		categories["STL"] = append(categories["STL"], team.Totals.STL)
-		categories["BLK"] = append(categories["BLK"], team.Totals.BLK)
-		categories["TO"] = append(categories["TO"], team.Totals.TO)
-		categories["FG%"] = append(categories["FG%"], team.Totals.FGPct)
-		categories["FT%"] = append(categories["FT%"], team.Totals.FTPct)
-		categories["3PM"] = append(categories["3PM"], team.Totals.TPM)
+	if err := s.db.QueryRowContext(ctx, query, teamID).Scan(dest...); err != nil {
+		return nil, err
 	}
 
-	zScores := make(map[string]float64)
-	zScores["PTS"] = s.calculateZScore(totals.PTS, categories["PTS"])
-	zScores["REB"] = s.calculateZScore(totals.REB, categories["REB"])
-	zScores["AST"] = s.calculateZScore(totals.AST, categories["AST"])
-	zScores["STL"] = s.calculateZScore(totals.STL, categories["STL"])
-	zScores["BLK"] = s.calculateZScore(totals.BLK, categories["BLK"])
-	zScores["TO"] = s.calculateZScore(totals.TO, categories["TO"]) * -1
-	zScores["FG%"] = s.calculateZScore(totals.FGPct, categories["FG%"])
-	zScores["FT%"] = s.calculateZScore(totals.FTPct, categories["FT%"])
-	zScores["3PM"] = s.calculateZScore(totals.TPM, categories["3PM"])
+	totals := make(map[string]float64, len(categories))
+	for i, cat := range categories {
+		totals[cat.Name] = values[i]
+	}
+	return totals, nil
+}
 
+// analyzeTeam scores the team against the league in each category, with
+// categories where lower is better inverted so higher is always better,
+// and picks out its three weakest and strongest.
+func (s *AnalysisService) analyzeTeam(teamID int, totals map[string]float64, allTeams []teamTotals, categories []analysisCategory) TeamAnalysis {
+	zScores := make(map[string]float64, len(categories))
 	var scores []CategoryScore
-	for cat, score := range zScores {
-		scores = append(scores, CategoryScore{Category: cat, ZScore: score})
+	for _, cat := range categories {
+		values := make([]float64, 0, len(allTeams))
+		for _, team := range allTeams {
+			values = append(values, team.Totals[cat.Name])
+		}
+		z := s.calculateZScore(totals[cat.Name], values)
+		if cat.lowerIsBetter {
+			z = -z
+		}
+		zScores[cat.Name] = z
+		scores = append(scores, CategoryScore{Category: cat.Name, ZScore: z})
 	}
 
-	sort.Slice(scores, func(i, j int) bool {
+	sort.SliceStable(scores, func(i, j int) bool {
 		return scores[i].ZScore < scores[j].ZScore
 	})
 
-	weak := scores[:3]
-	strong := scores[len(scores)-3:]
+	n := 3
+	if len(scores) < n {
+		n = len(scores)
+	}
+	weak := append([]CategoryScore(nil), scores[:n]...)
+	strong := append([]CategoryScore(nil), scores[len(scores)-n:]...)
 
-	sort.Slice(strong, func(i, j int) bool {
+	sort.SliceStable(strong, func(i, j int) bool {
 		return strong[i].ZScore > strong[j].ZScore
 	})
 
@@ -232,42 +264,74 @@ func (s *AnalysisService) analyzePositionNeeds(ctx context.Context, teamID int)
 	return needs, nil
 }
 
+// saveTeamAnalysis saves the team's weakest and strongest categories and
+// position needs in team_analysis, and its score in every category in the
+// team_category_scores table:
+//
+//	CREATE TABLE team_category_scores (
+//		team_id  INTEGER NOT NULL REFERENCES fantasy_teams(id),
+//		category TEXT NOT NULL,
+//		zscore   REAL NOT NULL,
+//		PRIMARY KEY (team_id, category)
+//	);
+//
+// team_analysis's per-category z-score columns are no longer written.
 func (s *AnalysisService) saveTeamAnalysis(ctx context.Context, analysis TeamAnalysis) error {
-	query := `
-		INSERT OR REPLACE INTO team_analysis (
-			team_id, pts_zscore, reb_zscore, ast_zscore, stl_zscore, blk_zscore,
-			to_zscore, fg_pct_zscore, ft_pct_zscore, tpm_zscore,
-			weakest_cat_1, weakest_cat_2, weakest_cat_3,
-			strongest_cat_1, strongest_cat_2, strongest_cat_3,
-			needs_pg, needs_sg, needs_sf, needs_pf, needs_c
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	columns := []string{
+		"weakest_cat_1", "weakest_cat_2", "weakest_cat_3",
+		"strongest_cat_1", "strongest_cat_2", "strongest_cat_3",
+		"needs_pg", "needs_sg", "needs_sf", "needs_pf", "needs_c",
+	}
+	query := dialect.For(s.db).Upsert("team_analysis",
+		append([]string{"team_id"}, columns...), []string{"team_id"}, columns)
 
-	_, err := s.db.ExecContext(ctx, query,
+	_, err = tx.ExecContext(ctx, query,
 		analysis.TeamID,
-		analysis.CategoryScores["PTS"],
-		analysis.CategoryScores["REB"],
-		analysis.CategoryScores["AST"],
-		analysis.CategoryScores["STL"],
-		analysis.CategoryScores["BLK"],
-		analysis.CategoryScores["TO"],
-		analysis.CategoryScores["FG%"],
-		analysis.CategoryScores["FT%"],
-		analysis.CategoryScores["3PM"],
-		analysis.WeakCategories[0].Category,
-		analysis.WeakCategories[1].Category,
-		analysis.WeakCategories[2].Category,
-		analysis.StrongCategories[0].Category,
-		analysis.StrongCategories[1].Category,
-		analysis.StrongCategories[2].Category,
+		categoryAt(analysis.WeakCategories, 0),
+		categoryAt(analysis.WeakCategories, 1),
+		categoryAt(analysis.WeakCategories, 2),
+		categoryAt(analysis.StrongCategories, 0),
+		categoryAt(analysis.StrongCategories, 1),
+		categoryAt(analysis.StrongCategories, 2),
 		contains(analysis.PositionNeeds, "PG"),
 		contains(analysis.PositionNeeds, "SG"),
 		contains(analysis.PositionNeeds, "SF"),
 		contains(analysis.PositionNeeds, "PF"),
 		contains(analysis.PositionNeeds, "C"),
 	)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM team_category_scores WHERE team_id = ?`, analysis.TeamID); err != nil {
+		return err
+	}
+	for category, z := range analysis.CategoryScores {
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO team_category_scores (team_id, category, zscore) VALUES (?, ?, ?)`,
+			analysis.TeamID, category, z,
+		)
+		if err != nil {
+			return err
+		}
+	}
 
-	return err
+	return tx.Commit()
+}
+
+// categoryAt returns the name of the i-th category score, or "" if there
+// are fewer.
+func categoryAt(scores []CategoryScore, i int) string {
+	if i >= len(scores) {
+		return ""
+	}
+	return scores[i].Category
 }
 
 func (s *AnalysisService) getLeagueTeams(ctx context.Context, leagueID int) ([]int, error) {
@@ -1,8 +1,12 @@
 package service
 
 import (
+	"fmt"
 	"math"
+	"reflect"
 	"testing"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
 )
 
 func TestCalculateZScore(t *testing.T) {
@@ -67,16 +71,13 @@ func TestAnalyzeTeam(t *testing.T) {
 		TPM:   12.0,
 	}
 
-	allTeams := []struct {
-		TeamID int
-		Totals TeamCategoryTotals
-	}{
-		{1, totals},
-		{2, TeamCategoryTotals{PTS: 100.0, REB: 60.0, AST: 70.0, STL: 12.0, BLK: 10.0, TO: 18.0, FGPct: 0.43, FTPct: 0.75, TPM: 10.0}},
-		{3, TeamCategoryTotals{PTS: 110.0, REB: 55.0, AST: 75.0, STL: 11.0, BLK: 9.0, TO: 16.0, FGPct: 0.44, FTPct: 0.78, TPM: 11.0}},
+	allTeams := []teamTotals{
+		{1, totals.byCategory()},
+		{2, TeamCategoryTotals{PTS: 100.0, REB: 60.0, AST: 70.0, STL: 12.0, BLK: 10.0, TO: 18.0, FGPct: 0.43, FTPct: 0.75, TPM: 10.0}.byCategory()},
+		{3, TeamCategoryTotals{PTS: 110.0, REB: 55.0, AST: 75.0, STL: 11.0, BLK: 9.0, TO: 16.0, FGPct: 0.44, FTPct: 0.78, TPM: 11.0}.byCategory()},
 	}
 
-	analysis := service.analyzeTeam(teamID, totals, allTeams)
+	analysis := service.analyzeTeam(teamID, totals.byCategory(), allTeams, defaultAnalysisCategories())
 
 	if analysis.TeamID != teamID {
 		t.Errorf("TeamID incorrect: got %d, want %d", analysis.TeamID, teamID)
@@ -101,6 +102,48 @@ func TestAnalyzeTeam(t *testing.T) {
 	}
 }
 
+func TestAnalyzeTeamLeagueCategories(t *testing.T) {
+	service := &AnalysisService{}
+
+	// A league without turnovers that scores double-doubles.
+	stored := []repository.LeagueCategory{
+		{Name: "PTS"}, {Name: "REB"}, {Name: "AST"}, {Name: "STL"},
+		{Name: "BLK"}, {Name: "FG%"}, {Name: "FT%"}, {Name: "DD"},
+		{Name: "A/T"},
+	}
+	categories := analysisCategoriesFor(stored)
+	if len(categories) != 7 {
+		t.Fatalf("Expected the unprojected DD and A/T to be left out, got %d categories", len(categories))
+	}
+	if unsupported := unsupportedCategories(stored); !reflect.DeepEqual(unsupported, []string{"DD", "A/T"}) {
+		t.Errorf("unsupportedCategories() = %v, want [DD A/T]", unsupported)
+	}
+
+	allTeams := []teamTotals{
+		{1, map[string]float64{"PTS": 120, "REB": 40, "DD": 3, "TO": 30}},
+		{2, map[string]float64{"PTS": 100, "REB": 50, "DD": 1, "TO": 10}},
+	}
+
+	analysis := service.analyzeTeam(1, allTeams[0].Totals, allTeams, categories)
+
+	if len(analysis.CategoryScores) != 7 {
+		t.Errorf("Should have 7 category scores, got %d", len(analysis.CategoryScores))
+	}
+	if _, ok := analysis.CategoryScores["TO"]; ok {
+		t.Error("Turnovers should not be scored in a league without them")
+	}
+	if _, ok := analysis.CategoryScores["DD"]; ok {
+		t.Error("Double-doubles are not projected, so should not be scored")
+	}
+	if analysis.WeakCategories[0].Category != "REB" {
+		t.Errorf("Expected REB to be weakest, got %s", analysis.WeakCategories[0].Category)
+	}
+
+	if defaults := analysisCategoriesFor(nil); len(defaults) != 9 {
+		t.Errorf("Expected the nine standard categories for an unknown league, got %d", len(defaults))
+	}
+}
+
 func TestComplementaryScoreCalculation(t *testing.T) {
 	teamAWeak := []CategoryScore{
 		{Category: "REB", ZScore: -1.5},
@@ -260,3 +303,111 @@ func TestContains(t *testing.T) {
 		}
 	}
 }
+
+func TestSuggestPuntStrategies(t *testing.T) {
+	service := &AnalysisService{}
+
+	analysis := TeamAnalysis{
+		TeamID: 1,
+		CategoryScores: map[string]float64{
+			"PTS": 0.5,
+			"REB": 1.2,
+			"AST": -0.4,
+			"STL": 0.1,
+			"BLK": 0.9,
+			"TO":  0.3,
+			"FG%": 0.8,
+			"FT%": -1.5,
+			"3PM": -0.2,
+		},
+	}
+
+	players := []leaguePlayerProjection{
+		// Own team: a guard who carries FT% and a big who does not.
+		{PlayerID: 1, TeamID: 1, Position: "PG", Projections: CategoryProjections{PTS: 20, REB: 4, AST: 8, STL: 1.5, BLK: 0.2, TO: 3, FGPct: 0.44, FTPct: 0.90, TPM: 2.5}},
+		{PlayerID: 2, TeamID: 1, Position: "C", Projections: CategoryProjections{PTS: 14, REB: 11, AST: 1, STL: 0.6, BLK: 1.8, TO: 1.5, FGPct: 0.60, FTPct: 0.60, TPM: 0}},
+		// Other teams: a poor free throw shooting big and a sharpshooter.
+		{PlayerID: 3, TeamID: 2, Position: "C", Projections: CategoryProjections{PTS: 18, REB: 13, AST: 2, STL: 1, BLK: 2.5, TO: 2, FGPct: 0.62, FTPct: 0.55, TPM: 0.1}},
+		{PlayerID: 4, TeamID: 2, Position: "SG", Projections: CategoryProjections{PTS: 16, REB: 3, AST: 3, STL: 0.8, BLK: 0.1, TO: 1.2, FGPct: 0.45, FTPct: 0.88, TPM: 3}},
+	}
+
+	strategies := service.suggestPuntStrategies(analysis, players)
+
+	if len(strategies) != 3 {
+		t.Fatalf("Expected 3 strategies, got %d", len(strategies))
+	}
+	for i, want := range []string{"FT%", "AST", "3PM"} {
+		if strategies[i].Category != want {
+			t.Errorf("Strategy %d: got %s, want %s", i, strategies[i].Category, want)
+		}
+	}
+
+	ft := strategies[0]
+	if ft.Improvement <= 0 {
+		t.Errorf("Punting FT%% should improve the average z-score, got %.3f", ft.Improvement)
+	}
+	if !contains(ft.TargetPositions, "C") {
+		t.Errorf("Punting FT%% should target centers, got %v", ft.TargetPositions)
+	}
+	if ft.Guidance == "" {
+		t.Error("Expected roster guidance")
+	}
+
+	if len(ft.TradeTargets) != 1 || ft.TradeTargets[0].PlayerID != 3 {
+		t.Errorf("Expected player 3 as the only trade target, got %+v", ft.TradeTargets)
+	}
+	if len(ft.TradeAway) != 1 || ft.TradeAway[0].PlayerID != 1 {
+		t.Errorf("Expected player 1 as the only player to trade away, got %+v", ft.TradeAway)
+	}
+}
+
+func TestSuggestPuntStrategiesStrongTeam(t *testing.T) {
+	service := &AnalysisService{}
+
+	analysis := TeamAnalysis{
+		TeamID:         1,
+		CategoryScores: map[string]float64{"PTS": 0.5, "REB": 0.2, "AST": 0},
+	}
+
+	if strategies := service.suggestPuntStrategies(analysis, nil); len(strategies) != 0 {
+		t.Errorf("Expected no strategies for a team without weak categories, got %d", len(strategies))
+	}
+}
+
+func TestProjectMatchup(t *testing.T) {
+	guard := PlayerProjection{PlayerID: 1, PTS: 25, REB: 4, AST: 8, STL: 1.5, BLK: 0.2, TO: 3.5, FGPct: 0.45, FTPct: 0.88, TPM: 3.0}
+	wing := PlayerProjection{PlayerID: 2, PTS: 20, REB: 6, AST: 4, STL: 1.2, BLK: 0.6, TO: 2.0, FGPct: 0.47, FTPct: 0.82, TPM: 2.2}
+	big := PlayerProjection{PlayerID: 3, PTS: 15, REB: 11, AST: 2, STL: 0.6, BLK: 1.8, TO: 1.5, FGPct: 0.58, FTPct: 0.68, TPM: 0.3}
+
+	teamA := []PlayerProjection{guard, wing}
+	teamB := []PlayerProjection{big}
+
+	projection := projectMatchup(teamA, teamB, map[int]int{1: 4, 2: 4, 3: 2}, defaultAnalysisCategories())
+
+	if len(projection.CategoryWinProbabilities) != 9 {
+		t.Fatalf("Expected 9 categories, got %d", len(projection.CategoryWinProbabilities))
+	}
+	if math.Abs(projection.ExpectedTeamAWins+projection.ExpectedTeamBWins-9) > 0.001 {
+		t.Errorf("Expected wins should sum to 9, got %.2f + %.2f", projection.ExpectedTeamAWins, projection.ExpectedTeamBWins)
+	}
+	if projection.CategoryWinProbabilities["PTS"] < 0.9 {
+		t.Errorf("Team A should almost surely win points, got %.2f", projection.CategoryWinProbabilities["PTS"])
+	}
+	if projection.CategoryWinProbabilities["FG%"] > 0.1 {
+		t.Errorf("Team B should almost surely win FG%%, got %.2f", projection.CategoryWinProbabilities["FG%"])
+	}
+	if projection.CategoryWinProbabilities["TO"] > 0.1 {
+		t.Errorf("Team A's extra turnovers should lose the category, got %.2f", projection.CategoryWinProbabilities["TO"])
+	}
+	if projection.TeamAWinProbability < 0.5 {
+		t.Errorf("Team A should be favored, got %.2f", projection.TeamAWinProbability)
+	}
+
+	wins := int(math.Round(projection.ExpectedTeamAWins))
+	if want := fmt.Sprintf("%d-%d", wins, 9-wins); projection.Score != want {
+		t.Errorf("Score = %q, want %q", projection.Score, want)
+	}
+	if projection.TeamATotals.PTS != 180 {
+		t.Errorf("Team A points = %.0f, want 180 over 4 games each", projection.TeamATotals.PTS)
+	}
+}
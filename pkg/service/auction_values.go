@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// defaultAuctionBudget is each team's draft budget, Yahoo's default.
+	defaultAuctionBudget = 200
+	// minAuctionBid is the least a drafted player can cost.
+	minAuctionBid = 1
+	// defaultRosterSize is used for leagues without saved roster
+	// positions.
+	defaultRosterSize = 13
+)
+
+// AuctionService prices players for auction drafts and keeper decisions.
+type AuctionService struct {
+	db         *sql.DB
+	leagueRepo *repository.LeagueRepository
+	budget     int
+}
+
+// AuctionServiceOption configures an AuctionService.
+type AuctionServiceOption func(*AuctionService)
+
+// WithAuctionBudget sets each team's draft budget. It defaults to $200.
+func WithAuctionBudget(budget int) AuctionServiceOption {
+	return func(s *AuctionService) {
+		s.budget = budget
+	}
+}
+
+// AuctionValue is what a player is worth in the league's auction draft.
+type AuctionValue struct {
+	PlayerID   int
+	PlayerName string
+	Position   string
+	Rank       int
+	// Value is the player's z-score and VORP how far it is above the best
+	// player left once every roster is full.
+	Value float64
+	VORP  float64
+	// Dollars is zero for players who would not be drafted.
+	Dollars float64
+}
+
+// KeeperValue weighs what keeping a player costs against their auction
+// value.
+type KeeperValue struct {
+	PlayerID   int
+	PlayerName string
+	Dollars    float64
+	// KeeperRound and KeeperCost are the player's keeper price as recorded,
+	// a draft round or dollars; zero when not set. Cost is that price in
+	// dollars.
+	KeeperRound int
+	KeeperCost  float64
+	// DraftRound and DraftCost are where and for how much the player was
+	// drafted; zero when undrafted or the draft was not imported.
+	DraftRound int
+	DraftCost  float64
+	Cost       float64
+	// Surplus is Dollars minus Cost; keepers worth keeping have a positive
+	// surplus.
+	Surplus float64
+}
+
+// auctionPlayer is a player to price.
+type auctionPlayer struct {
+	PlayerID   int
+	PlayerName string
+	Position   string
+	Value      float64
+}
+
+func NewAuctionService(db *sql.DB, opts ...AuctionServiceOption) AuctionCalculator {
+	s := &AuctionService{
+		db:         db,
+		leagueRepo: repository.NewLeagueRepository(db),
+		budget:     defaultAuctionBudget,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// AuctionValues prices every player with a projection in the league, most
+// valuable first. Each team fills its roster, reserve slots excluded, so
+// the league drafts num_teams times that many players. Every drafted
+// player costs at least $1, and the rest of the league's money is split in
+// proportion to value over replacement, replacement being the first player
+// who would go undrafted. Values are the z-scores ValuationService saves,
+// so run it first.
+func (s *AuctionService) AuctionValues(ctx context.Context, leagueID int) (_ []AuctionValue, err error) {
+	ctx, span := startSpan(ctx, "AuctionService.AuctionValues", attribute.Int("league.id", leagueID))
+	defer func() { endSpan(span, err) }()
+
+	teams, rosterSize, err := s.getLeagueSize(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league size: %w", err)
+	}
+
+	players, err := s.getAuctionPlayers(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player values: %w", err)
+	}
+
+	return s.auctionValues(players, teams, rosterSize), nil
+}
+
+// KeeperValues weighs each of the team's players' auction value against
+// their keeper price, best surplus first. Keeper prices come from the
+// player_keeper_info table DynastyWeighting reads, with a column for
+// auction leagues, where keepers cost dollars rather than a draft round:
+//
+//	ALTER TABLE player_keeper_info ADD COLUMN keeper_cost INTEGER;
+//
+// A keeper round costs what the player expected to go in the middle of that
+// round is worth. Players without a keeper price cost what they were drafted
+// for, as imported by LeagueService.ImportDraft, and undrafted players the
+// minimum bid.
+func (s *AuctionService) KeeperValues(ctx context.Context, teamID int) (_ []KeeperValue, err error) {
+	ctx, span := startSpan(ctx, "AuctionService.KeeperValues", attribute.Int("team.id", teamID))
+	defer func() { endSpan(span, err) }()
+
+	var leagueID int
+	if err := s.db.QueryRowContext(ctx, `SELECT league_id FROM fantasy_teams WHERE id = ?`, teamID).Scan(&leagueID); err != nil {
+		return nil, fmt.Errorf("failed to get league ID: %w", err)
+	}
+
+	values, err := s.AuctionValues(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, _, err := s.getLeagueSize(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league size: %w", err)
+	}
+
+	keepers, err := s.getKeeperPrices(ctx, leagueID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keeper prices: %w", err)
+	}
+
+	return s.keeperValues(values, keepers, teams), nil
+}
+
+// auctionValues prices players for a league of teams rosters of rosterSize.
+func (s *AuctionService) auctionValues(players []auctionPlayer, teams, rosterSize int) []AuctionValue {
+	sorted := append([]auctionPlayer(nil), players...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Value > sorted[j].Value
+	})
+
+	drafted := teams * rosterSize
+	if drafted > len(sorted) {
+		drafted = len(sorted)
+	}
+
+	replacement := 0.0
+	switch {
+	case drafted < len(sorted):
+		replacement = sorted[drafted].Value
+	case drafted > 0:
+		replacement = sorted[drafted-1].Value
+	}
+
+	values := make([]AuctionValue, len(sorted))
+	totalVORP := 0.0
+	for i, p := range sorted {
+		values[i] = AuctionValue{
+			PlayerID:   p.PlayerID,
+			PlayerName: p.PlayerName,
+			Position:   p.Position,
+			Rank:       i + 1,
+			Value:      p.Value,
+			VORP:       p.Value - replacement,
+		}
+		if i < drafted && values[i].VORP > 0 {
+			totalVORP += values[i].VORP
+		}
+	}
+
+	surplus := float64(teams*s.budget - drafted*minAuctionBid)
+	for i := 0; i < drafted; i++ {
+		values[i].Dollars = minAuctionBid
+		if totalVORP > 0 && surplus > 0 && values[i].VORP > 0 {
+			values[i].Dollars += surplus * values[i].VORP / totalVORP
+		}
+	}
+
+	return values
+}
+
+// keeperPrice is a rostered player's keeper price.
+type keeperPrice struct {
+	PlayerID   int
+	Round      int
+	Cost       float64
+	DraftRound int
+	DraftCost  float64
+}
+
+// keeperValues prices each keeper against values, which must be in rank
+// order.
+func (s *AuctionService) keeperValues(values []AuctionValue, keepers []keeperPrice, teams int) []KeeperValue {
+	byPlayer := make(map[int]AuctionValue, len(values))
+	for _, v := range values {
+		byPlayer[v.PlayerID] = v
+	}
+
+	var result []KeeperValue
+	for _, k := range keepers {
+		v, ok := byPlayer[k.PlayerID]
+		if !ok {
+			continue
+		}
+
+		kv := KeeperValue{
+			PlayerID:    k.PlayerID,
+			PlayerName:  v.PlayerName,
+			Dollars:     v.Dollars,
+			KeeperRound: k.Round,
+			KeeperCost:  k.Cost,
+			DraftRound:  k.DraftRound,
+			DraftCost:   k.DraftCost,
+			Cost:        minAuctionBid,
+		}
+		switch {
+		case k.Cost > 0:
+			kv.Cost = k.Cost
+		case k.Round > 0:
+			kv.Cost = roundCost(values, k.Round, teams)
+		case k.DraftCost > 0:
+			kv.Cost = k.DraftCost
+		case k.DraftRound > 0:
+			kv.Cost = roundCost(values, k.DraftRound, teams)
+		}
+		kv.Surplus = kv.Dollars - kv.Cost
+		result = append(result, kv)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Surplus > result[j].Surplus
+	})
+	return result
+}
+
+// roundCost returns the dollar value of the player expected to be taken in
+// the middle of the draft round, or the minimum bid past the last drafted
+// player.
+func roundCost(values []AuctionValue, round, teams int) float64 {
+	pick := (round-1)*teams + (teams+1)/2
+	if pick < 1 || pick > len(values) || values[pick-1].Dollars < minAuctionBid {
+		return minAuctionBid
+	}
+	return values[pick-1].Dollars
+}
+
+// getLeagueSize returns the league's number of teams and how many players
+// each team drafts.
+func (s *AuctionService) getLeagueSize(ctx context.Context, leagueID int) (teams, rosterSize int, err error) {
+	if err := s.db.QueryRowContext(ctx, `SELECT num_teams FROM fantasy_leagues WHERE id = ?`, leagueID).Scan(&teams); err != nil {
+		return 0, 0, err
+	}
+
+	slots, err := s.leagueRepo.GetRosterPositions(ctx, leagueID)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, slot := range slots {
+		if !containsFold(reserveSlots, slot.Position) {
+			rosterSize += slot.Count
+		}
+	}
+	if rosterSize == 0 {
+		rosterSize = defaultRosterSize
+	}
+
+	return teams, rosterSize, nil
+}
+
+func (s *AuctionService) getAuctionPlayers(ctx context.Context, leagueID int) ([]auctionPlayer, error) {
+	query := `
+		SELECT p.id, p.full_name, COALESCE(pos.code, 'F'), pp.z_score
+		FROM player_projections pp
+		JOIN players p ON pp.player_id = p.id
+		LEFT JOIN player_positions plp ON p.id = plp.player_id AND plp.is_primary = 1
+		LEFT JOIN positions pos ON plp.position_id = pos.id
+		WHERE pp.league_id = ?
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []auctionPlayer
+	for rows.Next() {
+		var p auctionPlayer
+		if err := rows.Scan(&p.PlayerID, &p.PlayerName, &p.Position, &p.Value); err != nil {
+			return nil, err
+		}
+		players = append(players, p)
+	}
+
+	return players, rows.Err()
+}
+
+func (s *AuctionService) getKeeperPrices(ctx context.Context, leagueID, teamID int) ([]keeperPrice, error) {
+	query := `
+		SELECT fr.player_id, COALESCE(k.keeper_round, 0), COALESCE(k.keeper_cost, 0),
+		       COALESCE(dr.round, 0), COALESCE(dr.cost, 0)
+		FROM fantasy_rosters fr
+		LEFT JOIN player_keeper_info k ON k.player_id = fr.player_id AND k.league_id = ?
+		LEFT JOIN draft_results dr ON dr.player_id = fr.player_id AND dr.league_id = ?
+		WHERE fr.team_id = ?
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID, leagueID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var prices []keeperPrice
+	for rows.Next() {
+		var k keeperPrice
+		if err := rows.Scan(&k.PlayerID, &k.Round, &k.Cost, &k.DraftRound, &k.DraftCost); err != nil {
+			return nil, err
+		}
+		prices = append(prices, k)
+	}
+
+	return prices, rows.Err()
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAuctionValues(t *testing.T) {
+	service := &AuctionService{budget: 20}
+	players := []auctionPlayer{
+		{PlayerID: 1, Value: 5},
+		{PlayerID: 2, Value: 3},
+		{PlayerID: 3, Value: 2},
+		{PlayerID: 4, Value: 1},
+		{PlayerID: 5, Value: 0},
+		{PlayerID: 6, Value: -1},
+	}
+
+	// Two teams of two draft four players; player 5 is replacement.
+	values := service.auctionValues(players, 2, 2)
+
+	total := 0.0
+	for _, v := range values {
+		total += v.Dollars
+	}
+	if math.Abs(total-40) > 1e-9 {
+		t.Errorf("Expected the league's $40 to be spent, got $%.2f", total)
+	}
+
+	// $36 left after the $1 minimums, split 5:3:2:1 by VORP.
+	expected := []float64{1 + 36*5.0/11, 1 + 36*3.0/11, 1 + 36*2.0/11, 1 + 36*1.0/11, 0, 0}
+	for i, v := range values {
+		if v.Rank != i+1 || v.PlayerID != i+1 {
+			t.Errorf("Expected player %d at rank %d, got player %d at %d", i+1, i+1, v.PlayerID, v.Rank)
+		}
+		if math.Abs(v.Dollars-expected[i]) > 1e-9 {
+			t.Errorf("Player %d: $%.2f, want $%.2f", v.PlayerID, v.Dollars, expected[i])
+		}
+	}
+}
+
+func TestKeeperValues(t *testing.T) {
+	service := &AuctionService{budget: 20}
+	values := []AuctionValue{
+		{PlayerID: 1, Rank: 1, Dollars: 17},
+		{PlayerID: 2, Rank: 2, Dollars: 11},
+		{PlayerID: 3, Rank: 3, Dollars: 8},
+		{PlayerID: 4, Rank: 4, Dollars: 4},
+		{PlayerID: 5, Rank: 5},
+	}
+	keepers := []keeperPrice{
+		{PlayerID: 1, Cost: 25},
+		{PlayerID: 2, DraftRound: 1, DraftCost: 10},
+		{PlayerID: 3, Round: 1, DraftCost: 2},
+		{PlayerID: 4},
+		{PlayerID: 5, Round: 4},
+		{PlayerID: 9, Cost: 5},
+	}
+
+	result := service.keeperValues(values, keepers, 2)
+
+	if len(result) != 5 {
+		t.Fatalf("Expected players without a value to be skipped, got %d keepers", len(result))
+	}
+
+	got := make(map[int]KeeperValue)
+	for _, k := range result {
+		got[k.PlayerID] = k
+	}
+	if got[1].Cost != 25 || got[1].Surplus != -8 {
+		t.Errorf("Expected a dollar keeper cost to be used, got %+v", got[1])
+	}
+	if got[2].Cost != 10 || got[2].Surplus != 1 {
+		t.Errorf("Expected an unkept player to cost their draft price, got %+v", got[2])
+	}
+	// The middle of round one in a two-team league is the first pick.
+	if got[3].Cost != 17 || got[3].Surplus != -9 {
+		t.Errorf("Expected a first-round keeper to cost $17 over their draft price, got %+v", got[3])
+	}
+	if got[4].Cost != minAuctionBid || got[4].Surplus != 3 {
+		t.Errorf("Expected a player without a keeper price to cost the minimum bid, got %+v", got[4])
+	}
+	if got[5].Cost != minAuctionBid {
+		t.Errorf("Expected a round past the draft to cost the minimum bid, got %+v", got[5])
+	}
+	if result[0].PlayerID != 4 {
+		t.Errorf("Expected the best surplus first, got player %d", result[0].PlayerID)
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// ValuationServiceOption configures a ValuationService.
+type ValuationServiceOption func(*ValuationService)
+
+// WithPuntCategories leaves the given categories out of each player's total
+// z-score in category leagues, so rankings favor players who help the
+// remaining categories. The punted categories' z-scores are still stored.
+func WithPuntCategories(categories ...string) ValuationServiceOption {
+	return func(s *ValuationService) {
+		for _, category := range categories {
+			s.punts = append(s.punts, strings.ToUpper(strings.TrimSpace(category)))
+		}
+	}
+}
+
+// byCategory returns the projections keyed by category name.
+func (p CategoryProjections) byCategory() map[string]float64 {
+	return map[string]float64{
+		"PTS": p.PTS,
+		"REB": p.REB,
+		"AST": p.AST,
+		"STL": p.STL,
+		"BLK": p.BLK,
+		"TO":  p.TO,
+		"FG%": p.FGPct,
+		"FT%": p.FTPct,
+		"3PM": p.TPM,
+	}
+}
+
+// calculateCategoryZScores values players the way category leagues rank
+// them: each player's z-score in each of the nine categories, turnovers
+// inverted so higher is always better, summed into ZScore. Punted
+// categories are left out of the sum.
+//
+// FG% and FT% are valued by their impact on a team's percentage, the
+// player's percentage less the pool's times their attempts, so a
+// high-volume shooter counts for more than a 1-for-1 one. Means and
+// standard deviations are taken over the draftable pool: the poolSize
+// players with the highest z-scores against all players, or every player
+// when poolSize is 0 or larger than the player count.
+func (s *ValuationService) calculateCategoryZScores(players []PlayerValue, poolSize int) {
+	if len(players) == 0 {
+		return
+	}
+
+	s.applyCategoryZScores(players, players)
+	if poolSize <= 0 || poolSize >= len(players) {
+		return
+	}
+
+	pool := append([]PlayerValue(nil), players...)
+	sort.SliceStable(pool, func(i, j int) bool {
+		return pool[i].ZScore > pool[j].ZScore
+	})
+	s.applyCategoryZScores(players, pool[:poolSize])
+}
+
+// applyCategoryZScores sets the players' category z-scores against the
+// means and standard deviations of pool.
+func (s *ValuationService) applyCategoryZScores(players, pool []PlayerValue) {
+	var fgMade, fgAttempts, ftMade, ftAttempts float64
+	for _, p := range pool {
+		fgMade += p.Projections.FGPct * p.FGA
+		fgAttempts += p.FGA
+		ftMade += p.Projections.FTPct * p.FTA
+		ftAttempts += p.FTA
+	}
+	var fgPct, ftPct float64
+	if fgAttempts > 0 {
+		fgPct = fgMade / fgAttempts
+	}
+	if ftAttempts > 0 {
+		ftPct = ftMade / ftAttempts
+	}
+
+	// values returns the player's value in each category, with the
+	// percentage categories as their impact on the pool's percentage.
+	values := func(p PlayerValue) map[string]float64 {
+		v := p.Projections.byCategory()
+		v["FG%"] = (p.Projections.FGPct - fgPct) * p.FGA
+		v["FT%"] = (p.Projections.FTPct - ftPct) * p.FTA
+		return v
+	}
+
+	means := make(map[string]float64, len(categoryNames))
+	stdDevs := make(map[string]float64, len(categoryNames))
+	for _, cat := range categoryNames {
+		sum := 0.0
+		for _, p := range pool {
+			sum += values(p)[cat]
+		}
+		mean := sum / float64(len(pool))
+
+		variance := 0.0
+		for _, p := range pool {
+			diff := values(p)[cat] - mean
+			variance += diff * diff
+		}
+		means[cat] = mean
+		stdDevs[cat] = math.Sqrt(variance / float64(len(pool)))
+	}
+
+	for i := range players {
+		values := values(players[i])
+		z := make(map[string]float64, len(categoryNames))
+		total := 0.0
+		for _, cat := range categoryNames {
+			if stdDevs[cat] > 0 {
+				z[cat] = (values[cat] - means[cat]) / stdDevs[cat]
+			}
+			if cat == "TO" {
+				z[cat] = -z[cat]
+			}
+			if !contains(s.punts, cat) {
+				total += z[cat]
+			}
+		}
+
+		players[i].CategoryZScores = CategoryProjections{
+			PTS:   z["PTS"],
+			REB:   z["REB"],
+			AST:   z["AST"],
+			STL:   z["STL"],
+			BLK:   z["BLK"],
+			TO:    z["TO"],
+			FGPct: z["FG%"],
+			FTPct: z["FT%"],
+			TPM:   z["3PM"],
+		}
+		players[i].ZScore = total
+	}
+}
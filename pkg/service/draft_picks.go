@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DraftPick is a future draft pick traded as an asset. OriginalTeamID is the
+// team whose pick it is, which can differ from the team trading it.
+type DraftPick struct {
+	Year           int `json:"year"`
+	Round          int `json:"round"`
+	OriginalTeamID int `json:"original_team_id,omitempty"`
+}
+
+// PickValuation values draft picks in fantasy points per game, the unit
+// player trade values use, so picks and players can be weighed together.
+type PickValuation struct {
+	// RoundValues is the value of a pick in each round, first round first.
+	// Picks in later rounds are worth nothing.
+	RoundValues []float64
+	// YearDiscount is the fraction of value a pick loses for each season
+	// beyond the current one.
+	YearDiscount float64
+}
+
+// DefaultPickValuation returns a valuation in which a first-round pick is
+// worth about as much as a solid starter.
+func DefaultPickValuation() PickValuation {
+	return PickValuation{
+		RoundValues:  []float64{25, 18, 12, 8, 5, 3, 2, 1},
+		YearDiscount: 0.15,
+	}
+}
+
+// Value returns the pick's value given the league's current season.
+func (v PickValuation) Value(pick DraftPick, currentYear int) float64 {
+	if pick.Round < 1 || pick.Round > len(v.RoundValues) {
+		return 0
+	}
+	value := v.RoundValues[pick.Round-1]
+	if years := pick.Year - currentYear; years > 0 {
+		value *= math.Pow(1-v.YearDiscount, float64(years))
+	}
+	return value
+}
+
+// WithPickValuation replaces the default draft pick valuation.
+func WithPickValuation(valuation PickValuation) EvaluationServiceOption {
+	return func(s *EvaluationService) {
+		s.picks = &valuation
+	}
+}
+
+func (s *EvaluationService) pickValuation() PickValuation {
+	if s.picks != nil {
+		return *s.picks
+	}
+	return DefaultPickValuation()
+}
+
+// EvaluateTradeWithPicks evaluates a trade of players and draft picks. The
+// players are evaluated as EvaluateTrade does; the picks then count toward
+// each side's value and the fairness score.
+func (s *EvaluationService) EvaluateTradeWithPicks(
+	ctx context.Context,
+	leagueID int,
+	teamAID int,
+	teamAGives []int,
+	teamAPicks []DraftPick,
+	teamBID int,
+	teamBGives []int,
+	teamBPicks []DraftPick,
+) (_ *TradeEvaluation, err error) {
+	ctx, span := startSpan(ctx, "EvaluationService.EvaluateTradeWithPicks",
+		attribute.Int("league.id", leagueID),
+		attribute.Int("team_a.id", teamAID),
+		attribute.Int("team_b.id", teamBID),
+		attribute.Int("picks", len(teamAPicks)+len(teamBPicks)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	evaluation, err := s.EvaluateTrade(ctx, leagueID, teamAID, teamAGives, teamBID, teamBGives)
+	if err != nil {
+		return nil, err
+	}
+	if len(teamAPicks) == 0 && len(teamBPicks) == 0 {
+		return evaluation, nil
+	}
+
+	currentYear, err := s.getLeagueSeasonYear(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league season: %w", err)
+	}
+
+	teamAProjections, err := s.GetPlayerProjections(ctx, leagueID, teamAGives)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team A projections: %w", err)
+	}
+
+	teamBProjections, err := s.GetPlayerProjections(ctx, leagueID, teamBGives)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team B projections: %w", err)
+	}
+
+	s.applyPicks(evaluation, teamAProjections, teamAPicks, teamBProjections, teamBPicks, currentYear)
+	return evaluation, nil
+}
+
+// applyPicks adds the picks' value to each side of an evaluation of the
+// player-only trade.
+func (s *EvaluationService) applyPicks(
+	evaluation *TradeEvaluation,
+	teamAPlayers []PlayerProjection,
+	teamAPicks []DraftPick,
+	teamBPlayers []PlayerProjection,
+	teamBPicks []DraftPick,
+	currentYear int,
+) {
+	valuation := s.pickValuation()
+	teamAPickValue := 0.0
+	for _, pick := range teamAPicks {
+		teamAPickValue += valuation.Value(pick, currentYear)
+	}
+	teamBPickValue := 0.0
+	for _, pick := range teamBPicks {
+		teamBPickValue += valuation.Value(pick, currentYear)
+	}
+
+	evaluation.TeamAImpact.ValueChange += teamBPickValue - teamAPickValue
+	evaluation.TeamAImpact.NetBenefit += teamBPickValue - teamAPickValue
+	evaluation.TeamBImpact.ValueChange += teamAPickValue - teamBPickValue
+	evaluation.TeamBImpact.NetBenefit += teamAPickValue - teamBPickValue
+
+	evaluation.FairnessScore = s.calculateFairnessScore(
+		append(append([]PlayerProjection(nil), teamAPlayers...), PlayerProjection{FPG: teamAPickValue}),
+		append(append([]PlayerProjection(nil), teamBPlayers...), PlayerProjection{FPG: teamBPickValue}),
+	)
+	evaluation.IsFair = s.isFair(evaluation.FairnessScore)
+	evaluation.Recommendation = s.generateRecommendation(evaluation)
+}
+
+func (s *EvaluationService) getLeagueSeasonYear(ctx context.Context, leagueID int) (int, error) {
+	query := `SELECT season_year FROM fantasy_leagues WHERE id = ?`
+	var year int
+	err := s.db.QueryRowContext(ctx, query, leagueID).Scan(&year)
+	return year, err
+}
+
+// EvaluateProposal evaluates a proposal, including any draft picks in it.
+func (s *TradeService) EvaluateProposal(ctx context.Context, proposal *TradeProposal) (*TradeEvaluation, error) {
+	return s.evaluator.EvaluateTradeWithPicks(
+		ctx,
+		proposal.LeagueID,
+		proposal.TeamAID,
+		proposal.TeamAGives,
+		proposal.TeamAGivesPicks,
+		proposal.TeamBID,
+		proposal.TeamBGives,
+		proposal.TeamBGivesPicks,
+	)
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestPickValuation(t *testing.T) {
+	v := DefaultPickValuation()
+
+	tests := []struct {
+		name     string
+		pick     DraftPick
+		expected float64
+	}{
+		{"Current first", DraftPick{Year: 2025, Round: 1}, 25},
+		{"Current third", DraftPick{Year: 2025, Round: 3}, 12},
+		{"Next year first", DraftPick{Year: 2026, Round: 1}, 25 * 0.85},
+		{"Two years out", DraftPick{Year: 2027, Round: 2}, 18 * 0.85 * 0.85},
+		{"Beyond valued rounds", DraftPick{Year: 2025, Round: 14}, 0},
+		{"Invalid round", DraftPick{Year: 2025, Round: 0}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if value := v.Value(tt.pick, 2025); math.Abs(value-tt.expected) > 0.001 {
+				t.Errorf("Value = %.3f, want %.3f", value, tt.expected)
+			}
+		})
+	}
+}
+
+func TestApplyPicks(t *testing.T) {
+	service := NewEvaluationService(nil, WithPickValuation(PickValuation{RoundValues: []float64{10}})).(*EvaluationService)
+
+	teamA := []PlayerProjection{{FPG: 40}}
+	teamB := []PlayerProjection{{FPG: 30}}
+	evaluation := &TradeEvaluation{
+		TeamAImpact:   TradeImpact{ValueChange: -10, NetBenefit: -10},
+		TeamBImpact:   TradeImpact{ValueChange: 10, NetBenefit: 10},
+		FairnessScore: service.calculateFairnessScore(teamA, teamB),
+	}
+	if evaluation.FairnessScore >= 75 {
+		t.Fatalf("Players alone should be lopsided, got %.1f", evaluation.FairnessScore)
+	}
+
+	service.applyPicks(evaluation, teamA, nil, teamB, []DraftPick{{Year: 2025, Round: 1}}, 2025)
+
+	if evaluation.FairnessScore != 100 {
+		t.Errorf("A first-round pick should even the trade, got %.1f", evaluation.FairnessScore)
+	}
+	if !evaluation.IsFair {
+		t.Error("Expected the trade to be fair with the pick")
+	}
+	if evaluation.TeamAImpact.ValueChange != 0 || evaluation.TeamBImpact.ValueChange != 0 {
+		t.Errorf("ValueChange = %.1f / %.1f, want 0 / 0",
+			evaluation.TeamAImpact.ValueChange, evaluation.TeamBImpact.ValueChange)
+	}
+}
+
+func TestTradeDetailsJSON(t *testing.T) {
+	details := tradeDetails{
+		TeamAGives:      []int{1},
+		TeamBGives:      []int{2},
+		TeamBGivesPicks: []DraftPick{{Year: 2026, Round: 2, OriginalTeamID: 7}},
+	}
+
+	data, err := json.Marshal(details)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var legacy map[string]json.RawMessage
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		t.Fatal(err)
+	}
+	if string(legacy["team_a_gives"]) != "[1]" {
+		t.Errorf("team_a_gives = %s, want [1]", legacy["team_a_gives"])
+	}
+	if _, ok := legacy["team_a_gives_picks"]; ok {
+		t.Error("Empty pick lists should be omitted")
+	}
+
+	var decoded tradeDetails
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.TeamBGivesPicks) != 1 || decoded.TeamBGivesPicks[0] != details.TeamBGivesPicks[0] {
+		t.Errorf("TeamBGivesPicks = %+v", decoded.TeamBGivesPicks)
+	}
+}
+
+// pickEvaluator records the picks it is asked to evaluate.
+type pickEvaluator struct {
+	Evaluator
+	teamAPicks, teamBPicks []DraftPick
+}
+
+func (e *pickEvaluator) EvaluateTradeWithPicks(ctx context.Context, leagueID int, teamAID int, teamAGives []int, teamAPicks []DraftPick, teamBID int, teamBGives []int, teamBPicks []DraftPick) (*TradeEvaluation, error) {
+	e.teamAPicks, e.teamBPicks = teamAPicks, teamBPicks
+	return &TradeEvaluation{FairnessScore: 80}, nil
+}
+
+func TestEvaluateProposalUsesEvaluator(t *testing.T) {
+	evaluator := &pickEvaluator{}
+	service := NewTradeService(nil, evaluator, nil)
+
+	proposal := &TradeProposal{
+		TeamAGives:      []int{1},
+		TeamBGivesPicks: []DraftPick{{Year: 2027, Round: 1}},
+	}
+	evaluation, err := service.EvaluateProposal(context.Background(), proposal)
+	if err != nil {
+		t.Fatalf("EvaluateProposal failed: %v", err)
+	}
+
+	if evaluation.FairnessScore != 80 || len(evaluator.teamBPicks) != 1 || len(evaluator.teamAPicks) != 0 {
+		t.Errorf("EvaluateProposal did not pass the proposal's picks to the evaluator: %+v", evaluator)
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// DynastyWeighting adjusts a player's trade value in keeper and dynasty
+// leagues, where what a player is worth next season matters as much as
+// what they produce this one. Each input adds to or subtracts from a
+// multiplier on the player's fantasy points per game; inputs that are
+// unknown (zero) leave it alone.
+type DynastyWeighting struct {
+	// PeakAge is the age players are valued at face value.
+	PeakAge int
+	// DeclinePerYear is the discount for each year a player is older than
+	// PeakAge, and YouthBonusPerYear the premium for each year younger.
+	DeclinePerYear    float64
+	YouthBonusPerYear float64
+	// KeeperRoundBonus is the premium for a player kept with the last pick
+	// of the draft, scaled down linearly to nothing for a first-round
+	// keeper. DraftRounds is the number of rounds in the league's draft.
+	KeeperRoundBonus float64
+	DraftRounds      int
+	// ContractYearBonus is the premium for each contract year a player has
+	// beyond the current season.
+	ContractYearBonus float64
+	// MaxAdjustment caps how far the multiplier may move from 1 in either
+	// direction.
+	MaxAdjustment float64
+}
+
+// DefaultDynastyWeighting returns weights suited to a typical NBA keeper
+// league.
+func DefaultDynastyWeighting() DynastyWeighting {
+	return DynastyWeighting{
+		PeakAge:           27,
+		DeclinePerYear:    0.05,
+		YouthBonusPerYear: 0.03,
+		KeeperRoundBonus:  0.15,
+		DraftRounds:       13,
+		ContractYearBonus: 0.03,
+		MaxAdjustment:     0.3,
+	}
+}
+
+// Multiplier returns the factor the player's fantasy points per game are
+// scaled by.
+func (w DynastyWeighting) Multiplier(p PlayerProjection) float64 {
+	adjustment := 0.0
+
+	if p.Age > 0 && w.PeakAge > 0 {
+		years := float64(p.Age - w.PeakAge)
+		if years > 0 {
+			adjustment -= years * w.DeclinePerYear
+		} else {
+			adjustment -= years * w.YouthBonusPerYear
+		}
+	}
+
+	if p.KeeperRound > 0 && w.DraftRounds > 1 {
+		round := math.Min(float64(p.KeeperRound), float64(w.DraftRounds))
+		adjustment += w.KeeperRoundBonus * (round - 1) / float64(w.DraftRounds-1)
+	}
+
+	if p.ContractYears > 1 {
+		adjustment += float64(p.ContractYears-1) * w.ContractYearBonus
+	}
+
+	if w.MaxAdjustment > 0 {
+		adjustment = math.Max(-w.MaxAdjustment, math.Min(w.MaxAdjustment, adjustment))
+	}
+
+	return 1 + adjustment
+}
+
+// applyDynastyWeighting loads the players' keeper inputs from the
+// player_keeper_info table and scales their FPG:
+//
+//	CREATE TABLE player_keeper_info (
+//		league_id      INTEGER NOT NULL,
+//		player_id      INTEGER NOT NULL,
+//		age            INTEGER,
+//		keeper_round   INTEGER,
+//		contract_years INTEGER,
+//		PRIMARY KEY (league_id, player_id)
+//	);
+//
+// Players without a row are left unchanged.
+func (s *EvaluationService) applyDynastyWeighting(ctx context.Context, leagueID int, projections []PlayerProjection) error {
+	query := `
+		SELECT COALESCE(age, 0), COALESCE(keeper_round, 0), COALESCE(contract_years, 0)
+		FROM player_keeper_info
+		WHERE league_id = ? AND player_id = ?
+	`
+
+	for i := range projections {
+		p := &projections[i]
+		err := s.db.QueryRowContext(ctx, query, leagueID, p.PlayerID).Scan(&p.Age, &p.KeeperRound, &p.ContractYears)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get keeper info for player %d: %w", p.PlayerID, err)
+		}
+		p.FPG *= s.dynasty.Multiplier(*p)
+	}
+
+	return nil
+}
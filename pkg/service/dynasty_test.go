@@ -0,0 +1,82 @@
+package service
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDynastyMultiplier(t *testing.T) {
+	w := DefaultDynastyWeighting()
+
+	tests := []struct {
+		name     string
+		player   PlayerProjection
+		expected float64
+	}{
+		{
+			name:     "Unknown inputs",
+			player:   PlayerProjection{},
+			expected: 1.0,
+		},
+		{
+			name:     "Peak age",
+			player:   PlayerProjection{Age: 27},
+			expected: 1.0,
+		},
+		{
+			name:     "Aging veteran",
+			player:   PlayerProjection{Age: 33},
+			expected: 1.0 - 6*0.05,
+		},
+		{
+			name:     "Young player",
+			player:   PlayerProjection{Age: 21},
+			expected: 1.0 + 6*0.03,
+		},
+		{
+			name:     "Last-round keeper",
+			player:   PlayerProjection{KeeperRound: 13},
+			expected: 1.15,
+		},
+		{
+			name:     "First-round keeper",
+			player:   PlayerProjection{KeeperRound: 1},
+			expected: 1.0,
+		},
+		{
+			name:     "Long contract",
+			player:   PlayerProjection{ContractYears: 3},
+			expected: 1.06,
+		},
+		{
+			name:     "Capped premium",
+			player:   PlayerProjection{Age: 19, KeeperRound: 13, ContractYears: 4},
+			expected: 1.3,
+		},
+		{
+			name:     "Capped discount",
+			player:   PlayerProjection{Age: 38},
+			expected: 0.7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := w.Multiplier(tt.player)
+			if math.Abs(result-tt.expected) > 0.001 {
+				t.Errorf("Multiplier = %.3f, want %.3f", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWithDynastyWeighting(t *testing.T) {
+	if s := NewEvaluationService(nil).(*EvaluationService); s.dynasty != nil {
+		t.Error("Dynasty weighting should be off by default")
+	}
+
+	s := NewEvaluationService(nil, WithDynastyWeighting(DefaultDynastyWeighting())).(*EvaluationService)
+	if s.dynasty == nil || s.dynasty.PeakAge != 27 {
+		t.Errorf("dynasty = %+v, want the default weighting", s.dynasty)
+	}
+}
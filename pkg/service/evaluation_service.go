@@ -5,10 +5,25 @@ import (
 	"database/sql"
 	"fmt"
 	"math"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type EvaluationService struct {
-	db *sql.DB
+	db             *sql.DB
+	projectionRepo *repository.ProjectionRepository
+	teamRepo       *repository.TeamRepository
+	leagueRepo     *repository.LeagueRepository
+	matchupRepo    *repository.MatchupRepository
+	dynasty        *DynastyWeighting
+	picks          *PickValuation
+	// fairnessThreshold is the lowest fairness score counted as fair; 0
+	// means defaultFairnessThreshold.
+	fairnessThreshold float64
+	// matchups fetches the schedule LoadSchedule returns; nil reads the
+	// stored matchups.
+	matchups MatchupFetcher
 }
 
 type TradeImpact struct {
@@ -32,6 +47,7 @@ type TradeEvaluation struct {
 	FairnessScore  float64
 	IsFair         bool
 	Recommendation string
+	Mode           EvaluationMode
 }
 
 type PlayerProjection struct {
@@ -47,10 +63,47 @@ type PlayerProjection struct {
 	FTPct      float64
 	TPM        float64
 	Position   string
+
+	// Age, KeeperRound and ContractYears feed DynastyWeighting in keeper
+	// leagues. Zero means unknown.
+	Age           int
+	KeeperRound   int
+	ContractYears int
 }
 
-func NewEvaluationService(db *sql.DB) *EvaluationService {
-	return &EvaluationService{db: db}
+// EvaluationServiceOption configures an EvaluationService.
+type EvaluationServiceOption func(*EvaluationService)
+
+// WithDynastyWeighting adjusts player values for age, keeper cost and
+// contract length, for keeper and dynasty leagues.
+func WithDynastyWeighting(weighting DynastyWeighting) EvaluationServiceOption {
+	return func(s *EvaluationService) {
+		s.dynasty = &weighting
+	}
+}
+
+// WithFairnessThreshold sets the lowest fairness score, from 0 to 100, an
+// evaluation counts as fair. Pass the TradeConfig.FairnessThreshold the
+// trade service filters suggestions with, so that the suggestions it keeps
+// are not labelled unfair.
+func WithFairnessThreshold(threshold float64) EvaluationServiceOption {
+	return func(s *EvaluationService) {
+		s.fairnessThreshold = threshold
+	}
+}
+
+func NewEvaluationService(db *sql.DB, opts ...EvaluationServiceOption) Evaluator {
+	s := &EvaluationService{
+		db:             db,
+		projectionRepo: repository.NewProjectionRepository(db),
+		teamRepo:       repository.NewTeamRepository(db),
+		leagueRepo:     repository.NewLeagueRepository(db),
+		matchupRepo:    repository.NewMatchupRepository(db),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *EvaluationService) EvaluateTrade(
@@ -60,13 +113,28 @@ func (s *EvaluationService) EvaluateTrade(
 	teamAGives []int,
 	teamBID int,
 	teamBGives []int,
-) (*TradeEvaluation, error) {
-	teamAProjections, err := s.getPlayerProjections(ctx, leagueID, teamAGives)
+) (_ *TradeEvaluation, err error) {
+	ctx, span := startSpan(ctx, "EvaluationService.EvaluateTrade",
+		attribute.Int("league.id", leagueID),
+		attribute.Int("team_a.id", teamAID),
+		attribute.Int("team_b.id", teamBID),
+	)
+	defer func() { endSpan(span, err) }()
+
+	scoringType, err := s.getLeagueScoringType(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league scoring type: %w", err)
+	}
+	if evaluationModeFor(scoringType) == EvaluationModePoints {
+		return s.EvaluatePointsTrade(ctx, leagueID, teamAID, teamAGives, teamBID, teamBGives, nil)
+	}
+
+	teamAProjections, err := s.GetPlayerProjections(ctx, leagueID, teamAGives)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team A projections: %w", err)
 	}
 
-	teamBProjections, err := s.getPlayerProjections(ctx, leagueID, teamBGives)
+	teamBProjections, err := s.GetPlayerProjections(ctx, leagueID, teamBGives)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get team B projections: %w", err)
 	}
@@ -87,7 +155,8 @@ func (s *EvaluationService) EvaluateTrade(
 		TeamAImpact:   teamAImpact,
 		TeamBImpact:   teamBImpact,
 		FairnessScore: fairnessScore,
-		IsFair:        fairnessScore >= 75.0,
+		IsFair:        s.isFair(fairnessScore),
+		Mode:          EvaluationModeCategories,
 	}
 
 	evaluation.Recommendation = s.generateRecommendation(evaluation)
@@ -131,7 +200,7 @@ func (s *EvaluationService) calculateTeamImpact(
 	playersIn []PlayerProjection,
 	playersOut []PlayerProjection,
 ) (TradeImpact, error) {
-	currentTotals, err := s.getTeamCategoryTotals(ctx, teamID)
+	currentTotals, err := s.GetTeamCategoryTotals(ctx, teamID)
 	if err != nil {
 		return TradeImpact{}, err
 	}
@@ -205,6 +274,12 @@ func (s *EvaluationService) simulateTrade(
 	return result
 }
 
+// CategoryChanges returns how each counting category of the current totals
+// would change if playersOut were traded for playersIn.
+func (s *EvaluationService) CategoryChanges(current TeamCategoryTotals, playersIn, playersOut []PlayerProjection) []CategoryChange {
+	return s.calculateCategoryChanges(current, s.simulateTrade(current, playersIn, playersOut))
+}
+
 func (s *EvaluationService) calculateCategoryChanges(
 	before TeamCategoryTotals,
 	after TeamCategoryTotals,
@@ -290,6 +365,15 @@ func (s *EvaluationService) calculateNetBenefit(
 	return benefit
 }
 
+// isFair reports whether a fairness score reaches the service's threshold.
+func (s *EvaluationService) isFair(fairnessScore float64) bool {
+	threshold := s.fairnessThreshold
+	if threshold <= 0 {
+		threshold = defaultFairnessThreshold
+	}
+	return fairnessScore >= threshold
+}
+
 func (s *EvaluationService) generateRecommendation(eval *TradeEvaluation) string {
 	if !eval.IsFair {
 		return "Trade is imbalanced. Value difference too large."
@@ -318,7 +402,10 @@ func (s *EvaluationService) sumFPG(players []PlayerProjection) float64 {
 	return total
 }
 
-func (s *EvaluationService) getPlayerProjections(
+// GetPlayerProjections returns the league's projections for the players,
+// adjusted for dynasty leagues when the service weighs them. Players
+// without projections are left out.
+func (s *EvaluationService) GetPlayerProjections(
 	ctx context.Context,
 	leagueID int,
 	playerIDs []int,
@@ -362,10 +449,18 @@ func (s *EvaluationService) getPlayerProjections(
 		projections = append(projections, p)
 	}
 
+	if s.dynasty != nil {
+		if err := s.applyDynastyWeighting(ctx, leagueID, projections); err != nil {
+			return nil, err
+		}
+	}
+
 	return projections, nil
 }
 
-func (s *EvaluationService) getTeamCategoryTotals(
+// GetTeamCategoryTotals returns the summed projections of the team's
+// starters, with the percentage categories averaged.
+func (s *EvaluationService) GetTeamCategoryTotals(
 	ctx context.Context,
 	teamID int,
 ) (TeamCategoryTotals, error) {
@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// exportRow is a row read back from an export, keyed by column name. NULLs
+// are nil.
+type exportRow map[string]interface{}
+
+// snapshot is an export's rows by entity name.
+type snapshot map[string][]exportRow
+
+// Restore loads an export written by Export from dir into the database,
+// keeping the IDs rows were exported with so that references between them
+// still hold. Before anything is written, the export's schema version must
+// match ExportSchemaVersion and every foreign key in it must point at a row
+// in the export. The league must not already be in the database. Players
+// already in it are matched by Yahoo player key and reused, and the
+// export's rosters and projections are pointed at their IDs; a new player
+// whose exported ID belongs to another player gets a new ID the same way.
+// The restore runs in one transaction.
+//
+// Since rows keep their IDs, Postgres sequences behind id columns do not
+// advance and must be reset after a restore.
+func (s *ExportService) Restore(ctx context.Context, dir string) (_ *ExportManifest, err error) {
+	ctx, span := startSpan(ctx, "ExportService.Restore", attribute.String("dir", dir))
+	defer func() { endSpan(span, err) }()
+
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("league.id", manifest.LeagueID))
+
+	snap, err := readSnapshot(dir, manifest)
+	if err != nil {
+		return nil, err
+	}
+	if err := snap.validate(manifest.LeagueID); err != nil {
+		return nil, fmt.Errorf("invalid export: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var existing int
+	err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM fantasy_leagues WHERE id = ?`, manifest.LeagueID).Scan(&existing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for league %d: %w", manifest.LeagueID, err)
+	}
+	if existing > 0 {
+		return nil, fmt.Errorf("league %d already exists", manifest.LeagueID)
+	}
+
+	// playerIDs maps exported player IDs to the IDs they were restored as.
+	playerIDs := make(map[string]interface{})
+	for _, entity := range exportEntities {
+		for i, row := range snap[entity.name] {
+			for _, ref := range entity.refs {
+				if ref.entity != "players" {
+					continue
+				}
+				if id, ok := playerIDs[exportKey(row[ref.column])]; ok {
+					row[ref.column] = id
+				}
+			}
+
+			if entity.name == "players" {
+				id, err := s.restorePlayer(ctx, tx, entity.table, row)
+				if err != nil {
+					return nil, fmt.Errorf("failed to restore %s row %d: %w", entity.name, i+1, err)
+				}
+				playerIDs[exportKey(row["id"])] = id
+				continue
+			}
+
+			columns, args, err := row.columns()
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s row %d: %w", entity.name, i+1, err)
+			}
+			if _, err := tx.ExecContext(ctx, insertQuery(entity.table, columns), args...); err != nil {
+				return nil, fmt.Errorf("failed to restore %s row %d: %w", entity.name, i+1, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// restorePlayer writes an exported player and returns the ID the export's
+// references to them should use: the ID of the player already in the
+// database with the same Yahoo player key, the exported ID if it is free,
+// or a new one.
+func (s *ExportService) restorePlayer(ctx context.Context, tx *sql.Tx, table string, row exportRow) (interface{}, error) {
+	if row["id"] == nil || row["yahoo_player_key"] == nil {
+		return nil, fmt.Errorf("player has no id or yahoo_player_key")
+	}
+
+	var existing int64
+	err := tx.QueryRowContext(ctx, `SELECT id FROM `+table+` WHERE yahoo_player_key = ?`, row["yahoo_player_key"]).Scan(&existing)
+	if err == nil {
+		return existing, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to look up player: %w", err)
+	}
+
+	var taken int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM `+table+` WHERE id = ?`, row["id"]).Scan(&taken); err != nil {
+		return nil, fmt.Errorf("failed to check player id: %w", err)
+	}
+
+	if taken == 0 {
+		columns, args, err := row.columns()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, insertQuery(table, columns), args...); err != nil {
+			return nil, err
+		}
+		return row["id"], nil
+	}
+
+	fresh := make(exportRow, len(row)-1)
+	for column, v := range row {
+		if column != "id" {
+			fresh[column] = v
+		}
+	}
+	columns, args, err := fresh.columns()
+	if err != nil {
+		return nil, err
+	}
+	return dialect.For(s.db).InsertID(ctx, tx, insertQuery(table, columns), args...)
+}
+
+func insertQuery(table string, columns []string) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), placeholders)
+}
+
+func readManifest(dir string) (*ExportManifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var manifest ExportManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if manifest.SchemaVersion != ExportSchemaVersion {
+		return nil, fmt.Errorf("export has schema version %d, expected %d", manifest.SchemaVersion, ExportSchemaVersion)
+	}
+	if manifest.Format != ExportJSON && manifest.Format != ExportCSV {
+		return nil, fmt.Errorf("unsupported export format %q", manifest.Format)
+	}
+
+	return &manifest, nil
+}
+
+// readSnapshot reads the entity files the manifest lists. Files are looked
+// up by name in dir, so a manifest cannot point outside it.
+func readSnapshot(dir string, manifest *ExportManifest) (snapshot, error) {
+	known := make(map[string]bool, len(exportEntities))
+	for _, entity := range exportEntities {
+		known[entity.name] = true
+	}
+
+	snap := make(snapshot)
+	for _, e := range manifest.Entities {
+		if !known[e.Name] {
+			return nil, fmt.Errorf("export has unknown entity %q", e.Name)
+		}
+
+		f, err := os.Open(filepath.Join(dir, filepath.Base(e.File)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", e.Name, err)
+		}
+		rows, err := readRows(f, manifest.Format)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", e.Name, err)
+		}
+		if len(rows) != e.Rows {
+			return nil, fmt.Errorf("%s has %d rows, manifest lists %d", e.Name, len(rows), e.Rows)
+		}
+		snap[e.Name] = rows
+	}
+
+	return snap, nil
+}
+
+// readRows reads rows written by writeRows. JSON numbers are read as
+// int64 where they are whole and float64 otherwise; CSV fields are read as
+// strings, which the database converts for the column.
+func readRows(r io.Reader, format ExportFormat) ([]exportRow, error) {
+	if format == ExportCSV {
+		records, err := csv.NewReader(r).ReadAll()
+		if err != nil || len(records) == 0 {
+			return nil, err
+		}
+
+		header := records[0]
+		rows := make([]exportRow, 0, len(records)-1)
+		for _, record := range records[1:] {
+			row := make(exportRow, len(header))
+			for i, field := range record {
+				if field == "" {
+					row[header[i]] = nil
+				} else {
+					row[header[i]] = field
+				}
+			}
+			rows = append(rows, row)
+		}
+		return rows, nil
+	}
+
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var rows []exportRow
+	if err := dec.Decode(&rows); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		for column, v := range row {
+			n, ok := v.(json.Number)
+			if !ok {
+				continue
+			}
+			if i, err := n.Int64(); err == nil {
+				row[column] = i
+			} else if f, err := n.Float64(); err == nil {
+				row[column] = f
+			} else {
+				return nil, fmt.Errorf("invalid number %s in column %s", n, column)
+			}
+		}
+	}
+	return rows, nil
+}
+
+// validate checks that the snapshot holds the league and that every
+// non-NULL foreign key in it refers to a row in the snapshot.
+func (s snapshot) validate(leagueID int) error {
+	leagues := s["leagues"]
+	if len(leagues) != 1 || exportKey(leagues[0]["id"]) != strconv.Itoa(leagueID) {
+		return fmt.Errorf("expected league %d in leagues", leagueID)
+	}
+
+	ids := make(map[string]map[string]bool)
+	for _, entity := range exportEntities {
+		ids[entity.name] = make(map[string]bool)
+		for _, row := range s[entity.name] {
+			if id := row["id"]; id != nil {
+				ids[entity.name][exportKey(id)] = true
+			}
+		}
+	}
+
+	for _, entity := range exportEntities {
+		for i, row := range s[entity.name] {
+			for _, ref := range entity.refs {
+				v := row[ref.column]
+				if v == nil {
+					continue
+				}
+				if !ids[ref.entity][exportKey(v)] {
+					return fmt.Errorf("%s row %d: %s %v is not in %s", entity.name, i+1, ref.column, v, ref.entity)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// exportKey returns an ID read from either format in one form, so that the
+// JSON int64 3 and the CSV string "3" match.
+func exportKey(v interface{}) string {
+	return fmt.Sprint(v)
+}
+
+// columns returns the row's column names, sorted, and their values in the
+// same order. Column names are written into the INSERT, so anything other
+// than a plain identifier is rejected.
+func (r exportRow) columns() ([]string, []interface{}, error) {
+	columns := make([]string, 0, len(r))
+	for column := range r {
+		if !isColumnName(column) {
+			return nil, nil, fmt.Errorf("invalid column name %q", column)
+		}
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	args := make([]interface{}, len(columns))
+	for i, column := range columns {
+		args[i] = r[column]
+	}
+	return columns, args, nil
+}
+
+func isColumnName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if ch != '_' && (ch < 'a' || ch > 'z') && (ch < 'A' || ch > 'Z') && (i == 0 || ch < '0' || ch > '9') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const exportTestSchema = `
+	CREATE TABLE fantasy_leagues (id INTEGER PRIMARY KEY, name TEXT NOT NULL);
+	CREATE TABLE fantasy_teams (id INTEGER PRIMARY KEY, league_id INTEGER NOT NULL, name TEXT NOT NULL);
+	CREATE TABLE players (id INTEGER PRIMARY KEY, yahoo_player_key TEXT NOT NULL UNIQUE, name TEXT NOT NULL);
+	CREATE TABLE fantasy_rosters (id INTEGER PRIMARY KEY, team_id INTEGER NOT NULL, player_id INTEGER NOT NULL);
+	CREATE TABLE player_projections (league_id INTEGER NOT NULL, player_id INTEGER NOT NULL, points REAL);
+	CREATE TABLE team_analysis (team_id INTEGER PRIMARY KEY, overall_score REAL);
+	CREATE TABLE team_category_scores (team_id INTEGER NOT NULL, category TEXT NOT NULL, zscore REAL);
+	CREATE TABLE power_rankings (league_id INTEGER NOT NULL, week INTEGER NOT NULL, team_id INTEGER NOT NULL, rank INTEGER NOT NULL);
+	CREATE TABLE trade_proposals (id INTEGER PRIMARY KEY, league_id INTEGER NOT NULL, team_a_id INTEGER NOT NULL, team_b_id INTEGER NOT NULL, status TEXT);
+`
+
+func openExportTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(exportTestSchema); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	return db
+}
+
+func TestExportRestore(t *testing.T) {
+	ctx := context.Background()
+	src := openExportTestDB(t)
+	_, err := src.Exec(`
+		INSERT INTO fantasy_leagues VALUES (1, 'Main'), (2, 'Other');
+		INSERT INTO fantasy_teams VALUES (10, 1, 'Ballers'), (11, 1, 'Hoopers'), (20, 2, 'Elsewhere');
+		INSERT INTO players VALUES (100, 'nba.p.1', 'Guard'), (101, 'nba.p.2', 'Center'), (200, 'nba.p.3', 'Unrostered');
+		INSERT INTO fantasy_rosters VALUES (1, 10, 100), (2, 11, 101), (3, 20, 200);
+		INSERT INTO player_projections VALUES (1, 100, 31.5), (1, 101, NULL);
+		INSERT INTO team_analysis VALUES (10, 1.25), (11, -0.5);
+		INSERT INTO team_category_scores VALUES (10, 'PTS', 0.75);
+		INSERT INTO power_rankings VALUES (1, 3, 10, 1), (1, 3, 11, 2);
+		INSERT INTO trade_proposals VALUES (5, 1, 10, 11, 'suggested');
+	`)
+	if err != nil {
+		t.Fatalf("Failed to seed database: %v", err)
+	}
+
+	for _, format := range []ExportFormat{ExportJSON, ExportCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			dir := t.TempDir()
+			if _, err := NewExportService(src).Export(ctx, 1, dir, format); err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+
+			dst := openExportTestDB(t)
+			manifest, err := NewExportService(dst).Restore(ctx, dir)
+			if err != nil {
+				t.Fatalf("Restore failed: %v", err)
+			}
+			if manifest.LeagueID != 1 {
+				t.Errorf("Expected league 1, got %d", manifest.LeagueID)
+			}
+
+			for table, want := range map[string]int{
+				"fantasy_leagues": 1, "fantasy_teams": 2, "players": 2, "fantasy_rosters": 2,
+				"player_projections": 2, "team_analysis": 2, "team_category_scores": 1,
+				"power_rankings": 2, "trade_proposals": 1,
+			} {
+				var got int
+				if err := dst.QueryRow(`SELECT COUNT(*) FROM ` + table).Scan(&got); err != nil {
+					t.Fatalf("Failed to count %s: %v", table, err)
+				}
+				if got != want {
+					t.Errorf("Expected %d rows in %s, got %d", want, table, got)
+				}
+			}
+
+			var points sql.NullFloat64
+			if err := dst.QueryRow(`SELECT points FROM player_projections WHERE player_id = 101`).Scan(&points); err != nil {
+				t.Fatalf("Failed to read projection: %v", err)
+			}
+			if points.Valid {
+				t.Errorf("Expected a NULL projection to restore as NULL, got %v", points.Float64)
+			}
+
+			if _, err := NewExportService(dst).Restore(ctx, dir); err == nil || !strings.Contains(err.Error(), "already exists") {
+				t.Errorf("Expected restoring the league twice to fail, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRestoreMatchesPlayersByKey(t *testing.T) {
+	ctx := context.Background()
+	src := openExportTestDB(t)
+	_, err := src.Exec(`
+		INSERT INTO fantasy_leagues VALUES (1, 'Main');
+		INSERT INTO fantasy_teams VALUES (10, 1, 'Ballers'), (11, 1, 'Hoopers');
+		INSERT INTO players VALUES (100, 'nba.p.1', 'Guard'), (101, 'nba.p.2', 'Center');
+		INSERT INTO fantasy_rosters VALUES (1, 10, 100), (2, 11, 101);
+		INSERT INTO player_projections VALUES (1, 100, 31.5), (1, 101, 28.0);
+	`)
+	if err != nil {
+		t.Fatalf("Failed to seed database: %v", err)
+	}
+
+	for _, format := range []ExportFormat{ExportJSON, ExportCSV} {
+		t.Run(string(format), func(t *testing.T) {
+			dir := t.TempDir()
+			if _, err := NewExportService(src).Export(ctx, 1, dir, format); err != nil {
+				t.Fatalf("Export failed: %v", err)
+			}
+
+			// Another player has the guard's ID, and the center is already
+			// in the database under a different one.
+			dst := openExportTestDB(t)
+			_, err := dst.Exec(`INSERT INTO players VALUES (100, 'nba.p.9', 'Someone Else'), (7, 'nba.p.2', 'Center')`)
+			if err != nil {
+				t.Fatalf("Failed to seed database: %v", err)
+			}
+
+			if _, err := NewExportService(dst).Restore(ctx, dir); err != nil {
+				t.Fatalf("Restore failed: %v", err)
+			}
+
+			var players int
+			if err := dst.QueryRow(`SELECT COUNT(*) FROM players`).Scan(&players); err != nil {
+				t.Fatal(err)
+			}
+			if players != 3 {
+				t.Errorf("Expected 3 players, got %d", players)
+			}
+
+			var name string
+			if err := dst.QueryRow(`SELECT name FROM players WHERE id = 100`).Scan(&name); err != nil {
+				t.Fatal(err)
+			}
+			if name != "Someone Else" {
+				t.Errorf("Expected player 100 to be left alone, got %q", name)
+			}
+
+			for _, tt := range []struct {
+				query string
+				key   string
+			}{
+				{`SELECT p.yahoo_player_key FROM fantasy_rosters r JOIN players p ON r.player_id = p.id WHERE r.team_id = 10`, "nba.p.1"},
+				{`SELECT p.yahoo_player_key FROM fantasy_rosters r JOIN players p ON r.player_id = p.id WHERE r.team_id = 11`, "nba.p.2"},
+				{`SELECT p.yahoo_player_key FROM player_projections pp JOIN players p ON pp.player_id = p.id WHERE pp.points = 31.5`, "nba.p.1"},
+				{`SELECT p.yahoo_player_key FROM player_projections pp JOIN players p ON pp.player_id = p.id WHERE pp.points = 28.0`, "nba.p.2"},
+			} {
+				var key string
+				if err := dst.QueryRow(tt.query).Scan(&key); err != nil {
+					t.Fatalf("%s: %v", tt.query, err)
+				}
+				if key != tt.key {
+					t.Errorf("%s: expected %s, got %s", tt.query, tt.key, key)
+				}
+			}
+		})
+	}
+}
+
+func TestRestoreRejectsInvalidExports(t *testing.T) {
+	ctx := context.Background()
+	src := openExportTestDB(t)
+	_, err := src.Exec(`
+		INSERT INTO fantasy_leagues VALUES (1, 'Main');
+		INSERT INTO fantasy_teams VALUES (10, 1, 'Ballers');
+		INSERT INTO trade_proposals VALUES (5, 1, 10, 10, 'suggested');
+	`)
+	if err != nil {
+		t.Fatalf("Failed to seed database: %v", err)
+	}
+
+	export := func(t *testing.T) string {
+		dir := t.TempDir()
+		if _, err := NewExportService(src).Export(ctx, 1, dir, ExportJSON); err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+		return dir
+	}
+	rewrite := func(t *testing.T, path, old, new string) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), old) {
+			t.Fatalf("%s does not contain %q", path, old)
+		}
+		if err := os.WriteFile(path, []byte(strings.Replace(string(data), old, new, 1)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("schema version", func(t *testing.T) {
+		dir := export(t)
+		rewrite(t, filepath.Join(dir, "manifest.json"), `"schema_version": 1`, `"schema_version": 99`)
+		_, err := NewExportService(openExportTestDB(t)).Restore(ctx, dir)
+		if err == nil || !strings.Contains(err.Error(), "schema version 99") {
+			t.Errorf("Expected a schema version error, got %v", err)
+		}
+	})
+
+	t.Run("foreign key", func(t *testing.T) {
+		dir := export(t)
+		rewrite(t, filepath.Join(dir, "trade_proposals.json"), `"team_b_id":10`, `"team_b_id":11`)
+		dst := openExportTestDB(t)
+		_, err := NewExportService(dst).Restore(ctx, dir)
+		if err == nil || !strings.Contains(err.Error(), "team_b_id 11 is not in teams") {
+			t.Errorf("Expected a foreign key error, got %v", err)
+		}
+
+		var leagues int
+		if err := dst.QueryRow(`SELECT COUNT(*) FROM fantasy_leagues`).Scan(&leagues); err != nil {
+			t.Fatal(err)
+		}
+		if leagues != 0 {
+			t.Errorf("Expected nothing restored from an invalid export, got %d leagues", leagues)
+		}
+	})
+}
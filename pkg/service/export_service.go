@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ExportSchemaVersion is the version of the database schema exports are
+// written from. Restoring checks it against the version an export records.
+const ExportSchemaVersion = 1
+
+// ExportFormat is the file format of an export.
+type ExportFormat string
+
+const (
+	// ExportJSON writes each entity as a JSON array of objects keyed by
+	// column name.
+	ExportJSON ExportFormat = "json"
+	// ExportCSV writes each entity as CSV with a header row of column
+	// names. NULLs are written as empty fields, and empty fields are
+	// restored as NULL.
+	ExportCSV ExportFormat = "csv"
+)
+
+// exportEntity is a table exported for a league. Each ? in query is bound
+// to the league ID. Entities are listed parents first, so that restoring
+// them in order satisfies foreign keys.
+type exportEntity struct {
+	name  string
+	table string
+	query string
+	// refs are the entity's foreign keys, checked before a restore.
+	refs []exportRef
+}
+
+// exportRef is a column holding the id of a row in another entity.
+type exportRef struct {
+	column string
+	entity string
+}
+
+var exportEntities = []exportEntity{
+	{"leagues", "fantasy_leagues", `SELECT * FROM fantasy_leagues WHERE id = ?`, nil},
+	{"teams", "fantasy_teams", `SELECT * FROM fantasy_teams WHERE league_id = ?`, []exportRef{
+		{"league_id", "leagues"},
+	}},
+	{"players", "players", `
+		SELECT * FROM players WHERE id IN (
+			SELECT r.player_id FROM fantasy_rosters r JOIN fantasy_teams t ON r.team_id = t.id WHERE t.league_id = ?
+			UNION
+			SELECT player_id FROM player_projections WHERE league_id = ?
+		)`, nil},
+	{"rosters", "fantasy_rosters", `
+		SELECT r.* FROM fantasy_rosters r
+		JOIN fantasy_teams t ON r.team_id = t.id
+		WHERE t.league_id = ?`, []exportRef{
+		{"team_id", "teams"},
+		{"player_id", "players"},
+	}},
+	{"projections", "player_projections", `SELECT * FROM player_projections WHERE league_id = ?`, []exportRef{
+		{"league_id", "leagues"},
+		{"player_id", "players"},
+	}},
+	{"team_analysis", "team_analysis", `
+		SELECT a.* FROM team_analysis a
+		JOIN fantasy_teams t ON a.team_id = t.id
+		WHERE t.league_id = ?`, []exportRef{
+		{"team_id", "teams"},
+	}},
+	{"team_category_scores", "team_category_scores", `
+		SELECT c.* FROM team_category_scores c
+		JOIN fantasy_teams t ON c.team_id = t.id
+		WHERE t.league_id = ?`, []exportRef{
+		{"team_id", "teams"},
+	}},
+	{"power_rankings", "power_rankings", `SELECT * FROM power_rankings WHERE league_id = ?`, []exportRef{
+		{"league_id", "leagues"},
+		{"team_id", "teams"},
+	}},
+	{"trade_proposals", "trade_proposals", `SELECT * FROM trade_proposals WHERE league_id = ?`, []exportRef{
+		{"league_id", "leagues"},
+		{"team_a_id", "teams"},
+		{"team_b_id", "teams"},
+	}},
+}
+
+// ExportService writes a league's stored data to files, for backup or for
+// analysis in other tools.
+type ExportService struct {
+	db *sql.DB
+}
+
+// ExportManifest describes an export. It is written with the entity files
+// as manifest.json.
+type ExportManifest struct {
+	SchemaVersion int              `json:"schema_version"`
+	LeagueID      int              `json:"league_id"`
+	Format        ExportFormat     `json:"format"`
+	ExportedAt    time.Time        `json:"exported_at"`
+	Entities      []ExportedEntity `json:"entities"`
+}
+
+// ExportedEntity is the file an entity was written to, relative to the
+// export's directory, and how many rows it holds.
+type ExportedEntity struct {
+	Name  string `json:"name"`
+	Table string `json:"table"`
+	File  string `json:"file"`
+	Rows  int    `json:"rows"`
+}
+
+func NewExportService(db *sql.DB) Exporter {
+	return &ExportService{db: db}
+}
+
+// Export writes the league, its teams, the players on their rosters or
+// with projections, rosters, projections, team analyses, power rankings
+// and trade proposals to dir, one file per entity, along with
+// manifest.json. dir is created if needed, and files already in it are
+// overwritten.
+func (s *ExportService) Export(ctx context.Context, leagueID int, dir string, format ExportFormat) (_ *ExportManifest, err error) {
+	ctx, span := startSpan(ctx, "ExportService.Export",
+		attribute.Int("league.id", leagueID),
+		attribute.String("format", string(format)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	if format != ExportJSON && format != ExportCSV {
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	manifest := &ExportManifest{
+		SchemaVersion: ExportSchemaVersion,
+		LeagueID:      leagueID,
+		Format:        format,
+		ExportedAt:    time.Now().UTC(),
+	}
+	for _, entity := range exportEntities {
+		file := entity.name + "." + string(format)
+		rows, err := s.exportFile(ctx, entity, leagueID, filepath.Join(dir, file), format)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export %s: %w", entity.name, err)
+		}
+		manifest.Entities = append(manifest.Entities, ExportedEntity{
+			Name:  entity.name,
+			Table: entity.table,
+			File:  file,
+			Rows:  rows,
+		})
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), data, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	return manifest, nil
+}
+
+func (s *ExportService) exportFile(ctx context.Context, entity exportEntity, leagueID int, path string, format ExportFormat) (n int, err error) {
+	args := make([]interface{}, strings.Count(entity.query, "?"))
+	for i := range args {
+		args[i] = leagueID
+	}
+	rows, err := s.db.QueryContext(ctx, entity.query+" ORDER BY 1", args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	return writeRows(rows, f, format)
+}
+
+// writeRows writes every row in rows to w in the format and returns how
+// many it wrote.
+func writeRows(rows *sql.Rows, w io.Writer, format ExportFormat) (int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	var cw *csv.Writer
+	switch format {
+	case ExportCSV:
+		cw = csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return 0, err
+		}
+	case ExportJSON:
+		if _, err := io.WriteString(w, "["); err != nil {
+			return 0, err
+		}
+	}
+
+	values := make([]interface{}, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+
+	n := 0
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return n, err
+		}
+
+		switch format {
+		case ExportCSV:
+			record := make([]string, len(columns))
+			for i, v := range values {
+				record[i] = csvValue(exportValue(v))
+			}
+			if err := cw.Write(record); err != nil {
+				return n, err
+			}
+		case ExportJSON:
+			object := make(map[string]interface{}, len(columns))
+			for i, v := range values {
+				object[columns[i]] = exportValue(v)
+			}
+			data, err := json.Marshal(object)
+			if err != nil {
+				return n, err
+			}
+			sep := ",\n  "
+			if n == 0 {
+				sep = "\n  "
+			}
+			if _, err := io.WriteString(w, sep+string(data)); err != nil {
+				return n, err
+			}
+		}
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return n, err
+	}
+
+	switch format {
+	case ExportCSV:
+		cw.Flush()
+		return n, cw.Error()
+	default:
+		_, err = io.WriteString(w, "\n]\n")
+		return n, err
+	}
+}
+
+// exportValue converts a scanned column value into one that encodes the
+// same way in JSON and CSV: text as strings and times in RFC 3339.
+func exportValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	default:
+		return v
+	}
+}
+
+func csvValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
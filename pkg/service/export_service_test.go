@@ -0,0 +1,62 @@
+package service
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestWriteRows(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	query := `
+		SELECT 1 AS id, 'Smith, Jr.' AS name, 1.5 AS score, NULL AS note
+		UNION ALL
+		SELECT 2, 'Jones', 0.25, 'keeper'
+		ORDER BY 1
+	`
+
+	for _, tt := range []struct {
+		format ExportFormat
+		want   string
+	}{
+		{ExportCSV, "id,name,score,note\n1,\"Smith, Jr.\",1.5,\n2,Jones,0.25,keeper\n"},
+		{ExportJSON, ""},
+	} {
+		rows, err := db.Query(query)
+		if err != nil {
+			t.Fatalf("Failed to query: %v", err)
+		}
+		var buf bytes.Buffer
+		n, err := writeRows(rows, &buf, tt.format)
+		rows.Close()
+		if err != nil {
+			t.Fatalf("writeRows(%s) failed: %v", tt.format, err)
+		}
+		if n != 2 {
+			t.Errorf("Expected 2 %s rows, got %d", tt.format, n)
+		}
+
+		if tt.format == ExportCSV {
+			if buf.String() != tt.want {
+				t.Errorf("Unexpected CSV:\n%s", buf.String())
+			}
+			continue
+		}
+
+		var got []map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("Export is not valid JSON: %v\n%s", err, buf.String())
+		}
+		if len(got) != 2 || got[0]["name"] != "Smith, Jr." || got[0]["note"] != nil || got[1]["score"] != 0.25 {
+			t.Errorf("Unexpected JSON rows: %+v", got)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// PositionUpgrade is a free agent who would improve on a rostered player at
+// the rostered player's position.
+type PositionUpgrade struct {
+	Position string
+	Add      WaiverPlayer
+	Drop     WaiverPlayer
+	// ValueGain is how much higher the free agent's z-score is.
+	ValueGain float64
+}
+
+// FindUpgrades compares each of the team's players, reserve slots aside,
+// with the best available free agent eligible at their primary position,
+// and returns the swaps that would gain value, largest gain first. Each
+// free agent is suggested once: the team's weakest players pick first.
+//
+// Free agents come from Yahoo when the service has a Yahoo client, and are
+// otherwise the league's projected players no team has rostered.
+func (s *AnalysisService) FindUpgrades(ctx context.Context, teamID int) (_ []PositionUpgrade, err error) {
+	ctx, span := startSpan(ctx, "AnalysisService.FindUpgrades", attribute.Int("team.id", teamID))
+	defer func() { endSpan(span, err) }()
+
+	var leagueID int
+	var gameKey, yahooLeagueID string
+	query := `
+		SELECT ft.league_id, fl.yahoo_game_key, fl.yahoo_league_id
+		FROM fantasy_teams ft
+		JOIN fantasy_leagues fl ON ft.league_id = fl.id
+		WHERE ft.id = ?
+	`
+	if err := s.db.QueryRowContext(ctx, query, teamID).Scan(&leagueID, &gameKey, &yahooLeagueID); err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	var freeAgents []waiverCandidate
+	if s.yahooClient != nil {
+		var keys []string
+		keys, err = getFreeAgentKeys(ctx, s.yahooClient, fmt.Sprintf("%s.l.%s", gameKey, yahooLeagueID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get free agents: %w", err)
+		}
+		freeAgents, err = getWaiverCandidates(ctx, s.db, leagueID, keys)
+	} else {
+		freeAgents, err = s.getUnrosteredPlayers(ctx, leagueID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to value free agents: %w", err)
+	}
+
+	roster, err := getDroppablePlayers(ctx, s.db, leagueID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster: %w", err)
+	}
+
+	eligible, err := s.playerRepo.GetEligiblePositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eligible positions: %w", err)
+	}
+
+	return s.findUpgrades(roster, freeAgents, eligible), nil
+}
+
+// findUpgrades pairs rostered players, weakest first, with the best
+// remaining free agent eligible at their position. Free agents without
+// eligibility data are taken to play only their primary position.
+func (s *AnalysisService) findUpgrades(roster, freeAgents []waiverCandidate, eligible map[int][]string) []PositionUpgrade {
+	roster = append([]waiverCandidate(nil), roster...)
+	sort.SliceStable(roster, func(i, j int) bool {
+		return roster[i].zScore < roster[j].zScore
+	})
+
+	freeAgents = append([]waiverCandidate(nil), freeAgents...)
+	sort.SliceStable(freeAgents, func(i, j int) bool {
+		return freeAgents[i].zScore > freeAgents[j].zScore
+	})
+
+	taken := make(map[int]bool)
+	var upgrades []PositionUpgrade
+	for _, r := range roster {
+		position := r.player.Position
+		for _, fa := range freeAgents {
+			if fa.zScore <= r.zScore {
+				break
+			}
+			if taken[fa.player.PlayerID] {
+				continue
+			}
+			positions := eligible[fa.player.PlayerID]
+			if len(positions) == 0 {
+				positions = []string{fa.player.Position}
+			}
+			if !containsFold(positions, position) {
+				continue
+			}
+
+			taken[fa.player.PlayerID] = true
+			add, drop := fa.player, r.player
+			add.Value, drop.Value = fa.zScore, r.zScore
+			upgrades = append(upgrades, PositionUpgrade{
+				Position:  position,
+				Add:       add,
+				Drop:      drop,
+				ValueGain: fa.zScore - r.zScore,
+			})
+			break
+		}
+	}
+
+	sort.SliceStable(upgrades, func(i, j int) bool {
+		return upgrades[i].ValueGain > upgrades[j].ValueGain
+	})
+	return upgrades
+}
+
+// getUnrosteredPlayers returns the league's projected players that no team
+// in it has rostered.
+func (s *AnalysisService) getUnrosteredPlayers(ctx context.Context, leagueID int) ([]waiverCandidate, error) {
+	query := `
+		SELECT p.id, p.yahoo_player_key, p.full_name, COALESCE(pos.code, 'F'),
+		       pp.z_score, pp.z_pts, pp.z_reb, pp.z_ast, pp.z_stl, pp.z_blk,
+		       pp.z_to, pp.z_fg_pct, pp.z_ft_pct, pp.z_3pm
+		FROM players p
+		JOIN player_projections pp ON p.id = pp.player_id AND pp.league_id = ?
+		LEFT JOIN player_positions plp ON p.id = plp.player_id AND plp.is_primary = 1
+		LEFT JOIN positions pos ON plp.position_id = pos.id
+		WHERE p.id NOT IN (
+			SELECT fr.player_id
+			FROM fantasy_rosters fr
+			JOIN fantasy_teams ft ON fr.team_id = ft.id
+			WHERE ft.league_id = ?
+		)
+	`
+
+	return queryCandidates(ctx, s.db, query, leagueID, leagueID)
+}
+
+// RecommendUpgrades turns the team's position upgrades from FindUpgrades
+// into add/drop moves, with FAAB bids in leagues that use FAAB, best first.
+// It returns at most limit moves, or all of them if limit is 0.
+func (s *WaiverService) RecommendUpgrades(ctx context.Context, teamID int, limit int) (_ []WaiverRecommendation, err error) {
+	ctx, span := startSpan(ctx, "WaiverService.RecommendUpgrades",
+		attribute.Int("team.id", teamID),
+		attribute.Int("limit", limit),
+	)
+	defer func() { endSpan(span, err) }()
+
+	var leagueID int
+	var teamKey, gameKey, yahooLeagueID string
+	query := `
+		SELECT ft.league_id, ft.yahoo_team_key, fl.yahoo_game_key, fl.yahoo_league_id
+		FROM fantasy_teams ft
+		JOIN fantasy_leagues fl ON ft.league_id = fl.id
+		WHERE ft.id = ?
+	`
+	if err := s.db.QueryRowContext(ctx, query, teamID).Scan(&leagueID, &teamKey, &gameKey, &yahooLeagueID); err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	if err := s.ensureValues(ctx, leagueID); err != nil {
+		return nil, err
+	}
+
+	upgrades, err := s.analysis.FindUpgrades(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	budget, err := s.getFAABBudget(ctx, fmt.Sprintf("%s.l.%s", gameKey, yahooLeagueID), teamKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get FAAB budget: %w", err)
+	}
+
+	if limit > 0 && len(upgrades) > limit {
+		upgrades = upgrades[:limit]
+	}
+	recommendations := make([]WaiverRecommendation, 0, len(upgrades))
+	for _, u := range upgrades {
+		recommendations = append(recommendations, WaiverRecommendation{
+			Add:       u.Add,
+			Drop:      u.Drop,
+			ValueGain: u.ValueGain,
+			FAABBid:   faabBid(u.ValueGain, budget),
+		})
+	}
+	return recommendations, nil
+}
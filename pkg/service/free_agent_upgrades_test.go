@@ -0,0 +1,46 @@
+package service
+
+import "testing"
+
+func TestFindUpgrades(t *testing.T) {
+	service := &AnalysisService{}
+
+	candidate := func(id int, position string, z float64) waiverCandidate {
+		return waiverCandidate{player: WaiverPlayer{PlayerID: id, Position: position}, zScore: z}
+	}
+	roster := []waiverCandidate{
+		candidate(1, "PG", 2.0),
+		candidate(2, "C", -0.5),
+		candidate(3, "C", 0.2),
+		candidate(4, "SF", 1.0),
+	}
+	freeAgents := []waiverCandidate{
+		candidate(10, "PG", 1.5),
+		candidate(11, "C", 0.8),
+		candidate(12, "PF", 0.6),
+		candidate(13, "SG", 3.0),
+	}
+	eligible := map[int][]string{
+		12: {"PF", "C"},
+	}
+
+	upgrades := service.findUpgrades(roster, freeAgents, eligible)
+
+	if len(upgrades) != 2 {
+		t.Fatalf("Expected 2 upgrades, got %+v", upgrades)
+	}
+
+	// The weaker center picks first and gets the best center.
+	if upgrades[0].Drop.PlayerID != 2 || upgrades[0].Add.PlayerID != 11 || upgrades[0].ValueGain != 1.3 {
+		t.Errorf("Expected player 11 for player 2 first, got %+v", upgrades[0])
+	}
+	// The next center gets the power forward who is also center-eligible.
+	if upgrades[1].Drop.PlayerID != 3 || upgrades[1].Add.PlayerID != 12 || upgrades[1].Position != "C" {
+		t.Errorf("Expected player 12 for player 3, got %+v", upgrades[1])
+	}
+	for _, u := range upgrades {
+		if u.Add.PlayerID == 13 || u.Add.PlayerID == 10 {
+			t.Errorf("Free agents should only replace players at their positions with a gain, got %+v", u)
+		}
+	}
+}
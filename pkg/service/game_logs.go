@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SyncGameLogs stores the weekly stat lines of every rostered player in the
+// league for each of the weeks, replacing weeks already synced. Consistency,
+// recent-form and trend analyses read them back through
+// repository.GameLogRepository.
+func (s *LeagueService) SyncGameLogs(ctx context.Context, leagueID int, weeks []int) (err error) {
+	ctx, span := startSpan(ctx, "LeagueService.SyncGameLogs",
+		attribute.Int("league.id", leagueID),
+		attribute.Int("weeks", len(weeks)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	startedAt := time.Now()
+	synced := 0
+	defer func() { s.recordSync(ctx, leagueID, "game_logs", synced, startedAt, err) }()
+
+	var gameKey, yahooLeagueID string
+	var season int
+	query := `SELECT yahoo_game_key, yahoo_league_id, season_year FROM fantasy_leagues WHERE id = ?`
+	if err := s.db.QueryRowContext(ctx, query, leagueID).Scan(&gameKey, &yahooLeagueID, &season); err != nil {
+		return fmt.Errorf("failed to get league: %w", err)
+	}
+	leagueKey := fmt.Sprintf("%s.l.%s", gameKey, yahooLeagueID)
+
+	playerIDs, err := s.getRosteredPlayerKeys(ctx, leagueID)
+	if err != nil {
+		return fmt.Errorf("failed to get rostered players: %w", err)
+	}
+	if len(playerIDs) == 0 {
+		return nil
+	}
+	// Sorted so each week's request, and its cache key, is the same from
+	// one sync to the next.
+	keys := make([]string, 0, len(playerIDs))
+	for key := range playerIDs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, week := range weeks {
+		players, err := s.yahooClient.GetPlayersStats(ctx, leagueKey, keys, week)
+		if err != nil {
+			return fmt.Errorf("failed to fetch week %d stats: %w", week, err)
+		}
+
+		var logs []repository.GameLog
+		for _, p := range players {
+			playerID, ok := playerIDs[p.PlayerKey]
+			if !ok || p.PlayerStats == nil {
+				continue
+			}
+			logs = append(logs, gameLogFromStats(playerID, season, week, p.PlayerStats.Stats))
+		}
+
+		if err := s.gameLogRepo.Save(ctx, logs); err != nil {
+			return fmt.Errorf("failed to save week %d game logs: %w", week, err)
+		}
+		synced += len(logs)
+		s.logger.Debug("synced game logs", "league_key", leagueKey, "week", week, "players", len(logs))
+	}
+
+	return nil
+}
+
+// gameLogFromStats converts a player's Yahoo stats for one week to a game
+// log. Percentages are left out; they follow from the makes and attempts.
+func gameLogFromStats(playerID, season, week int, stats []yahoo.Stat) repository.GameLog {
+	nba, _ := yahoo.ParseNBAStats(stats)
+	return repository.GameLog{
+		PlayerID:    playerID,
+		Season:      season,
+		Week:        week,
+		GamesPlayed: nba.GamesPlayed,
+		Minutes:     nba.Minutes,
+		FGM:         nba.FGM,
+		FGA:         nba.FGA,
+		FTM:         nba.FTM,
+		FTA:         nba.FTA,
+		TPM:         nba.ThreePointsMade,
+		Points:      nba.Points,
+		Rebounds:    nba.Rebounds,
+		Assists:     nba.Assists,
+		Steals:      nba.Steals,
+		Blocks:      nba.Blocks,
+		Turnovers:   nba.Turnovers,
+	}
+}
+
+// getRosteredPlayerKeys maps the Yahoo player keys of the league's rostered
+// players to their player IDs.
+func (s *LeagueService) getRosteredPlayerKeys(ctx context.Context, leagueID int) (map[string]int, error) {
+	query := `
+		SELECT DISTINCT p.yahoo_player_key, p.id
+		FROM fantasy_rosters fr
+		JOIN fantasy_teams ft ON fr.team_id = ft.id
+		JOIN players p ON fr.player_id = p.id
+		WHERE ft.league_id = ?
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make(map[string]int)
+	for rows.Next() {
+		var key string
+		var id int
+		if err := rows.Scan(&key, &id); err != nil {
+			return nil, err
+		}
+		keys[key] = id
+	}
+
+	return keys, rows.Err()
+}
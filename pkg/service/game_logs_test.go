@@ -0,0 +1,38 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+)
+
+func TestGameLogFromStats(t *testing.T) {
+	stats := []yahoo.Stat{
+		{StatID: yahoo.StatIDGamesPlayed, Value: "3"},
+		{StatID: yahoo.StatIDMinutesPlayed, Value: "101:30"},
+		{StatID: yahoo.StatIDFGM, Value: "24"},
+		{StatID: yahoo.StatIDFGA, Value: "50"},
+		{StatID: yahoo.StatIDFTM, Value: "10"},
+		{StatID: yahoo.StatIDFTA, Value: "12"},
+		{StatID: yahoo.StatID3PM, Value: "7"},
+		{StatID: yahoo.StatIDPoints, Value: "65"},
+		{StatID: yahoo.StatIDRebounds, Value: "14"},
+		{StatID: yahoo.StatIDAssists, Value: "21"},
+		{StatID: yahoo.StatIDTurnovers, Value: "-"},
+	}
+
+	log := gameLogFromStats(7, 2025, 4, stats)
+
+	if log.PlayerID != 7 || log.Season != 2025 || log.Week != 4 {
+		t.Errorf("Expected the log to be keyed by player, season and week, got %+v", log)
+	}
+	if log.GamesPlayed != 3 || log.Minutes != 101.5 {
+		t.Errorf("Expected 3 games in 101.5 minutes, got %d in %v", log.GamesPlayed, log.Minutes)
+	}
+	if log.FGM != 24 || log.FGA != 50 || log.FTM != 10 || log.FTA != 12 {
+		t.Errorf("Expected makes and attempts to be kept, got %+v", log)
+	}
+	if log.Points != 65 || log.Assists != 21 || log.Turnovers != 0 {
+		t.Errorf("Expected unparseable stats to be zero, got %+v", log)
+	}
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// minTendencyMatchups is how many matchups against an opponent a category
+// tendency needs before it is reported.
+const minTendencyMatchups = 2
+
+// HeadToHeadReport is a team's record against each opponent it has played.
+type HeadToHeadReport struct {
+	LeagueID int
+	TeamID   int
+	TeamKey  string
+	// Opponents is ordered by matchups played, then by name.
+	Opponents []OpponentRecord
+}
+
+// OpponentRecord is a team's history against one opponent.
+type OpponentRecord struct {
+	OpponentID   int
+	OpponentKey  string
+	OpponentName string
+	Matchups     int
+	Wins         int
+	Losses       int
+	Ties         int
+	// AverageMargin is the team's average score minus the opponent's:
+	// fantasy points in points leagues, categories won in category leagues.
+	AverageMargin float64
+	// Categories holds the team's record in each category, most lopsided
+	// first. It is empty in points leagues.
+	Categories []CategoryRecord
+	// Tendencies describes the lopsided categories, such as "You always
+	// lose FT% to Team X".
+	Tendencies []string
+}
+
+// CategoryRecord is a team's record in one category against an opponent.
+type CategoryRecord struct {
+	Category string
+	Wins     int
+	Losses   int
+	Ties     int
+}
+
+// winRate returns the share of the category's matchups won, ties counting
+// as half.
+func (r CategoryRecord) winRate() float64 {
+	n := r.Wins + r.Losses + r.Ties
+	if n == 0 {
+		return 0.5
+	}
+	return (float64(r.Wins) + 0.5*float64(r.Ties)) / float64(n)
+}
+
+// HeadToHead reports the team's record, average margin and category
+// tendencies against every opponent it has played, from the completed
+// matchups of the season so far. Without a Yahoo client it uses the
+// matchups stored by LeagueService.SyncMatchups.
+func (s *AnalysisService) HeadToHead(ctx context.Context, teamID int) (_ *HeadToHeadReport, err error) {
+	ctx, span := startSpan(ctx, "AnalysisService.HeadToHead", attribute.Int("team.id", teamID))
+	defer func() { endSpan(span, err) }()
+
+	var leagueID, currentWeek int
+	var teamKey, gameKey, yahooLeagueID string
+	query := `
+		SELECT ft.league_id, ft.yahoo_team_key, fl.yahoo_game_key, fl.yahoo_league_id, fl.current_week
+		FROM fantasy_teams ft
+		JOIN fantasy_leagues fl ON ft.league_id = fl.id
+		WHERE ft.id = ?
+	`
+	if err := s.db.QueryRowContext(ctx, query, teamID).Scan(&leagueID, &teamKey, &gameKey, &yahooLeagueID, &currentWeek); err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+	leagueKey := fmt.Sprintf("%s.l.%s", gameKey, yahooLeagueID)
+
+	categories, err := s.statNames(ctx, leagueID, leagueKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stat categories: %w", err)
+	}
+
+	var matchups []yahoo.Matchup
+	for week := 1; week <= currentWeek; week++ {
+		weekMatchups, err := s.weekMatchups(ctx, leagueID, leagueKey, week)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch week %d matchups: %w", week, err)
+		}
+		matchups = append(matchups, weekMatchups...)
+	}
+
+	teamIDs, err := s.teamRepo.GetIDsByYahooKey(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %w", err)
+	}
+
+	report := &HeadToHeadReport{
+		LeagueID:  leagueID,
+		TeamID:    teamID,
+		TeamKey:   teamKey,
+		Opponents: headToHead(teamKey, matchups, categories),
+	}
+	for i := range report.Opponents {
+		report.Opponents[i].OpponentID = teamIDs[report.Opponents[i].OpponentKey]
+	}
+	return report, nil
+}
+
+// statNames maps the league's stat IDs to their display names, from Yahoo
+// or from the stored scoreboard when the service has no Yahoo client.
+func (s *AnalysisService) statNames(ctx context.Context, leagueID int, leagueKey string) (map[int]string, error) {
+	if s.yahooClient == nil {
+		return s.matchupRepo.GetStatNames(ctx, leagueID)
+	}
+
+	statCategories, err := s.yahooClient.GetLeagueStatCategories(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	categories := make(map[int]string, len(statCategories))
+	for _, cat := range statCategories {
+		categories[cat.StatID] = cat.DisplayName
+	}
+	return categories, nil
+}
+
+// headToHead builds the team's record against each opponent from completed
+// matchups. categories maps stat IDs to the names tendencies use.
+func headToHead(teamKey string, matchups []yahoo.Matchup, categories map[int]string) []OpponentRecord {
+	records := make(map[string]*OpponentRecord)
+	categoryRecords := make(map[string]map[string]*CategoryRecord)
+	margins := make(map[string]float64)
+
+	for _, m := range matchups {
+		if m.Status != "postevent" || len(m.Teams) != 2 {
+			continue
+		}
+		var team, opponent yahoo.MatchupTeam
+		switch teamKey {
+		case m.Teams[0].TeamKey:
+			team, opponent = m.Teams[0], m.Teams[1]
+		case m.Teams[1].TeamKey:
+			team, opponent = m.Teams[1], m.Teams[0]
+		default:
+			continue
+		}
+
+		r, ok := records[opponent.TeamKey]
+		if !ok {
+			r = &OpponentRecord{OpponentKey: opponent.TeamKey, OpponentName: opponent.Name}
+			records[opponent.TeamKey] = r
+			categoryRecords[opponent.TeamKey] = make(map[string]*CategoryRecord)
+		}
+		r.Matchups++
+		switch {
+		case m.IsTied:
+			r.Ties++
+		case m.WinnerTeamKey == teamKey:
+			r.Wins++
+		default:
+			r.Losses++
+		}
+
+		if len(m.StatWinners) == 0 {
+			margins[opponent.TeamKey] += team.Points - opponent.Points
+			continue
+		}
+		wins, losses, _ := m.CategoryRecord(teamKey)
+		margins[opponent.TeamKey] += float64(wins - losses)
+
+		for _, sw := range m.StatWinners {
+			name, ok := categories[sw.StatID]
+			if !ok {
+				name = fmt.Sprintf("stat %d", sw.StatID)
+			}
+			cr, ok := categoryRecords[opponent.TeamKey][name]
+			if !ok {
+				cr = &CategoryRecord{Category: name}
+				categoryRecords[opponent.TeamKey][name] = cr
+			}
+			switch {
+			case sw.IsTied:
+				cr.Ties++
+			case sw.WinnerTeamKey == teamKey:
+				cr.Wins++
+			default:
+				cr.Losses++
+			}
+		}
+	}
+
+	opponents := make([]OpponentRecord, 0, len(records))
+	for key, r := range records {
+		r.AverageMargin = margins[key] / float64(r.Matchups)
+		for _, cr := range categoryRecords[key] {
+			r.Categories = append(r.Categories, *cr)
+		}
+		sort.Slice(r.Categories, func(i, j int) bool {
+			di := math.Abs(r.Categories[i].winRate() - 0.5)
+			dj := math.Abs(r.Categories[j].winRate() - 0.5)
+			if di != dj {
+				return di > dj
+			}
+			return r.Categories[i].Category < r.Categories[j].Category
+		})
+		for _, cr := range r.Categories {
+			if t := tendency(cr, r.OpponentName); t != "" {
+				r.Tendencies = append(r.Tendencies, t)
+			}
+		}
+		opponents = append(opponents, *r)
+	}
+
+	sort.Slice(opponents, func(i, j int) bool {
+		if opponents[i].Matchups != opponents[j].Matchups {
+			return opponents[i].Matchups > opponents[j].Matchups
+		}
+		return opponents[i].OpponentName < opponents[j].OpponentName
+	})
+	return opponents
+}
+
+// tendency describes a lopsided category record, or returns "" if the
+// record is close or too short to say.
+func tendency(r CategoryRecord, opponentName string) string {
+	if r.Wins+r.Losses+r.Ties < minTendencyMatchups {
+		return ""
+	}
+	switch rate := r.winRate(); {
+	case rate == 1:
+		return fmt.Sprintf("You always win %s against %s", r.Category, opponentName)
+	case rate == 0:
+		return fmt.Sprintf("You always lose %s to %s", r.Category, opponentName)
+	case rate >= 0.75:
+		return fmt.Sprintf("You usually win %s against %s", r.Category, opponentName)
+	case rate <= 0.25:
+		return fmt.Sprintf("You usually lose %s to %s", r.Category, opponentName)
+	default:
+		return ""
+	}
+}
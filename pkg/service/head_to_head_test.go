@@ -0,0 +1,79 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+)
+
+func TestHeadToHead(t *testing.T) {
+	categories := map[int]string{5: "FG%", 8: "FT%", 12: "PTS"}
+	matchup := func(opponent, opponentName, winner string, ft, pts string) yahoo.Matchup {
+		return yahoo.Matchup{
+			Status:        "postevent",
+			WinnerTeamKey: winner,
+			Teams:         []yahoo.MatchupTeam{{TeamKey: opponent, Name: opponentName}, {TeamKey: "t.1", Name: "Mine"}},
+			StatWinners: []yahoo.StatWinner{
+				{StatID: 5, WinnerTeamKey: "t.1"},
+				{StatID: 8, WinnerTeamKey: ft},
+				{StatID: 12, WinnerTeamKey: pts},
+			},
+		}
+	}
+	matchups := []yahoo.Matchup{
+		matchup("t.2", "Team X", "t.1", "t.2", "t.1"),
+		matchup("t.2", "Team X", "t.2", "t.2", "t.2"),
+		matchup("t.3", "Team Y", "t.1", "t.1", "t.1"),
+		{Status: "midevent", Teams: []yahoo.MatchupTeam{{TeamKey: "t.1"}, {TeamKey: "t.2"}}},
+		{Status: "postevent", Teams: []yahoo.MatchupTeam{{TeamKey: "t.2"}, {TeamKey: "t.3"}}},
+	}
+
+	opponents := headToHead("t.1", matchups, categories)
+	if len(opponents) != 2 {
+		t.Fatalf("Expected 2 opponents, got %+v", opponents)
+	}
+
+	x := opponents[0]
+	if x.OpponentKey != "t.2" || x.Matchups != 2 || x.Wins != 1 || x.Losses != 1 {
+		t.Errorf("Unexpected record against Team X: %+v", x)
+	}
+	// 2-1 in categories, then 1-2.
+	if x.AverageMargin != 0 {
+		t.Errorf("Expected an even average margin, got %.2f", x.AverageMargin)
+	}
+	if x.Categories[0].Category != "FG%" || x.Categories[1].Category != "FT%" {
+		t.Errorf("Expected the lopsided categories first, got %+v", x.Categories)
+	}
+
+	want := map[string]bool{
+		"You always win FG% against Team X": true,
+		"You always lose FT% to Team X":     true,
+	}
+	if len(x.Tendencies) != len(want) {
+		t.Errorf("Expected %d tendencies, got %v", len(want), x.Tendencies)
+	}
+	for _, tendency := range x.Tendencies {
+		if !want[tendency] {
+			t.Errorf("Unexpected tendency %q", tendency)
+		}
+	}
+
+	if y := opponents[1]; y.AverageMargin != 3 || len(y.Tendencies) != 0 {
+		t.Errorf("Expected a 3-category margin and no tendencies from one matchup, got %+v", y)
+	}
+}
+
+func TestHeadToHeadPoints(t *testing.T) {
+	matchups := []yahoo.Matchup{
+		{Status: "postevent", WinnerTeamKey: "t.1", Teams: []yahoo.MatchupTeam{{TeamKey: "t.1", Points: 1100}, {TeamKey: "t.2", Points: 1000}}},
+		{Status: "postevent", IsTied: true, Teams: []yahoo.MatchupTeam{{TeamKey: "t.2", Points: 950}, {TeamKey: "t.1", Points: 950}}},
+	}
+
+	opponents := headToHead("t.1", matchups, nil)
+	if len(opponents) != 1 {
+		t.Fatalf("Expected 1 opponent, got %d", len(opponents))
+	}
+	if r := opponents[0]; r.Wins != 1 || r.Ties != 1 || r.AverageMargin != 50 || len(r.Categories) != 0 {
+		t.Errorf("Unexpected points league record %+v", r)
+	}
+}
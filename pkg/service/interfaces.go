@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"io"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+)
+
+// Trader finds, stores and submits trade suggestions. TradeService
+// implements it; servicetest.Trader is a stand-in for tests.
+type Trader interface {
+	GenerateSuggestions(ctx context.Context, teamID int, limit int) ([]*TradeSuggestion, error)
+	FindTradesForCategories(ctx context.Context, teamID int, want []string, giveUp []string) ([]*TradeSuggestion, error)
+	EvaluateProposal(ctx context.Context, proposal *TradeProposal) (*TradeEvaluation, error)
+	SaveProposal(ctx context.Context, proposal *TradeProposal) error
+	GetProposalsByTeam(ctx context.Context, teamID int) ([]*TradeSuggestion, error)
+	RecordOutcome(ctx context.Context, proposalID int, outcome TradeOutcome) error
+	OutcomeStats(ctx context.Context, leagueID int) ([]FairnessBandStats, error)
+	SubmitToYahoo(ctx context.Context, suggestionID int) (*yahoo.Transaction, error)
+}
+
+// Evaluator judges how a trade affects both teams. EvaluationService
+// implements it; servicetest.Evaluator is a stand-in for tests.
+type Evaluator interface {
+	EvaluateTrade(ctx context.Context, leagueID int, teamAID int, teamAGives []int, teamBID int, teamBGives []int) (*TradeEvaluation, error)
+	EvaluateTradeWithPicks(ctx context.Context, leagueID int, teamAID int, teamAGives []int, teamAPicks []DraftPick, teamBID int, teamBGives []int, teamBPicks []DraftPick) (*TradeEvaluation, error)
+	EvaluatePointsTrade(ctx context.Context, leagueID int, teamAID int, teamAGives []int, teamBID int, teamBGives []int, schedule []ScheduleWeek) (*TradeEvaluation, error)
+	LoadSchedule(ctx context.Context, leagueID int, leagueKey string, fromWeek, toWeek int) ([]ScheduleWeek, error)
+	SimulateTradeSchedule(ctx context.Context, leagueID int, teamAID int, teamAGives []int, teamBID int, teamBGives []int, schedule []ScheduleWeek) (*ScheduleSimulation, error)
+	GetPlayerProjections(ctx context.Context, leagueID int, playerIDs []int) ([]PlayerProjection, error)
+	GetTeamCategoryTotals(ctx context.Context, teamID int) (TeamCategoryTotals, error)
+	CategoryChanges(current TeamCategoryTotals, playersIn, playersOut []PlayerProjection) []CategoryChange
+}
+
+// Analyzer finds each team's category strengths and weaknesses and ranks
+// the teams. AnalysisService implements it; servicetest.Analyzer is a
+// stand-in for tests.
+type Analyzer interface {
+	AnalyzeAllTeams(ctx context.Context, leagueID int) error
+	GetTeamAnalysis(ctx context.Context, teamID int) (*TeamAnalysis, error)
+	SuggestPuntStrategies(ctx context.Context, teamID int) ([]PuntStrategy, error)
+	ProjectMatchup(ctx context.Context, leagueID, week, teamAID, teamBID int) (*MatchupProjection, error)
+	PowerRankings(ctx context.Context, leagueID int) ([]PowerRanking, error)
+	GetPowerRankingHistory(ctx context.Context, leagueID, teamID int) ([]PowerRanking, error)
+	FindUpgrades(ctx context.Context, teamID int) ([]PositionUpgrade, error)
+	HeadToHead(ctx context.Context, teamID int) (*HeadToHeadReport, error)
+}
+
+// Valuator values every player in a league. ValuationService implements
+// it; servicetest.Valuator is a stand-in for tests.
+type Valuator interface {
+	CalculateAllPlayerValues(ctx context.Context, leagueID int) error
+	GetPositionScarcity(ctx context.Context, leagueID int) ([]PositionScarcity, error)
+}
+
+// WaiverRecommender suggests free agent pickups. WaiverService implements
+// it; servicetest.WaiverRecommender is a stand-in for tests.
+type WaiverRecommender interface {
+	RecommendPickups(ctx context.Context, teamID int, limit int) ([]WaiverRecommendation, error)
+	RecommendUpgrades(ctx context.Context, teamID int, limit int) ([]WaiverRecommendation, error)
+}
+
+// LineupOptimizer sets optimal lineups. LineupService implements it;
+// servicetest.LineupOptimizer is a stand-in for tests.
+type LineupOptimizer interface {
+	OptimizeLineup(ctx context.Context, teamID int, coverage yahoo.RosterCoverage) (*Lineup, error)
+	SubmitLineup(ctx context.Context, lineup *Lineup) error
+}
+
+// ProjectionImporter imports third-party projections. ProjectionImportService
+// implements it; servicetest.ProjectionImporter is a stand-in for tests.
+type ProjectionImporter interface {
+	ImportCSV(ctx context.Context, leagueID int, r io.Reader, opts ...ProjectionImportOption) (*ProjectionImport, error)
+	ImportJSON(ctx context.Context, leagueID int, r io.Reader, opts ...ProjectionImportOption) (*ProjectionImport, error)
+	ImportProjections(ctx context.Context, leagueID int, source string, projections []ImportedProjection) (*ProjectionImport, error)
+}
+
+// AuctionCalculator prices players for auction drafts and keepers.
+// AuctionService implements it; servicetest.AuctionCalculator is a
+// stand-in for tests.
+type AuctionCalculator interface {
+	AuctionValues(ctx context.Context, leagueID int) ([]AuctionValue, error)
+	KeeperValues(ctx context.Context, teamID int) ([]KeeperValue, error)
+}
+
+// SyncMonitor reports the state of a league's syncs. SyncService
+// implements it; servicetest.SyncMonitor is a stand-in for tests.
+type SyncMonitor interface {
+	GetStatus(ctx context.Context, leagueID int) ([]SyncStatus, error)
+}
+
+// Exporter writes a league's stored data to files and restores it from
+// them. ExportService implements it; servicetest.Exporter is a stand-in for
+// tests.
+type Exporter interface {
+	Export(ctx context.Context, leagueID int, dir string, format ExportFormat) (*ExportManifest, error)
+	Restore(ctx context.Context, dir string) (*ExportManifest, error)
+}
+
+var (
+	_ Trader    = (*TradeService)(nil)
+	_ Evaluator = (*EvaluationService)(nil)
+	_ Analyzer  = (*AnalysisService)(nil)
+	_ Valuator  = (*ValuationService)(nil)
+
+	_ WaiverRecommender  = (*WaiverService)(nil)
+	_ LineupOptimizer    = (*LineupService)(nil)
+	_ ProjectionImporter = (*ProjectionImportService)(nil)
+	_ AuctionCalculator  = (*AuctionService)(nil)
+	_ SyncMonitor        = (*SyncService)(nil)
+	_ Exporter           = (*ExportService)(nil)
+)
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ManagerRecord is a manager's combined record across the seasons of a
+// league stored by ImportHistory.
+type ManagerRecord struct {
+	// ManagerGUID is empty for managers Yahoo sent no GUID for, who are
+	// matched across seasons by name instead.
+	ManagerGUID string
+	// ManagerName is the name from their most recent season.
+	ManagerName string
+	Seasons     int
+	Wins        int
+	Losses      int
+	Ties        int
+	WinPct      float64
+	// Championships counts the seasons they finished first. BestFinish and
+	// AverageFinish are final ranks.
+	Championships int
+	BestFinish    int
+	AverageFinish float64
+}
+
+// ImportHistory follows the league's renew keys back through its prior
+// seasons, storing each season not already stored with its teams' final
+// records, and returns how many seasons it imported. It stops after
+// maxSeasons prior seasons, or at the first season when maxSeasons is 0 or
+// less. Rosters of past seasons are not imported.
+func (s *LeagueService) ImportHistory(ctx context.Context, leagueID, maxSeasons int) (imported int, err error) {
+	ctx, span := startSpan(ctx, "LeagueService.ImportHistory",
+		attribute.Int("league.id", leagueID),
+		attribute.Int("max_seasons", maxSeasons),
+	)
+	defer func() { endSpan(span, err) }()
+
+	startedAt := time.Now()
+	defer func() { s.recordSync(ctx, leagueID, "history", imported, startedAt, err) }()
+
+	league, err := s.leagueRepo.GetByID(ctx, leagueID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get league: %w", err)
+	}
+
+	var userGUID string
+	if team, err := s.teamRepo.GetUserTeam(ctx, leagueID); err == nil {
+		userGUID = team.ManagerGUID
+	} else if err != sql.ErrNoRows {
+		return 0, fmt.Errorf("failed to get user team: %w", err)
+	}
+
+	key := league.RenewLeagueKey
+	for seasons := 0; key != "" && (maxSeasons <= 0 || seasons < maxSeasons); seasons++ {
+		stored, err := s.leagueRepo.GetByKey(ctx, key)
+		if err == nil {
+			key = stored.RenewLeagueKey
+			continue
+		}
+		if err != sql.ErrNoRows {
+			return imported, fmt.Errorf("failed to check season %s: %w", key, err)
+		}
+
+		renew, err := s.importSeason(ctx, key, userGUID)
+		if err != nil {
+			return imported, fmt.Errorf("failed to import season %s: %w", key, err)
+		}
+		imported++
+		key = renew
+	}
+
+	s.logger.Info("league history import complete", "league_id", leagueID, "seasons", imported)
+	return imported, nil
+}
+
+// importSeason stores a past season of a league and its teams, marking the
+// user's team by their manager GUID, and returns the season's renew key.
+func (s *LeagueService) importSeason(ctx context.Context, leagueKey, userGUID string) (string, error) {
+	yahooLeague, err := s.yahooClient.GetLeague(ctx, leagueKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch league: %w", err)
+	}
+	teams, err := s.yahooClient.GetLeagueTeams(ctx, leagueKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch teams: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	league := &repository.League{
+		YahooLeagueID:    yahooLeague.YahooLeagueID,
+		YahooGameKey:     yahooLeague.YahooGameKey,
+		LeagueName:       yahooLeague.LeagueName,
+		SeasonYear:       yahooLeague.SeasonYear,
+		ScoringType:      yahooLeague.ScoringType,
+		NumTeams:         yahooLeague.NumTeams,
+		CurrentWeek:      yahooLeague.CurrentWeek,
+		RenewLeagueKey:   yahooLeague.Renew,
+		RenewedLeagueKey: yahooLeague.Renewed,
+	}
+	if err := s.leagueRepo.WithTx(tx).Create(ctx, league); err != nil {
+		return "", err
+	}
+
+	teamRepo := s.teamRepo.WithTx(tx)
+	for _, t := range teams {
+		team := &repository.FantasyTeam{
+			LeagueID:     league.ID,
+			YahooTeamID:  t.YahooTeamID,
+			YahooTeamKey: t.YahooTeamKey,
+			TeamName:     t.TeamName,
+			ManagerName:  t.ManagerName,
+			ManagerGUID:  t.ManagerGUID,
+			IsUserTeam:   userGUID != "" && t.ManagerGUID == userGUID,
+			Wins:         t.Wins,
+			Losses:       t.Losses,
+			Ties:         t.Ties,
+			Rank:         t.Rank,
+		}
+		if err := teamRepo.Upsert(ctx, team); err != nil {
+			return "", err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+	s.logger.Debug("imported past season", "league_key", leagueKey, "season", league.SeasonYear, "teams", len(teams))
+
+	return yahooLeague.Renew, nil
+}
+
+// ManagerRecords returns the combined records of every manager in the
+// league's stored seasons, the league and those its renew keys lead back
+// to, best win percentage first.
+func (s *LeagueService) ManagerRecords(ctx context.Context, leagueID int) (records []ManagerRecord, err error) {
+	ctx, span := startSpan(ctx, "LeagueService.ManagerRecords", attribute.Int("league.id", leagueID))
+	defer func() { endSpan(span, err) }()
+
+	league, err := s.leagueRepo.GetByID(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league: %w", err)
+	}
+
+	var seasons [][]*repository.FantasyTeam
+	seen := make(map[int]bool)
+	for league != nil && !seen[league.ID] {
+		seen[league.ID] = true
+		teams, err := s.teamRepo.GetByLeague(ctx, league.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get teams for season %d: %w", league.SeasonYear, err)
+		}
+		seasons = append(seasons, teams)
+
+		if league.RenewLeagueKey == "" {
+			break
+		}
+		league, err = s.leagueRepo.GetByKey(ctx, league.RenewLeagueKey)
+		if err == sql.ErrNoRows {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get prior season: %w", err)
+		}
+	}
+
+	return managerRecords(seasons), nil
+}
+
+// managerRecords combines each manager's teams across seasons, given most
+// recent first.
+func managerRecords(seasons [][]*repository.FantasyTeam) []ManagerRecord {
+	byManager := make(map[string]*ManagerRecord)
+	finishes := make(map[string]int)
+	var order []string
+	for _, teams := range seasons {
+		for _, team := range teams {
+			id := team.ManagerGUID
+			if id == "" {
+				id = "name:" + team.ManagerName
+			}
+			record, ok := byManager[id]
+			if !ok {
+				record = &ManagerRecord{ManagerGUID: team.ManagerGUID, ManagerName: team.ManagerName}
+				byManager[id] = record
+				order = append(order, id)
+			}
+
+			record.Seasons++
+			record.Wins += team.Wins
+			record.Losses += team.Losses
+			record.Ties += team.Ties
+			if team.Rank > 0 {
+				if team.Rank == 1 {
+					record.Championships++
+				}
+				if record.BestFinish == 0 || team.Rank < record.BestFinish {
+					record.BestFinish = team.Rank
+				}
+				finishes[id]++
+				record.AverageFinish += float64(team.Rank)
+			}
+		}
+	}
+
+	records := make([]ManagerRecord, 0, len(order))
+	for _, id := range order {
+		record := byManager[id]
+		if n := finishes[id]; n > 0 {
+			record.AverageFinish /= float64(n)
+		}
+		if games := record.Wins + record.Losses + record.Ties; games > 0 {
+			record.WinPct = (float64(record.Wins) + float64(record.Ties)/2) / float64(games)
+		}
+		records = append(records, *record)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].WinPct != records[j].WinPct {
+			return records[i].WinPct > records[j].WinPct
+		}
+		return records[i].Wins > records[j].Wins
+	})
+	return records
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+)
+
+func TestManagerRecords(t *testing.T) {
+	seasons := [][]*repository.FantasyTeam{
+		{
+			{ManagerGUID: "A", ManagerName: "Sam", Wins: 10, Losses: 4, Rank: 1},
+			{ManagerGUID: "B", ManagerName: "Alex", Wins: 6, Losses: 8, Rank: 3},
+			{ManagerName: "Jo", Wins: 7, Losses: 7, Rank: 2},
+		},
+		{
+			{ManagerGUID: "A", ManagerName: "Sammy", Wins: 5, Losses: 8, Ties: 1, Rank: 3},
+			{ManagerGUID: "B", ManagerName: "Alex", Wins: 9, Losses: 5, Rank: 1},
+			{ManagerName: "Jo", Wins: 7, Losses: 7, Rank: 2},
+		},
+	}
+
+	got := managerRecords(seasons)
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 managers, got %+v", got)
+	}
+
+	sam := got[0]
+	if sam.ManagerGUID != "A" || sam.ManagerName != "Sam" {
+		t.Errorf("Expected Sam, under their most recent name, to lead, got %+v", sam)
+	}
+	if sam.Seasons != 2 || sam.Wins != 15 || sam.Losses != 12 || sam.Ties != 1 || sam.Championships != 1 {
+		t.Errorf("Expected Sam's seasons to be combined, got %+v", sam)
+	}
+	if sam.BestFinish != 1 || sam.AverageFinish != 2 || sam.WinPct != 15.5/28 {
+		t.Errorf("BestFinish, AverageFinish, WinPct = %d, %v, %v", sam.BestFinish, sam.AverageFinish, sam.WinPct)
+	}
+
+	if got[1].ManagerName != "Alex" || got[2].ManagerName != "Jo" || got[2].Seasons != 2 {
+		t.Errorf("Expected Alex then Jo, matched by name, got %+v", got[1:])
+	}
+}
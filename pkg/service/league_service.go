@@ -4,11 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
 	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type LeagueService struct {
@@ -16,7 +20,35 @@ type LeagueService struct {
 	leagueRepo  *repository.LeagueRepository
 	teamRepo    *repository.TeamRepository
 	rosterRepo  *repository.RosterRepository
+	playerRepo  *repository.PlayerRepository
+	matchupRepo *repository.MatchupRepository
+	draftRepo   *repository.DraftResultRepository
+	gameLogRepo *repository.GameLogRepository
+	historyRepo *repository.RosterHistoryRepository
 	db          *sql.DB
+	logger      yahoo.Logger
+	// syncWorkers is how many rosters a sync fetches at once.
+	syncWorkers int
+}
+
+// defaultSyncWorkers is how many rosters a sync fetches at once unless
+// WithSyncWorkers says otherwise.
+const defaultSyncWorkers = 4
+
+// LeagueServiceOption configures a LeagueService.
+type LeagueServiceOption func(*LeagueService)
+
+// WithSyncWorkers sets how many team rosters a sync fetches from Yahoo at
+// once. Requests still wait on the client's rate limiter, so more workers
+// only help while the limiter has room. Values below 1 fetch one at a
+// time.
+func WithSyncWorkers(n int) LeagueServiceOption {
+	return func(s *LeagueService) {
+		if n < 1 {
+			n = 1
+		}
+		s.syncWorkers = n
+	}
 }
 
 func NewLeagueService(
@@ -25,17 +57,45 @@ func NewLeagueService(
 	teamRepo *repository.TeamRepository,
 	rosterRepo *repository.RosterRepository,
 	db *sql.DB,
+	opts ...LeagueServiceOption,
 ) *LeagueService {
-	return &LeagueService{
+	s := &LeagueService{
 		yahooClient: yahooClient,
 		leagueRepo:  leagueRepo,
 		teamRepo:    teamRepo,
 		rosterRepo:  rosterRepo,
+		playerRepo:  repository.NewPlayerRepository(db),
+		matchupRepo: repository.NewMatchupRepository(db),
+		draftRepo:   repository.NewDraftResultRepository(db),
+		gameLogRepo: repository.NewGameLogRepository(db),
+		historyRepo: repository.NewRosterHistoryRepository(db),
 		db:          db,
+		logger:      yahooClient.Logger(),
+		syncWorkers: defaultSyncWorkers,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
 }
 
-func (s *LeagueService) ImportLeague(ctx context.Context, yahooLeagueID string, isUserTeamID string) error {
+// ImportLeague saves the league with its settings, teams and rosters in a
+// single transaction, so a failed import leaves nothing behind but a failed
+// sync_history row.
+func (s *LeagueService) ImportLeague(ctx context.Context, yahooLeagueID string, isUserTeamID string) (err error) {
+	ctx, span := startSpan(ctx, "LeagueService.ImportLeague", attribute.String("yahoo.league_id", yahooLeagueID))
+	defer func() { endSpan(span, err) }()
+
+	startedAt := time.Now()
+	leagueID, teams := 0, 0
+	defer func() {
+		if err != nil {
+			s.recordSync(ctx, 0, "full", 0, startedAt, fmt.Errorf("import of league %s: %w", yahooLeagueID, err))
+			return
+		}
+		s.recordSync(ctx, leagueID, "full", teams, startedAt, nil)
+	}()
+
 	existing, err := s.leagueRepo.GetByYahooID(ctx, yahooLeagueID)
 	if err != nil && err != sql.ErrNoRows {
 		return fmt.Errorf("failed to check existing league: %w", err)
@@ -59,79 +119,245 @@ func (s *LeagueService) ImportLeague(ctx context.Context, yahooLeagueID string,
 	}
 
 	if targetLeague == nil {
-		return fmt.Errorf("league not found in user's leagues")
+		return fmt.Errorf("%w: %s is not in the user's leagues", yahoo.ErrLeagueNotFound, yahooLeagueID)
 	}
 
-	scoringSettings := map[string]float64{
-		"PTS": 1.0,
-		"REB": 1.2,
-		"AST": 1.5,
-		"STL": 3.0,
-		"BLK": 3.0,
-		"TO":  -1.0,
-		"3PM": 1.0,
+	leagueKey := fmt.Sprintf("%s.l.%s", targetLeague.YahooGameKey, targetLeague.YahooLeagueID)
+	settings, err := s.yahooClient.GetLeagueSettings(ctx, leagueKey)
+	if err != nil {
+		return fmt.Errorf("failed to fetch league settings: %w", err)
+	}
+	scoringJSON, err := json.Marshal(scoringSettingsFromLeague(settings))
+	if err != nil {
+		return fmt.Errorf("failed to encode scoring settings: %w", err)
 	}
-	scoringJSON, _ := json.Marshal(scoringSettings)
 
 	league := &repository.League{
-		YahooLeagueID:   targetLeague.YahooLeagueID,
-		YahooGameKey:    targetLeague.YahooGameKey,
-		LeagueName:      targetLeague.LeagueName,
-		SeasonYear:      targetLeague.SeasonYear,
-		ScoringType:     targetLeague.ScoringType,
-		ScoringSettings: string(scoringJSON),
-		NumTeams:        targetLeague.NumTeams,
-		CurrentWeek:     targetLeague.CurrentWeek,
+		YahooLeagueID:    targetLeague.YahooLeagueID,
+		YahooGameKey:     targetLeague.YahooGameKey,
+		LeagueName:       targetLeague.LeagueName,
+		SeasonYear:       targetLeague.SeasonYear,
+		ScoringType:      targetLeague.ScoringType,
+		ScoringSettings:  string(scoringJSON),
+		NumTeams:         targetLeague.NumTeams,
+		CurrentWeek:      targetLeague.CurrentWeek,
+		RenewLeagueKey:   targetLeague.Renew,
+		RenewedLeagueKey: targetLeague.Renewed,
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	if err := s.leagueRepo.Create(ctx, league); err != nil {
+	leagueRepo := s.leagueRepo.WithTx(tx)
+	if err := leagueRepo.Create(ctx, league); err != nil {
 		return fmt.Errorf("failed to save league: %w", err)
 	}
 
-	if err := s.SyncTeamsAndRosters(ctx, league.ID, targetLeague.YahooLeagueID, isUserTeamID); err != nil {
+	if err := leagueRepo.SaveRosterPositions(ctx, league.ID, rosterSlotsFromSettings(settings)); err != nil {
+		return fmt.Errorf("failed to save roster positions: %w", err)
+	}
+
+	if err := leagueRepo.SaveStatCategories(ctx, league.ID, leagueCategoriesFromSettings(settings)); err != nil {
+		return fmt.Errorf("failed to save stat categories: %w", err)
+	}
+
+	teams, err = s.syncTeamsAndRosters(ctx, tx, league.ID, targetLeague.YahooLeagueID, isUserTeamID, true)
+	if err != nil {
 		return fmt.Errorf("failed to sync teams and rosters: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit league import: %w", err)
+	}
+	leagueID = league.ID
+
 	return nil
 }
 
+// scoringSettingsFromLeague converts the league's scoring rules into the
+// weights ValuationService scores players with. Points leagues use their stat
+// modifiers. Category leagues weight each scored category 1, or -1 where
+// lower is better, such as turnovers; categories the league does not score
+// weigh 0.
+func scoringSettingsFromLeague(settings *yahoo.LeagueSettings) ScoringSettings {
+	var ss ScoringSettings
+	weights := map[string]*float64{
+		"PTS": &ss.PTS,
+		"REB": &ss.REB,
+		"AST": &ss.AST,
+		"STL": &ss.STL,
+		"BLK": &ss.BLK,
+		"TO":  &ss.TO,
+		"3PM": &ss.TPM,
+		"FG%": &ss.FGPct,
+		"FT%": &ss.FTPct,
+	}
+
+	scoring := yahoo.NewScoring(settings)
+	if scoring.IsPointsLeague() {
+		for name, weight := range weights {
+			*weight, _ = scoring.ModifierByName(name)
+		}
+		return ss
+	}
+
+	categories := yahoo.NewStatRegistry(settings.ScoredCategories())
+	for name, weight := range weights {
+		statID, ok := categories.Lookup(name)
+		if !ok {
+			continue
+		}
+		cat, _ := categories.Category(statID)
+		if cat.SortOrder == 1 {
+			*weight = 1
+		} else {
+			*weight = -1
+		}
+	}
+	return ss
+}
+
+func rosterSlotsFromSettings(settings *yahoo.LeagueSettings) []repository.RosterSlot {
+	slots := make([]repository.RosterSlot, 0, len(settings.RosterPositions))
+	for _, pos := range settings.RosterPositions {
+		slots = append(slots, repository.RosterSlot{
+			Position:   pos.Position,
+			Count:      pos.Count,
+			IsStarting: pos.IsStartingPosition,
+		})
+	}
+	return slots
+}
+
+// SyncTeamsAndRosters saves the league's teams and their rosters in a
+// single transaction and records the outcome in sync_history. Teams and
+// roster entries already stored are updated in place, and players no longer
+// on a team's roster are removed from it, so repeated syncs converge. The
+// adds, drops and lineup moves each sync finds are kept in roster_history.
+//
+// Every roster is refetched, since lineup changes do not count as Yahoo
+// moves, but only the players of new teams and of teams whose move or trade
+// count changed since the last sync are saved again.
 func (s *LeagueService) SyncTeamsAndRosters(ctx context.Context, leagueID int, yahooLeagueID string, userTeamID string) error {
+	return s.syncLeague(ctx, leagueID, yahooLeagueID, userTeamID, false)
+}
+
+// SyncAllRosters is SyncTeamsAndRosters saving every rostered player again.
+func (s *LeagueService) SyncAllRosters(ctx context.Context, leagueID int, yahooLeagueID string, userTeamID string) error {
+	return s.syncLeague(ctx, leagueID, yahooLeagueID, userTeamID, true)
+}
+
+func (s *LeagueService) syncLeague(ctx context.Context, leagueID int, yahooLeagueID string, userTeamID string, full bool) (err error) {
+	syncType := "incremental"
+	if full {
+		syncType = "full"
+	}
+	startedAt := time.Now()
+	rosters := 0
+	defer func() { s.recordSync(ctx, leagueID, syncType, rosters, startedAt, err) }()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if rosters, err = s.syncTeamsAndRosters(ctx, tx, leagueID, yahooLeagueID, userTeamID, full); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// syncTeamsAndRosters saves the league's teams and rosters in tx and returns
+// how many rosters it fetched. Unless full is set, the players on teams
+// whose move counts are unchanged are looked up rather than saved again.
+// A team's first sync saves its roster as the baseline later syncs diff
+// against, without recording it in roster_history.
+func (s *LeagueService) syncTeamsAndRosters(ctx context.Context, tx *sql.Tx, leagueID int, yahooLeagueID string, userTeamID string, full bool) (_ int, err error) {
 	leagueKey := fmt.Sprintf("nba.l.%s", yahooLeagueID)
+	ctx, span := startSpan(ctx, "LeagueService.SyncTeamsAndRosters",
+		attribute.Int("league.id", leagueID),
+		attribute.String("yahoo.league_key", leagueKey),
+		attribute.Bool("full", full),
+	)
+	defer func() { endSpan(span, err) }()
 
 	teams, err := s.yahooClient.GetLeagueTeams(ctx, leagueKey)
 	if err != nil {
-		return fmt.Errorf("failed to fetch teams: %w", err)
+		return 0, fmt.Errorf("failed to fetch teams: %w", err)
+	}
+
+	s.logger.Info("syncing league teams and rosters", "league_key", leagueKey, "teams", len(teams))
+
+	teamRepo := s.teamRepo.WithTx(tx)
+	rosterRepo := s.rosterRepo.WithTx(tx)
+	playerRepo := s.playerRepo.WithTx(tx)
+	historyRepo := s.historyRepo.WithTx(tx)
+	now := time.Now()
+
+	stored, err := teamRepo.GetByLeague(ctx, leagueID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get stored teams: %w", err)
+	}
+	storedTeams := make(map[string]*repository.FantasyTeam, len(stored))
+	for _, team := range stored {
+		storedTeams[team.YahooTeamKey] = team
 	}
 
+	// Save the teams first, then fetch the rosters concurrently and save
+	// them in the order Yahoo listed the teams.
+	pending := make([]*repository.FantasyTeam, 0, len(teams))
+	refresh := make([]bool, 0, len(teams))
 	for _, yahooTeam := range teams {
 		isUserTeam := yahooTeam.YahooTeamID == userTeamID
 
 		team := &repository.FantasyTeam{
-			LeagueID:     leagueID,
-			YahooTeamID:  yahooTeam.YahooTeamID,
-			YahooTeamKey: yahooTeam.YahooTeamKey,
-			TeamName:     yahooTeam.TeamName,
-			ManagerName:  yahooTeam.ManagerName,
-			IsUserTeam:   isUserTeam,
-			Wins:         yahooTeam.Wins,
-			Losses:       yahooTeam.Losses,
-			Ties:         yahooTeam.Ties,
-			Rank:         yahooTeam.Rank,
+			LeagueID:       leagueID,
+			YahooTeamID:    yahooTeam.YahooTeamID,
+			YahooTeamKey:   yahooTeam.YahooTeamKey,
+			TeamName:       yahooTeam.TeamName,
+			ManagerName:    yahooTeam.ManagerName,
+			ManagerGUID:    yahooTeam.ManagerGUID,
+			IsUserTeam:     isUserTeam,
+			Wins:           yahooTeam.Wins,
+			Losses:         yahooTeam.Losses,
+			Ties:           yahooTeam.Ties,
+			Rank:           yahooTeam.Rank,
+			NumberOfMoves:  yahooTeam.NumberOfMoves,
+			NumberOfTrades: yahooTeam.NumberOfTrades,
 		}
 
-		if err := s.teamRepo.Create(ctx, team); err != nil {
-			return fmt.Errorf("failed to save team %s: %w", yahooTeam.TeamName, err)
+		if err := teamRepo.Upsert(ctx, team); err != nil {
+			return 0, fmt.Errorf("failed to save team %s: %w", yahooTeam.TeamName, err)
 		}
 
-		roster, err := s.yahooClient.GetTeamRoster(ctx, yahooTeam.YahooTeamKey)
+		pending = append(pending, team)
+		refresh = append(refresh, full || rosterChanged(storedTeams[yahooTeam.YahooTeamKey], yahooTeam))
+	}
+
+	rosters, err := s.fetchRosters(ctx, pending)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, team := range pending {
+		roster := rosters[i]
+
+		before, err := rosterRepo.GetByTeam(ctx, team.ID)
 		if err != nil {
-			return fmt.Errorf("failed to fetch roster for team %s: %w", yahooTeam.TeamName, err)
+			return 0, fmt.Errorf("failed to get roster for team %s: %w", team.TeamName, err)
 		}
 
+		after := make([]repository.RosterEntry, 0, len(roster))
+		playerIDs := make([]int, 0, len(roster))
 		for _, rosterEntry := range roster {
-			playerID, err := s.rosterRepo.GetPlayerIDByYahooKey(ctx, rosterEntry.PlayerKey)
+			playerID, err := rosterPlayerID(ctx, playerRepo, rosterRepo, rosterEntry, refresh[i])
 			if err != nil {
-				continue
+				return 0, fmt.Errorf("failed to save player %s: %w", rosterEntry.PlayerKey, err)
 			}
 
 			entry := &repository.RosterEntry{
@@ -142,26 +368,272 @@ func (s *LeagueService) SyncTeamsAndRosters(ctx context.Context, leagueID int, y
 				IsStarting:       rosterEntry.IsStarting,
 			}
 
-			if err := s.rosterRepo.Create(ctx, entry); err != nil {
-				return fmt.Errorf("failed to save roster entry: %w", err)
+			if err := rosterRepo.Upsert(ctx, entry); err != nil {
+				return 0, fmt.Errorf("failed to save roster entry: %w", err)
 			}
+			after = append(after, *entry)
+			playerIDs = append(playerIDs, playerID)
+		}
+
+		if err := rosterRepo.DeleteOthers(ctx, team.ID, playerIDs); err != nil {
+			return 0, fmt.Errorf("failed to remove dropped players from team %s: %w", team.TeamName, err)
+		}
+
+		var changes []repository.RosterChange
+		if storedTeams[team.YahooTeamKey] != nil {
+			changes = diffRoster(team.ID, before, after, now)
+		}
+		if err := historyRepo.Record(ctx, changes); err != nil {
+			return 0, fmt.Errorf("failed to record roster changes for team %s: %w", team.TeamName, err)
 		}
+
+		s.logger.Debug("synced team roster", "league_key", leagueKey, "team", team.TeamName, "players", len(roster), "changes", len(changes), "progress", fmt.Sprintf("%d/%d", i+1, len(pending)))
 	}
 
-	now := time.Now()
-	if err := s.leagueRepo.UpdateSyncTime(ctx, leagueID); err != nil {
-		return fmt.Errorf("failed to update sync time: %w", err)
+	if err := s.leagueRepo.WithTx(tx).UpdateSyncTime(ctx, leagueID); err != nil {
+		return 0, fmt.Errorf("failed to update sync time: %w", err)
+	}
+	s.logger.Info("league sync complete", "league_key", leagueKey, "teams", len(teams), "rosters", len(pending))
+
+	return len(pending), nil
+}
+
+// fetchRosters fetches the teams' rosters from Yahoo on up to syncWorkers
+// goroutines and returns them in the order of teams. The first failure
+// cancels the fetches still running and is returned.
+func (s *LeagueService) fetchRosters(ctx context.Context, teams []*repository.FantasyTeam) ([][]yahoo.Roster, error) {
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	rosters := make([][]yahoo.Roster, len(teams))
+	var once sync.Once
+	var fetchErr error
+	forEachIndex(fetchCtx, len(teams), s.syncWorkers, func(i int) {
+		roster, err := s.yahooClient.GetTeamRoster(fetchCtx, teams[i].YahooTeamKey)
+		if err != nil {
+			once.Do(func() {
+				fetchErr = fmt.Errorf("failed to fetch roster for team %s: %w", teams[i].TeamName, err)
+				cancel()
+			})
+			return
+		}
+		rosters[i] = roster
+	})
+
+	if fetchErr != nil {
+		return nil, fetchErr
+	}
+	// Rosters are missing if ctx was done before every fetch started.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return rosters, nil
+}
+
+// rosterPlayerID returns the ID of the rostered player, saving them from
+// Yahoo when refresh is set or they are not stored yet.
+func rosterPlayerID(ctx context.Context, playerRepo *repository.PlayerRepository, rosterRepo *repository.RosterRepository, entry yahoo.Roster, refresh bool) (int, error) {
+	if !refresh {
+		playerID, err := rosterRepo.GetPlayerIDByYahooKey(ctx, entry.PlayerKey)
+		if err == nil {
+			return playerID, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, err
+		}
+	}
+	return playerRepo.UpsertFromYahoo(ctx, rosterPlayer(entry))
+}
+
+// rosterChanged reports whether a team's roster may have changed since it
+// was stored: the team is new, or Yahoo counts moves or trades it had not
+// made then.
+func rosterChanged(stored *repository.FantasyTeam, team yahoo.Team) bool {
+	return stored == nil ||
+		stored.NumberOfMoves != team.NumberOfMoves ||
+		stored.NumberOfTrades != team.NumberOfTrades
+}
+
+// recordSync adds a sync's outcome to sync_history. syncType names what was
+// synced: "full" and "incremental" for teams and rosters, "matchups",
+// "draft", "game_logs" and "history". items counts the rosters fetched, or
+// the matchups, picks, logs or past seasons saved:
+//
+//	CREATE TABLE sync_history (
+//		id            INTEGER PRIMARY KEY AUTOINCREMENT,
+//		league_id     INTEGER REFERENCES fantasy_leagues(id),
+//		sync_type     TEXT NOT NULL,
+//		sync_status   TEXT NOT NULL,
+//		items_synced  INTEGER DEFAULT 0,
+//		error_message TEXT,
+//		started_at    DATETIME,
+//		completed_at  DATETIME DEFAULT CURRENT_TIMESTAMP
+//	);
+//
+// A leagueID of 0 is stored as NULL, for imports that failed and rolled
+// back their league. Failing to record is logged rather than returned, so
+// that it cannot hide the sync's own result.
+func (s *LeagueService) recordSync(ctx context.Context, leagueID int, syncType string, items int, startedAt time.Time, syncErr error) {
+	var league interface{}
+	if leagueID != 0 {
+		league = leagueID
+	}
+	status := "success"
+	var message interface{}
+	if syncErr != nil {
+		status = "failed"
+		message = syncErr.Error()
 	}
 
-	syncQuery := `
-		INSERT INTO sync_history (league_id, sync_type, sync_status, items_synced, completed_at)
-		VALUES (?, 'full', 'success', ?, ?)
+	query := `
+		INSERT INTO sync_history (league_id, sync_type, sync_status, items_synced, error_message, started_at, completed_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
-	s.db.ExecContext(ctx, syncQuery, leagueID, len(teams), now)
+	_, err := s.db.ExecContext(context.WithoutCancel(ctx), query, league, syncType, status, items, message, startedAt, time.Now())
+	if err != nil {
+		s.logger.Warn("failed to record sync", "league_id", leagueID, "error", err)
+	}
+}
+
+// SyncMatchups stores the league's scoreboard for each of the weeks: every
+// matchup with its status, both teams' scores and, in category leagues, who
+// won each category. Weeks already synced are replaced.
+func (s *LeagueService) SyncMatchups(ctx context.Context, leagueID int, weeks []int) (err error) {
+	ctx, span := startSpan(ctx, "LeagueService.SyncMatchups",
+		attribute.Int("league.id", leagueID),
+		attribute.Int("weeks", len(weeks)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	startedAt := time.Now()
+	synced := 0
+	defer func() { s.recordSync(ctx, leagueID, "matchups", synced, startedAt, err) }()
+
+	var gameKey, yahooLeagueID string
+	query := `SELECT yahoo_game_key, yahoo_league_id FROM fantasy_leagues WHERE id = ?`
+	if err := s.db.QueryRowContext(ctx, query, leagueID).Scan(&gameKey, &yahooLeagueID); err != nil {
+		return fmt.Errorf("failed to get league: %w", err)
+	}
+	leagueKey := fmt.Sprintf("%s.l.%s", gameKey, yahooLeagueID)
+
+	categories, err := s.yahooClient.GetLeagueStatCategories(ctx, leagueKey)
+	if err != nil {
+		return fmt.Errorf("failed to fetch stat categories: %w", err)
+	}
+	statNames := make(map[int]string, len(categories))
+	for _, cat := range categories {
+		statNames[cat.StatID] = cat.DisplayName
+	}
+
+	for _, week := range weeks {
+		matchups, err := s.yahooClient.GetLeagueMatchups(ctx, leagueKey, week)
+		if err != nil {
+			return fmt.Errorf("failed to fetch week %d matchups: %w", week, err)
+		}
+		if err := s.matchupRepo.SaveWeek(ctx, leagueID, week, matchups, statNames); err != nil {
+			return fmt.Errorf("failed to save week %d matchups: %w", week, err)
+		}
+		synced += len(matchups)
+		s.logger.Debug("synced matchups", "league_key", leagueKey, "week", week, "matchups", len(matchups))
+	}
+
+	return nil
+}
+
+// ImportDraft stores the league's draft results, replacing any imported
+// before. Picks of players missing from the players table are upserted when
+// Yahoo sent their details and skipped otherwise, so rosters should be
+// synced first.
+func (s *LeagueService) ImportDraft(ctx context.Context, leagueID int) (err error) {
+	ctx, span := startSpan(ctx, "LeagueService.ImportDraft", attribute.Int("league.id", leagueID))
+	defer func() { endSpan(span, err) }()
+
+	startedAt := time.Now()
+	synced := 0
+	defer func() { s.recordSync(ctx, leagueID, "draft", synced, startedAt, err) }()
+
+	var gameKey, yahooLeagueID string
+	query := `SELECT yahoo_game_key, yahoo_league_id FROM fantasy_leagues WHERE id = ?`
+	if err := s.db.QueryRowContext(ctx, query, leagueID).Scan(&gameKey, &yahooLeagueID); err != nil {
+		return fmt.Errorf("failed to get league: %w", err)
+	}
+	leagueKey := fmt.Sprintf("%s.l.%s", gameKey, yahooLeagueID)
+
+	results, err := s.yahooClient.GetLeagueDraftResults(ctx, leagueKey)
+	if err != nil {
+		return fmt.Errorf("failed to fetch draft results: %w", err)
+	}
+
+	teams, err := s.teamRepo.GetByLeague(ctx, leagueID)
+	if err != nil {
+		return fmt.Errorf("failed to get teams: %w", err)
+	}
+	teamIDs := make(map[string]int, len(teams))
+	for _, team := range teams {
+		teamIDs[team.YahooTeamKey] = team.ID
+	}
+
+	var picks []repository.DraftResult
+	skipped := 0
+	for _, result := range results {
+		teamID, ok := teamIDs[result.TeamKey]
+		if !ok || result.PlayerKey == "" {
+			skipped++
+			continue
+		}
+
+		playerID, err := s.draftPlayerID(ctx, result)
+		if err == sql.ErrNoRows {
+			skipped++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to save player %s: %w", result.PlayerKey, err)
+		}
+
+		picks = append(picks, repository.DraftResult{
+			LeagueID: leagueID,
+			Pick:     result.Pick,
+			Round:    result.Round,
+			TeamID:   teamID,
+			PlayerID: playerID,
+			Cost:     result.Cost,
+		})
+	}
+
+	if err := s.draftRepo.ReplaceForLeague(ctx, leagueID, picks); err != nil {
+		return fmt.Errorf("failed to save draft results: %w", err)
+	}
+	synced = len(picks)
+	s.logger.Info("draft import complete", "league_key", leagueKey, "picks", len(picks), "skipped", skipped)
 
 	return nil
 }
 
+// draftPlayerID returns the ID of a drafted player, upserting them when the
+// pick carries their details. It returns sql.ErrNoRows for unknown players
+// Yahoo sent no details for.
+func (s *LeagueService) draftPlayerID(ctx context.Context, result yahoo.DraftResult) (int, error) {
+	if result.Player.Name.Full != "" {
+		player := result.Player
+		player.PlayerKey = result.PlayerKey
+		return s.playerRepo.UpsertFromYahoo(ctx, player)
+	}
+	return s.rosterRepo.GetPlayerIDByYahooKey(ctx, result.PlayerKey)
+}
+
+// rosterPlayer returns the player details carried on a roster entry.
+func rosterPlayer(entry yahoo.Roster) yahoo.Player {
+	return yahoo.Player{
+		PlayerKey:         entry.PlayerKey,
+		PlayerID:          entry.PlayerID,
+		Name:              entry.Name,
+		EditorialTeamAbbr: entry.EditorialTeamAbbr,
+		DisplayPosition:   strings.Join(entry.EligiblePositions, ","),
+		EligiblePositions: entry.EligiblePositions,
+	}
+}
+
 func (s *LeagueService) GetUserLeagues(ctx context.Context) ([]*repository.League, error) {
 	return s.leagueRepo.GetAll(ctx)
 }
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+)
+
+func TestScoringSettingsFromLeague(t *testing.T) {
+	categories := []yahoo.StatCategory{
+		{StatID: 5, DisplayName: "FG%", SortOrder: 1, Enabled: true},
+		{StatID: 10, DisplayName: "3PTM", SortOrder: 1, Enabled: true},
+		{StatID: 12, DisplayName: "PTS", SortOrder: 1, Enabled: true},
+		{StatID: 17, DisplayName: "ST", SortOrder: 1, Enabled: true},
+		{StatID: 19, DisplayName: "TO", SortOrder: 0, Enabled: true},
+		{StatID: 9004003, DisplayName: "FGM/A", SortOrder: 1, Enabled: true, IsOnlyDisplayStat: true},
+	}
+
+	t.Run("points league", func(t *testing.T) {
+		settings := &yahoo.LeagueSettings{
+			StatCategories: categories,
+			StatModifiers:  map[int]float64{10: 0.5, 12: 1, 17: 2, 19: -1.5},
+		}
+		got := scoringSettingsFromLeague(settings)
+		want := ScoringSettings{PTS: 1, STL: 2, TO: -1.5, TPM: 0.5}
+		if got != want {
+			t.Errorf("scoringSettingsFromLeague() = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("category league", func(t *testing.T) {
+		settings := &yahoo.LeagueSettings{StatCategories: categories}
+		got := scoringSettingsFromLeague(settings)
+		want := ScoringSettings{PTS: 1, STL: 1, TO: -1, TPM: 1, FGPct: 1}
+		if got != want {
+			t.Errorf("scoringSettingsFromLeague() = %+v, want %+v", got, want)
+		}
+	})
+}
+
+func TestLeagueCategoriesFromSettings(t *testing.T) {
+	settings := &yahoo.LeagueSettings{StatCategories: []yahoo.StatCategory{
+		{StatID: 5, DisplayName: "FG%", SortOrder: 1, Enabled: true},
+		{StatID: 10, DisplayName: "3PTM", SortOrder: 1, Enabled: true},
+		{StatID: 19, DisplayName: "TO", SortOrder: 0, Enabled: true},
+		{StatID: 27, DisplayName: "DD", SortOrder: 1, Enabled: true},
+		{StatID: 9004003, DisplayName: "FGM/A", SortOrder: 1, Enabled: true, IsOnlyDisplayStat: true},
+	}}
+
+	got := leagueCategoriesFromSettings(settings)
+	want := []repository.LeagueCategory{
+		{Name: "FG%"},
+		{Name: "3PM"},
+		{Name: "TO", LowerIsBetter: true},
+		{Name: "DD"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("leagueCategoriesFromSettings() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("category %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRosterChanged(t *testing.T) {
+	stored := &repository.FantasyTeam{NumberOfMoves: 4, NumberOfTrades: 1}
+	team := func(moves, trades int) yahoo.Team {
+		return yahoo.Team{TeamMetadata: yahoo.TeamMetadata{NumberOfMoves: moves, NumberOfTrades: trades}}
+	}
+
+	tests := []struct {
+		name   string
+		stored *repository.FantasyTeam
+		team   yahoo.Team
+		want   bool
+	}{
+		{"new team", nil, team(0, 0), true},
+		{"unchanged", stored, team(4, 1), false},
+		{"new move", stored, team(5, 1), true},
+		{"new trade", stored, team(4, 2), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rosterChanged(tt.stored, tt.team); got != tt.want {
+				t.Errorf("rosterChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
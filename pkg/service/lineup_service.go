@@ -0,0 +1,462 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxLineupSwaps bounds the starter/bench swaps tried when improving a
+// category league lineup against the opponent.
+const maxLineupSwaps = 50
+
+// LineupService sets optimal lineups.
+type LineupService struct {
+	db             *sql.DB
+	yahooClient    *yahoo.Client
+	playerRepo     *repository.PlayerRepository
+	teamRepo       *repository.TeamRepository
+	leagueRepo     *repository.LeagueRepository
+	projectionRepo *repository.ProjectionRepository
+}
+
+// LineupSlot is a player in a lineup and the slot they fill. Value is what
+// the player is projected to contribute over the coverage period: fantasy
+// points in points leagues and z-score in category leagues.
+type LineupSlot struct {
+	Position   string
+	PlayerID   int
+	PlayerKey  string
+	PlayerName string
+	Value      float64
+}
+
+// Lineup is an optimized lineup for one week or date.
+type Lineup struct {
+	TeamID   int
+	TeamKey  string
+	Coverage yahoo.RosterCoverage
+	Mode     EvaluationMode
+	Starters []LineupSlot
+	Bench    []LineupSlot
+	// ProjectedPoints is the starters' projected fantasy points. It is
+	// only set in points leagues.
+	ProjectedPoints float64
+	// ExpectedCategoryWins is the starters' expected category wins against
+	// the week's opponent. It is only set in category leagues when the
+	// opponent is known.
+	ExpectedCategoryWins float64
+	// Changes are the moves from the team's current lineup, ready for
+	// SubmitLineup.
+	Changes []yahoo.PositionChange
+}
+
+func NewLineupService(db *sql.DB, yahooClient *yahoo.Client) LineupOptimizer {
+	return &LineupService{
+		db:             db,
+		yahooClient:    yahooClient,
+		playerRepo:     repository.NewPlayerRepository(db),
+		teamRepo:       repository.NewTeamRepository(db),
+		leagueRepo:     repository.NewLeagueRepository(db),
+		projectionRepo: repository.NewProjectionRepository(db),
+	}
+}
+
+// OptimizeLineup assigns the team's players to the league's starting slots,
+// respecting eligible positions and flex slots such as G, F and UTIL.
+// Points leagues maximize projected fantasy points. Category leagues start
+// the players with the highest z-scores, then swap starters and bench
+// players while that raises the expected category wins against the
+// coverage week's opponent. For week coverage, projections are scaled by
+// the games each player plays that week.
+//
+// Players in reserve slots such as IL stay where they are.
+func (s *LineupService) OptimizeLineup(ctx context.Context, teamID int, coverage yahoo.RosterCoverage) (_ *Lineup, err error) {
+	ctx, span := startSpan(ctx, "LineupService.OptimizeLineup",
+		attribute.Int("team.id", teamID),
+		attribute.Int("week", coverage.Week),
+	)
+	defer func() { endSpan(span, err) }()
+
+	var leagueID int
+	var teamKey, scoringType, gameKey, yahooLeagueID string
+	query := `
+		SELECT ft.league_id, ft.yahoo_team_key, fl.scoring_type, fl.yahoo_game_key, fl.yahoo_league_id
+		FROM fantasy_teams ft
+		JOIN fantasy_leagues fl ON ft.league_id = fl.id
+		WHERE ft.id = ?
+	`
+	err = s.db.QueryRowContext(ctx, query, teamID).Scan(&leagueID, &teamKey, &scoringType, &gameKey, &yahooLeagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	slots, err := s.leagueRepo.GetRosterPositions(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster positions: %w", err)
+	}
+	if len(slots) == 0 {
+		return nil, fmt.Errorf("league %d has no roster positions; re-import it", leagueID)
+	}
+
+	players, err := s.getLineupPlayers(ctx, leagueID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster: %w", err)
+	}
+
+	games := map[int]int{}
+	if coverage.Week > 0 {
+		games, err = s.projectionRepo.GetWeeklyGames(ctx, leagueID, coverage.Week)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get weekly games: %w", err)
+		}
+	}
+
+	mode := evaluationModeFor(scoringType)
+	var opponent []PlayerProjection
+	var categories []analysisCategory
+	if mode == EvaluationModeCategories {
+		categories, err = leagueAnalysisCategories(ctx, s.leagueRepo, leagueID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get league categories: %w", err)
+		}
+	}
+	if mode == EvaluationModeCategories && s.yahooClient != nil {
+		leagueKey := fmt.Sprintf("%s.l.%s", gameKey, yahooLeagueID)
+		opponent, err = s.getOpponentStarters(ctx, leagueID, leagueKey, teamID, coverage.Week)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get opponent: %w", err)
+		}
+	}
+
+	lineup := s.optimizeLineup(startingSlots(slots), players, games, mode, opponent, categories)
+	lineup.TeamID = teamID
+	lineup.TeamKey = teamKey
+	lineup.Coverage = coverage
+	return lineup, nil
+}
+
+// SubmitLineup sends the lineup's changes to Yahoo.
+func (s *LineupService) SubmitLineup(ctx context.Context, lineup *Lineup) (err error) {
+	ctx, span := startSpan(ctx, "LineupService.SubmitLineup",
+		attribute.Int("team.id", lineup.TeamID),
+		attribute.Int("changes", len(lineup.Changes)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	if s.yahooClient == nil {
+		return fmt.Errorf("lineup service has no Yahoo client")
+	}
+	if err := s.yahooClient.SetLineup(ctx, lineup.TeamKey, lineup.Coverage, lineup.Changes); err != nil {
+		return fmt.Errorf("failed to set lineup: %w", err)
+	}
+	return nil
+}
+
+// lineupPlayer is a rostered player with what the optimizer needs to know.
+type lineupPlayer struct {
+	PlayerProjection
+	PlayerKey  string
+	PlayerName string
+	ZScore     float64
+	// Selected is the player's current slot.
+	Selected string
+	Eligible []string
+}
+
+// startingSlots expands the league's starting roster positions into one
+// entry per slot, bench and reserve slots excluded.
+func startingSlots(slots []repository.RosterSlot) []string {
+	var result []string
+	for _, slot := range slots {
+		if !slot.IsStarting || slot.Position == "BN" || containsFold(reserveSlots, slot.Position) {
+			continue
+		}
+		for i := 0; i < slot.Count; i++ {
+			result = append(result, slot.Position)
+		}
+	}
+	return result
+}
+
+// optimizeLineup picks the starters and the slot each fills. opponent, if
+// given, is the opposing starters in a category league, played in
+// categories.
+func (s *LineupService) optimizeLineup(slots []string, players []lineupPlayer, games map[int]int, mode EvaluationMode, opponent []PlayerProjection, categories []analysisCategory) *Lineup {
+	lineup := &Lineup{Mode: mode}
+
+	var active []lineupPlayer
+	for _, p := range players {
+		if containsFold(reserveSlots, p.Selected) {
+			continue
+		}
+		active = append(active, p)
+	}
+
+	value := func(p lineupPlayer) float64 {
+		g, ok := games[p.PlayerID]
+		if !ok {
+			g = 1
+			if len(games) > 0 {
+				g = defaultGamesPerWeek
+			}
+		}
+		if mode == EvaluationModePoints {
+			return p.FPG * float64(g)
+		}
+		if g == 0 {
+			return 0
+		}
+		return p.ZScore
+	}
+
+	// Players who fit in the slots together form a matroid, so adding them
+	// best first whenever they still fit maximizes the total value.
+	order := make([]int, len(active))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return value(active[order[i]]) > value(active[order[j]])
+	})
+
+	starting := make(map[int]bool)
+	matcher := newSlotMatcher(slots)
+	for _, i := range order {
+		if matcher.add(i, active[i].Eligible) {
+			starting[i] = true
+		}
+	}
+
+	if mode == EvaluationModeCategories && len(opponent) > 0 {
+		starting, matcher = s.improveCategoryLineup(slots, active, starting, games, opponent, categories)
+		lineup.ExpectedCategoryWins = expectedCategoryWins(active, starting, games, opponent, categories)
+	}
+
+	assigned := make(map[int]string)
+	for slot, i := range matcher.slotPlayer {
+		if i >= 0 {
+			assigned[i] = slots[slot]
+		}
+	}
+
+	for i, p := range active {
+		entry := LineupSlot{
+			Position:   assigned[i],
+			PlayerID:   p.PlayerID,
+			PlayerKey:  p.PlayerKey,
+			PlayerName: p.PlayerName,
+			Value:      value(p),
+		}
+		if entry.Position == "" {
+			entry.Position = "BN"
+			lineup.Bench = append(lineup.Bench, entry)
+		} else {
+			lineup.Starters = append(lineup.Starters, entry)
+			if mode == EvaluationModePoints {
+				lineup.ProjectedPoints += entry.Value
+			}
+		}
+		if entry.Position != p.Selected {
+			lineup.Changes = append(lineup.Changes, yahoo.PositionChange{PlayerKey: p.PlayerKey, Position: entry.Position})
+		}
+	}
+
+	return lineup
+}
+
+// improveCategoryLineup swaps starters for bench players, best swap first,
+// while a swap raises the expected category wins against the opponent.
+func (s *LineupService) improveCategoryLineup(slots []string, players []lineupPlayer, starting map[int]bool, games map[int]int, opponent []PlayerProjection, categories []analysisCategory) (map[int]bool, *slotMatcher) {
+	best := expectedCategoryWins(players, starting, games, opponent, categories)
+
+	for swaps := 0; swaps < maxLineupSwaps; swaps++ {
+		var bestSwap map[int]bool
+		for out := range players {
+			if !starting[out] {
+				continue
+			}
+			for in := range players {
+				if starting[in] {
+					continue
+				}
+				candidate := make(map[int]bool, len(starting))
+				for i := range starting {
+					if i != out {
+						candidate[i] = true
+					}
+				}
+				candidate[in] = true
+
+				if !fitsSlots(slots, players, candidate) {
+					continue
+				}
+				if wins := expectedCategoryWins(players, candidate, games, opponent, categories); wins > best+1e-9 {
+					best, bestSwap = wins, candidate
+				}
+			}
+		}
+		if bestSwap == nil {
+			break
+		}
+		starting = bestSwap
+	}
+
+	matcher := newSlotMatcher(slots)
+	for i := range players {
+		if starting[i] {
+			matcher.add(i, players[i].Eligible)
+		}
+	}
+	return starting, matcher
+}
+
+// expectedCategoryWins returns the starters' expected wins against the
+// opponent in the given categories.
+func expectedCategoryWins(players []lineupPlayer, starting map[int]bool, games map[int]int, opponent []PlayerProjection, categories []analysisCategory) float64 {
+	var starters []PlayerProjection
+	for i, p := range players {
+		if starting[i] {
+			starters = append(starters, p.PlayerProjection)
+		}
+	}
+
+	wins := 0.0
+	mine := weeklyTotals(starters, games)
+	theirs := weeklyTotals(opponent, games)
+	for _, p := range categoryWinProbabilities(mine, theirs, categories) {
+		wins += p
+	}
+	return wins
+}
+
+// fitsSlots reports whether every player in set can be given a slot.
+func fitsSlots(slots []string, players []lineupPlayer, set map[int]bool) bool {
+	matcher := newSlotMatcher(slots)
+	for i := range players {
+		if set[i] && !matcher.add(i, players[i].Eligible) {
+			return false
+		}
+	}
+	return true
+}
+
+// slotMatcher assigns players to slots they are eligible for, using
+// augmenting paths so an earlier assignment can move to make room.
+type slotMatcher struct {
+	slots      []string
+	eligible   map[int][]string
+	slotPlayer []int
+}
+
+func newSlotMatcher(slots []string) *slotMatcher {
+	m := &slotMatcher{
+		slots:      slots,
+		eligible:   make(map[int][]string),
+		slotPlayer: make([]int, len(slots)),
+	}
+	for i := range m.slotPlayer {
+		m.slotPlayer[i] = -1
+	}
+	return m
+}
+
+// add gives player p a slot if possible, moving earlier players between
+// slots as needed. It reports whether p got a slot; if not, the existing
+// assignments are unchanged.
+func (m *slotMatcher) add(p int, eligible []string) bool {
+	m.eligible[p] = eligible
+	if m.augment(p, make([]bool, len(m.slots))) {
+		return true
+	}
+	delete(m.eligible, p)
+	return false
+}
+
+func (m *slotMatcher) augment(p int, visited []bool) bool {
+	for i, slot := range m.slots {
+		if visited[i] || !slotAccepts(slot, m.eligible[p]) {
+			continue
+		}
+		visited[i] = true
+		if m.slotPlayer[i] == -1 || m.augment(m.slotPlayer[i], visited) {
+			m.slotPlayer[i] = p
+			return true
+		}
+	}
+	return false
+}
+
+func (s *LineupService) getLineupPlayers(ctx context.Context, leagueID, teamID int) ([]lineupPlayer, error) {
+	query := `
+		SELECT p.id, p.yahoo_player_key, p.full_name, COALESCE(fr.selected_position, 'BN'),
+		       pp.fpg, pp.z_score, pp.proj_pts, pp.proj_reb, pp.proj_ast, pp.proj_stl,
+		       pp.proj_blk, pp.proj_to, pp.proj_fg_pct, pp.proj_ft_pct, pp.proj_3pm
+		FROM fantasy_rosters fr
+		JOIN players p ON fr.player_id = p.id
+		JOIN player_projections pp ON p.id = pp.player_id AND pp.league_id = ?
+		WHERE fr.team_id = ?
+		ORDER BY p.id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []lineupPlayer
+	for rows.Next() {
+		var p lineupPlayer
+		err := rows.Scan(
+			&p.PlayerID, &p.PlayerKey, &p.PlayerName, &p.Selected,
+			&p.FPG, &p.ZScore, &p.PTS, &p.REB, &p.AST, &p.STL,
+			&p.BLK, &p.TO, &p.FGPct, &p.FTPct, &p.TPM,
+		)
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	eligible, err := s.playerRepo.GetEligiblePositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range players {
+		players[i].Eligible = eligible[players[i].PlayerID]
+	}
+	return players, nil
+}
+
+// getOpponentStarters returns the starters of the team's opponent in the
+// given week, or nil if it has none. Week 0 is the current week.
+func (s *LineupService) getOpponentStarters(ctx context.Context, leagueID int, leagueKey string, teamID, week int) ([]PlayerProjection, error) {
+	teamIDs, err := s.teamRepo.GetIDsByYahooKey(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	matchups, err := s.yahooClient.GetLeagueMatchups(ctx, leagueKey, week)
+	if err != nil {
+		return nil, err
+	}
+
+	opponentID, ok := NewScheduleWeek(week, matchups, teamIDs).Opponents[teamID]
+	if !ok {
+		return nil, nil
+	}
+	starters, err := s.projectionRepo.GetStarters(ctx, leagueID, opponentID)
+	if err != nil {
+		return nil, err
+	}
+	return playerProjections(starters), nil
+}
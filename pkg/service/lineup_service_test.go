@@ -0,0 +1,104 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+)
+
+func TestStartingSlots(t *testing.T) {
+	slots := startingSlots([]repository.RosterSlot{
+		{Position: "PG", Count: 1, IsStarting: true},
+		{Position: "Util", Count: 2, IsStarting: true},
+		{Position: "BN", Count: 3},
+		{Position: "IL", Count: 1},
+	})
+
+	if len(slots) != 3 || slots[0] != "PG" || slots[1] != "Util" || slots[2] != "Util" {
+		t.Errorf("Expected [PG Util Util], got %v", slots)
+	}
+}
+
+func TestOptimizeLineupPoints(t *testing.T) {
+	service := &LineupService{}
+	slots := []string{"PG", "C", "G", "UTIL"}
+	players := []lineupPlayer{
+		{PlayerProjection: PlayerProjection{PlayerID: 1, FPG: 40}, PlayerKey: "p.1", Selected: "BN", Eligible: []string{"PG", "SG"}},
+		{PlayerProjection: PlayerProjection{PlayerID: 2, FPG: 35}, PlayerKey: "p.2", Selected: "PG", Eligible: []string{"PG"}},
+		{PlayerProjection: PlayerProjection{PlayerID: 3, FPG: 30}, PlayerKey: "p.3", Selected: "C", Eligible: []string{"C"}},
+		{PlayerProjection: PlayerProjection{PlayerID: 4, FPG: 25}, PlayerKey: "p.4", Selected: "UTIL", Eligible: []string{"PF"}},
+		{PlayerProjection: PlayerProjection{PlayerID: 5, FPG: 20}, PlayerKey: "p.5", Selected: "G", Eligible: []string{"SG"}},
+		{PlayerProjection: PlayerProjection{PlayerID: 6, FPG: 50}, PlayerKey: "p.6", Selected: "IL", Eligible: []string{"C"}},
+	}
+	// Player 1 plays four games, player 2 only one.
+	games := map[int]int{1: 4, 2: 1}
+
+	lineup := service.optimizeLineup(slots, players, games, EvaluationModePoints, nil, nil)
+
+	starters := make(map[int]string)
+	for _, s := range lineup.Starters {
+		starters[s.PlayerID] = s.Position
+	}
+	if len(starters) != 4 {
+		t.Fatalf("Expected 4 starters, got %v", lineup.Starters)
+	}
+	if _, ok := starters[6]; ok {
+		t.Error("Players in reserve slots should stay out of the lineup")
+	}
+	if _, ok := starters[2]; ok {
+		t.Error("Expected the one-game player to be benched")
+	}
+	if starters[3] != "C" || starters[4] != "UTIL" {
+		t.Errorf("Expected the center at C and the forward at UTIL, got %v", starters)
+	}
+	if starters[1] != "PG" && starters[1] != "G" {
+		t.Errorf("Expected player 1 in a guard slot, got %q", starters[1])
+	}
+
+	// 40*4 + 30*3 + 25*3 + 20*3
+	if lineup.ProjectedPoints != 385 {
+		t.Errorf("Expected 385 projected points, got %.1f", lineup.ProjectedPoints)
+	}
+
+	changed := make(map[string]string)
+	for _, c := range lineup.Changes {
+		changed[c.PlayerKey] = c.Position
+	}
+	if changed["p.2"] != "BN" {
+		t.Errorf("Expected player 2 to move to the bench, got %v", lineup.Changes)
+	}
+	if _, ok := changed["p.3"]; ok {
+		t.Error("Players staying in their slot should not be in the changes")
+	}
+	if _, ok := changed["p.6"]; ok {
+		t.Error("Players in reserve slots should not be moved")
+	}
+}
+
+func TestOptimizeLineupCategories(t *testing.T) {
+	service := &LineupService{}
+	slots := []string{"UTIL", "UTIL"}
+	// The scorer has the highest z-score, but the opponent's big man wins
+	// points anyway; starting a second rebounder wins rebounds instead.
+	players := []lineupPlayer{
+		{PlayerProjection: PlayerProjection{PlayerID: 1, REB: 12, BLK: 2, FGPct: 0.55, FTPct: 0.7}, ZScore: 5, Eligible: []string{"C"}},
+		{PlayerProjection: PlayerProjection{PlayerID: 2, PTS: 25, AST: 3, FGPct: 0.45, FTPct: 0.85}, ZScore: 4, Eligible: []string{"SG"}},
+		{PlayerProjection: PlayerProjection{PlayerID: 3, REB: 10, BLK: 1, FGPct: 0.55, FTPct: 0.7}, ZScore: 1, Eligible: []string{"PF"}},
+	}
+	opponent := []PlayerProjection{
+		{PlayerID: 10, PTS: 60, REB: 18, AST: 1, FGPct: 0.5, FTPct: 0.75},
+		{PlayerID: 11, PTS: 20, REB: 2, AST: 8, FGPct: 0.5, FTPct: 0.75},
+	}
+
+	greedy := map[int]bool{0: true, 1: true}
+	before := expectedCategoryWins(players, greedy, nil, opponent, defaultAnalysisCategories())
+
+	lineup := service.optimizeLineup(slots, players, nil, EvaluationModeCategories, opponent, defaultAnalysisCategories())
+
+	if lineup.ExpectedCategoryWins <= before {
+		t.Errorf("Expected swaps to improve on %.2f category wins, got %.2f", before, lineup.ExpectedCategoryWins)
+	}
+	if len(lineup.Bench) != 1 || lineup.Bench[0].PlayerID != 2 {
+		t.Errorf("Expected the scorer on the bench, got %v", lineup.Bench)
+	}
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// MatchupProjection is the expected outcome of one week's head-to-head
+// matchup, from team A's side.
+type MatchupProjection struct {
+	LeagueID    int
+	Week        int
+	TeamAID     int
+	TeamBID     int
+	TeamATotals TeamCategoryTotals
+	TeamBTotals TeamCategoryTotals
+	// CategoryWinProbabilities is team A's chance of winning each category.
+	CategoryWinProbabilities map[string]float64
+	// ExpectedTeamAWins and ExpectedTeamBWins are the expected number of
+	// categories each team wins.
+	ExpectedTeamAWins float64
+	ExpectedTeamBWins float64
+	// TeamAWinProbability is team A's chance of winning the matchup.
+	TeamAWinProbability float64
+	// Score is the expected category score, such as "6-3".
+	Score string
+}
+
+// ProjectMatchup projects the week's matchup between two teams from their
+// starters' projections and the games each plays that week, as stored in
+// player_weekly_games. Players without a row are assumed to play three games.
+func (s *AnalysisService) ProjectMatchup(ctx context.Context, leagueID, week, teamAID, teamBID int) (_ *MatchupProjection, err error) {
+	ctx, span := startSpan(ctx, "AnalysisService.ProjectMatchup",
+		attribute.Int("league.id", leagueID),
+		attribute.Int("week", week),
+		attribute.Int("team_a.id", teamAID),
+		attribute.Int("team_b.id", teamBID),
+	)
+	defer func() { endSpan(span, err) }()
+
+	teamA, err := s.projectionRepo.GetStarters(ctx, leagueID, teamAID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team A projections: %w", err)
+	}
+
+	teamB, err := s.projectionRepo.GetStarters(ctx, leagueID, teamBID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team B projections: %w", err)
+	}
+
+	games, err := s.projectionRepo.GetWeeklyGames(ctx, leagueID, week)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weekly games: %w", err)
+	}
+
+	categories, err := s.getLeagueCategories(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league categories: %w", err)
+	}
+
+	projection := projectMatchup(playerProjections(teamA), playerProjections(teamB), games, categories)
+	projection.LeagueID = leagueID
+	projection.Week = week
+	projection.TeamAID = teamAID
+	projection.TeamBID = teamBID
+	return projection, nil
+}
+
+// projectMatchup projects a matchup between two sets of starters in the
+// given categories. Category win probabilities come from the same normal
+// approximation SimulateTradeSchedule uses.
+func projectMatchup(teamA, teamB []PlayerProjection, games map[int]int, categories []analysisCategory) *MatchupProjection {
+	projection := &MatchupProjection{
+		TeamATotals: weeklyTotals(teamA, games),
+		TeamBTotals: weeklyTotals(teamB, games),
+	}
+	projection.CategoryWinProbabilities = categoryWinProbabilities(projection.TeamATotals, projection.TeamBTotals, categories)
+	projection.TeamAWinProbability = matchupWinProbability(projection.CategoryWinProbabilities)
+
+	for _, p := range projection.CategoryWinProbabilities {
+		projection.ExpectedTeamAWins += p
+	}
+	played := len(projection.CategoryWinProbabilities)
+	projection.ExpectedTeamBWins = float64(played) - projection.ExpectedTeamAWins
+
+	teamAWins := int(math.Round(projection.ExpectedTeamAWins))
+	projection.Score = fmt.Sprintf("%d-%d", teamAWins, played-teamAWins)
+	return projection
+}
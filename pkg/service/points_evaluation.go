@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// EvaluationMode is how EvaluateTrade judges a trade.
+type EvaluationMode string
+
+const (
+	// EvaluationModeCategories compares the nine category totals, for
+	// head-to-head category and rotisserie leagues.
+	EvaluationModeCategories EvaluationMode = "categories"
+	// EvaluationModePoints compares projected fantasy points, for points
+	// leagues.
+	EvaluationModePoints EvaluationMode = "points"
+)
+
+// positionGapPenalty is the share of an outgoing player's fantasy points a
+// team loses when the trade leaves it with nobody at that position, since a
+// weaker player has to fill the lineup slot.
+const positionGapPenalty = 0.5
+
+// evaluationModeFor returns the evaluation mode for a Yahoo scoring type:
+// "headpoint" and "point" leagues score points, "head" and "roto" leagues
+// score categories.
+func evaluationModeFor(scoringType string) EvaluationMode {
+	switch scoringType {
+	case "headpoint", "point":
+		return EvaluationModePoints
+	default:
+		return EvaluationModeCategories
+	}
+}
+
+// EvaluatePointsTrade evaluates a trade purely on projected fantasy points.
+// Player projections in points leagues are already scored with the league's
+// stat modifiers. When schedule is given, each player's value is their
+// projected points over its remaining games rather than per game, so
+// players with more games left are worth more. Teams lose part of an
+// outgoing player's value when the trade leaves them without anyone at
+// that player's position.
+func (s *EvaluationService) EvaluatePointsTrade(
+	ctx context.Context,
+	leagueID int,
+	teamAID int,
+	teamAGives []int,
+	teamBID int,
+	teamBGives []int,
+	schedule []ScheduleWeek,
+) (_ *TradeEvaluation, err error) {
+	ctx, span := startSpan(ctx, "EvaluationService.EvaluatePointsTrade",
+		attribute.Int("league.id", leagueID),
+		attribute.Int("team_a.id", teamAID),
+		attribute.Int("team_b.id", teamBID),
+		attribute.Int("weeks", len(schedule)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	teamAProjections, err := s.GetPlayerProjections(ctx, leagueID, teamAGives)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team A projections: %w", err)
+	}
+
+	teamBProjections, err := s.GetPlayerProjections(ctx, leagueID, teamBGives)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team B projections: %w", err)
+	}
+
+	teamARoster, err := s.getRosterProjections(ctx, leagueID, teamAID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team A roster: %w", err)
+	}
+
+	teamBRoster, err := s.getRosterProjections(ctx, leagueID, teamBID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team B roster: %w", err)
+	}
+
+	return s.evaluatePoints(
+		teamAID, teamARoster, teamAProjections,
+		teamBID, teamBRoster, teamBProjections,
+		schedule,
+	), nil
+}
+
+func (s *EvaluationService) evaluatePoints(
+	teamAID int,
+	teamARoster []PlayerProjection,
+	teamAGives []PlayerProjection,
+	teamBID int,
+	teamBRoster []PlayerProjection,
+	teamBGives []PlayerProjection,
+	schedule []ScheduleWeek,
+) *TradeEvaluation {
+	if len(schedule) > 0 {
+		teamAGives = s.scheduleWeighted(teamAGives, schedule)
+		teamBGives = s.scheduleWeighted(teamBGives, schedule)
+	}
+
+	fairnessScore := s.calculateFairnessScore(teamAGives, teamBGives)
+
+	evaluation := &TradeEvaluation{
+		TeamAImpact:   s.pointsImpact(teamAID, teamARoster, teamBGives, teamAGives),
+		TeamBImpact:   s.pointsImpact(teamBID, teamBRoster, teamAGives, teamBGives),
+		FairnessScore: fairnessScore,
+		IsFair:        s.isFair(fairnessScore),
+		Mode:          EvaluationModePoints,
+	}
+
+	evaluation.Recommendation = s.generateRecommendation(evaluation)
+
+	return evaluation
+}
+
+func (s *EvaluationService) pointsImpact(
+	teamID int,
+	roster []PlayerProjection,
+	playersIn []PlayerProjection,
+	playersOut []PlayerProjection,
+) TradeImpact {
+	valueChange := s.sumFPG(playersIn) - s.sumFPG(playersOut)
+
+	netBenefit := valueChange
+	positionImpact := s.analyzePositionImpact(playersIn, playersOut)
+	if gaps := s.positionGaps(roster, playersIn, playersOut); len(gaps) > 0 {
+		for _, p := range playersOut {
+			if contains(gaps, p.Position) {
+				netBenefit -= p.FPG * positionGapPenalty
+			}
+		}
+		positionImpact = fmt.Sprintf("Leaves no %s", gaps[0])
+	}
+
+	return TradeImpact{
+		TeamID:         teamID,
+		ValueChange:    valueChange,
+		PositionImpact: positionImpact,
+		NetBenefit:     netBenefit,
+	}
+}
+
+// positionGaps returns the positions the roster covers before the trade but
+// not after it. The roster is the team's full roster, bench included, and
+// outgoing players not on it are ignored.
+func (s *EvaluationService) positionGaps(roster, playersIn, playersOut []PlayerProjection) []string {
+	counts := make(map[string]int)
+	rostered := make(map[int]bool)
+	for _, p := range roster {
+		counts[p.Position]++
+		rostered[p.PlayerID] = true
+	}
+
+	var positions []string
+	for _, p := range playersOut {
+		if !rostered[p.PlayerID] {
+			continue
+		}
+		if !contains(positions, p.Position) {
+			positions = append(positions, p.Position)
+		}
+		counts[p.Position]--
+	}
+	for _, p := range playersIn {
+		counts[p.Position]++
+	}
+
+	var gaps []string
+	for _, pos := range positions {
+		if counts[pos] <= 0 {
+			gaps = append(gaps, pos)
+		}
+	}
+	return gaps
+}
+
+// scheduleWeighted returns copies of the projections with FPG replaced by
+// the player's projected points over the schedule.
+func (s *EvaluationService) scheduleWeighted(players []PlayerProjection, schedule []ScheduleWeek) []PlayerProjection {
+	result := make([]PlayerProjection, len(players))
+	for i, p := range players {
+		games := 0
+		for _, week := range schedule {
+			g, ok := week.Games[p.PlayerID]
+			if !ok {
+				g = defaultGamesPerWeek
+			}
+			games += g
+		}
+		p.FPG *= float64(games)
+		result[i] = p
+	}
+	return result
+}
+
+func (s *EvaluationService) getLeagueScoringType(ctx context.Context, leagueID int) (string, error) {
+	query := `SELECT scoring_type FROM fantasy_leagues WHERE id = ?`
+	var scoringType string
+	err := s.db.QueryRowContext(ctx, query, leagueID).Scan(&scoringType)
+	return scoringType, err
+}
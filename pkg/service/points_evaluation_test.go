@@ -0,0 +1,142 @@
+package service
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEvaluationModeFor(t *testing.T) {
+	tests := []struct {
+		scoringType string
+		expected    EvaluationMode
+	}{
+		{"head", EvaluationModeCategories},
+		{"roto", EvaluationModeCategories},
+		{"headpoint", EvaluationModePoints},
+		{"point", EvaluationModePoints},
+		{"", EvaluationModeCategories},
+	}
+
+	for _, tt := range tests {
+		if mode := evaluationModeFor(tt.scoringType); mode != tt.expected {
+			t.Errorf("evaluationModeFor(%q) = %s, want %s", tt.scoringType, mode, tt.expected)
+		}
+	}
+}
+
+func TestEvaluatePoints(t *testing.T) {
+	service := &EvaluationService{}
+
+	pg := PlayerProjection{PlayerID: 1, FPG: 40.0, Position: "PG"}
+	center := PlayerProjection{PlayerID: 2, FPG: 38.0, Position: "C"}
+	teamARoster := []PlayerProjection{pg, {PlayerID: 3, FPG: 30.0, Position: "PG"}, {PlayerID: 4, FPG: 25.0, Position: "SF"}}
+	teamBRoster := []PlayerProjection{center, {PlayerID: 5, FPG: 28.0, Position: "PF"}}
+
+	eval := service.evaluatePoints(
+		10, teamARoster, []PlayerProjection{pg},
+		20, teamBRoster, []PlayerProjection{center},
+		nil,
+	)
+
+	if eval.Mode != EvaluationModePoints {
+		t.Errorf("Mode = %s, want points", eval.Mode)
+	}
+	if !eval.IsFair {
+		t.Errorf("40 for 38 points should be fair, got score %.1f", eval.FairnessScore)
+	}
+	if math.Abs(eval.TeamAImpact.ValueChange-(-2.0)) > 0.01 {
+		t.Errorf("Team A ValueChange = %.2f, want -2.00", eval.TeamAImpact.ValueChange)
+	}
+	if len(eval.TeamAImpact.CategoryImprovements) != 0 {
+		t.Error("Points evaluations should not report category changes")
+	}
+
+	// Team A keeps a PG; team B gives up its only center.
+	if math.Abs(eval.TeamAImpact.NetBenefit-(-2.0)) > 0.01 {
+		t.Errorf("Team A NetBenefit = %.2f, want -2.00", eval.TeamAImpact.NetBenefit)
+	}
+	expectedB := 2.0 - 38.0*positionGapPenalty
+	if math.Abs(eval.TeamBImpact.NetBenefit-expectedB) > 0.01 {
+		t.Errorf("Team B NetBenefit = %.2f, want %.2f", eval.TeamBImpact.NetBenefit, expectedB)
+	}
+	if eval.TeamBImpact.PositionImpact != "Leaves no C" {
+		t.Errorf("Team B PositionImpact = %q", eval.TeamBImpact.PositionImpact)
+	}
+}
+
+func TestEvaluatePointsWithSchedule(t *testing.T) {
+	service := &EvaluationService{}
+
+	playerA := PlayerProjection{PlayerID: 1, FPG: 40.0, Position: "PG"}
+	playerB := PlayerProjection{PlayerID: 2, FPG: 40.0, Position: "PG"}
+	schedule := []ScheduleWeek{
+		{Week: 20, Games: map[int]int{1: 4, 2: 2}},
+		{Week: 21, Games: map[int]int{1: 4, 2: 2}},
+	}
+
+	perGame := service.evaluatePoints(10, nil, []PlayerProjection{playerA}, 20, nil, []PlayerProjection{playerB}, nil)
+	if perGame.FairnessScore != 100.0 {
+		t.Errorf("Equal per-game values should be perfectly fair, got %.1f", perGame.FairnessScore)
+	}
+
+	eval := service.evaluatePoints(10, nil, []PlayerProjection{playerA}, 20, nil, []PlayerProjection{playerB}, schedule)
+	if math.Abs(eval.TeamAImpact.ValueChange-(160.0-320.0)) > 0.01 {
+		t.Errorf("Team A ValueChange = %.2f, want -160.00", eval.TeamAImpact.ValueChange)
+	}
+	if eval.IsFair {
+		t.Errorf("Giving up twice the remaining games should not be fair, got score %.1f", eval.FairnessScore)
+	}
+}
+
+func TestPositionGaps(t *testing.T) {
+	service := &EvaluationService{}
+
+	starter := PlayerProjection{PlayerID: 1, FPG: 35.0, Position: "C"}
+	bench := PlayerProjection{PlayerID: 2, FPG: 15.0, Position: "C"}
+	guard := PlayerProjection{PlayerID: 3, FPG: 30.0, Position: "PG"}
+	roster := []PlayerProjection{starter, bench, guard}
+
+	// A bench C leaves the starting C behind.
+	if gaps := service.positionGaps(roster, nil, []PlayerProjection{bench}); len(gaps) != 0 {
+		t.Errorf("Trading a bench C should leave no gaps, got %v", gaps)
+	}
+
+	// The bench C fills in for a traded starting C.
+	if gaps := service.positionGaps(roster, nil, []PlayerProjection{starter}); len(gaps) != 0 {
+		t.Errorf("Bench C should cover the traded starter, got %v", gaps)
+	}
+
+	if gaps := service.positionGaps(roster, nil, []PlayerProjection{starter, bench}); len(gaps) != 1 || gaps[0] != "C" {
+		t.Errorf("Trading both centers should leave a C gap, got %v", gaps)
+	}
+
+	// Players not on the roster do not count against it.
+	other := PlayerProjection{PlayerID: 4, FPG: 20.0, Position: "PG"}
+	if gaps := service.positionGaps(roster, nil, []PlayerProjection{guard, other}); len(gaps) != 1 || gaps[0] != "PG" {
+		t.Errorf("Expected a PG gap, got %v", gaps)
+	}
+	if gaps := service.positionGaps(roster, nil, []PlayerProjection{other}); len(gaps) != 0 {
+		t.Errorf("Trading an unrostered player should leave no gaps, got %v", gaps)
+	}
+}
+
+func TestWithFairnessThreshold(t *testing.T) {
+	star := PlayerProjection{PlayerID: 1, FPG: 40.0, Position: "PG"}
+	role := PlayerProjection{PlayerID: 2, FPG: 25.0, Position: "PG"}
+
+	strict := &EvaluationService{}
+	eval := strict.evaluatePoints(10, nil, []PlayerProjection{star}, 20, nil, []PlayerProjection{role}, nil)
+	if eval.IsFair {
+		t.Fatalf("40 for 25 points should not be fair by default, got score %.1f", eval.FairnessScore)
+	}
+
+	loose := &EvaluationService{}
+	WithFairnessThreshold(eval.FairnessScore)(loose)
+	eval = loose.evaluatePoints(10, nil, []PlayerProjection{star}, 20, nil, []PlayerProjection{role}, nil)
+	if !eval.IsFair {
+		t.Errorf("Score %.1f should be fair at a threshold of %.1f", eval.FairnessScore, eval.FairnessScore)
+	}
+	if eval.Recommendation == "Trade is imbalanced. Value difference too large." {
+		t.Errorf("Fair trade recommended as imbalanced")
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+)
+
+// PositionRankLabel returns the player's rank at their primary position in
+// the form "PG #12", or "" if they have not been ranked.
+func (p PlayerValue) PositionRankLabel() string {
+	if p.Position == "" || p.PositionRank == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s #%d", p.Position, p.PositionRank)
+}
+
+// rankPositions ranks players, highest value first, among the players
+// sharing each of their positions. eligible maps player IDs to their
+// eligible positions; the primary position always counts. As with overall
+// ranks, ties are broken by player ID.
+func (s *ValuationService) rankPositions(players []PlayerValue, eligible map[int][]string, value func(PlayerValue) float64) {
+	values := playerValuesBy(players, value)
+	byPosition := make(map[string][]int)
+	for i, p := range players {
+		positions := eligible[p.PlayerID]
+		if p.Position != "" && !contains(positions, p.Position) {
+			positions = append([]string{p.Position}, positions...)
+		}
+		for _, position := range positions {
+			byPosition[position] = append(byPosition[position], i)
+		}
+		players[i].PositionRanks = make(map[string]int, len(positions))
+	}
+
+	for position, indexes := range byPosition {
+		sortByValue(players, values, indexes)
+		for rank, i := range indexes {
+			players[i].PositionRanks[position] = rank + 1
+		}
+	}
+
+	for i := range players {
+		players[i].PositionRank = players[i].PositionRanks[players[i].Position]
+	}
+}
+
+// savePositionRanks replaces the league's rows in the player_position_ranks
+// table, which holds each player's rank at every position they are eligible
+// at:
+//
+//	CREATE TABLE player_position_ranks (
+//		league_id INTEGER NOT NULL REFERENCES fantasy_leagues(id),
+//		player_id INTEGER NOT NULL REFERENCES players(id),
+//		position  TEXT NOT NULL,
+//		rank      INTEGER NOT NULL,
+//		PRIMARY KEY (league_id, player_id, position)
+//	);
+func (s *ValuationService) savePositionRanks(ctx context.Context, leagueID int, players []PlayerValue) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM player_position_ranks WHERE league_id = ?`, leagueID); err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO player_position_ranks (league_id, player_id, position, rank)
+		VALUES (?, ?, ?, ?)
+	`
+
+	for _, p := range players {
+		for position, rank := range p.PositionRanks {
+			if _, err := tx.ExecContext(ctx, insertQuery, leagueID, p.PlayerID, position, rank); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,271 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// defaultLeagueSize is the number of teams assumed when a league's size
+	// is unknown.
+	defaultLeagueSize = 12
+	// minScarcity and maxScarcity bound the position multipliers, so a
+	// thin or oddly shaped player pool cannot swamp player value.
+	minScarcity = 0.8
+	maxScarcity = 1.5
+)
+
+// PositionScarcity describes how quickly value drops off at a position
+// across the league's player pool, and the multiplier that earns players
+// there.
+type PositionScarcity struct {
+	Position string
+	// Depth is how many players at the position the league starts: the
+	// number of teams times the position's starting slots, including its
+	// share of the flex slots that accept it.
+	Depth int
+	// TopValue is the average z-score of the Depth best players at the
+	// position, and ReplacementValue the z-score of the last of them.
+	TopValue         float64
+	ReplacementValue float64
+	// DropOff is TopValue minus ReplacementValue: how much better the
+	// starters at the position are than what is left on waivers.
+	DropOff float64
+	// Multiplier is the position's DropOff relative to the average across
+	// positions, bounded to [0.8, 1.5].
+	Multiplier float64
+}
+
+// calculatePositionScarcity computes each position's scarcity from the
+// players' z-scores. depths gives the starters per roster slot, which
+// positionDepths spreads over the players' primary positions; positions no
+// slot accepts are assumed to start one per team.
+func (s *ValuationService) calculatePositionScarcity(players []PlayerValue, depths map[string]int) []PositionScarcity {
+	values := make(map[string][]float64)
+	for _, p := range players {
+		values[p.Position] = append(values[p.Position], p.ZScore)
+	}
+
+	positions := make([]string, 0, len(values))
+	for position := range values {
+		positions = append(positions, position)
+	}
+	fallback := depths[""]
+	depths = positionDepths(depths, positions)
+	if fallback <= 0 {
+		fallback = defaultLeagueSize
+	}
+
+	var scarcity []PositionScarcity
+	totalDropOff := 0.0
+	for position, vs := range values {
+		sort.Sort(sort.Reverse(sort.Float64Slice(vs)))
+
+		depth := depths[position]
+		if depth <= 0 {
+			depth = fallback
+		}
+		if depth > len(vs) {
+			depth = len(vs)
+		}
+
+		top := 0.0
+		for _, v := range vs[:depth] {
+			top += v
+		}
+		top /= float64(depth)
+
+		ps := PositionScarcity{
+			Position:         position,
+			Depth:            depth,
+			TopValue:         top,
+			ReplacementValue: vs[depth-1],
+			DropOff:          top - vs[depth-1],
+		}
+		totalDropOff += ps.DropOff
+		scarcity = append(scarcity, ps)
+	}
+
+	for i := range scarcity {
+		scarcity[i].Multiplier = 1.0
+		if totalDropOff > 0 {
+			average := totalDropOff / float64(len(scarcity))
+			multiplier := scarcity[i].DropOff / average
+			scarcity[i].Multiplier = math.Max(minScarcity, math.Min(maxScarcity, multiplier))
+		}
+	}
+
+	sort.Slice(scarcity, func(i, j int) bool {
+		return scarcity[i].Position < scarcity[j].Position
+	})
+	return scarcity
+}
+
+// positionDepths spreads the starters per roster slot over the primary
+// positions each slot accepts, so that flex slots such as G, F and Util
+// count towards PG and SG, SF and PF, and every position. A slot's starters
+// are split evenly between the positions it accepts.
+func positionDepths(slots map[string]int, positions []string) map[string]int {
+	shares := make(map[string]float64, len(positions))
+	for slot, depth := range slots {
+		if slot == "" {
+			continue
+		}
+		var accepted []string
+		for _, position := range positions {
+			if slotAccepts(slot, []string{position}) {
+				accepted = append(accepted, position)
+			}
+		}
+		for _, position := range accepted {
+			shares[position] += float64(depth) / float64(len(accepted))
+		}
+	}
+
+	depths := make(map[string]int, len(shares))
+	for position, share := range shares {
+		depths[position] = int(math.Round(share))
+	}
+	return depths
+}
+
+// getPositionDepths returns how many players the league starts in each
+// roster slot, from its roster positions and number of teams. The "" entry
+// is the number of teams, used for positions no slot accepts.
+func (s *ValuationService) getPositionDepths(ctx context.Context, leagueID int) (map[string]int, error) {
+	numTeams, err := s.getNumTeams(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	slots, err := s.leagueRepo.GetRosterPositions(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+
+	depths := map[string]int{"": numTeams}
+	for _, slot := range slots {
+		if slot.IsStarting {
+			depths[slot.Position] += slot.Count * numTeams
+		}
+	}
+	return depths, nil
+}
+
+// getDraftablePoolSize returns how many players the league's teams roster
+// between them: the number of teams times the roster slots other than
+// injured and not-active ones. It is 0 if the league has no roster
+// positions.
+func (s *ValuationService) getDraftablePoolSize(ctx context.Context, leagueID int) (int, error) {
+	numTeams, err := s.getNumTeams(ctx, leagueID)
+	if err != nil {
+		return 0, err
+	}
+
+	slots, err := s.leagueRepo.GetRosterPositions(ctx, leagueID)
+	if err != nil {
+		return 0, err
+	}
+
+	rosterSize := 0
+	for _, slot := range slots {
+		switch slot.Position {
+		case "IL", "IL+", "NA":
+		default:
+			rosterSize += slot.Count
+		}
+	}
+	return rosterSize * numTeams, nil
+}
+
+// getNumTeams returns the league's number of teams, or defaultLeagueSize
+// if it is unknown.
+func (s *ValuationService) getNumTeams(ctx context.Context, leagueID int) (int, error) {
+	var numTeams int
+	query := `SELECT COALESCE(num_teams, 0) FROM fantasy_leagues WHERE id = ?`
+	if err := s.db.QueryRowContext(ctx, query, leagueID).Scan(&numTeams); err != nil {
+		return 0, err
+	}
+	if numTeams <= 0 {
+		numTeams = defaultLeagueSize
+	}
+	return numTeams, nil
+}
+
+// savePositionScarcity replaces the league's rows in the position_scarcity
+// table, which keeps the computed multipliers for inspection:
+//
+//	CREATE TABLE position_scarcity (
+//		league_id         INTEGER NOT NULL REFERENCES fantasy_leagues(id),
+//		position          TEXT NOT NULL,
+//		depth             INTEGER NOT NULL,
+//		top_value         REAL NOT NULL,
+//		replacement_value REAL NOT NULL,
+//		drop_off          REAL NOT NULL,
+//		multiplier        REAL NOT NULL,
+//		PRIMARY KEY (league_id, position)
+//	);
+func (s *ValuationService) savePositionScarcity(ctx context.Context, leagueID int, scarcity []PositionScarcity) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM position_scarcity WHERE league_id = ?`, leagueID); err != nil {
+		return err
+	}
+
+	insertQuery := `
+		INSERT INTO position_scarcity (
+			league_id, position, depth, top_value, replacement_value, drop_off, multiplier
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	for _, ps := range scarcity {
+		_, err := tx.ExecContext(ctx, insertQuery,
+			leagueID, ps.Position, ps.Depth, ps.TopValue, ps.ReplacementValue, ps.DropOff, ps.Multiplier,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetPositionScarcity returns the position multipliers computed by the
+// league's last CalculateAllPlayerValues, ordered by position.
+func (s *ValuationService) GetPositionScarcity(ctx context.Context, leagueID int) (_ []PositionScarcity, err error) {
+	ctx, span := startSpan(ctx, "ValuationService.GetPositionScarcity", attribute.Int("league.id", leagueID))
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT position, depth, top_value, replacement_value, drop_off, multiplier
+		FROM position_scarcity
+		WHERE league_id = ?
+		ORDER BY position
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get position scarcity: %w", err)
+	}
+	defer rows.Close()
+
+	var scarcity []PositionScarcity
+	for rows.Next() {
+		var ps PositionScarcity
+		err := rows.Scan(&ps.Position, &ps.Depth, &ps.TopValue, &ps.ReplacementValue, &ps.DropOff, &ps.Multiplier)
+		if err != nil {
+			return nil, err
+		}
+		scarcity = append(scarcity, ps)
+	}
+
+	return scarcity, rows.Err()
+}
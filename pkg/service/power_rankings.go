@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Power ranking scores weigh the season record most, then recent form and
+// strength of schedule. They sum to one, so scores run from 0 to 100.
+const (
+	seasonWeight   = 0.5
+	formWeight     = 0.3
+	scheduleWeight = 0.2
+
+	// formWeeks is how many of the latest completed weeks make up recent
+	// form.
+	formWeeks = 3
+)
+
+// Power ranking trends, comparing a team's rank with the last saved ranking.
+const (
+	TrendUp   = "↑"
+	TrendDown = "↓"
+	TrendFlat = "→"
+)
+
+// PowerRanking is a team's place in the league's power rankings after a
+// week. SeasonScore, FormScore and ScheduleScore are win rates between 0
+// and 1, where a tie counts as half a win.
+type PowerRanking struct {
+	LeagueID int
+	Week     int
+	TeamID   int
+	TeamName string
+	Rank     int
+	// PreviousRank is the team's rank in the last saved ranking, or 0 if
+	// there is none.
+	PreviousRank int
+	// Trend is TrendUp, TrendDown or TrendFlat.
+	Trend string
+	Score float64
+	// SeasonScore is the team's win rate over the season and FormScore its
+	// win rate over the last three weeks.
+	SeasonScore float64
+	FormScore   float64
+	// ScheduleScore is the average season win rate of the team's opponents.
+	ScheduleScore float64
+}
+
+// matchupResult is one team's result in one completed week: 1 for a win,
+// 0.5 for a tie and 0 for a loss.
+type matchupResult struct {
+	Week       int
+	TeamID     int
+	OpponentID int
+	Result     float64
+}
+
+// PowerRankings ranks the league's teams on their season record, recent
+// form and strength of schedule, using the matchup results of every
+// completed week. Matchups come from Yahoo, or from those stored by
+// LeagueService.SyncMatchups when the service has no Yahoo client. The
+// rankings are saved for the latest completed
+// week in the power_rankings table, and each team's trend compares it with
+// the ranking saved for an earlier week:
+//
+//	CREATE TABLE power_rankings (
+//		league_id      INTEGER NOT NULL REFERENCES fantasy_leagues(id),
+//		week           INTEGER NOT NULL,
+//		team_id        INTEGER NOT NULL REFERENCES fantasy_teams(id),
+//		rank           INTEGER NOT NULL,
+//		score          REAL NOT NULL,
+//		season_score   REAL NOT NULL,
+//		form_score     REAL NOT NULL,
+//		schedule_score REAL NOT NULL,
+//		created_at     DATETIME DEFAULT CURRENT_TIMESTAMP,
+//		PRIMARY KEY (league_id, week, team_id)
+//	);
+//
+// It returns no rankings before the first week is complete.
+func (s *AnalysisService) PowerRankings(ctx context.Context, leagueID int) (_ []PowerRanking, err error) {
+	ctx, span := startSpan(ctx, "AnalysisService.PowerRankings", attribute.Int("league.id", leagueID))
+	defer func() { endSpan(span, err) }()
+
+	var gameKey, yahooLeagueID string
+	var currentWeek int
+	query := `SELECT yahoo_game_key, yahoo_league_id, current_week FROM fantasy_leagues WHERE id = ?`
+	if err := s.db.QueryRowContext(ctx, query, leagueID).Scan(&gameKey, &yahooLeagueID, &currentWeek); err != nil {
+		return nil, fmt.Errorf("failed to get league: %w", err)
+	}
+	leagueKey := fmt.Sprintf("%s.l.%s", gameKey, yahooLeagueID)
+
+	teamIDs, err := s.teamRepo.GetIDsByYahooKey(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %w", err)
+	}
+
+	var results []matchupResult
+	for week := 1; week <= currentWeek; week++ {
+		matchups, err := s.weekMatchups(ctx, leagueID, leagueKey, week)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch week %d matchups: %w", week, err)
+		}
+		results = append(results, matchupResults(week, matchups, teamIDs)...)
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	rankings := s.powerRankings(results)
+	week := rankings[0].Week
+
+	names, err := s.getTeamNames(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team names: %w", err)
+	}
+
+	previous, err := s.getPreviousRanks(ctx, leagueID, week)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous rankings: %w", err)
+	}
+
+	for i := range rankings {
+		rankings[i].LeagueID = leagueID
+		rankings[i].TeamName = names[rankings[i].TeamID]
+		rankings[i].PreviousRank = previous[rankings[i].TeamID]
+		rankings[i].Trend = trend(rankings[i].Rank, rankings[i].PreviousRank)
+	}
+
+	if err := s.savePowerRankings(ctx, rankings); err != nil {
+		return nil, fmt.Errorf("failed to save power rankings: %w", err)
+	}
+
+	return rankings, nil
+}
+
+// GetPowerRankingHistory returns the team's saved power rankings, one per
+// week, oldest first. Trends are not stored; each is recomputed from the
+// week before it.
+func (s *AnalysisService) GetPowerRankingHistory(ctx context.Context, leagueID, teamID int) (_ []PowerRanking, err error) {
+	ctx, span := startSpan(ctx, "AnalysisService.GetPowerRankingHistory",
+		attribute.Int("league.id", leagueID),
+		attribute.Int("team.id", teamID),
+	)
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT pr.week, pr.rank, pr.score, pr.season_score, pr.form_score,
+		       pr.schedule_score, ft.team_name
+		FROM power_rankings pr
+		JOIN fantasy_teams ft ON pr.team_id = ft.id
+		WHERE pr.league_id = ? AND pr.team_id = ?
+		ORDER BY pr.week
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get power rankings: %w", err)
+	}
+	defer rows.Close()
+
+	var history []PowerRanking
+	for rows.Next() {
+		r := PowerRanking{LeagueID: leagueID, TeamID: teamID}
+		err := rows.Scan(&r.Week, &r.Rank, &r.Score, &r.SeasonScore, &r.FormScore, &r.ScheduleScore, &r.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		if n := len(history); n > 0 {
+			r.PreviousRank = history[n-1].Rank
+		}
+		r.Trend = trend(r.Rank, r.PreviousRank)
+		history = append(history, r)
+	}
+
+	return history, rows.Err()
+}
+
+// weekMatchups returns the league's matchups for the week from Yahoo, or
+// from the stored scoreboard when the service has no Yahoo client.
+func (s *AnalysisService) weekMatchups(ctx context.Context, leagueID int, leagueKey string, week int) ([]yahoo.Matchup, error) {
+	if s.yahooClient == nil {
+		return s.matchupRepo.GetWeek(ctx, leagueID, week)
+	}
+	return s.yahooClient.GetLeagueMatchups(ctx, leagueKey, week)
+}
+
+// matchupResults returns both teams' results in each of the week's
+// completed matchups. Matchups with teams missing from teamIDs are skipped.
+func matchupResults(week int, matchups []yahoo.Matchup, teamIDs map[string]int) []matchupResult {
+	var results []matchupResult
+	for _, m := range matchups {
+		if m.Status != "postevent" || len(m.Teams) != 2 {
+			continue
+		}
+		a, okA := teamIDs[m.Teams[0].TeamKey]
+		b, okB := teamIDs[m.Teams[1].TeamKey]
+		if !okA || !okB {
+			continue
+		}
+
+		resultA := 0.5
+		switch {
+		case m.IsTied:
+		case m.WinnerTeamKey == m.Teams[0].TeamKey:
+			resultA = 1
+		case m.WinnerTeamKey == m.Teams[1].TeamKey:
+			resultA = 0
+		default:
+			continue
+		}
+
+		results = append(results,
+			matchupResult{Week: week, TeamID: a, OpponentID: b, Result: resultA},
+			matchupResult{Week: week, TeamID: b, OpponentID: a, Result: 1 - resultA},
+		)
+	}
+	return results
+}
+
+// powerRankings scores and ranks every team with results, as of the latest
+// week among them.
+func (s *AnalysisService) powerRankings(results []matchupResult) []PowerRanking {
+	byTeam := make(map[int][]matchupResult)
+	latest := 0
+	for _, r := range results {
+		byTeam[r.TeamID] = append(byTeam[r.TeamID], r)
+		if r.Week > latest {
+			latest = r.Week
+		}
+	}
+
+	season := make(map[int]float64, len(byTeam))
+	for teamID, games := range byTeam {
+		season[teamID] = winRate(games)
+	}
+
+	rankings := make([]PowerRanking, 0, len(byTeam))
+	for teamID, games := range byTeam {
+		sort.Slice(games, func(i, j int) bool { return games[i].Week < games[j].Week })
+		recent := games
+		if len(recent) > formWeeks {
+			recent = recent[len(recent)-formWeeks:]
+		}
+
+		schedule := 0.0
+		for _, g := range games {
+			schedule += season[g.OpponentID]
+		}
+		schedule /= float64(len(games))
+
+		r := PowerRanking{
+			Week:          latest,
+			TeamID:        teamID,
+			SeasonScore:   season[teamID],
+			FormScore:     winRate(recent),
+			ScheduleScore: schedule,
+		}
+		r.Score = 100 * (seasonWeight*r.SeasonScore + formWeight*r.FormScore + scheduleWeight*r.ScheduleScore)
+		rankings = append(rankings, r)
+	}
+
+	sort.Slice(rankings, func(i, j int) bool {
+		if rankings[i].Score != rankings[j].Score {
+			return rankings[i].Score > rankings[j].Score
+		}
+		if rankings[i].SeasonScore != rankings[j].SeasonScore {
+			return rankings[i].SeasonScore > rankings[j].SeasonScore
+		}
+		return rankings[i].TeamID < rankings[j].TeamID
+	})
+	for i := range rankings {
+		rankings[i].Rank = i + 1
+		rankings[i].Trend = TrendFlat
+	}
+	return rankings
+}
+
+func winRate(results []matchupResult) float64 {
+	if len(results) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, r := range results {
+		sum += r.Result
+	}
+	return sum / float64(len(results))
+}
+
+// trend compares a rank with the previous one; a lower rank is better.
+func trend(rank, previous int) string {
+	switch {
+	case previous == 0 || rank == previous:
+		return TrendFlat
+	case rank < previous:
+		return TrendUp
+	default:
+		return TrendDown
+	}
+}
+
+func (s *AnalysisService) getTeamNames(ctx context.Context, leagueID int) (map[int]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT id, team_name FROM fantasy_teams WHERE league_id = ?`, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[int]string)
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		names[id] = name
+	}
+
+	return names, rows.Err()
+}
+
+// getPreviousRanks returns each team's rank in the latest ranking saved for
+// a week before week.
+func (s *AnalysisService) getPreviousRanks(ctx context.Context, leagueID, week int) (map[int]int, error) {
+	query := `
+		SELECT team_id, rank
+		FROM power_rankings
+		WHERE league_id = ? AND week = (
+			SELECT MAX(week) FROM power_rankings WHERE league_id = ? AND week < ?
+		)
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID, leagueID, week)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ranks := make(map[int]int)
+	for rows.Next() {
+		var teamID, rank int
+		if err := rows.Scan(&teamID, &rank); err != nil {
+			return nil, err
+		}
+		ranks[teamID] = rank
+	}
+
+	return ranks, rows.Err()
+}
+
+func (s *AnalysisService) savePowerRankings(ctx context.Context, rankings []PowerRanking) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := dialect.For(s.db).Upsert("power_rankings",
+		[]string{"league_id", "week", "team_id", "rank", "score", "season_score", "form_score", "schedule_score"},
+		[]string{"league_id", "week", "team_id"},
+		[]string{"rank", "score", "season_score", "form_score", "schedule_score"},
+	)
+
+	for _, r := range rankings {
+		_, err := tx.ExecContext(ctx, query,
+			r.LeagueID, r.Week, r.TeamID, r.Rank, r.Score,
+			r.SeasonScore, r.FormScore, r.ScheduleScore,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
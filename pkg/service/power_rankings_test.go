@@ -0,0 +1,94 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+)
+
+func TestMatchupResults(t *testing.T) {
+	teamIDs := map[string]int{"t.1": 1, "t.2": 2, "t.3": 3, "t.4": 4}
+	matchups := []yahoo.Matchup{
+		{Status: "postevent", WinnerTeamKey: "t.2", Teams: []yahoo.MatchupTeam{{TeamKey: "t.1"}, {TeamKey: "t.2"}}},
+		{Status: "postevent", IsTied: true, Teams: []yahoo.MatchupTeam{{TeamKey: "t.3"}, {TeamKey: "t.4"}}},
+		{Status: "midevent", Teams: []yahoo.MatchupTeam{{TeamKey: "t.1"}, {TeamKey: "t.3"}}},
+	}
+
+	results := matchupResults(5, matchups, teamIDs)
+	if len(results) != 4 {
+		t.Fatalf("Expected results for the two completed matchups, got %v", results)
+	}
+
+	expected := map[int]float64{1: 0, 2: 1, 3: 0.5, 4: 0.5}
+	for _, r := range results {
+		if r.Week != 5 || r.Result != expected[r.TeamID] {
+			t.Errorf("Unexpected result %+v", r)
+		}
+	}
+}
+
+func TestPowerRankings(t *testing.T) {
+	service := &AnalysisService{}
+
+	var results []matchupResult
+	play := func(week, winner, loser int) {
+		results = append(results,
+			matchupResult{Week: week, TeamID: winner, OpponentID: loser, Result: 1},
+			matchupResult{Week: week, TeamID: loser, OpponentID: winner, Result: 0},
+		)
+	}
+	// Team 1 started hot but has lost three straight; team 2 has won
+	// three straight after a slow start.
+	play(1, 1, 3)
+	play(1, 2, 4)
+	play(2, 1, 4)
+	play(2, 3, 2)
+	play(3, 3, 1)
+	play(3, 2, 4)
+	play(4, 2, 1)
+	play(4, 4, 3)
+	play(5, 4, 1)
+	play(5, 2, 3)
+
+	rankings := service.powerRankings(results)
+	if len(rankings) != 4 {
+		t.Fatalf("Expected 4 teams, got %d", len(rankings))
+	}
+
+	if rankings[0].TeamID != 2 || rankings[0].Rank != 1 {
+		t.Errorf("Expected team 2 first, got %+v", rankings[0])
+	}
+	for _, r := range rankings {
+		if r.Week != 5 {
+			t.Errorf("Expected rankings as of week 5, got %d", r.Week)
+		}
+		if r.TeamID == 1 && r.FormScore != 0 {
+			t.Errorf("Expected team 1 to have no recent wins, got %.2f", r.FormScore)
+		}
+	}
+
+	r := rankings[0]
+	expected := 100 * (seasonWeight*r.SeasonScore + formWeight*r.FormScore + scheduleWeight*r.ScheduleScore)
+	if math.Abs(r.Score-expected) > 1e-9 {
+		t.Errorf("Score = %.2f, want %.2f", r.Score, expected)
+	}
+}
+
+func TestTrend(t *testing.T) {
+	tests := []struct {
+		rank, previous int
+		expected       string
+	}{
+		{1, 3, TrendUp},
+		{4, 2, TrendDown},
+		{2, 2, TrendFlat},
+		{2, 0, TrendFlat},
+	}
+
+	for _, tt := range tests {
+		if result := trend(tt.rank, tt.previous); result != tt.expected {
+			t.Errorf("trend(%d, %d) = %s, want %s", tt.rank, tt.previous, result, tt.expected)
+		}
+	}
+}
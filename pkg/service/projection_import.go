@@ -0,0 +1,554 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// minNameSimilarity is how alike, from 0 to 1, an imported player name must
+// be to a Yahoo player's name to match it when no name matches exactly.
+const minNameSimilarity = 0.85
+
+// ProjectionImportService imports third-party player projections, such as
+// FantasyPros or Hashtag Basketball exports, into a league's valuations.
+type ProjectionImportService struct {
+	db        *sql.DB
+	valuation Valuator
+}
+
+// ProjectionImportOption configures one import.
+type ProjectionImportOption func(*projectionImportConfig)
+
+type projectionImportConfig struct {
+	source       string
+	seasonTotals bool
+}
+
+// WithProjectionSource names where the projections came from, such as
+// "fantasypros". It defaults to "import".
+func WithProjectionSource(source string) ProjectionImportOption {
+	return func(c *projectionImportConfig) {
+		c.source = source
+	}
+}
+
+// WithSeasonTotals reads counting stats as season totals rather than per
+// game averages. They are divided by each player's projected games, which
+// the file must then include.
+func WithSeasonTotals() ProjectionImportOption {
+	return func(c *projectionImportConfig) {
+		c.seasonTotals = true
+	}
+}
+
+// ProjectionImport summarizes an import.
+type ProjectionImport struct {
+	LeagueID int
+	Source   string
+	// Rows is how many projections the file had and Matched how many of
+	// them were matched to a Yahoo player.
+	Rows    int
+	Matched int
+	// Unmatched lists the names of players that matched no Yahoo player.
+	Unmatched []string
+}
+
+// ImportedProjection is one player's third-party projection. Projections
+// are per game.
+type ImportedProjection struct {
+	Name        string
+	Team        string
+	Games       float64
+	Projections CategoryProjections
+}
+
+func NewProjectionImportService(db *sql.DB, valuation Valuator) ProjectionImporter {
+	return &ProjectionImportService{
+		db:        db,
+		valuation: valuation,
+	}
+}
+
+// ImportCSV imports projections from a CSV file with a header row. Columns
+// are found by name, in any order and case: the player's name (Name or
+// Player), their NBA team (Team), projected games (GP or G) and any of the
+// nine categories (PTS, REB, AST, STL, BLK, TO, FG%, FT% and 3PM, with
+// common variants such as TOV or 3PTM). Percentages may be fractions or
+// out of 100.
+//
+// See ImportProjections for what happens to the rows.
+func (s *ProjectionImportService) ImportCSV(ctx context.Context, leagueID int, r io.Reader, opts ...ProjectionImportOption) (*ProjectionImport, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read projections CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("projections CSV is empty")
+	}
+
+	header := records[0]
+	var rows []map[string]string
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return s.importRows(ctx, leagueID, rows, opts)
+}
+
+// ImportJSON imports projections from a JSON array of objects, one per
+// player, with the same fields ImportCSV reads columns for. Values may be
+// numbers or strings.
+func (s *ProjectionImportService) ImportJSON(ctx context.Context, leagueID int, r io.Reader, opts ...ProjectionImportOption) (*ProjectionImport, error) {
+	var objects []map[string]interface{}
+	if err := json.NewDecoder(r).Decode(&objects); err != nil {
+		return nil, fmt.Errorf("failed to decode projections JSON: %w", err)
+	}
+
+	rows := make([]map[string]string, 0, len(objects))
+	for _, object := range objects {
+		row := make(map[string]string, len(object))
+		for field, value := range object {
+			switch v := value.(type) {
+			case string:
+				row[field] = v
+			case float64:
+				row[field] = strconv.FormatFloat(v, 'f', -1, 64)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return s.importRows(ctx, leagueID, rows, opts)
+}
+
+// importRows parses the rows and imports them as ImportProjections does.
+func (s *ProjectionImportService) importRows(ctx context.Context, leagueID int, rows []map[string]string, opts []ProjectionImportOption) (*ProjectionImport, error) {
+	config := projectionImportConfig{source: "import"}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	projections, err := parseProjections(rows, config.seasonTotals)
+	if err != nil {
+		return nil, err
+	}
+	return s.ImportProjections(ctx, leagueID, config.source, projections)
+}
+
+// ImportProjections matches each projection to a Yahoo player by name,
+// using the team to break ties, and replaces the league's imported
+// projections with the matched ones. The league's player values are then
+// recalculated, so player_projections reflects the imports; players without
+// an imported projection keep being valued from their season stats.
+// Imported projections are kept in the imported_projections table:
+//
+//	CREATE TABLE imported_projections (
+//		league_id   INTEGER NOT NULL REFERENCES fantasy_leagues(id),
+//		player_id   INTEGER NOT NULL REFERENCES players(id),
+//		source      TEXT NOT NULL,
+//		games       REAL,
+//		proj_pts    REAL, proj_reb REAL, proj_ast REAL, proj_stl REAL,
+//		proj_blk    REAL, proj_to REAL, proj_fg_pct REAL, proj_ft_pct REAL,
+//		proj_3pm    REAL,
+//		imported_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+//		PRIMARY KEY (league_id, player_id)
+//	);
+//
+// Team matching reads the players table's team abbreviation:
+//
+//	ALTER TABLE players ADD COLUMN editorial_team_abbr TEXT;
+func (s *ProjectionImportService) ImportProjections(ctx context.Context, leagueID int, source string, projections []ImportedProjection) (_ *ProjectionImport, err error) {
+	ctx, span := startSpan(ctx, "ProjectionImportService.ImportProjections",
+		attribute.Int("league.id", leagueID),
+		attribute.String("source", source),
+		attribute.Int("rows", len(projections)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	players, err := s.getMatchablePlayers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get players: %w", err)
+	}
+
+	matched, unmatched := matchProjections(projections, players)
+	result := &ProjectionImport{
+		LeagueID:  leagueID,
+		Source:    source,
+		Rows:      len(projections),
+		Matched:   len(matched),
+		Unmatched: unmatched,
+	}
+	if len(matched) == 0 {
+		return result, nil
+	}
+
+	if err := s.saveImportedProjections(ctx, leagueID, source, matched); err != nil {
+		return nil, fmt.Errorf("failed to save projections: %w", err)
+	}
+
+	if err := s.valuation.CalculateAllPlayerValues(ctx, leagueID); err != nil {
+		return nil, fmt.Errorf("failed to recalculate player values: %w", err)
+	}
+
+	return result, nil
+}
+
+// projectionColumns maps each field of a projection to the column names
+// it is read from, in lower case.
+var projectionColumns = map[string][]string{
+	"name":  {"name", "player", "player name", "player_name", "full_name"},
+	"team":  {"team", "tm", "nba team"},
+	"games": {"gp", "g", "games"},
+	"PTS":   {"pts", "points"},
+	"REB":   {"reb", "trb", "rebounds"},
+	"AST":   {"ast", "assists"},
+	"STL":   {"stl", "steals"},
+	"BLK":   {"blk", "blocks"},
+	"TO":    {"to", "tov", "turnovers"},
+	"FG%":   {"fg%", "fg_pct", "fgpct", "fg pct"},
+	"FT%":   {"ft%", "ft_pct", "ftpct", "ft pct"},
+	"3PM":   {"3pm", "3ptm", "3pt", "tpm", "threes"},
+}
+
+// parseProjections reads projections from rows keyed by column name.
+// Rows without a name are skipped.
+func parseProjections(rows []map[string]string, seasonTotals bool) ([]ImportedProjection, error) {
+	var projections []ImportedProjection
+	for i, row := range rows {
+		fields := make(map[string]string)
+		for column, value := range row {
+			column = strings.ToLower(strings.TrimSpace(column))
+			for field, names := range projectionColumns {
+				if contains(names, column) {
+					fields[field] = strings.TrimSpace(value)
+				}
+			}
+		}
+		if fields["name"] == "" {
+			continue
+		}
+
+		p := ImportedProjection{Name: fields["name"], Team: fields["team"]}
+		values := make(map[string]float64)
+		for field, value := range fields {
+			if field == "name" || field == "team" || value == "" {
+				continue
+			}
+			v, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid %s %q", i+1, field, value)
+			}
+			values[field] = v
+		}
+		p.Games = values["games"]
+
+		if seasonTotals {
+			if p.Games <= 0 {
+				return nil, fmt.Errorf("row %d: season totals need projected games for %s", i+1, p.Name)
+			}
+			for _, field := range []string{"PTS", "REB", "AST", "STL", "BLK", "TO", "3PM"} {
+				values[field] /= p.Games
+			}
+		}
+		for _, field := range []string{"FG%", "FT%"} {
+			if values[field] > 1 {
+				values[field] /= 100
+			}
+		}
+
+		p.Projections = CategoryProjections{
+			PTS:   values["PTS"],
+			REB:   values["REB"],
+			AST:   values["AST"],
+			STL:   values["STL"],
+			BLK:   values["BLK"],
+			TO:    values["TO"],
+			FGPct: values["FG%"],
+			FTPct: values["FT%"],
+			TPM:   values["3PM"],
+		}
+		projections = append(projections, p)
+	}
+	return projections, nil
+}
+
+// matchablePlayer is a Yahoo player that imported names are matched to.
+type matchablePlayer struct {
+	PlayerID int
+	Name     string
+	Team     string
+}
+
+// matchProjections matches projections to players by normalized name.
+// Names without an exact match go to the most similar name, if it is at
+// least minNameSimilarity alike. When several players match equally well,
+// the one on the projection's team wins; if none is, the projection is left
+// unmatched rather than guessed. It returns the matched projections by
+// player ID and the names of the unmatched ones.
+func matchProjections(projections []ImportedProjection, players []matchablePlayer) (map[int]ImportedProjection, []string) {
+	normalized := make([]string, len(players))
+	for i, p := range players {
+		normalized[i] = normalizePlayerName(p.Name)
+	}
+
+	matched := make(map[int]ImportedProjection)
+	var unmatched []string
+	for _, proj := range projections {
+		name := normalizePlayerName(proj.Name)
+
+		best := 0.0
+		var candidates []matchablePlayer
+		for i, p := range players {
+			similarity := nameSimilarity(name, normalized[i])
+			if similarity < minNameSimilarity || similarity < best {
+				continue
+			}
+			if similarity > best {
+				best, candidates = similarity, nil
+			}
+			candidates = append(candidates, p)
+		}
+
+		if len(candidates) > 1 {
+			var onTeam []matchablePlayer
+			for _, p := range candidates {
+				if proj.Team != "" && sameTeam(p.Team, proj.Team) {
+					onTeam = append(onTeam, p)
+				}
+			}
+			candidates = onTeam
+		}
+		if len(candidates) != 1 {
+			unmatched = append(unmatched, proj.Name)
+			continue
+		}
+		matched[candidates[0].PlayerID] = proj
+	}
+	return matched, unmatched
+}
+
+// nameDiacritics maps accented letters common in NBA names to plain ones.
+var nameDiacritics = strings.NewReplacer(
+	"á", "a", "à", "a", "ä", "a", "â", "a", "ā", "a",
+	"é", "e", "è", "e", "ë", "e", "ê", "e",
+	"í", "i", "ï", "i", "î", "i",
+	"ó", "o", "ö", "o", "ô", "o", "ō", "o",
+	"ú", "u", "ü", "u", "ū", "u",
+	"ć", "c", "č", "c", "ç", "c",
+	"ñ", "n", "ņ", "n", "ş", "s", "š", "s", "ž", "z", "ģ", "g", "ķ", "k",
+)
+
+// nameSuffixes are dropped from names, since sources disagree on them.
+var nameSuffixes = []string{"jr", "sr", "ii", "iii", "iv", "v"}
+
+// normalizePlayerName lower-cases a name and strips accents, punctuation
+// and generational suffixes, so "Jaren Jackson Jr." and "jaren jackson"
+// compare equal.
+func normalizePlayerName(name string) string {
+	name = nameDiacritics.Replace(strings.ToLower(name))
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r):
+			return r
+		case r == '-':
+			return ' '
+		default:
+			return -1
+		}
+	}, name)
+
+	words := strings.Fields(name)
+	for len(words) > 1 && contains(nameSuffixes, words[len(words)-1]) {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+// nameSimilarity returns 1 minus the edit distance between two names over
+// the longer one's length.
+func nameSimilarity(a, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 1
+	}
+
+	// Levenshtein distance, keeping one row of the table.
+	row := make([]int, len(rb)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current := row[j]
+			row[j] = minInt(minInt(row[j]+1, row[j-1]+1), prev+cost)
+			prev = current
+		}
+	}
+	return 1 - float64(row[len(rb)])/float64(longest)
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// teamAbbreviations maps abbreviations other sources use to Yahoo's.
+var teamAbbreviations = map[string]string{
+	"GSW":  "GS",
+	"NYK":  "NY",
+	"SAS":  "SA",
+	"NOP":  "NO",
+	"PHX":  "PHO",
+	"UTAH": "UTA",
+	"WSH":  "WAS",
+	"BRK":  "BKN",
+}
+
+// sameTeam reports whether two team abbreviations name the same team.
+func sameTeam(a, b string) bool {
+	canonical := func(team string) string {
+		team = strings.ToUpper(strings.TrimSpace(team))
+		if yahoo, ok := teamAbbreviations[team]; ok {
+			return yahoo
+		}
+		return team
+	}
+	return canonical(a) == canonical(b)
+}
+
+func (s *ProjectionImportService) getMatchablePlayers(ctx context.Context) ([]matchablePlayer, error) {
+	query := `SELECT id, full_name, COALESCE(editorial_team_abbr, '') FROM players WHERE is_active = 1`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []matchablePlayer
+	for rows.Next() {
+		var p matchablePlayer
+		if err := rows.Scan(&p.PlayerID, &p.Name, &p.Team); err != nil {
+			return nil, err
+		}
+		players = append(players, p)
+	}
+
+	return players, rows.Err()
+}
+
+func (s *ProjectionImportService) saveImportedProjections(ctx context.Context, leagueID int, source string, projections map[int]ImportedProjection) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM imported_projections WHERE league_id = ?`, leagueID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO imported_projections (
+			league_id, player_id, source, games, proj_pts, proj_reb, proj_ast,
+			proj_stl, proj_blk, proj_to, proj_fg_pct, proj_ft_pct, proj_3pm
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	for playerID, p := range projections {
+		_, err := tx.ExecContext(ctx, query,
+			leagueID, playerID, source, p.Games,
+			p.Projections.PTS, p.Projections.REB, p.Projections.AST,
+			p.Projections.STL, p.Projections.BLK, p.Projections.TO,
+			p.Projections.FGPct, p.Projections.FTPct, p.Projections.TPM,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// getImportedProjections returns the league's imported projections by
+// player ID.
+func (s *ValuationService) getImportedProjections(ctx context.Context, leagueID int) (map[int]CategoryProjections, error) {
+	query := `
+		SELECT player_id, proj_pts, proj_reb, proj_ast, proj_stl, proj_blk,
+		       proj_to, proj_fg_pct, proj_ft_pct, proj_3pm
+		FROM imported_projections
+		WHERE league_id = ?
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	imported := make(map[int]CategoryProjections)
+	for rows.Next() {
+		var playerID int
+		var p CategoryProjections
+		err := rows.Scan(&playerID, &p.PTS, &p.REB, &p.AST, &p.STL, &p.BLK, &p.TO, &p.FGPct, &p.FTPct, &p.TPM)
+		if err != nil {
+			return nil, err
+		}
+		imported[playerID] = p
+	}
+
+	return imported, rows.Err()
+}
+
+// applyImportedProjections replaces the season stats of players with an
+// imported projection.
+func applyImportedProjections(players []PlayerStats, imported map[int]CategoryProjections) {
+	for i := range players {
+		p, ok := imported[players[i].PlayerID]
+		if !ok {
+			continue
+		}
+		players[i].PointsPerGame = p.PTS
+		players[i].ReboundsPerGame = p.REB
+		players[i].AssistsPerGame = p.AST
+		players[i].StealsPerGame = p.STL
+		players[i].BlocksPerGame = p.BLK
+		players[i].TurnoversPerGame = p.TO
+		players[i].FGPercentage = p.FGPct
+		players[i].FTPercentage = p.FTPct
+		players[i].ThreePointersMade = p.TPM
+	}
+}
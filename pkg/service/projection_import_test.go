@@ -0,0 +1,110 @@
+package service
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseProjections(t *testing.T) {
+	rows := []map[string]string{
+		{"Player": "Nikola Jokić", "Team": "DEN", "GP": "70", "PTS": "1820", "REB": "840", "AST": "630", "FG%": "58.0", "FT%": ".82", "3PTM": "70", "TOV": "210"},
+		{"Player": "", "PTS": "100"},
+	}
+
+	projections, err := parseProjections(rows, true)
+	if err != nil {
+		t.Fatalf("parseProjections: %v", err)
+	}
+	if len(projections) != 1 {
+		t.Fatalf("Expected the row without a name to be skipped, got %d rows", len(projections))
+	}
+
+	p := projections[0]
+	if p.Name != "Nikola Jokić" || p.Team != "DEN" || p.Games != 70 {
+		t.Errorf("Unexpected player fields %+v", p)
+	}
+	if p.Projections.PTS != 26 || p.Projections.REB != 12 || p.Projections.TPM != 1 || p.Projections.TO != 3 {
+		t.Errorf("Expected per-game counting stats, got %+v", p.Projections)
+	}
+	if math.Abs(p.Projections.FGPct-0.58) > 1e-9 || p.Projections.FTPct != 0.82 {
+		t.Errorf("Expected percentages as fractions, got FG%% %.3f FT%% %.3f", p.Projections.FGPct, p.Projections.FTPct)
+	}
+
+	if _, err := parseProjections([]map[string]string{{"Name": "A", "PTS": "20"}}, true); err == nil {
+		t.Error("Expected an error for season totals without games")
+	}
+	if _, err := parseProjections([]map[string]string{{"Name": "A", "PTS": "lots"}}, false); err == nil {
+		t.Error("Expected an error for a non-numeric stat")
+	}
+}
+
+func TestNormalizePlayerName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Jaren Jackson Jr.", "jaren jackson"},
+		{"Luka Dončić", "luka doncic"},
+		{"Shai Gilgeous-Alexander", "shai gilgeous alexander"},
+		{"De'Aaron Fox", "deaaron fox"},
+		{"Gary Trent Jr", "gary trent"},
+	}
+
+	for _, tt := range tests {
+		if result := normalizePlayerName(tt.name); result != tt.expected {
+			t.Errorf("normalizePlayerName(%q) = %q, want %q", tt.name, result, tt.expected)
+		}
+	}
+}
+
+func TestMatchProjections(t *testing.T) {
+	players := []matchablePlayer{
+		{PlayerID: 1, Name: "Jaren Jackson Jr.", Team: "Mem"},
+		{PlayerID: 2, Name: "Marcus Morris Sr.", Team: "CLE"},
+		{PlayerID: 3, Name: "Markieff Morris", Team: "DAL"},
+		{PlayerID: 4, Name: "Jalen Williams", Team: "OKC"},
+		{PlayerID: 5, Name: "Jalen Williams", Team: "GS"},
+		{PlayerID: 6, Name: "Bogdan Bogdanović", Team: "ATL"},
+	}
+	projections := []ImportedProjection{
+		{Name: "Jaren Jackson", Team: "MEM"},
+		{Name: "Bogdan Bogdanovich", Team: "ATL"},
+		{Name: "Jalen Williams", Team: "GSW"},
+		{Name: "Jalen Williams"},
+		{Name: "Someone Else"},
+	}
+
+	matched, unmatched := matchProjections(projections, players)
+
+	if matched[1].Name != "Jaren Jackson" {
+		t.Error("Expected a match despite the suffix")
+	}
+	if matched[6].Name != "Bogdan Bogdanovich" {
+		t.Error("Expected a fuzzy match for a close spelling")
+	}
+	if p, ok := matched[5]; !ok || p.Team != "GSW" {
+		t.Error("Expected the team to pick between players with the same name")
+	}
+	if _, ok := matched[4]; ok {
+		t.Error("Expected no guess between same-named players without a team")
+	}
+	if len(unmatched) != 2 || unmatched[0] != "Jalen Williams" || unmatched[1] != "Someone Else" {
+		t.Errorf("Unexpected unmatched names %v", unmatched)
+	}
+}
+
+func TestApplyImportedProjections(t *testing.T) {
+	players := []PlayerStats{
+		{PlayerID: 1, PrimaryPosition: "C", PointsPerGame: 10},
+		{PlayerID: 2, PrimaryPosition: "PG", PointsPerGame: 15},
+	}
+
+	applyImportedProjections(players, map[int]CategoryProjections{1: {PTS: 22, FGPct: 0.6}})
+
+	if players[0].PointsPerGame != 22 || players[0].FGPercentage != 0.6 || players[0].PrimaryPosition != "C" {
+		t.Errorf("Expected player 1's stats replaced, got %+v", players[0])
+	}
+	if players[1].PointsPerGame != 15 {
+		t.Errorf("Expected player 2's stats unchanged, got %+v", players[1])
+	}
+}
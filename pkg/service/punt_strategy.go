@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// PuntStrategy is a suggestion to give up on one category so the roster can
+// be built around the others.
+type PuntStrategy struct {
+	Category string
+	// CurrentZScore is the team's z-score in the category; punting makes
+	// sense for the categories the team is already losing.
+	CurrentZScore float64
+	// Improvement is how much the team's average z-score rises once the
+	// punted category no longer counts.
+	Improvement     float64
+	TargetPositions []string
+	BoostCategories []string
+	Guidance        string
+	// TradeTargets are players on other teams who are strong in the
+	// remaining categories but weak in the punted one, so they are worth
+	// more to a punting team than to the league. TradeAway are the team's own
+	// players whose value comes mostly from the punted category.
+	TradeTargets []PuntPlayer
+	TradeAway    []PuntPlayer
+}
+
+// PuntPlayer is a player affected by a punt strategy.
+type PuntPlayer struct {
+	PlayerID   int
+	PlayerName string
+	TeamID     int
+	Position   string
+	// PuntValue is the player's total z-score over the categories that
+	// still count; CategoryZScore is their z-score in the punted category.
+	PuntValue      float64
+	CategoryZScore float64
+}
+
+type puntProfile struct {
+	positions []string
+	boosts    []string
+	guidance  string
+}
+
+// puntProfiles describes how rosters punting each category are usually
+// built: which positions to target and which categories rise as a result.
+var puntProfiles = map[string]puntProfile{
+	"FT%": {[]string{"C", "PF"}, []string{"REB", "BLK", "FG%"}, "Load up on bigs who dominate the glass and protect the rim; poor free throw shooters come cheap."},
+	"AST": {[]string{"C", "PF", "SF"}, []string{"REB", "BLK", "FG%"}, "Skip high-priced point guards and stack frontcourt production."},
+	"3PM": {[]string{"C", "PF"}, []string{"REB", "BLK", "FG%"}, "Favor interior scorers; efficient bigs win FG% alongside the boards."},
+	"FG%": {[]string{"PG", "SG"}, []string{"3PM", "AST", "FT%", "STL"}, "Chase volume guards and shooters without worrying about efficiency."},
+	"TO":  {[]string{"PG", "SG"}, []string{"AST", "PTS", "3PM"}, "Target high-usage ball handlers; their turnovers no longer hurt."},
+	"PTS": {[]string{"C", "PF"}, []string{"REB", "BLK", "FG%", "TO"}, "Roster low-usage role players who fill the defensive categories."},
+	"REB": {[]string{"PG", "SG"}, []string{"AST", "STL", "3PM", "FT%"}, "Go small: guards and wings who shoot and create."},
+	"BLK": {[]string{"PG", "SG"}, []string{"AST", "STL", "3PM", "FT%"}, "Go small: rim protectors are expensive and help little elsewhere."},
+	"STL": {[]string{"C", "PF"}, []string{"REB", "BLK", "FG%"}, "Trade perimeter defenders for bigs."},
+}
+
+const (
+	maxPuntStrategies   = 3
+	maxPuntTradePlayers = 3
+)
+
+// SuggestPuntStrategies suggests up to three categories the team could punt,
+// worst category first. Only categories where the team is below the league
+// average are considered.
+func (s *AnalysisService) SuggestPuntStrategies(ctx context.Context, teamID int) (_ []PuntStrategy, err error) {
+	ctx, span := startSpan(ctx, "AnalysisService.SuggestPuntStrategies", attribute.Int("team.id", teamID))
+	defer func() { endSpan(span, err) }()
+
+	var leagueID int
+	if err := s.db.QueryRowContext(ctx, `SELECT league_id FROM fantasy_teams WHERE id = ?`, teamID).Scan(&leagueID); err != nil {
+		return nil, fmt.Errorf("failed to get league ID: %w", err)
+	}
+
+	teams, err := s.getLeagueTeams(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %w", err)
+	}
+
+	categories, err := s.getLeagueCategories(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league categories: %w", err)
+	}
+
+	var allTotals []teamTotals
+	var totals map[string]float64
+	for _, id := range teams {
+		t, err := s.calculateTeamCategoryTotals(ctx, id, categories)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate totals for team %d: %w", id, err)
+		}
+		if id == teamID {
+			totals = t
+		}
+		allTotals = append(allTotals, teamTotals{TeamID: id, Totals: t})
+	}
+
+	players, err := s.getLeaguePlayerProjections(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player projections: %w", err)
+	}
+
+	analysis := s.analyzeTeam(teamID, totals, allTotals, categories)
+	return s.suggestPuntStrategies(analysis, players), nil
+}
+
+type leaguePlayerProjection struct {
+	PlayerID    int
+	PlayerName  string
+	TeamID      int
+	Position    string
+	Projections CategoryProjections
+}
+
+func (s *AnalysisService) suggestPuntStrategies(analysis TeamAnalysis, players []leaguePlayerProjection) []PuntStrategy {
+	avg := func(skip string) float64 {
+		sum, n := 0.0, 0
+		for cat, z := range analysis.CategoryScores {
+			if cat != skip {
+				sum += z
+				n++
+			}
+		}
+		if n == 0 {
+			return 0
+		}
+		return sum / float64(n)
+	}
+	baseline := avg("")
+
+	playerZ := s.playerCategoryZScores(players)
+
+	var strategies []PuntStrategy
+	for cat, z := range analysis.CategoryScores {
+		profile, ok := puntProfiles[cat]
+		if !ok || z >= 0 {
+			continue
+		}
+		strategy := PuntStrategy{
+			Category:        cat,
+			CurrentZScore:   z,
+			Improvement:     avg(cat) - baseline,
+			TargetPositions: profile.positions,
+			BoostCategories: profile.boosts,
+			Guidance:        profile.guidance,
+		}
+
+		for i, p := range players {
+			pp := PuntPlayer{
+				PlayerID:       p.PlayerID,
+				PlayerName:     p.PlayerName,
+				TeamID:         p.TeamID,
+				Position:       p.Position,
+				CategoryZScore: playerZ[i][cat],
+			}
+			for c, pz := range playerZ[i] {
+				if _, scored := analysis.CategoryScores[c]; scored && c != cat {
+					pp.PuntValue += pz
+				}
+			}
+			switch {
+			case p.TeamID != analysis.TeamID && pp.CategoryZScore < 0:
+				strategy.TradeTargets = append(strategy.TradeTargets, pp)
+			case p.TeamID == analysis.TeamID && pp.CategoryZScore > 0:
+				strategy.TradeAway = append(strategy.TradeAway, pp)
+			}
+		}
+		sort.Slice(strategy.TradeTargets, func(i, j int) bool {
+			return strategy.TradeTargets[i].PuntValue > strategy.TradeTargets[j].PuntValue
+		})
+		sort.Slice(strategy.TradeAway, func(i, j int) bool {
+			return strategy.TradeAway[i].CategoryZScore > strategy.TradeAway[j].CategoryZScore
+		})
+		if len(strategy.TradeTargets) > maxPuntTradePlayers {
+			strategy.TradeTargets = strategy.TradeTargets[:maxPuntTradePlayers]
+		}
+		if len(strategy.TradeAway) > maxPuntTradePlayers {
+			strategy.TradeAway = strategy.TradeAway[:maxPuntTradePlayers]
+		}
+
+		strategies = append(strategies, strategy)
+	}
+
+	sort.Slice(strategies, func(i, j int) bool {
+		return strategies[i].CurrentZScore < strategies[j].CurrentZScore
+	})
+	if len(strategies) > maxPuntStrategies {
+		strategies = strategies[:maxPuntStrategies]
+	}
+	return strategies
+}
+
+// playerCategoryZScores returns each player's z-score per category against
+// the other players, with turnovers inverted so higher is always better.
+func (s *AnalysisService) playerCategoryZScores(players []leaguePlayerProjection) []map[string]float64 {
+	all := make(map[string][]float64)
+	for _, p := range players {
+		for cat, v := range p.Projections.byCategory() {
+			all[cat] = append(all[cat], v)
+		}
+	}
+
+	zScores := make([]map[string]float64, len(players))
+	for i, p := range players {
+		zScores[i] = make(map[string]float64)
+		for cat, v := range p.Projections.byCategory() {
+			z := s.calculateZScore(v, all[cat])
+			if cat == "TO" {
+				z = -z
+			}
+			zScores[i][cat] = z
+		}
+	}
+	return zScores
+}
+
+func (s *AnalysisService) getLeaguePlayerProjections(ctx context.Context, leagueID int) ([]leaguePlayerProjection, error) {
+	query := `
+		SELECT p.id, p.full_name, fr.team_id, COALESCE(pos.code, 'F') as position,
+		       pp.proj_pts, pp.proj_reb, pp.proj_ast, pp.proj_stl, pp.proj_blk,
+		       pp.proj_to, pp.proj_fg_pct, pp.proj_ft_pct, pp.proj_3pm
+		FROM fantasy_rosters fr
+		JOIN fantasy_teams ft ON fr.team_id = ft.id
+		JOIN players p ON fr.player_id = p.id
+		JOIN player_projections pp ON p.id = pp.player_id AND pp.league_id = ft.league_id
+		LEFT JOIN player_positions plp ON p.id = plp.player_id AND plp.is_primary = 1
+		LEFT JOIN positions pos ON plp.position_id = pos.id
+		WHERE ft.league_id = ?
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var players []leaguePlayerProjection
+	for rows.Next() {
+		var p leaguePlayerProjection
+		err := rows.Scan(
+			&p.PlayerID, &p.PlayerName, &p.TeamID, &p.Position,
+			&p.Projections.PTS, &p.Projections.REB, &p.Projections.AST,
+			&p.Projections.STL, &p.Projections.BLK, &p.Projections.TO,
+			&p.Projections.FGPct, &p.Projections.FTPct, &p.Projections.TPM,
+		)
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, p)
+	}
+
+	return players, rows.Err()
+}
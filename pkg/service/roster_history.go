@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+)
+
+// GetPlayerRosterHistory returns every add, drop and lineup move league
+// syncs have recorded for the player, newest first, so callers can tell who
+// dropped a player and when. Changes are dated by the sync that found them.
+func (s *LeagueService) GetPlayerRosterHistory(ctx context.Context, playerID int) ([]*repository.RosterChange, error) {
+	return s.historyRepo.GetByPlayer(ctx, playerID)
+}
+
+// diffRoster compares a team's stored roster with the one just fetched and
+// returns the changes between them: players only in after were added,
+// players only in before were dropped, and players whose selected position
+// differs were moved.
+func diffRoster(teamID int, before []*repository.RosterEntry, after []repository.RosterEntry, at time.Time) []repository.RosterChange {
+	previous := make(map[int]string, len(before))
+	for _, entry := range before {
+		previous[entry.PlayerID] = entry.SelectedPosition
+	}
+
+	var changes []repository.RosterChange
+	current := make(map[int]bool, len(after))
+	for _, entry := range after {
+		current[entry.PlayerID] = true
+		position, ok := previous[entry.PlayerID]
+		switch {
+		case !ok:
+			changes = append(changes, repository.RosterChange{
+				TeamID:     teamID,
+				PlayerID:   entry.PlayerID,
+				ChangeType: repository.RosterAdd,
+				ToPosition: entry.SelectedPosition,
+				ChangedAt:  at,
+			})
+		case position != entry.SelectedPosition:
+			changes = append(changes, repository.RosterChange{
+				TeamID:       teamID,
+				PlayerID:     entry.PlayerID,
+				ChangeType:   repository.RosterMove,
+				FromPosition: position,
+				ToPosition:   entry.SelectedPosition,
+				ChangedAt:    at,
+			})
+		}
+	}
+
+	for _, entry := range before {
+		if !current[entry.PlayerID] {
+			changes = append(changes, repository.RosterChange{
+				TeamID:       teamID,
+				PlayerID:     entry.PlayerID,
+				ChangeType:   repository.RosterDrop,
+				FromPosition: entry.SelectedPosition,
+				ChangedAt:    at,
+			})
+		}
+	}
+
+	return changes
+}
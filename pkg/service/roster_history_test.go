@@ -0,0 +1,38 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+)
+
+func TestDiffRoster(t *testing.T) {
+	at := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	before := []*repository.RosterEntry{
+		{PlayerID: 1, SelectedPosition: "PG"},
+		{PlayerID: 2, SelectedPosition: "BN"},
+		{PlayerID: 3, SelectedPosition: "C"},
+	}
+	after := []repository.RosterEntry{
+		{PlayerID: 1, SelectedPosition: "PG"},
+		{PlayerID: 2, SelectedPosition: "UTIL"},
+		{PlayerID: 4, SelectedPosition: "BN"},
+	}
+
+	got := diffRoster(7, before, after, at)
+	want := []repository.RosterChange{
+		{TeamID: 7, PlayerID: 2, ChangeType: repository.RosterMove, FromPosition: "BN", ToPosition: "UTIL", ChangedAt: at},
+		{TeamID: 7, PlayerID: 4, ChangeType: repository.RosterAdd, ToPosition: "BN", ChangedAt: at},
+		{TeamID: 7, PlayerID: 3, ChangeType: repository.RosterDrop, FromPosition: "C", ChangedAt: at},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diffRoster() = %+v, want %+v", got, want)
+	}
+
+	unchanged := []repository.RosterEntry{*before[0], *before[1], *before[2]}
+	if got := diffRoster(7, before, unchanged, at); len(got) != 0 {
+		t.Errorf("Expected no changes for an unchanged roster, got %+v", got)
+	}
+}
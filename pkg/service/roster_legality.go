@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+)
+
+// reserveSlots are roster positions for injured or inactive players. They
+// do not count toward the lineup, and players stashed in them stay put.
+var reserveSlots = []string{"IL", "IL+", "NA"}
+
+// rosterCheck validates rosters against a league's roster positions.
+type rosterCheck struct {
+	// slots has one entry per open roster spot, reserve slots excluded.
+	slots []string
+	// eligible holds the positions of every player on the two rosters.
+	eligible map[int][]string
+	// reserved holds the players currently in reserve slots.
+	reserved map[int]bool
+}
+
+// loadRosterCheck loads the league's roster positions and the eligibility
+// of both teams' players. It returns nil when the league's roster positions
+// are unknown, in which case every trade passes.
+func (s *TradeService) loadRosterCheck(ctx context.Context, leagueID, teamAID, teamBID int) (*rosterCheck, error) {
+	slots, err := s.leagueRepo.GetRosterPositions(ctx, leagueID)
+	if err != nil {
+		return nil, err
+	}
+	if len(slots) == 0 {
+		return nil, nil
+	}
+
+	check := newRosterCheck(slots)
+	for _, teamID := range []int{teamAID, teamBID} {
+		if err := s.loadEligibility(ctx, teamID, check); err != nil {
+			return nil, err
+		}
+	}
+	return check, nil
+}
+
+func newRosterCheck(slots []repository.RosterSlot) *rosterCheck {
+	check := &rosterCheck{
+		eligible: make(map[int][]string),
+		reserved: make(map[int]bool),
+	}
+	for _, slot := range slots {
+		if containsFold(reserveSlots, slot.Position) {
+			continue
+		}
+		for i := 0; i < slot.Count; i++ {
+			check.slots = append(check.slots, slot.Position)
+		}
+	}
+	return check
+}
+
+func (s *TradeService) loadEligibility(ctx context.Context, teamID int, check *rosterCheck) error {
+	query := `
+		SELECT fr.player_id, COALESCE(fr.selected_position, ''), COALESCE(pos.code, '')
+		FROM fantasy_rosters fr
+		LEFT JOIN player_positions plp ON fr.player_id = plp.player_id
+		LEFT JOIN positions pos ON plp.position_id = pos.id
+		WHERE fr.team_id = ?
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, teamID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var playerID int
+		var selected, position string
+		if err := rows.Scan(&playerID, &selected, &position); err != nil {
+			return err
+		}
+		if containsFold(reserveSlots, selected) {
+			check.reserved[playerID] = true
+		}
+		if position != "" && !contains(check.eligible[playerID], position) {
+			check.eligible[playerID] = append(check.eligible[playerID], position)
+		}
+	}
+
+	return rows.Err()
+}
+
+// tradeRosters is what the trade finders need to check the rosters of one
+// pair of teams.
+type tradeRosters struct {
+	check *rosterCheck
+	teamA []int
+	teamB []int
+}
+
+func (s *TradeService) loadTradeRosters(ctx context.Context, leagueID, teamAID, teamBID int) (tradeRosters, error) {
+	check, err := s.loadRosterCheck(ctx, leagueID, teamAID, teamBID)
+	if err != nil || check == nil {
+		return tradeRosters{}, err
+	}
+
+	teamA, err := s.getRosterPlayerIDs(ctx, teamAID)
+	if err != nil {
+		return tradeRosters{}, err
+	}
+
+	teamB, err := s.getRosterPlayerIDs(ctx, teamBID)
+	if err != nil {
+		return tradeRosters{}, err
+	}
+
+	return tradeRosters{check: check, teamA: teamA, teamB: teamB}, nil
+}
+
+// problem returns why the package leaves a roster illegal, or "".
+func (r tradeRosters) problem(pkg tradePackage) string {
+	return r.check.checkTrade(r.teamA, r.teamB, pkg)
+}
+
+// checkTrade returns why the trade leaves either roster illegal, or "" if
+// both rosters remain legal.
+func (c *rosterCheck) checkTrade(teamARoster, teamBRoster []int, pkg tradePackage) string {
+	if c == nil {
+		return ""
+	}
+	if problem := c.check(tradeRoster(teamARoster, pkg.teamAGives, pkg.teamBGives)); problem != "" {
+		return "Team A " + problem
+	}
+	if problem := c.check(tradeRoster(teamBRoster, pkg.teamBGives, pkg.teamAGives)); problem != "" {
+		return "Team B " + problem
+	}
+	return ""
+}
+
+// check assigns every active player on the roster to a slot they are
+// eligible for, returning "" if that is possible and a description of the
+// problem otherwise.
+func (c *rosterCheck) check(roster []int) string {
+	var players []int
+	for _, id := range roster {
+		if !c.reserved[id] {
+			players = append(players, id)
+		}
+	}
+	if len(players) > len(c.slots) {
+		return fmt.Sprintf("would have %d active players for %d roster spots", len(players), len(c.slots))
+	}
+
+	matcher := newSlotMatcher(c.slots)
+	for p := range players {
+		if !matcher.add(p, c.eligible[players[p]]) {
+			positions := strings.Join(c.eligible[players[p]], "/")
+			if positions == "" {
+				positions = "player"
+			}
+			return fmt.Sprintf("would have no roster spot for a %s", positions)
+		}
+	}
+	return ""
+}
+
+// slotAccepts reports whether a player eligible at positions may fill slot.
+// Util and bench slots take anyone; G and F take either guard or forward
+// position.
+func slotAccepts(slot string, positions []string) bool {
+	switch strings.ToUpper(slot) {
+	case "BN", "UTIL":
+		return true
+	case "G":
+		return containsFold(positions, "PG") || containsFold(positions, "SG") || containsFold(positions, "G")
+	case "F":
+		return containsFold(positions, "SF") || containsFold(positions, "PF") || containsFold(positions, "F")
+	default:
+		return containsFold(positions, slot)
+	}
+}
+
+// tradeRoster returns the roster's player IDs after trading away out and
+// receiving in.
+func tradeRoster(roster []int, out, in []RosterPlayer) []int {
+	outIDs := make(map[int]bool, len(out))
+	for _, p := range out {
+		outIDs[p.PlayerID] = true
+	}
+
+	result := make([]int, 0, len(roster)+len(in))
+	for _, id := range roster {
+		if !outIDs[id] {
+			result = append(result, id)
+		}
+	}
+	for _, p := range in {
+		result = append(result, p.PlayerID)
+	}
+	return result
+}
+
+func containsFold(slice []string, item string) bool {
+	for _, s := range slice {
+		if strings.EqualFold(s, item) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *TradeService) getRosterPlayerIDs(ctx context.Context, teamID int) ([]int, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT player_id FROM fantasy_rosters WHERE team_id = ?`, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
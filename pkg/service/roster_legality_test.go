@@ -0,0 +1,116 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+)
+
+func testRosterCheck() *rosterCheck {
+	check := newRosterCheck([]repository.RosterSlot{
+		{Position: "PG", Count: 1, IsStarting: true},
+		{Position: "C", Count: 1, IsStarting: true},
+		{Position: "UTIL", Count: 1, IsStarting: true},
+		{Position: "IL", Count: 1},
+	})
+	check.eligible = map[int][]string{
+		1: {"PG"},
+		2: {"C"},
+		3: {"SG", "SF"},
+		4: {"PF", "C"},
+		5: {"PG", "SG"},
+		6: {"C"},
+	}
+	return check
+}
+
+func TestSlotAccepts(t *testing.T) {
+	tests := []struct {
+		slot      string
+		positions []string
+		expected  bool
+	}{
+		{"PG", []string{"PG", "SG"}, true},
+		{"C", []string{"PG", "SG"}, false},
+		{"G", []string{"SG"}, true},
+		{"F", []string{"PG"}, false},
+		{"Util", []string{"C"}, true},
+		{"BN", nil, true},
+	}
+
+	for _, tt := range tests {
+		if result := slotAccepts(tt.slot, tt.positions); result != tt.expected {
+			t.Errorf("slotAccepts(%q, %v) = %v, want %v", tt.slot, tt.positions, result, tt.expected)
+		}
+	}
+}
+
+func TestRosterCheck(t *testing.T) {
+	check := testRosterCheck()
+
+	if len(check.slots) != 3 {
+		t.Fatalf("Expected the IL slot to be excluded, got slots %v", check.slots)
+	}
+
+	tests := []struct {
+		name    string
+		roster  []int
+		illegal bool
+	}{
+		{"Legal roster", []int{1, 2, 3}, false},
+		{"Too many players", []int{1, 2, 3, 4}, true},
+		{"No center", []int{1, 3, 5}, true},
+		{"Matching needed", []int{4, 2, 5}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := check.check(tt.roster)
+			if (problem != "") != tt.illegal {
+				t.Errorf("check(%v) = %q, want illegal %v", tt.roster, problem, tt.illegal)
+			}
+		})
+	}
+
+	check.reserved[4] = true
+	if problem := check.check([]int{1, 2, 3, 4}); problem != "" {
+		t.Errorf("Players in reserve slots should not need a spot, got %q", problem)
+	}
+}
+
+func TestCheckTrade(t *testing.T) {
+	check := testRosterCheck()
+	teamA := []int{1, 2, 3}
+	teamB := []int{5, 4, 6}
+
+	// Team A gives up its only center for a guard.
+	pkg := tradePackage{
+		teamAGives: []RosterPlayer{{PlayerID: 2}},
+		teamBGives: []RosterPlayer{{PlayerID: 5}},
+	}
+	if problem := check.checkTrade(teamA, teamB, pkg); problem == "" || problem[:6] != "Team A" {
+		t.Errorf("Expected team A to be illegal, got %q", problem)
+	}
+
+	// Two-for-one leaves team B over the roster size.
+	pkg = tradePackage{
+		teamAGives: []RosterPlayer{{PlayerID: 1}, {PlayerID: 3}},
+		teamBGives: []RosterPlayer{{PlayerID: 5}},
+	}
+	if problem := check.checkTrade(teamA, teamB, pkg); problem == "" || problem[:6] != "Team B" {
+		t.Errorf("Expected team B to be illegal, got %q", problem)
+	}
+
+	pkg = tradePackage{
+		teamAGives: []RosterPlayer{{PlayerID: 1}},
+		teamBGives: []RosterPlayer{{PlayerID: 5}},
+	}
+	if problem := check.checkTrade(teamA, teamB, pkg); problem != "" {
+		t.Errorf("Expected a legal trade, got %q", problem)
+	}
+
+	var unknown *rosterCheck
+	if problem := unknown.checkTrade(teamA, teamB, pkg); problem != "" {
+		t.Errorf("Unknown roster positions should allow every trade, got %q", problem)
+	}
+}
@@ -0,0 +1,376 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// defaultGamesPerWeek is used for players whose games in a week are unknown.
+const defaultGamesPerWeek = 3
+
+// percentageStdDev is the assumed weekly spread of FG% and FT% between two
+// teams when estimating who wins the category.
+const percentageStdDev = 0.015
+
+// ScheduleWeek is one remaining fantasy week of a head-to-head league.
+type ScheduleWeek struct {
+	Week int
+	// Opponents maps each fantasy team ID to its opponent's.
+	Opponents map[int]int
+	// Games maps player IDs to the games they play this week. Players that
+	// are missing are assumed to play defaultGamesPerWeek games.
+	Games map[int]int
+}
+
+// ScheduleImpact is how a trade changes one team's outlook over the
+// remaining schedule.
+type ScheduleImpact struct {
+	TeamID         int
+	WeeksSimulated int
+	// ExpectedCategoryWins is the number of category wins expected over the
+	// simulated weeks.
+	ExpectedCategoryWinsBefore float64
+	ExpectedCategoryWinsAfter  float64
+	// ExpectedMatchupWins sums the weekly matchup win probabilities.
+	ExpectedMatchupWinsBefore float64
+	ExpectedMatchupWinsAfter  float64
+	// WinProbabilityDelta is the change in the average weekly matchup win
+	// probability.
+	WinProbabilityDelta float64
+}
+
+// ScheduleSimulation is the result of simulating the remaining schedule
+// before and after a trade.
+type ScheduleSimulation struct {
+	TeamAImpact ScheduleImpact
+	TeamBImpact ScheduleImpact
+}
+
+// NewScheduleWeek builds a schedule week from Yahoo's matchups for it.
+// teamIDs maps Yahoo team keys to fantasy team IDs; matchups with teams
+// that are not in it are skipped.
+func NewScheduleWeek(week int, matchups []yahoo.Matchup, teamIDs map[string]int) ScheduleWeek {
+	sw := ScheduleWeek{Week: week, Opponents: make(map[int]int)}
+	for _, m := range matchups {
+		if len(m.Teams) != 2 {
+			continue
+		}
+		a, okA := teamIDs[m.Teams[0].TeamKey]
+		b, okB := teamIDs[m.Teams[1].TeamKey]
+		if !okA || !okB {
+			continue
+		}
+		sw.Opponents[a] = b
+		sw.Opponents[b] = a
+	}
+	return sw
+}
+
+// MatchupFetcher fetches a league's matchups for a week. *yahoo.Client
+// implements it.
+type MatchupFetcher interface {
+	GetLeagueMatchups(ctx context.Context, leagueKey string, week int) ([]yahoo.Matchup, error)
+}
+
+// WithMatchupFetcher makes LoadSchedule fetch matchups with fetcher rather
+// than read those stored by LeagueService.SyncMatchups.
+func WithMatchupFetcher(fetcher MatchupFetcher) EvaluationServiceOption {
+	return func(s *EvaluationService) {
+		s.matchups = fetcher
+	}
+}
+
+// LoadSchedule fetches the league's matchups for weeks fromWeek through
+// toWeek with the service's MatchupFetcher, or reads those stored by
+// LeagueService.SyncMatchups when it has none. Player games per week are
+// left unset.
+func (s *EvaluationService) LoadSchedule(ctx context.Context, leagueID int, leagueKey string, fromWeek, toWeek int) (_ []ScheduleWeek, err error) {
+	ctx, span := startSpan(ctx, "EvaluationService.LoadSchedule",
+		attribute.Int("league.id", leagueID),
+		attribute.String("yahoo.league_key", leagueKey),
+	)
+	defer func() { endSpan(span, err) }()
+
+	teamIDs, err := s.teamRepo.GetIDsByYahooKey(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %w", err)
+	}
+
+	var schedule []ScheduleWeek
+	for week := fromWeek; week <= toWeek; week++ {
+		var matchups []yahoo.Matchup
+		if s.matchups != nil {
+			matchups, err = s.matchups.GetLeagueMatchups(ctx, leagueKey, week)
+		} else {
+			matchups, err = s.matchupRepo.GetWeek(ctx, leagueID, week)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch week %d matchups: %w", week, err)
+		}
+		schedule = append(schedule, NewScheduleWeek(week, matchups, teamIDs))
+	}
+
+	return schedule, nil
+}
+
+// SimulateTradeSchedule plays out the remaining schedule for both teams
+// with and without the trade, using each player's per-game projections
+// times their games each week, and reports the expected wins in the
+// league's categories and matchups.
+func (s *EvaluationService) SimulateTradeSchedule(
+	ctx context.Context,
+	leagueID int,
+	teamAID int,
+	teamAGives []int,
+	teamBID int,
+	teamBGives []int,
+	schedule []ScheduleWeek,
+) (_ *ScheduleSimulation, err error) {
+	ctx, span := startSpan(ctx, "EvaluationService.SimulateTradeSchedule",
+		attribute.Int("league.id", leagueID),
+		attribute.Int("team_a.id", teamAID),
+		attribute.Int("team_b.id", teamBID),
+		attribute.Int("weeks", len(schedule)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	rosters := make(map[int][]PlayerProjection)
+	for _, week := range schedule {
+		for teamID := range week.Opponents {
+			if _, ok := rosters[teamID]; ok {
+				continue
+			}
+			roster, err := s.getStarterProjections(ctx, leagueID, teamID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get roster for team %d: %w", teamID, err)
+			}
+			rosters[teamID] = roster
+		}
+	}
+	for _, teamID := range []int{teamAID, teamBID} {
+		if _, ok := rosters[teamID]; !ok {
+			roster, err := s.getStarterProjections(ctx, leagueID, teamID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get roster for team %d: %w", teamID, err)
+			}
+			rosters[teamID] = roster
+		}
+	}
+
+	teamAProjections, err := s.GetPlayerProjections(ctx, leagueID, teamAGives)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team A projections: %w", err)
+	}
+
+	teamBProjections, err := s.GetPlayerProjections(ctx, leagueID, teamBGives)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team B projections: %w", err)
+	}
+
+	categories, err := leagueAnalysisCategories(ctx, s.leagueRepo, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league categories: %w", err)
+	}
+
+	after := make(map[int][]PlayerProjection, len(rosters))
+	for teamID, roster := range rosters {
+		after[teamID] = roster
+	}
+	after[teamAID] = swapPlayers(rosters[teamAID], teamAGives, teamBProjections)
+	after[teamBID] = swapPlayers(rosters[teamBID], teamBGives, teamAProjections)
+
+	return &ScheduleSimulation{
+		TeamAImpact: s.scheduleImpact(teamAID, rosters, after, schedule, categories),
+		TeamBImpact: s.scheduleImpact(teamBID, rosters, after, schedule, categories),
+	}, nil
+}
+
+func (s *EvaluationService) scheduleImpact(teamID int, before, after map[int][]PlayerProjection, schedule []ScheduleWeek, categories []analysisCategory) ScheduleImpact {
+	impact := ScheduleImpact{TeamID: teamID}
+	impact.ExpectedCategoryWinsBefore, impact.ExpectedMatchupWinsBefore, impact.WeeksSimulated = s.simulateSchedule(teamID, before, schedule, categories)
+	impact.ExpectedCategoryWinsAfter, impact.ExpectedMatchupWinsAfter, _ = s.simulateSchedule(teamID, after, schedule, categories)
+	if impact.WeeksSimulated > 0 {
+		impact.WinProbabilityDelta = (impact.ExpectedMatchupWinsAfter - impact.ExpectedMatchupWinsBefore) / float64(impact.WeeksSimulated)
+	}
+	return impact
+}
+
+// simulateSchedule returns the team's expected category wins and matchup
+// wins in the given categories over the weeks in which it has an opponent.
+func (s *EvaluationService) simulateSchedule(teamID int, rosters map[int][]PlayerProjection, schedule []ScheduleWeek, categories []analysisCategory) (categoryWins, matchupWins float64, weeks int) {
+	for _, week := range schedule {
+		opponentID, ok := week.Opponents[teamID]
+		if !ok {
+			continue
+		}
+		mine := weeklyTotals(rosters[teamID], week.Games)
+		theirs := weeklyTotals(rosters[opponentID], week.Games)
+
+		probabilities := categoryWinProbabilities(mine, theirs, categories)
+		for _, p := range probabilities {
+			categoryWins += p
+		}
+		matchupWins += matchupWinProbability(probabilities)
+		weeks++
+	}
+	return categoryWins, matchupWins, weeks
+}
+
+// weeklyTotals projects a roster's totals for one week. Counting stats are
+// per-game projections times games played; percentages are averaged over
+// the players with games, as GetTeamCategoryTotals does.
+func weeklyTotals(players []PlayerProjection, games map[int]int) TeamCategoryTotals {
+	var totals TeamCategoryTotals
+	var pctPlayers int
+	for _, p := range players {
+		g, ok := games[p.PlayerID]
+		if !ok {
+			g = defaultGamesPerWeek
+		}
+		if g <= 0 {
+			continue
+		}
+		n := float64(g)
+		totals.PTS += p.PTS * n
+		totals.REB += p.REB * n
+		totals.AST += p.AST * n
+		totals.STL += p.STL * n
+		totals.BLK += p.BLK * n
+		totals.TO += p.TO * n
+		totals.TPM += p.TPM * n
+		totals.FGPct += p.FGPct
+		totals.FTPct += p.FTPct
+		pctPlayers++
+	}
+	if pctPlayers > 0 {
+		totals.FGPct /= float64(pctPlayers)
+		totals.FTPct /= float64(pctPlayers)
+	}
+	return totals
+}
+
+// categoryWinProbabilities estimates the chance of winning each of the
+// categories with a normal approximation. Counting stats are treated as
+// Poisson, so their spread is the square root of the combined totals.
+// Categories the totals do not cover are left out.
+func categoryWinProbabilities(mine, theirs TeamCategoryTotals, categories []analysisCategory) map[string]float64 {
+	mineByCategory, theirsByCategory := mine.byCategory(), theirs.byCategory()
+
+	probabilities := make(map[string]float64, len(categories))
+	for _, cat := range categories {
+		a, ok := mineByCategory[cat.Name]
+		if !ok {
+			continue
+		}
+		b := theirsByCategory[cat.Name]
+		if cat.lowerIsBetter {
+			a, b = b, a
+		}
+
+		if cat.average {
+			probabilities[cat.Name] = normalCDF((a - b) / percentageStdDev)
+			continue
+		}
+		sd := math.Sqrt(a + b)
+		if sd < 1 {
+			sd = 1
+		}
+		probabilities[cat.Name] = normalCDF((a - b) / sd)
+	}
+	return probabilities
+}
+
+// matchupWinProbability returns the chance of winning more than half of the
+// categories, counting a split as half a win.
+func matchupWinProbability(probabilities map[string]float64) float64 {
+	// dist[k] is the probability of winning exactly k categories.
+	dist := []float64{1}
+	for _, p := range probabilities {
+		next := make([]float64, len(dist)+1)
+		for k, q := range dist {
+			next[k] += q * (1 - p)
+			next[k+1] += q * p
+		}
+		dist = next
+	}
+
+	n := len(probabilities)
+	win := 0.0
+	for k, q := range dist {
+		switch {
+		case 2*k > n:
+			win += q
+		case 2*k == n:
+			win += q / 2
+		}
+	}
+	return win
+}
+
+func normalCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
+
+// swapPlayers removes the players with the given IDs from the roster and
+// adds the incoming ones.
+func swapPlayers(roster []PlayerProjection, outIDs []int, in []PlayerProjection) []PlayerProjection {
+	result := make([]PlayerProjection, 0, len(roster)+len(in))
+	for _, p := range roster {
+		if !containsInt(outIDs, p.PlayerID) {
+			result = append(result, p)
+		}
+	}
+	return append(result, in...)
+}
+
+func containsInt(slice []int, item int) bool {
+	for _, v := range slice {
+		if v == item {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *EvaluationService) getStarterProjections(ctx context.Context, leagueID int, teamID int) ([]PlayerProjection, error) {
+	starters, err := s.projectionRepo.GetStarters(ctx, leagueID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	return playerProjections(starters), nil
+}
+
+func (s *EvaluationService) getRosterProjections(ctx context.Context, leagueID int, teamID int) ([]PlayerProjection, error) {
+	roster, err := s.projectionRepo.GetRoster(ctx, leagueID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	return playerProjections(roster), nil
+}
+
+// playerProjections converts projections read from a ProjectionRepository.
+func playerProjections(projections []repository.Projection) []PlayerProjection {
+	result := make([]PlayerProjection, len(projections))
+	for i, p := range projections {
+		result[i] = PlayerProjection{
+			PlayerID: p.PlayerID,
+			FPG:      p.FPG,
+			PTS:      p.PTS,
+			REB:      p.REB,
+			AST:      p.AST,
+			STL:      p.STL,
+			BLK:      p.BLK,
+			TO:       p.TO,
+			FGPct:    p.FGPct,
+			FTPct:    p.FTPct,
+			TPM:      p.TPM,
+			Position: p.Position,
+		}
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+)
+
+func TestNewScheduleWeek(t *testing.T) {
+	matchups := []yahoo.Matchup{
+		{Teams: []yahoo.MatchupTeam{{TeamKey: "nba.l.1.t.1"}, {TeamKey: "nba.l.1.t.2"}}},
+		{Teams: []yahoo.MatchupTeam{{TeamKey: "nba.l.1.t.3"}, {TeamKey: "nba.l.1.t.9"}}},
+	}
+	teamIDs := map[string]int{"nba.l.1.t.1": 10, "nba.l.1.t.2": 20, "nba.l.1.t.3": 30}
+
+	week := NewScheduleWeek(5, matchups, teamIDs)
+
+	if week.Week != 5 {
+		t.Errorf("Week = %d, want 5", week.Week)
+	}
+	if week.Opponents[10] != 20 || week.Opponents[20] != 10 {
+		t.Errorf("Opponents = %v", week.Opponents)
+	}
+	if _, ok := week.Opponents[30]; ok {
+		t.Error("Matchup with an unknown team should be skipped")
+	}
+}
+
+func TestWeeklyTotals(t *testing.T) {
+	players := []PlayerProjection{
+		{PlayerID: 1, PTS: 20.0, FGPct: 0.50},
+		{PlayerID: 2, PTS: 10.0, FGPct: 0.40},
+		{PlayerID: 3, PTS: 15.0, FGPct: 0.30},
+	}
+	games := map[int]int{1: 4, 3: 0}
+
+	totals := weeklyTotals(players, games)
+
+	expectedPTS := 20.0*4 + 10.0*defaultGamesPerWeek
+	if math.Abs(totals.PTS-expectedPTS) > 0.01 {
+		t.Errorf("PTS = %.2f, want %.2f", totals.PTS, expectedPTS)
+	}
+	if math.Abs(totals.FGPct-0.45) > 0.001 {
+		t.Errorf("FG%% should only average players with games, got %.3f", totals.FGPct)
+	}
+}
+
+func TestCategoryWinProbabilities(t *testing.T) {
+	even := TeamCategoryTotals{PTS: 400, REB: 150, AST: 90, STL: 25, BLK: 15, TO: 50, TPM: 40, FGPct: 0.47, FTPct: 0.78}
+	for cat, p := range categoryWinProbabilities(even, even, defaultAnalysisCategories()) {
+		if math.Abs(p-0.5) > 0.001 {
+			t.Errorf("%s: evenly matched teams should be a coin flip, got %.3f", cat, p)
+		}
+	}
+
+	better := even
+	better.REB = 200
+	better.TO = 30
+	probs := categoryWinProbabilities(better, even, defaultAnalysisCategories())
+	if probs["REB"] < 0.9 {
+		t.Errorf("REB win probability = %.3f, want > 0.9", probs["REB"])
+	}
+	if probs["TO"] < 0.9 {
+		t.Errorf("Fewer turnovers should win TO, got %.3f", probs["TO"])
+	}
+}
+
+func TestCategoryWinProbabilitiesLeagueCategories(t *testing.T) {
+	stored := []repository.LeagueCategory{
+		{Name: "PTS"}, {Name: "REB"}, {Name: "AST"}, {Name: "STL"},
+		{Name: "BLK"}, {Name: "FG%"}, {Name: "FT%"}, {Name: "3PM"},
+	}
+
+	even := TeamCategoryTotals{PTS: 400, REB: 150, AST: 90, STL: 25, BLK: 15, TO: 50, TPM: 40, FGPct: 0.47, FTPct: 0.78}
+	probs := categoryWinProbabilities(even, even, analysisCategoriesFor(stored))
+	if len(probs) != 8 {
+		t.Errorf("Expected 8 categories, got %d", len(probs))
+	}
+	if _, ok := probs["TO"]; ok {
+		t.Error("An 8-category league should not be projected on TO")
+	}
+}
+
+func TestMatchupWinProbability(t *testing.T) {
+	tests := []struct {
+		name          string
+		probabilities map[string]float64
+		expected      float64
+	}{
+		{"Certain sweep", map[string]float64{"A": 1, "B": 1, "C": 1}, 1},
+		{"Certain loss", map[string]float64{"A": 0, "B": 0, "C": 0}, 0},
+		{"Coin flips", map[string]float64{"A": 0.5, "B": 0.5, "C": 0.5}, 0.5},
+		{"Split counts as half", map[string]float64{"A": 1, "B": 0}, 0.5},
+		{"Two of three certain", map[string]float64{"A": 1, "B": 1, "C": 0}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := matchupWinProbability(tt.probabilities)
+			if math.Abs(result-tt.expected) > 0.001 {
+				t.Errorf("got %.3f, want %.3f", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScheduleImpact(t *testing.T) {
+	service := &EvaluationService{}
+
+	guard := PlayerProjection{PlayerID: 1, PTS: 20, REB: 4, AST: 8, STL: 1.5, BLK: 0.3, TO: 3, TPM: 2.5, FGPct: 0.45, FTPct: 0.85}
+	big := PlayerProjection{PlayerID: 2, PTS: 18, REB: 12, AST: 2, STL: 0.8, BLK: 2.0, TO: 2, TPM: 0.5, FGPct: 0.58, FTPct: 0.70}
+	wing := PlayerProjection{PlayerID: 3, PTS: 15, REB: 6, AST: 3, STL: 1.0, BLK: 0.6, TO: 1.5, TPM: 2.0, FGPct: 0.47, FTPct: 0.80}
+
+	before := map[int][]PlayerProjection{
+		1: {guard, wing},
+		2: {big, wing},
+		3: {wing, wing},
+	}
+	after := map[int][]PlayerProjection{
+		1: swapPlayers(before[1], []int{1}, []PlayerProjection{big}),
+		2: swapPlayers(before[2], []int{2}, []PlayerProjection{guard}),
+		3: before[3],
+	}
+	schedule := []ScheduleWeek{
+		{Week: 10, Opponents: map[int]int{1: 3, 3: 1}},
+		{Week: 11, Opponents: map[int]int{1: 2, 2: 1}},
+		{Week: 12, Opponents: map[int]int{2: 3, 3: 2}},
+	}
+
+	impact := service.scheduleImpact(1, before, after, schedule, defaultAnalysisCategories())
+
+	if impact.WeeksSimulated != 2 {
+		t.Errorf("WeeksSimulated = %d, want 2", impact.WeeksSimulated)
+	}
+	if impact.ExpectedCategoryWinsBefore <= 0 || impact.ExpectedCategoryWinsBefore > 18 {
+		t.Errorf("ExpectedCategoryWinsBefore = %.2f out of range", impact.ExpectedCategoryWinsBefore)
+	}
+	expectedDelta := (impact.ExpectedMatchupWinsAfter - impact.ExpectedMatchupWinsBefore) / 2
+	if math.Abs(impact.WinProbabilityDelta-expectedDelta) > 0.0001 {
+		t.Errorf("WinProbabilityDelta = %.4f, want %.4f", impact.WinProbabilityDelta, expectedDelta)
+	}
+	if impact.ExpectedCategoryWinsAfter == impact.ExpectedCategoryWinsBefore {
+		t.Error("Swapping a guard for a big should change the expected category wins")
+	}
+}
+
+func TestSwapPlayers(t *testing.T) {
+	roster := []PlayerProjection{{PlayerID: 1}, {PlayerID: 2}, {PlayerID: 3}}
+	result := swapPlayers(roster, []int{1, 3}, []PlayerProjection{{PlayerID: 4}})
+
+	if len(result) != 2 || result[0].PlayerID != 2 || result[1].PlayerID != 4 {
+		t.Errorf("swapPlayers = %+v", result)
+	}
+}
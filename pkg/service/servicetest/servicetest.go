@@ -0,0 +1,454 @@
+// Package servicetest provides stand-ins for the service interfaces, for
+// testing code that depends on them without a database.
+//
+// Each stand-in has a field per method. A call runs the field's function
+// if it is set and returns zero values otherwise. Calls are counted so tests
+// can check what was used.
+package servicetest
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/service"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+)
+
+var (
+	_ service.Trader    = (*Trader)(nil)
+	_ service.Evaluator = (*Evaluator)(nil)
+	_ service.Analyzer  = (*Analyzer)(nil)
+	_ service.Valuator  = (*Valuator)(nil)
+
+	_ service.WaiverRecommender  = (*WaiverRecommender)(nil)
+	_ service.LineupOptimizer    = (*LineupOptimizer)(nil)
+	_ service.ProjectionImporter = (*ProjectionImporter)(nil)
+	_ service.AuctionCalculator  = (*AuctionCalculator)(nil)
+	_ service.SyncMonitor        = (*SyncMonitor)(nil)
+	_ service.Exporter           = (*Exporter)(nil)
+)
+
+// calls counts method calls by name. The zero value is ready to use.
+type calls struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (c *calls) record(method string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.counts == nil {
+		c.counts = make(map[string]int)
+	}
+	c.counts[method]++
+}
+
+// Calls returns how many times the named method was called.
+func (c *calls) Calls(method string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[method]
+}
+
+// Trader is a stand-in for service.Trader.
+type Trader struct {
+	calls
+
+	GenerateSuggestionsFunc     func(ctx context.Context, teamID int, limit int) ([]*service.TradeSuggestion, error)
+	FindTradesForCategoriesFunc func(ctx context.Context, teamID int, want []string, giveUp []string) ([]*service.TradeSuggestion, error)
+	EvaluateProposalFunc        func(ctx context.Context, proposal *service.TradeProposal) (*service.TradeEvaluation, error)
+	SaveProposalFunc            func(ctx context.Context, proposal *service.TradeProposal) error
+	GetProposalsByTeamFunc      func(ctx context.Context, teamID int) ([]*service.TradeSuggestion, error)
+	RecordOutcomeFunc           func(ctx context.Context, proposalID int, outcome service.TradeOutcome) error
+	OutcomeStatsFunc            func(ctx context.Context, leagueID int) ([]service.FairnessBandStats, error)
+	SubmitToYahooFunc           func(ctx context.Context, suggestionID int) (*yahoo.Transaction, error)
+}
+
+func (m *Trader) GenerateSuggestions(ctx context.Context, teamID int, limit int) ([]*service.TradeSuggestion, error) {
+	m.record("GenerateSuggestions")
+	if m.GenerateSuggestionsFunc == nil {
+		return nil, nil
+	}
+	return m.GenerateSuggestionsFunc(ctx, teamID, limit)
+}
+
+func (m *Trader) FindTradesForCategories(ctx context.Context, teamID int, want []string, giveUp []string) ([]*service.TradeSuggestion, error) {
+	m.record("FindTradesForCategories")
+	if m.FindTradesForCategoriesFunc == nil {
+		return nil, nil
+	}
+	return m.FindTradesForCategoriesFunc(ctx, teamID, want, giveUp)
+}
+
+func (m *Trader) EvaluateProposal(ctx context.Context, proposal *service.TradeProposal) (*service.TradeEvaluation, error) {
+	m.record("EvaluateProposal")
+	if m.EvaluateProposalFunc == nil {
+		return nil, nil
+	}
+	return m.EvaluateProposalFunc(ctx, proposal)
+}
+
+func (m *Trader) SaveProposal(ctx context.Context, proposal *service.TradeProposal) error {
+	m.record("SaveProposal")
+	if m.SaveProposalFunc == nil {
+		return nil
+	}
+	return m.SaveProposalFunc(ctx, proposal)
+}
+
+func (m *Trader) GetProposalsByTeam(ctx context.Context, teamID int) ([]*service.TradeSuggestion, error) {
+	m.record("GetProposalsByTeam")
+	if m.GetProposalsByTeamFunc == nil {
+		return nil, nil
+	}
+	return m.GetProposalsByTeamFunc(ctx, teamID)
+}
+
+func (m *Trader) RecordOutcome(ctx context.Context, proposalID int, outcome service.TradeOutcome) error {
+	m.record("RecordOutcome")
+	if m.RecordOutcomeFunc == nil {
+		return nil
+	}
+	return m.RecordOutcomeFunc(ctx, proposalID, outcome)
+}
+
+func (m *Trader) OutcomeStats(ctx context.Context, leagueID int) ([]service.FairnessBandStats, error) {
+	m.record("OutcomeStats")
+	if m.OutcomeStatsFunc == nil {
+		return nil, nil
+	}
+	return m.OutcomeStatsFunc(ctx, leagueID)
+}
+
+func (m *Trader) SubmitToYahoo(ctx context.Context, suggestionID int) (*yahoo.Transaction, error) {
+	m.record("SubmitToYahoo")
+	if m.SubmitToYahooFunc == nil {
+		return nil, nil
+	}
+	return m.SubmitToYahooFunc(ctx, suggestionID)
+}
+
+// Evaluator is a stand-in for service.Evaluator.
+type Evaluator struct {
+	calls
+
+	EvaluateTradeFunc          func(ctx context.Context, leagueID int, teamAID int, teamAGives []int, teamBID int, teamBGives []int) (*service.TradeEvaluation, error)
+	EvaluateTradeWithPicksFunc func(ctx context.Context, leagueID int, teamAID int, teamAGives []int, teamAPicks []service.DraftPick, teamBID int, teamBGives []int, teamBPicks []service.DraftPick) (*service.TradeEvaluation, error)
+	EvaluatePointsTradeFunc    func(ctx context.Context, leagueID int, teamAID int, teamAGives []int, teamBID int, teamBGives []int, schedule []service.ScheduleWeek) (*service.TradeEvaluation, error)
+	LoadScheduleFunc           func(ctx context.Context, leagueID int, leagueKey string, fromWeek, toWeek int) ([]service.ScheduleWeek, error)
+	SimulateTradeScheduleFunc  func(ctx context.Context, leagueID int, teamAID int, teamAGives []int, teamBID int, teamBGives []int, schedule []service.ScheduleWeek) (*service.ScheduleSimulation, error)
+	GetPlayerProjectionsFunc   func(ctx context.Context, leagueID int, playerIDs []int) ([]service.PlayerProjection, error)
+	GetTeamCategoryTotalsFunc  func(ctx context.Context, teamID int) (service.TeamCategoryTotals, error)
+	CategoryChangesFunc        func(current service.TeamCategoryTotals, playersIn, playersOut []service.PlayerProjection) []service.CategoryChange
+}
+
+func (m *Evaluator) EvaluateTrade(ctx context.Context, leagueID int, teamAID int, teamAGives []int, teamBID int, teamBGives []int) (*service.TradeEvaluation, error) {
+	m.record("EvaluateTrade")
+	if m.EvaluateTradeFunc == nil {
+		return nil, nil
+	}
+	return m.EvaluateTradeFunc(ctx, leagueID, teamAID, teamAGives, teamBID, teamBGives)
+}
+
+func (m *Evaluator) EvaluateTradeWithPicks(ctx context.Context, leagueID int, teamAID int, teamAGives []int, teamAPicks []service.DraftPick, teamBID int, teamBGives []int, teamBPicks []service.DraftPick) (*service.TradeEvaluation, error) {
+	m.record("EvaluateTradeWithPicks")
+	if m.EvaluateTradeWithPicksFunc == nil {
+		return nil, nil
+	}
+	return m.EvaluateTradeWithPicksFunc(ctx, leagueID, teamAID, teamAGives, teamAPicks, teamBID, teamBGives, teamBPicks)
+}
+
+func (m *Evaluator) EvaluatePointsTrade(ctx context.Context, leagueID int, teamAID int, teamAGives []int, teamBID int, teamBGives []int, schedule []service.ScheduleWeek) (*service.TradeEvaluation, error) {
+	m.record("EvaluatePointsTrade")
+	if m.EvaluatePointsTradeFunc == nil {
+		return nil, nil
+	}
+	return m.EvaluatePointsTradeFunc(ctx, leagueID, teamAID, teamAGives, teamBID, teamBGives, schedule)
+}
+
+func (m *Evaluator) LoadSchedule(ctx context.Context, leagueID int, leagueKey string, fromWeek, toWeek int) ([]service.ScheduleWeek, error) {
+	m.record("LoadSchedule")
+	if m.LoadScheduleFunc == nil {
+		return nil, nil
+	}
+	return m.LoadScheduleFunc(ctx, leagueID, leagueKey, fromWeek, toWeek)
+}
+
+func (m *Evaluator) SimulateTradeSchedule(ctx context.Context, leagueID int, teamAID int, teamAGives []int, teamBID int, teamBGives []int, schedule []service.ScheduleWeek) (*service.ScheduleSimulation, error) {
+	m.record("SimulateTradeSchedule")
+	if m.SimulateTradeScheduleFunc == nil {
+		return nil, nil
+	}
+	return m.SimulateTradeScheduleFunc(ctx, leagueID, teamAID, teamAGives, teamBID, teamBGives, schedule)
+}
+
+func (m *Evaluator) GetPlayerProjections(ctx context.Context, leagueID int, playerIDs []int) ([]service.PlayerProjection, error) {
+	m.record("GetPlayerProjections")
+	if m.GetPlayerProjectionsFunc == nil {
+		return nil, nil
+	}
+	return m.GetPlayerProjectionsFunc(ctx, leagueID, playerIDs)
+}
+
+func (m *Evaluator) GetTeamCategoryTotals(ctx context.Context, teamID int) (service.TeamCategoryTotals, error) {
+	m.record("GetTeamCategoryTotals")
+	if m.GetTeamCategoryTotalsFunc == nil {
+		return service.TeamCategoryTotals{}, nil
+	}
+	return m.GetTeamCategoryTotalsFunc(ctx, teamID)
+}
+
+func (m *Evaluator) CategoryChanges(current service.TeamCategoryTotals, playersIn, playersOut []service.PlayerProjection) []service.CategoryChange {
+	m.record("CategoryChanges")
+	if m.CategoryChangesFunc == nil {
+		return nil
+	}
+	return m.CategoryChangesFunc(current, playersIn, playersOut)
+}
+
+// Analyzer is a stand-in for service.Analyzer.
+type Analyzer struct {
+	calls
+
+	AnalyzeAllTeamsFunc        func(ctx context.Context, leagueID int) error
+	GetTeamAnalysisFunc        func(ctx context.Context, teamID int) (*service.TeamAnalysis, error)
+	SuggestPuntStrategiesFunc  func(ctx context.Context, teamID int) ([]service.PuntStrategy, error)
+	ProjectMatchupFunc         func(ctx context.Context, leagueID, week, teamAID, teamBID int) (*service.MatchupProjection, error)
+	PowerRankingsFunc          func(ctx context.Context, leagueID int) ([]service.PowerRanking, error)
+	GetPowerRankingHistoryFunc func(ctx context.Context, leagueID, teamID int) ([]service.PowerRanking, error)
+	FindUpgradesFunc           func(ctx context.Context, teamID int) ([]service.PositionUpgrade, error)
+	HeadToHeadFunc             func(ctx context.Context, teamID int) (*service.HeadToHeadReport, error)
+}
+
+func (m *Analyzer) AnalyzeAllTeams(ctx context.Context, leagueID int) error {
+	m.record("AnalyzeAllTeams")
+	if m.AnalyzeAllTeamsFunc == nil {
+		return nil
+	}
+	return m.AnalyzeAllTeamsFunc(ctx, leagueID)
+}
+
+func (m *Analyzer) GetTeamAnalysis(ctx context.Context, teamID int) (*service.TeamAnalysis, error) {
+	m.record("GetTeamAnalysis")
+	if m.GetTeamAnalysisFunc == nil {
+		return nil, nil
+	}
+	return m.GetTeamAnalysisFunc(ctx, teamID)
+}
+
+func (m *Analyzer) SuggestPuntStrategies(ctx context.Context, teamID int) ([]service.PuntStrategy, error) {
+	m.record("SuggestPuntStrategies")
+	if m.SuggestPuntStrategiesFunc == nil {
+		return nil, nil
+	}
+	return m.SuggestPuntStrategiesFunc(ctx, teamID)
+}
+
+func (m *Analyzer) ProjectMatchup(ctx context.Context, leagueID, week, teamAID, teamBID int) (*service.MatchupProjection, error) {
+	m.record("ProjectMatchup")
+	if m.ProjectMatchupFunc == nil {
+		return nil, nil
+	}
+	return m.ProjectMatchupFunc(ctx, leagueID, week, teamAID, teamBID)
+}
+
+func (m *Analyzer) PowerRankings(ctx context.Context, leagueID int) ([]service.PowerRanking, error) {
+	m.record("PowerRankings")
+	if m.PowerRankingsFunc == nil {
+		return nil, nil
+	}
+	return m.PowerRankingsFunc(ctx, leagueID)
+}
+
+func (m *Analyzer) GetPowerRankingHistory(ctx context.Context, leagueID, teamID int) ([]service.PowerRanking, error) {
+	m.record("GetPowerRankingHistory")
+	if m.GetPowerRankingHistoryFunc == nil {
+		return nil, nil
+	}
+	return m.GetPowerRankingHistoryFunc(ctx, leagueID, teamID)
+}
+
+func (m *Analyzer) FindUpgrades(ctx context.Context, teamID int) ([]service.PositionUpgrade, error) {
+	m.record("FindUpgrades")
+	if m.FindUpgradesFunc == nil {
+		return nil, nil
+	}
+	return m.FindUpgradesFunc(ctx, teamID)
+}
+
+func (m *Analyzer) HeadToHead(ctx context.Context, teamID int) (*service.HeadToHeadReport, error) {
+	m.record("HeadToHead")
+	if m.HeadToHeadFunc == nil {
+		return nil, nil
+	}
+	return m.HeadToHeadFunc(ctx, teamID)
+}
+
+// Valuator is a stand-in for service.Valuator.
+type Valuator struct {
+	calls
+
+	CalculateAllPlayerValuesFunc func(ctx context.Context, leagueID int) error
+	GetPositionScarcityFunc      func(ctx context.Context, leagueID int) ([]service.PositionScarcity, error)
+}
+
+func (m *Valuator) CalculateAllPlayerValues(ctx context.Context, leagueID int) error {
+	m.record("CalculateAllPlayerValues")
+	if m.CalculateAllPlayerValuesFunc == nil {
+		return nil
+	}
+	return m.CalculateAllPlayerValuesFunc(ctx, leagueID)
+}
+
+func (m *Valuator) GetPositionScarcity(ctx context.Context, leagueID int) ([]service.PositionScarcity, error) {
+	m.record("GetPositionScarcity")
+	if m.GetPositionScarcityFunc == nil {
+		return nil, nil
+	}
+	return m.GetPositionScarcityFunc(ctx, leagueID)
+}
+
+// WaiverRecommender is a stand-in for service.WaiverRecommender.
+type WaiverRecommender struct {
+	calls
+
+	RecommendPickupsFunc  func(ctx context.Context, teamID int, limit int) ([]service.WaiverRecommendation, error)
+	RecommendUpgradesFunc func(ctx context.Context, teamID int, limit int) ([]service.WaiverRecommendation, error)
+}
+
+func (m *WaiverRecommender) RecommendPickups(ctx context.Context, teamID int, limit int) ([]service.WaiverRecommendation, error) {
+	m.record("RecommendPickups")
+	if m.RecommendPickupsFunc == nil {
+		return nil, nil
+	}
+	return m.RecommendPickupsFunc(ctx, teamID, limit)
+}
+
+func (m *WaiverRecommender) RecommendUpgrades(ctx context.Context, teamID int, limit int) ([]service.WaiverRecommendation, error) {
+	m.record("RecommendUpgrades")
+	if m.RecommendUpgradesFunc == nil {
+		return nil, nil
+	}
+	return m.RecommendUpgradesFunc(ctx, teamID, limit)
+}
+
+// LineupOptimizer is a stand-in for service.LineupOptimizer.
+type LineupOptimizer struct {
+	calls
+
+	OptimizeLineupFunc func(ctx context.Context, teamID int, coverage yahoo.RosterCoverage) (*service.Lineup, error)
+	SubmitLineupFunc   func(ctx context.Context, lineup *service.Lineup) error
+}
+
+func (m *LineupOptimizer) OptimizeLineup(ctx context.Context, teamID int, coverage yahoo.RosterCoverage) (*service.Lineup, error) {
+	m.record("OptimizeLineup")
+	if m.OptimizeLineupFunc == nil {
+		return nil, nil
+	}
+	return m.OptimizeLineupFunc(ctx, teamID, coverage)
+}
+
+func (m *LineupOptimizer) SubmitLineup(ctx context.Context, lineup *service.Lineup) error {
+	m.record("SubmitLineup")
+	if m.SubmitLineupFunc == nil {
+		return nil
+	}
+	return m.SubmitLineupFunc(ctx, lineup)
+}
+
+// ProjectionImporter is a stand-in for service.ProjectionImporter.
+type ProjectionImporter struct {
+	calls
+
+	ImportCSVFunc         func(ctx context.Context, leagueID int, r io.Reader, opts ...service.ProjectionImportOption) (*service.ProjectionImport, error)
+	ImportJSONFunc        func(ctx context.Context, leagueID int, r io.Reader, opts ...service.ProjectionImportOption) (*service.ProjectionImport, error)
+	ImportProjectionsFunc func(ctx context.Context, leagueID int, source string, projections []service.ImportedProjection) (*service.ProjectionImport, error)
+}
+
+func (m *ProjectionImporter) ImportCSV(ctx context.Context, leagueID int, r io.Reader, opts ...service.ProjectionImportOption) (*service.ProjectionImport, error) {
+	m.record("ImportCSV")
+	if m.ImportCSVFunc == nil {
+		return nil, nil
+	}
+	return m.ImportCSVFunc(ctx, leagueID, r, opts...)
+}
+
+func (m *ProjectionImporter) ImportJSON(ctx context.Context, leagueID int, r io.Reader, opts ...service.ProjectionImportOption) (*service.ProjectionImport, error) {
+	m.record("ImportJSON")
+	if m.ImportJSONFunc == nil {
+		return nil, nil
+	}
+	return m.ImportJSONFunc(ctx, leagueID, r, opts...)
+}
+
+func (m *ProjectionImporter) ImportProjections(ctx context.Context, leagueID int, source string, projections []service.ImportedProjection) (*service.ProjectionImport, error) {
+	m.record("ImportProjections")
+	if m.ImportProjectionsFunc == nil {
+		return nil, nil
+	}
+	return m.ImportProjectionsFunc(ctx, leagueID, source, projections)
+}
+
+// AuctionCalculator is a stand-in for service.AuctionCalculator.
+type AuctionCalculator struct {
+	calls
+
+	AuctionValuesFunc func(ctx context.Context, leagueID int) ([]service.AuctionValue, error)
+	KeeperValuesFunc  func(ctx context.Context, teamID int) ([]service.KeeperValue, error)
+}
+
+func (m *AuctionCalculator) AuctionValues(ctx context.Context, leagueID int) ([]service.AuctionValue, error) {
+	m.record("AuctionValues")
+	if m.AuctionValuesFunc == nil {
+		return nil, nil
+	}
+	return m.AuctionValuesFunc(ctx, leagueID)
+}
+
+func (m *AuctionCalculator) KeeperValues(ctx context.Context, teamID int) ([]service.KeeperValue, error) {
+	m.record("KeeperValues")
+	if m.KeeperValuesFunc == nil {
+		return nil, nil
+	}
+	return m.KeeperValuesFunc(ctx, teamID)
+}
+
+// SyncMonitor is a stand-in for service.SyncMonitor.
+type SyncMonitor struct {
+	calls
+
+	GetStatusFunc func(ctx context.Context, leagueID int) ([]service.SyncStatus, error)
+}
+
+func (m *SyncMonitor) GetStatus(ctx context.Context, leagueID int) ([]service.SyncStatus, error) {
+	m.record("GetStatus")
+	if m.GetStatusFunc == nil {
+		return nil, nil
+	}
+	return m.GetStatusFunc(ctx, leagueID)
+}
+
+// Exporter is a stand-in for service.Exporter.
+type Exporter struct {
+	calls
+
+	ExportFunc  func(ctx context.Context, leagueID int, dir string, format service.ExportFormat) (*service.ExportManifest, error)
+	RestoreFunc func(ctx context.Context, dir string) (*service.ExportManifest, error)
+}
+
+func (m *Exporter) Export(ctx context.Context, leagueID int, dir string, format service.ExportFormat) (*service.ExportManifest, error) {
+	m.record("Export")
+	if m.ExportFunc == nil {
+		return nil, nil
+	}
+	return m.ExportFunc(ctx, leagueID, dir, format)
+}
+
+func (m *Exporter) Restore(ctx context.Context, dir string) (*service.ExportManifest, error) {
+	m.record("Restore")
+	if m.RestoreFunc == nil {
+		return nil, nil
+	}
+	return m.RestoreFunc(ctx, dir)
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SyncService reports how a league's syncs have gone, from the
+// sync_history rows LeagueService records.
+type SyncService struct {
+	db *sql.DB
+}
+
+// SyncStatus is the state of one type of sync for a league, such as
+// "incremental" roster syncs or "matchups".
+type SyncStatus struct {
+	SyncType string
+	// LastSuccess is when the latest successful sync completed, and nil if
+	// none has. ItemsSynced and Duration describe that sync; Duration is
+	// zero when its start was not recorded.
+	LastSuccess *time.Time
+	ItemsSynced int
+	Duration    time.Duration
+	// LastAttempt is when the latest sync of the type completed, whether or
+	// not it succeeded. Error is its error message when it failed.
+	LastAttempt time.Time
+	Failed      bool
+	Error       string
+}
+
+func NewSyncService(db *sql.DB) SyncMonitor {
+	return &SyncService{db: db}
+}
+
+// GetStatus returns the status of each type of sync the league has run,
+// ordered by type.
+func (s *SyncService) GetStatus(ctx context.Context, leagueID int) (statuses []SyncStatus, err error) {
+	ctx, span := startSpan(ctx, "SyncService.GetStatus", attribute.Int("league.id", leagueID))
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT sync_type, sync_status, COALESCE(items_synced, 0), error_message, started_at, completed_at
+		FROM sync_history
+		WHERE league_id = ?
+		ORDER BY completed_at DESC, id DESC
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sync history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []syncRecord
+	for rows.Next() {
+		var r syncRecord
+		var message sql.NullString
+		var startedAt, completedAt sql.NullTime
+		if err := rows.Scan(&r.syncType, &r.status, &r.items, &message, &startedAt, &completedAt); err != nil {
+			return nil, err
+		}
+		r.message = message.String
+		r.startedAt = startedAt.Time
+		r.completedAt = completedAt.Time
+		history = append(history, r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return syncStatuses(history), nil
+}
+
+// syncRecord is a sync_history row.
+type syncRecord struct {
+	syncType    string
+	status      string
+	items       int
+	message     string
+	startedAt   time.Time
+	completedAt time.Time
+}
+
+// syncStatuses summarizes history, newest first, into a status per sync
+// type.
+func syncStatuses(history []syncRecord) []SyncStatus {
+	byType := make(map[string]*SyncStatus)
+	var types []string
+	for _, r := range history {
+		status, ok := byType[r.syncType]
+		if !ok {
+			status = &SyncStatus{
+				SyncType:    r.syncType,
+				LastAttempt: r.completedAt,
+				Failed:      r.status != "success",
+			}
+			if status.Failed {
+				status.Error = r.message
+			}
+			byType[r.syncType] = status
+			types = append(types, r.syncType)
+		}
+
+		if status.LastSuccess == nil && r.status == "success" {
+			completedAt := r.completedAt
+			status.LastSuccess = &completedAt
+			status.ItemsSynced = r.items
+			if !r.startedAt.IsZero() {
+				status.Duration = r.completedAt.Sub(r.startedAt)
+			}
+		}
+	}
+
+	sort.Strings(types)
+	statuses := make([]SyncStatus, len(types))
+	for i, t := range types {
+		statuses[i] = *byType[t]
+	}
+	return statuses
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSyncStatuses(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2025, 1, d, hour, 0, 0, 0, time.UTC) }
+	history := []syncRecord{
+		{syncType: "incremental", status: "failed", message: "failed to fetch teams: timeout", completedAt: day(3, 9)},
+		{syncType: "matchups", status: "success", items: 6, startedAt: day(2, 8), completedAt: day(2, 9)},
+		{syncType: "incremental", status: "success", items: 2, startedAt: day(2, 7), completedAt: day(2, 8)},
+		{syncType: "incremental", status: "success", items: 12, startedAt: day(1, 7), completedAt: day(1, 8)},
+		{syncType: "full", status: "failed", message: "boom", completedAt: day(1, 6)},
+	}
+
+	got := syncStatuses(history)
+	if len(got) != 3 {
+		t.Fatalf("Expected 3 sync types, got %+v", got)
+	}
+
+	full, incremental, matchups := got[0], got[1], got[2]
+	if full.SyncType != "full" || full.LastSuccess != nil || !full.Failed || full.Error != "boom" {
+		t.Errorf("Expected a full sync that never succeeded, got %+v", full)
+	}
+	if incremental.SyncType != "incremental" || !incremental.Failed || !incremental.LastAttempt.Equal(day(3, 9)) {
+		t.Errorf("Expected the latest incremental sync to have failed, got %+v", incremental)
+	}
+	if incremental.LastSuccess == nil || !incremental.LastSuccess.Equal(day(2, 8)) || incremental.ItemsSynced != 2 || incremental.Duration != time.Hour {
+		t.Errorf("Expected the last incremental success on day 2, got %+v", incremental)
+	}
+	if matchups.Failed || matchups.Error != "" || matchups.ItemsSynced != 6 {
+		t.Errorf("Expected a successful matchup sync, got %+v", matchups)
+	}
+}
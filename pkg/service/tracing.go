@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan starts a span using the tracer provider of the span already in
+// ctx, falling back to the global provider, so services are traced with
+// whatever provider the caller passed to yahoo.WithTracerProvider.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	provider := otel.GetTracerProvider()
+	if parent := trace.SpanFromContext(ctx); parent.SpanContext().IsValid() {
+		provider = parent.TracerProvider()
+	}
+	return provider.Tracer(yahoo.TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
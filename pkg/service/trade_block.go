@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+)
+
+// WithTradeBlock makes the trade finders respect the teams' trade blocks:
+// untouchable players are never suggested, and trades for players another
+// team is shopping are ranked first.
+func WithTradeBlock(repo *repository.TradeBlockRepository) TradeServiceOption {
+	return func(s *TradeService) {
+		s.tradeBlock = repo
+	}
+}
+
+// tradeBlock holds the player IDs on a league's trade blocks and untouchable
+// lists. The zero value allows every trade.
+type tradeBlock struct {
+	available   map[int]bool
+	untouchable map[int]bool
+}
+
+func (s *TradeService) loadTradeBlock(ctx context.Context, leagueID int) (tradeBlock, error) {
+	if s.tradeBlock == nil {
+		return tradeBlock{}, nil
+	}
+
+	entries, err := s.tradeBlock.GetByLeague(ctx, leagueID)
+	if err != nil {
+		return tradeBlock{}, err
+	}
+	return newTradeBlock(entries), nil
+}
+
+func newTradeBlock(entries []*repository.TradeBlockEntry) tradeBlock {
+	block := tradeBlock{
+		available:   make(map[int]bool),
+		untouchable: make(map[int]bool),
+	}
+	for _, entry := range entries {
+		switch entry.Status {
+		case repository.TradeBlockAvailable:
+			block.available[entry.PlayerID] = true
+		case repository.TradeBlockUntouchable:
+			block.untouchable[entry.PlayerID] = true
+		}
+	}
+	return block
+}
+
+// tradeable returns the players that are not untouchable.
+func (b tradeBlock) tradeable(players []RosterPlayer) []RosterPlayer {
+	if len(b.untouchable) == 0 {
+		return players
+	}
+	result := make([]RosterPlayer, 0, len(players))
+	for _, p := range players {
+		if !b.untouchable[p.PlayerID] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// sortSuggestions ranks suggestions that target the trade block first, then
+// the fairest.
+func sortSuggestions(suggestions []*TradeSuggestion) {
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].TargetsTradeBlock != suggestions[j].TargetsTradeBlock {
+			return suggestions[i].TargetsTradeBlock
+		}
+		return suggestions[i].FairnessScore > suggestions[j].FairnessScore
+	})
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+)
+
+func TestTradeBlockTradeable(t *testing.T) {
+	block := newTradeBlock([]*repository.TradeBlockEntry{
+		{TeamID: 1, PlayerID: 1, Status: repository.TradeBlockUntouchable},
+		{TeamID: 2, PlayerID: 3, Status: repository.TradeBlockAvailable},
+	})
+
+	players := []RosterPlayer{{PlayerID: 1}, {PlayerID: 2}, {PlayerID: 3}}
+	tradeable := block.tradeable(players)
+
+	if len(tradeable) != 2 || tradeable[0].PlayerID != 2 || tradeable[1].PlayerID != 3 {
+		t.Errorf("tradeable = %+v, want players 2 and 3", tradeable)
+	}
+
+	var empty tradeBlock
+	if len(empty.tradeable(players)) != 3 {
+		t.Error("An empty trade block should allow every player")
+	}
+}
+
+func TestCandidatePackagesPreferTradeBlock(t *testing.T) {
+	teamA := []RosterPlayer{{PlayerID: 1, FPG: 30.0}}
+	teamB := []RosterPlayer{
+		{PlayerID: 2, FPG: 30.0},
+		{PlayerID: 3, FPG: 27.0},
+	}
+
+	service := &TradeService{config: TradeConfig{MaxPackageSize: 1}}
+	candidates := service.candidatePackages(teamA, teamB, 13, 13, map[int]bool{3: true}, nil)
+
+	if len(candidates) != 2 {
+		t.Fatalf("Expected 2 candidates, got %d", len(candidates))
+	}
+	if !candidates[0].targetsBlock || candidates[0].teamBGives[0].PlayerID != 3 {
+		t.Errorf("The trade block player should come first, got %+v", candidates[0])
+	}
+}
+
+func TestSortSuggestions(t *testing.T) {
+	suggestions := []*TradeSuggestion{
+		{ID: 1, FairnessScore: 95},
+		{ID: 2, FairnessScore: 80, TargetsTradeBlock: true},
+		{ID: 3, FairnessScore: 90},
+		{ID: 4, FairnessScore: 85, TargetsTradeBlock: true},
+	}
+
+	sortSuggestions(suggestions)
+
+	for i, want := range []int{4, 2, 1, 3} {
+		if suggestions[i].ID != want {
+			t.Errorf("Position %d: got suggestion %d, want %d", i, suggestions[i].ID, want)
+		}
+	}
+}
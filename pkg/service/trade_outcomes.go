@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TradeOutcome is what became of a trade proposal.
+type TradeOutcome string
+
+const (
+	TradeOutcomeAccepted TradeOutcome = "accepted"
+	TradeOutcomeRejected TradeOutcome = "rejected"
+	TradeOutcomeVetoed   TradeOutcome = "vetoed"
+)
+
+// fairnessBandWidth is the width, in fairness points, of the bands
+// OutcomeStats groups proposals into.
+const fairnessBandWidth = 10.0
+
+// FairnessBandStats summarizes the outcomes of the proposals whose fairness
+// score fell in [MinFairness, MaxFairness).
+type FairnessBandStats struct {
+	MinFairness float64
+	MaxFairness float64
+	Accepted    int
+	Rejected    int
+	Vetoed      int
+	// AcceptanceRate is the share of the band's proposals that were
+	// accepted, vetoed ones included, since the partner agreed to them.
+	AcceptanceRate float64
+}
+
+// RecordOutcome records what became of a saved proposal. The outcome is
+// stored in the trade_proposals table's outcome columns:
+//
+//	ALTER TABLE trade_proposals ADD COLUMN outcome TEXT;
+//	ALTER TABLE trade_proposals ADD COLUMN outcome_at DATETIME;
+//
+// The proposal's status is set to the outcome as well, so rejected
+// proposals drop out of GetProposalsByTeam.
+func (s *TradeService) RecordOutcome(ctx context.Context, proposalID int, outcome TradeOutcome) (err error) {
+	ctx, span := startSpan(ctx, "TradeService.RecordOutcome",
+		attribute.Int("proposal.id", proposalID),
+		attribute.String("outcome", string(outcome)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	switch outcome {
+	case TradeOutcomeAccepted, TradeOutcomeRejected, TradeOutcomeVetoed:
+	default:
+		return fmt.Errorf("unknown trade outcome %q", outcome)
+	}
+
+	query := `
+		UPDATE trade_proposals
+		SET outcome = ?, status = ?, outcome_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+
+	result, err := s.db.ExecContext(ctx, query, string(outcome), string(outcome), proposalID)
+	if err != nil {
+		return fmt.Errorf("failed to record trade outcome: %w", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to record trade outcome: %w", err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("trade proposal %d does not exist", proposalID)
+	}
+	return nil
+}
+
+// OutcomeStats groups the league's proposals with a recorded outcome into
+// fairness bands, lowest first, and reports how often each band's proposals
+// were accepted. Comparing the rates against TradeConfig.FairnessThreshold
+// shows whether suggestions are being held to too high or too low a bar.
+// Bands without proposals are left out.
+func (s *TradeService) OutcomeStats(ctx context.Context, leagueID int) (_ []FairnessBandStats, err error) {
+	ctx, span := startSpan(ctx, "TradeService.OutcomeStats",
+		attribute.Int("league.id", leagueID),
+	)
+	defer func() { endSpan(span, err) }()
+
+	query := `
+		SELECT fairness_score, outcome
+		FROM trade_proposals
+		WHERE league_id = ? AND outcome IS NOT NULL
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade outcomes: %w", err)
+	}
+	defer rows.Close()
+
+	var outcomes []proposalOutcome
+	for rows.Next() {
+		var o proposalOutcome
+		var outcome string
+		if err := rows.Scan(&o.fairness, &outcome); err != nil {
+			return nil, err
+		}
+		o.outcome = TradeOutcome(outcome)
+		outcomes = append(outcomes, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return outcomeStats(outcomes), nil
+}
+
+type proposalOutcome struct {
+	fairness float64
+	outcome  TradeOutcome
+}
+
+func outcomeStats(outcomes []proposalOutcome) []FairnessBandStats {
+	bands := int(math.Ceil(100 / fairnessBandWidth))
+	stats := make([]FairnessBandStats, bands)
+	for i := range stats {
+		stats[i].MinFairness = float64(i) * fairnessBandWidth
+		stats[i].MaxFairness = math.Min(100, float64(i+1)*fairnessBandWidth)
+	}
+
+	for _, o := range outcomes {
+		band := int(o.fairness / fairnessBandWidth)
+		if band < 0 {
+			band = 0
+		}
+		if band >= bands {
+			// A perfect score belongs in the top band.
+			band = bands - 1
+		}
+
+		switch o.outcome {
+		case TradeOutcomeAccepted:
+			stats[band].Accepted++
+		case TradeOutcomeRejected:
+			stats[band].Rejected++
+		case TradeOutcomeVetoed:
+			stats[band].Vetoed++
+		}
+	}
+
+	var result []FairnessBandStats
+	for _, band := range stats {
+		total := band.Accepted + band.Rejected + band.Vetoed
+		if total == 0 {
+			continue
+		}
+		band.AcceptanceRate = float64(band.Accepted+band.Vetoed) / float64(total)
+		result = append(result, band)
+	}
+	return result
+}
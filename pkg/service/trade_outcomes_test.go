@@ -0,0 +1,53 @@
+package service
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOutcomeStats(t *testing.T) {
+	outcomes := []proposalOutcome{
+		{fairness: 72, outcome: TradeOutcomeRejected},
+		{fairness: 78, outcome: TradeOutcomeRejected},
+		{fairness: 79.9, outcome: TradeOutcomeAccepted},
+		{fairness: 85, outcome: TradeOutcomeAccepted},
+		{fairness: 88, outcome: TradeOutcomeVetoed},
+		{fairness: 100, outcome: TradeOutcomeAccepted},
+	}
+
+	stats := outcomeStats(outcomes)
+
+	if len(stats) != 3 {
+		t.Fatalf("Expected 3 bands, got %+v", stats)
+	}
+
+	tests := []struct {
+		min, max float64
+		accepted int
+		rejected int
+		vetoed   int
+		rate     float64
+	}{
+		{70, 80, 1, 2, 0, 1.0 / 3},
+		{80, 90, 1, 0, 1, 1.0},
+		{90, 100, 1, 0, 0, 1.0},
+	}
+
+	for i, tt := range tests {
+		band := stats[i]
+		if band.MinFairness != tt.min || band.MaxFairness != tt.max {
+			t.Errorf("Band %d: got [%.0f, %.0f), want [%.0f, %.0f)", i, band.MinFairness, band.MaxFairness, tt.min, tt.max)
+		}
+		if band.Accepted != tt.accepted || band.Rejected != tt.rejected || band.Vetoed != tt.vetoed {
+			t.Errorf("Band %d: got %d/%d/%d accepted/rejected/vetoed, want %d/%d/%d",
+				i, band.Accepted, band.Rejected, band.Vetoed, tt.accepted, tt.rejected, tt.vetoed)
+		}
+		if math.Abs(band.AcceptanceRate-tt.rate) > 0.001 {
+			t.Errorf("Band %d: AcceptanceRate = %.3f, want %.3f", i, band.AcceptanceRate, tt.rate)
+		}
+	}
+
+	if outcomeStats(nil) != nil {
+		t.Error("Expected no bands without outcomes")
+	}
+}
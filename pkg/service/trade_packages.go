@@ -0,0 +1,136 @@
+package service
+
+import (
+	"math"
+	"sort"
+)
+
+// maxPackageCandidates caps how many packages are evaluated per team
+// pairing. Evaluating a trade costs several queries, so only the
+// closest-valued packages are kept.
+const maxPackageCandidates = 50
+
+// tradePackage is one candidate trade: the players each side gives up.
+type tradePackage struct {
+	teamAGives []RosterPlayer
+	teamBGives []RosterPlayer
+	valueGap   float64
+	// targetsBlock is set when team B gives up a player from the preferred
+	// set passed to candidatePackages.
+	targetsBlock bool
+}
+
+// candidatePackages pairs every package of up to MaxPackageSize players from
+// each roster, keeping those worth within ValueBand of each other and
+// that leave both rosters within MaxRosterSize and that keep accepts, if it
+// is not nil. Packages in which team B gives up a player in preferred come
+// first, then the closest-valued ones. keep runs before the list is cut to
+// maxPackageCandidates, so packages it accepts are not crowded out by ones
+// it would reject.
+func (s *TradeService) candidatePackages(teamAPlayers, teamBPlayers []RosterPlayer, teamASize, teamBSize int, preferred map[int]bool, keep func(tradePackage) bool) []tradePackage {
+	config := s.config.withDefaults()
+	teamAPackages := playerPackages(teamAPlayers, config.MaxPackageSize)
+	teamBPackages := playerPackages(teamBPlayers, config.MaxPackageSize)
+
+	var candidates []tradePackage
+	for _, gives := range teamAPackages {
+		givesValue := packageValue(gives)
+		for _, gets := range teamBPackages {
+			if !s.fitsRosters(len(gives), len(gets), teamASize, teamBSize) {
+				continue
+			}
+			getsValue := packageValue(gets)
+			if !withinValueBand(givesValue, getsValue, config.ValueBand) {
+				continue
+			}
+			pkg := tradePackage{
+				teamAGives:   gives,
+				teamBGives:   gets,
+				valueGap:     math.Abs(givesValue - getsValue),
+				targetsBlock: containsAny(preferred, gets),
+			}
+			if keep != nil && !keep(pkg) {
+				continue
+			}
+			candidates = append(candidates, pkg)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].targetsBlock != candidates[j].targetsBlock {
+			return candidates[i].targetsBlock
+		}
+		return candidates[i].valueGap < candidates[j].valueGap
+	})
+	if len(candidates) > maxPackageCandidates {
+		candidates = candidates[:maxPackageCandidates]
+	}
+	return candidates
+}
+
+// fitsRosters reports whether both teams stay within MaxRosterSize after
+// team A gives up given players and receives received players.
+func (s *TradeService) fitsRosters(given, received, teamASize, teamBSize int) bool {
+	if s.config.MaxRosterSize <= 0 {
+		return true
+	}
+	return teamASize-given+received <= s.config.MaxRosterSize &&
+		teamBSize-received+given <= s.config.MaxRosterSize
+}
+
+// playerPackages returns every combination of 1 to maxSize players.
+func playerPackages(players []RosterPlayer, maxSize int) [][]RosterPlayer {
+	var packages [][]RosterPlayer
+	var build func(start int, current []RosterPlayer)
+	build = func(start int, current []RosterPlayer) {
+		if len(current) > 0 {
+			packages = append(packages, append([]RosterPlayer(nil), current...))
+		}
+		if len(current) == maxSize {
+			return
+		}
+		for i := start; i < len(players); i++ {
+			build(i+1, append(current, players[i]))
+		}
+	}
+	build(0, nil)
+	return packages
+}
+
+func containsAny(playerIDs map[int]bool, players []RosterPlayer) bool {
+	for _, p := range players {
+		if playerIDs[p.PlayerID] {
+			return true
+		}
+	}
+	return false
+}
+
+func packageValue(players []RosterPlayer) float64 {
+	var total float64
+	for _, p := range players {
+		total += p.FPG
+	}
+	return total
+}
+
+func playerIDs(players []RosterPlayer) []int {
+	ids := make([]int, len(players))
+	for i, p := range players {
+		ids[i] = p.PlayerID
+	}
+	return ids
+}
+
+func tradePlayers(players []RosterPlayer) []TradePlayer {
+	result := make([]TradePlayer, len(players))
+	for i, p := range players {
+		result[i] = TradePlayer{
+			PlayerID:   p.PlayerID,
+			PlayerName: p.PlayerName,
+			Position:   p.Position,
+			FPG:        p.FPG,
+		}
+	}
+	return result
+}
@@ -0,0 +1,110 @@
+package service
+
+import "testing"
+
+func TestPlayerPackages(t *testing.T) {
+	players := []RosterPlayer{{PlayerID: 1}, {PlayerID: 2}, {PlayerID: 3}}
+
+	tests := []struct {
+		maxSize  int
+		expected int
+	}{
+		{maxSize: 1, expected: 3},
+		{maxSize: 2, expected: 6},
+		{maxSize: 3, expected: 7},
+	}
+
+	for _, tt := range tests {
+		packages := playerPackages(players, tt.maxSize)
+		if len(packages) != tt.expected {
+			t.Errorf("maxSize %d: got %d packages, want %d", tt.maxSize, len(packages), tt.expected)
+		}
+		for _, pkg := range packages {
+			if len(pkg) > tt.maxSize {
+				t.Errorf("maxSize %d: package of %d players", tt.maxSize, len(pkg))
+			}
+		}
+	}
+}
+
+func TestCandidatePackages(t *testing.T) {
+	teamA := []RosterPlayer{
+		{PlayerID: 1, FPG: 25.0},
+		{PlayerID: 2, FPG: 20.0},
+	}
+	teamB := []RosterPlayer{
+		{PlayerID: 3, FPG: 44.0},
+		{PlayerID: 4, FPG: 10.0},
+	}
+
+	service := &TradeService{config: TradeConfig{MaxPackageSize: 2}}
+	candidates := service.candidatePackages(teamA, teamB, 13, 13, nil, nil)
+
+	found := false
+	for _, c := range candidates {
+		if !withinValueBand(packageValue(c.teamAGives), packageValue(c.teamBGives), 15.0) {
+			t.Errorf("Package outside value band: %+v", c)
+		}
+		if len(c.teamAGives) == 2 && len(c.teamBGives) == 1 && c.teamBGives[0].PlayerID == 3 {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected a 2-for-1 package for player 3")
+	}
+
+	for i := 1; i < len(candidates); i++ {
+		if candidates[i].valueGap < candidates[i-1].valueGap {
+			t.Error("Candidates should be sorted by value gap")
+		}
+	}
+
+	oneForOne := &TradeService{config: TradeConfig{MaxPackageSize: 1}}
+	for _, c := range oneForOne.candidatePackages(teamA, teamB, 13, 13, nil, nil) {
+		if len(c.teamAGives) != 1 || len(c.teamBGives) != 1 {
+			t.Errorf("MaxPackageSize 1 should only produce 1-for-1 swaps, got %d-for-%d",
+				len(c.teamAGives), len(c.teamBGives))
+		}
+	}
+}
+
+func TestCandidatePackagesRosterLimit(t *testing.T) {
+	teamA := []RosterPlayer{
+		{PlayerID: 1, FPG: 25.0},
+		{PlayerID: 2, FPG: 20.0},
+	}
+	teamB := []RosterPlayer{
+		{PlayerID: 3, FPG: 44.0},
+	}
+
+	// Team B has a full roster, so it cannot take two players for one.
+	service := &TradeService{config: TradeConfig{MaxPackageSize: 2, MaxRosterSize: 13}}
+	for _, c := range service.candidatePackages(teamA, teamB, 13, 13, nil, nil) {
+		if len(c.teamAGives) > len(c.teamBGives) {
+			t.Errorf("Package would overfill team B's roster: %d-for-%d", len(c.teamAGives), len(c.teamBGives))
+		}
+	}
+
+	// With an open spot the 2-for-1 is allowed.
+	candidates := service.candidatePackages(teamA, teamB, 13, 12, nil, nil)
+	if len(candidates) != 1 || len(candidates[0].teamAGives) != 2 {
+		t.Errorf("Expected the 2-for-1 package with an open roster spot, got %+v", candidates)
+	}
+}
+
+func TestCandidatePackagesFilterBeforeCap(t *testing.T) {
+	teamA := []RosterPlayer{{PlayerID: 1, FPG: 30.0}}
+	var teamB []RosterPlayer
+	for id := 2; id < maxPackageCandidates+10; id++ {
+		teamB = append(teamB, RosterPlayer{PlayerID: id, FPG: 30.0})
+	}
+	last := teamB[len(teamB)-1].PlayerID
+
+	service := &TradeService{config: TradeConfig{MaxPackageSize: 1}}
+	keep := func(pkg tradePackage) bool { return pkg.teamBGives[0].PlayerID == last }
+	candidates := service.candidatePackages(teamA, teamB, 13, 13, nil, keep)
+
+	if len(candidates) != 1 || candidates[0].teamBGives[0].PlayerID != last {
+		t.Errorf("Expected only the package with player %d, got %+v", last, candidates)
+	}
+}
@@ -5,13 +5,113 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"sort"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type TradeService struct {
 	db            *sql.DB
-	evaluator     *EvaluationService
-	analysisService *AnalysisService
+	evaluator     Evaluator
+	analysisService Analyzer
+	config          TradeConfig
+	tradeBlock      *repository.TradeBlockRepository
+	leagueRepo      *repository.LeagueRepository
+	yahooClient     *yahoo.Client
+}
+
+// TradeConfig tunes how aggressively the trade finders search. Zero fields
+// fall back to the values in DefaultTradeConfig.
+type TradeConfig struct {
+	// MaxPackageSize is the most players either side may give up in one
+	// suggestion. 1 only considers 1-for-1 swaps; 2 adds 2-for-1 and 2-for-2
+	// packages. Larger sizes grow the search quickly.
+	MaxPackageSize int
+	// MaxRosterSize is the most players a team may roster. Uneven packages
+	// that would push the receiving team past it are skipped. 0 disables the
+	// check.
+	MaxRosterSize int
+	// FairnessThreshold is the lowest fairness score, from 0 to 100, a
+	// suggestion may have. Give the evaluator the same threshold with
+	// WithFairnessThreshold so its IsFair and recommendation agree.
+	FairnessThreshold float64
+	// ValueBand is how far apart, in percent of their average, the two sides'
+	// projected fantasy points may be before a package is skipped without
+	// evaluating it.
+	ValueBand float64
+	// MaxSuggestionsPerTeam caps the suggestions GenerateSuggestions keeps
+	// with each trade partner, fairest first. 0 keeps them all.
+	MaxSuggestionsPerTeam int
+	// MinComplementScore is how many of the user's weak categories must be
+	// the partner's strengths, and vice versa, before GenerateSuggestions
+	// looks for trades with them. Use a negative value to consider every
+	// team.
+	MinComplementScore int
+	// Workers is how many trade partners GenerateSuggestions searches, and
+	// how many candidate trades it evaluates, at once.
+	Workers int
+	// FlagIllegalRosters keeps suggestions that would leave a roster unable
+	// to fit the league's roster positions, with RosterWarning explaining
+	// why, instead of discarding them.
+	FlagIllegalRosters bool
+}
+
+// defaultFairnessThreshold is the fairness score trades must reach unless
+// configured otherwise.
+const defaultFairnessThreshold = 75.0
+
+// DefaultTradeConfig returns the configuration NewTradeService uses when no
+// options are given.
+func DefaultTradeConfig() TradeConfig {
+	return TradeConfig{
+		MaxPackageSize:     2,
+		FairnessThreshold:  defaultFairnessThreshold,
+		ValueBand:          15.0,
+		MinComplementScore: 2,
+		Workers:            4,
+	}
+}
+
+// withDefaults returns the config with zero fields replaced by their
+// defaults.
+func (c TradeConfig) withDefaults() TradeConfig {
+	defaults := DefaultTradeConfig()
+	if c.MaxPackageSize <= 0 {
+		c.MaxPackageSize = defaults.MaxPackageSize
+	}
+	if c.FairnessThreshold <= 0 {
+		c.FairnessThreshold = defaults.FairnessThreshold
+	}
+	if c.ValueBand <= 0 {
+		c.ValueBand = defaults.ValueBand
+	}
+	if c.Workers <= 0 {
+		c.Workers = defaults.Workers
+	}
+	if c.MinComplementScore == 0 {
+		c.MinComplementScore = defaults.MinComplementScore
+	}
+	return c
+}
+
+// TradeServiceOption configures a TradeService.
+type TradeServiceOption func(*TradeService)
+
+// WithTradeConfig replaces the default trade search configuration.
+func WithTradeConfig(config TradeConfig) TradeServiceOption {
+	return func(s *TradeService) {
+		s.config = config.withDefaults()
+	}
+}
+
+// WithTradeYahooClient lets the trade service submit saved proposals to
+// Yahoo.
+func WithTradeYahooClient(client *yahoo.Client) TradeServiceOption {
+	return func(s *TradeService) {
+		s.yahooClient = client
+	}
 }
 
 type TradeSuggestion struct {
@@ -23,7 +123,15 @@ type TradeSuggestion struct {
 	TeamBID          int
 	TeamBName        string
 	TeamBGives       []TradePlayer
+	TeamAGivesPicks  []DraftPick
+	TeamBGivesPicks  []DraftPick
 	FairnessScore    float64
+	// TargetsTradeBlock is set when team B gives up a player it has put on
+	// its trade block. Such suggestions are ranked first.
+	TargetsTradeBlock bool
+	// RosterWarning explains how the trade would leave a roster illegal.
+	// It is only set when TradeConfig.FlagIllegalRosters is.
+	RosterWarning    string
 	TeamABenefit     string
 	TeamBBenefit     string
 	Recommendation   string
@@ -37,11 +145,15 @@ type TradePlayer struct {
 }
 
 type TradeProposal struct {
+	// ID is set by SaveProposal.
+	ID               int
 	LeagueID         int
 	TeamAID          int
 	TeamBID          int
 	TeamAGives       []int
 	TeamBGives       []int
+	TeamAGivesPicks  []DraftPick
+	TeamBGivesPicks  []DraftPick
 	FairnessScore    float64
 	TeamAValueChange float64
 	TeamBValueChange float64
@@ -51,61 +163,85 @@ type TradeProposal struct {
 	Status           string
 }
 
-func NewTradeService(db *sql.DB, evaluator *EvaluationService, analysisService *AnalysisService) *TradeService {
-	return &TradeService{
+func NewTradeService(db *sql.DB, evaluator Evaluator, analysisService Analyzer, opts ...TradeServiceOption) Trader {
+	s := &TradeService{
 		db:              db,
 		evaluator:       evaluator,
 		analysisService: analysisService,
+		leagueRepo:      repository.NewLeagueRepository(db),
+		config:          DefaultTradeConfig(),
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-func (s *TradeService) GenerateSuggestions(ctx context.Context, teamID int, limit int) ([]*TradeSuggestion, error) {
+func (s *TradeService) GenerateSuggestions(ctx context.Context, teamID int, limit int) (_ []*TradeSuggestion, err error) {
+	ctx, span := startSpan(ctx, "TradeService.GenerateSuggestions",
+		attribute.Int("team.id", teamID),
+		attribute.Int("limit", limit),
+	)
+	defer func() { endSpan(span, err) }()
+
 	leagueID, err := s.getLeagueIDByTeam(ctx, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get league ID: %w", err)
 	}
 
-	userAnalysis, err := s.getUserTeamAnalysis(ctx, teamID)
+	userAnalysis, err := s.analysisService.GetTeamAnalysis(ctx, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user team analysis: %w", err)
 	}
+	if userAnalysis == nil {
+		return nil, fmt.Errorf("team %d has not been analyzed", teamID)
+	}
 
 	otherTeams, err := s.getOtherTeams(ctx, leagueID, teamID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get other teams: %w", err)
 	}
 
-	var suggestions []*TradeSuggestion
+	block, err := s.loadTradeBlock(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade block: %w", err)
+	}
 
-	for _, otherTeam := range otherTeams {
-		otherAnalysis, err := s.getUserTeamAnalysis(ctx, otherTeam.TeamID)
-		if err != nil {
-			continue
+	config := s.config.withDefaults()
+
+	// Gather each partner's candidate packages, then evaluate all of them on
+	// one worker pool. Results are kept in partner order.
+	searches := make([]*tradeSearch, len(otherTeams))
+	s.forEach(ctx, len(otherTeams), func(i int) {
+		otherAnalysis, err := s.analysisService.GetTeamAnalysis(ctx, otherTeams[i].TeamID)
+		if err != nil || otherAnalysis == nil {
+			return
 		}
 
 		complementScore := s.calculateComplementaryScore(userAnalysis, otherAnalysis)
-		if complementScore < 2 {
-			continue
+		if complementScore < config.MinComplementScore {
+			return
 		}
 
-		teamSuggestions, err := s.findTradesWithTeam(
-			ctx,
-			leagueID,
-			teamID,
-			otherTeam.TeamID,
-			userAnalysis,
-			otherAnalysis,
-		)
-		if err != nil {
-			continue
+		searches[i], _ = s.prepareTradeSearch(ctx, leagueID, teamID, otherTeams[i].TeamID, block)
+	})
+
+	var suggestions []*TradeSuggestion
+
+	for _, teamSuggestions := range s.evaluateSearches(ctx, searches) {
+		sortSuggestions(teamSuggestions)
+		if config.MaxSuggestionsPerTeam > 0 && len(teamSuggestions) > config.MaxSuggestionsPerTeam {
+			teamSuggestions = teamSuggestions[:config.MaxSuggestionsPerTeam]
 		}
 
 		suggestions = append(suggestions, teamSuggestions...)
 	}
 
-	sort.Slice(suggestions, func(i, j int) bool {
-		return suggestions[i].FairnessScore > suggestions[j].FairnessScore
-	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sortSuggestions(suggestions)
 
 	if len(suggestions) > limit {
 		suggestions = suggestions[:limit]
@@ -114,14 +250,16 @@ func (s *TradeService) GenerateSuggestions(ctx context.Context, teamID int, limi
 	return suggestions, nil
 }
 
-func (s *TradeService) findTradesWithTeam(
+// prepareTradeSearch finds the candidate packages between team A and team
+// B, dropping those that would leave a roster illegal unless they are only
+// to be flagged.
+func (s *TradeService) prepareTradeSearch(
 	ctx context.Context,
 	leagueID int,
 	teamAID int,
 	teamBID int,
-	teamAAnalysis *TeamAnalysis,
-	teamBAnalysis *TeamAnalysis,
-) ([]*TradeSuggestion, error) {
+	block tradeBlock,
+) (*tradeSearch, error) {
 	teamAPlayers, err := s.getRosterWithProjections(ctx, leagueID, teamAID)
 	if err != nil {
 		return nil, err
@@ -132,62 +270,81 @@ func (s *TradeService) findTradesWithTeam(
 		return nil, err
 	}
 
-	var suggestions []*TradeSuggestion
+	teamAPlayers = block.tradeable(teamAPlayers)
+	teamBPlayers = block.tradeable(teamBPlayers)
+
+	teamASize, err := s.getRosterSize(ctx, teamAID)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, playerA := range teamAPlayers {
-		for _, playerB := range teamBPlayers {
-			if !s.isGoodFit(playerA, playerB, teamAAnalysis, teamBAnalysis) {
-				continue
-			}
-
-			evaluation, err := s.evaluator.EvaluateTrade(
-				ctx,
-				leagueID,
-				teamAID,
-				[]int{playerB.PlayerID},
-				teamBID,
-				[]int{playerA.PlayerID},
-			)
-			if err != nil {
-				continue
-			}
-
-			if !evaluation.IsFair {
-				continue
-			}
-
-			teamAName, _ := s.getTeamName(ctx, teamAID)
-			teamBName, _ := s.getTeamName(ctx, teamBID)
-
-			suggestion := &TradeSuggestion{
-				LeagueID:  leagueID,
-				TeamAID:   teamAID,
-				TeamAName: teamAName,
-				TeamAGives: []TradePlayer{{
-					PlayerID:   playerA.PlayerID,
-					PlayerName: playerA.PlayerName,
-					Position:   playerA.Position,
-					FPG:        playerA.FPG,
-				}},
-				TeamBID:   teamBID,
-				TeamBName: teamBName,
-				TeamBGives: []TradePlayer{{
-					PlayerID:   playerB.PlayerID,
-					PlayerName: playerB.PlayerName,
-					Position:   playerB.Position,
-					FPG:        playerB.FPG,
-				}},
-				FairnessScore:  evaluation.FairnessScore,
-				TeamABenefit:   s.formatBenefit(evaluation.TeamAImpact),
-				TeamBBenefit:   s.formatBenefit(evaluation.TeamBImpact),
-				Recommendation: evaluation.Recommendation,
-			}
-
-			suggestions = append(suggestions, suggestion)
+	teamBSize, err := s.getRosterSize(ctx, teamBID)
+	if err != nil {
+		return nil, err
+	}
+
+	rosters, err := s.loadTradeRosters(ctx, leagueID, teamAID, teamBID)
+	if err != nil {
+		return nil, err
+	}
+
+	search := &tradeSearch{
+		leagueID: leagueID,
+		teamAID:  teamAID,
+		teamBID:  teamBID,
+	}
+	search.teamAName, _ = s.getTeamName(ctx, teamAID)
+	search.teamBName, _ = s.getTeamName(ctx, teamBID)
+
+	for _, pkg := range s.candidatePackages(teamAPlayers, teamBPlayers, teamASize, teamBSize, block.available, nil) {
+		warning := rosters.problem(pkg)
+		if warning != "" && !s.config.FlagIllegalRosters {
+			continue
 		}
+
+		search.candidates = append(search.candidates, tradeCandidate{pkg: pkg, warning: warning})
 	}
 
-	return suggestions, nil
+	return search, nil
+}
+
+// evaluateCandidate evaluates one candidate package, returning nil if the
+// trade is not fair enough to suggest.
+func (s *TradeService) evaluateCandidate(ctx context.Context, search *tradeSearch, candidate tradeCandidate) *TradeSuggestion {
+	pkg := candidate.pkg
+
+	evaluation, err := s.evaluator.EvaluateTrade(
+		ctx,
+		search.leagueID,
+		search.teamAID,
+		playerIDs(pkg.teamBGives),
+		search.teamBID,
+		playerIDs(pkg.teamAGives),
+	)
+	if err != nil {
+		return nil
+	}
+
+	if !s.isFair(evaluation) {
+		return nil
+	}
+
+	return &TradeSuggestion{
+		LeagueID:       search.leagueID,
+		TeamAID:        search.teamAID,
+		TeamAName:      search.teamAName,
+		TeamAGives:     tradePlayers(pkg.teamAGives),
+		TeamBID:        search.teamBID,
+		TeamBName:      search.teamBName,
+		TeamBGives:     tradePlayers(pkg.teamBGives),
+		FairnessScore:  evaluation.FairnessScore,
+		TeamABenefit:   s.formatBenefit(evaluation.TeamAImpact),
+		TeamBBenefit:   s.formatBenefit(evaluation.TeamBImpact),
+		Recommendation: evaluation.Recommendation,
+
+		TargetsTradeBlock: pkg.targetsBlock,
+		RosterWarning:     candidate.warning,
+	}
 }
 
 func (s *TradeService) calculateComplementaryScore(
@@ -220,21 +377,24 @@ func (s *TradeService) calculateComplementaryScore(
 	return score
 }
 
-func (s *TradeService) isGoodFit(
-	playerA RosterPlayer,
-	playerB RosterPlayer,
-	teamAAnalysis *TeamAnalysis,
-	teamBAnalysis *TeamAnalysis,
-) bool {
-	valueDiff := playerA.FPG - playerB.FPG
-	avgValue := (playerA.FPG + playerB.FPG) / 2.0
+// isFair reports whether the evaluation meets the configured fairness
+// threshold.
+func (s *TradeService) isFair(evaluation *TradeEvaluation) bool {
+	return evaluation.FairnessScore >= s.config.withDefaults().FairnessThreshold
+}
+
+// withinValueBand reports whether two sides of a trade are worth within band
+// percent of each other.
+func withinValueBand(valueA, valueB, band float64) bool {
+	valueDiff := valueA - valueB
+	avgValue := (valueA + valueB) / 2.0
 
 	if avgValue == 0 {
 		return false
 	}
 
 	percentDiff := (valueDiff / avgValue) * 100.0
-	if percentDiff < -15.0 || percentDiff > 15.0 {
+	if percentDiff < -band || percentDiff > band {
 		return false
 	}
 
@@ -264,12 +424,22 @@ func (s *TradeService) formatBenefit(impact TradeImpact) string {
 	return benefits
 }
 
+// tradeDetails is the trade_details JSON stored with a proposal.
+type tradeDetails struct {
+	TeamAGives      []int       `json:"team_a_gives"`
+	TeamBGives      []int       `json:"team_b_gives"`
+	TeamAGivesPicks []DraftPick `json:"team_a_gives_picks,omitempty"`
+	TeamBGivesPicks []DraftPick `json:"team_b_gives_picks,omitempty"`
+}
+
 func (s *TradeService) SaveProposal(ctx context.Context, proposal *TradeProposal) error {
-	tradeDetails := map[string][]int{
-		"team_a_gives": proposal.TeamAGives,
-		"team_b_gives": proposal.TeamBGives,
+	details := tradeDetails{
+		TeamAGives:      proposal.TeamAGives,
+		TeamBGives:      proposal.TeamBGives,
+		TeamAGivesPicks: proposal.TeamAGivesPicks,
+		TeamBGivesPicks: proposal.TeamBGivesPicks,
 	}
-	detailsJSON, err := json.Marshal(tradeDetails)
+	detailsJSON, err := json.Marshal(details)
 	if err != nil {
 		return err
 	}
@@ -282,7 +452,7 @@ func (s *TradeService) SaveProposal(ctx context.Context, proposal *TradeProposal
 		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	result, err := s.db.ExecContext(ctx, query,
+	id, err := dialect.For(s.db).InsertID(ctx, s.db, query,
 		proposal.LeagueID, proposal.TeamAID, proposal.TeamBID, string(detailsJSON),
 		proposal.FairnessScore, proposal.TeamAValueChange, proposal.TeamBValueChange,
 		proposal.TeamABenefits, proposal.TeamBBenefits, proposal.Source, proposal.Status,
@@ -290,9 +460,8 @@ func (s *TradeService) SaveProposal(ctx context.Context, proposal *TradeProposal
 	if err != nil {
 		return fmt.Errorf("failed to save proposal: %w", err)
 	}
-
-	_, err = result.LastInsertId()
-	return err
+	proposal.ID = int(id)
+	return nil
 }
 
 func (s *TradeService) GetProposalsByTeam(ctx context.Context, teamID int) ([]*TradeSuggestion, error) {
@@ -334,6 +503,12 @@ func (s *TradeService) GetProposalsByTeam(ctx context.Context, teamID int) ([]*T
 			TeamBBenefit:  teamBBenefits,
 		}
 
+		var details tradeDetails
+		if err := json.Unmarshal([]byte(detailsJSON), &details); err == nil {
+			suggestion.TeamAGivesPicks = details.TeamAGivesPicks
+			suggestion.TeamBGivesPicks = details.TeamBGivesPicks
+		}
+
 		suggestions = append(suggestions, suggestion)
 	}
 
@@ -383,58 +558,6 @@ func (s *TradeService) getRosterWithProjections(
 	return players, nil
 }
 
-func (s *TradeService) getUserTeamAnalysis(ctx context.Context, teamID int) (*TeamAnalysis, error) {
-	query := `
-		SELECT pts_zscore, reb_zscore, ast_zscore, stl_zscore, blk_zscore,
-		       to_zscore, fg_pct_zscore, ft_pct_zscore, tpm_zscore,
-		       weakest_cat_1, weakest_cat_2, weakest_cat_3,
-		       strongest_cat_1, strongest_cat_2, strongest_cat_3
-		FROM team_analysis
-		WHERE team_id = ?
-	`
-
-	var analysis TeamAnalysis
-	analysis.TeamID = teamID
-	analysis.CategoryScores = make(map[string]float64)
-
-	var weak1, weak2, weak3, strong1, strong2, strong3 string
-	var pts, reb, ast, stl, blk, to, fgPct, ftPct, tpm float64
-
-	err := s.db.QueryRowContext(ctx, query, teamID).Scan(
-		&pts, &reb, &ast, &stl, &blk, &to, &fgPct, &ftPct, &tpm,
-		&weak1, &weak2, &weak3,
-		&strong1, &strong2, &strong3,
-	)
-	if err == nil {
-		analysis.CategoryScores["PTS"] = pts
-		analysis.CategoryScores["REB"] = reb
-		analysis.CategoryScores["AST"] = ast
-		analysis.CategoryScores["STL"] = stl
-		analysis.CategoryScores["BLK"] = blk
-		analysis.CategoryScores["TO"] = to
-		analysis.CategoryScores["FG%"] = fgPct
-		analysis.CategoryScores["FT%"] = ftPct
-		analysis.CategoryScores["3PM"] = tpm
-	}
-	if err != nil {
-		return nil, err
-	}
-
-	analysis.WeakCategories = []CategoryScore{
-		{Category: weak1, ZScore: analysis.CategoryScores[weak1]},
-		{Category: weak2, ZScore: analysis.CategoryScores[weak2]},
-		{Category: weak3, ZScore: analysis.CategoryScores[weak3]},
-	}
-
-	analysis.StrongCategories = []CategoryScore{
-		{Category: strong1, ZScore: analysis.CategoryScores[strong1]},
-		{Category: strong2, ZScore: analysis.CategoryScores[strong2]},
-		{Category: strong3, ZScore: analysis.CategoryScores[strong3]},
-	}
-
-	return &analysis, nil
-}
-
 func (s *TradeService) getLeagueIDByTeam(ctx context.Context, teamID int) (int, error) {
 	query := `SELECT league_id FROM fantasy_teams WHERE id = ?`
 	var leagueID int
@@ -476,6 +599,13 @@ func (s *TradeService) getOtherTeams(ctx context.Context, leagueID int, excludeT
 	return teams, nil
 }
 
+func (s *TradeService) getRosterSize(ctx context.Context, teamID int) (int, error) {
+	query := `SELECT COUNT(*) FROM fantasy_rosters WHERE team_id = ?`
+	var size int
+	err := s.db.QueryRowContext(ctx, query, teamID).Scan(&size)
+	return size, err
+}
+
 func (s *TradeService) getTeamName(ctx context.Context, teamID int) (string, error) {
 	query := `SELECT team_name FROM fantasy_teams WHERE id = ?`
 	var name string
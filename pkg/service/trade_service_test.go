@@ -121,8 +121,8 @@ func TestCalculateComplementaryScore(t *testing.T) {
 	}
 }
 
-func TestIsGoodFit(t *testing.T) {
-	service := &TradeService{}
+func TestWithinValueBand(t *testing.T) {
+	band := DefaultTradeConfig().ValueBand
 
 	tests := []struct {
 		name         string
@@ -170,12 +170,7 @@ func TestIsGoodFit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := service.isGoodFit(
-				tt.playerA,
-				tt.playerB,
-				&TeamAnalysis{},
-				&TeamAnalysis{},
-			)
+			result := withinValueBand(tt.playerA.FPG, tt.playerB.FPG, band)
 
 			if result != tt.expectedFit {
 				valueDiff := math.Abs(tt.playerA.FPG - tt.playerB.FPG)
@@ -332,12 +327,12 @@ func TestOneForOneTradeLogic(t *testing.T) {
 		{PlayerID: 4, PlayerName: "Player B2", FPG: 30.0, Position: "C"},
 	}
 
-	service := &TradeService{}
+	band := DefaultTradeConfig().ValueBand
 
 	validTrades := 0
 	for _, playerA := range playersTeamA {
 		for _, playerB := range playersTeamB {
-			if service.isGoodFit(playerA, playerB, &TeamAnalysis{}, &TeamAnalysis{}) {
+			if withinValueBand(playerA.FPG, playerB.FPG, band) {
 				validTrades++
 			}
 		}
@@ -353,3 +348,48 @@ func TestOneForOneTradeLogic(t *testing.T) {
 		t.Logf("Found %d valid trades (expected ~%d)", validTrades, expectedValidTrades)
 	}
 }
+
+func TestTradeConfigDefaults(t *testing.T) {
+	config := TradeConfig{ValueBand: 25.0, MinComplementScore: -1}.withDefaults()
+
+	if config.ValueBand != 25.0 {
+		t.Errorf("ValueBand = %.1f, want 25.0", config.ValueBand)
+	}
+	if config.MinComplementScore != -1 {
+		t.Errorf("MinComplementScore = %d, want -1", config.MinComplementScore)
+	}
+	if config.FairnessThreshold != 75.0 {
+		t.Errorf("FairnessThreshold = %.1f, want the default 75.0", config.FairnessThreshold)
+	}
+	if config.MaxPackageSize != 2 {
+		t.Errorf("MaxPackageSize = %d, want the default 2", config.MaxPackageSize)
+	}
+
+	service := NewTradeService(nil, nil, nil).(*TradeService)
+	if service.config != DefaultTradeConfig() {
+		t.Errorf("NewTradeService config = %+v, want defaults", service.config)
+	}
+}
+
+func TestTradeConfigThresholds(t *testing.T) {
+	strict := NewTradeService(nil, nil, nil, WithTradeConfig(TradeConfig{FairnessThreshold: 90.0, ValueBand: 5.0})).(*TradeService)
+	loose := NewTradeService(nil, nil, nil, WithTradeConfig(TradeConfig{FairnessThreshold: 60.0, ValueBand: 30.0})).(*TradeService)
+
+	playerA := RosterPlayer{FPG: 50.0}
+	playerB := RosterPlayer{FPG: 40.0}
+
+	if withinValueBand(playerA.FPG, playerB.FPG, strict.config.ValueBand) {
+		t.Error("A 22% gap should fall outside a 5% value band")
+	}
+	if !withinValueBand(playerA.FPG, playerB.FPG, loose.config.ValueBand) {
+		t.Error("A 22% gap should fall inside a 30% value band")
+	}
+
+	evaluation := &TradeEvaluation{FairnessScore: 80.0}
+	if strict.isFair(evaluation) {
+		t.Error("A fairness score of 80 should not pass a threshold of 90")
+	}
+	if !loose.isFair(evaluation) {
+		t.Error("A fairness score of 80 should pass a threshold of 60")
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// SubmitToYahoo offers a saved proposal on Yahoo, from team A to team B, and
+// marks it pending. The Yahoo transaction key of the pending trade is saved
+// in the trade_proposals table:
+//
+//	ALTER TABLE trade_proposals ADD COLUMN yahoo_transaction_key TEXT;
+//
+// A proposal can only be submitted once, and proposals with draft picks
+// cannot be submitted at all, since Yahoo trades only carry players.
+func (s *TradeService) SubmitToYahoo(ctx context.Context, suggestionID int) (_ *yahoo.Transaction, err error) {
+	ctx, span := startSpan(ctx, "TradeService.SubmitToYahoo",
+		attribute.Int("proposal.id", suggestionID),
+	)
+	defer func() { endSpan(span, err) }()
+
+	if s.yahooClient == nil {
+		return nil, fmt.Errorf("trade service has no Yahoo client")
+	}
+
+	query := `
+		SELECT tp.team_a_id, tp.team_b_id, tp.trade_details,
+		       COALESCE(tp.yahoo_transaction_key, ''),
+		       fl.yahoo_game_key, fl.yahoo_league_id
+		FROM trade_proposals tp
+		JOIN fantasy_leagues fl ON tp.league_id = fl.id
+		WHERE tp.id = ?
+	`
+
+	var teamAID, teamBID int
+	var detailsJSON, transactionKey, gameKey, yahooLeagueID string
+	err = s.db.QueryRowContext(ctx, query, suggestionID).Scan(
+		&teamAID, &teamBID, &detailsJSON, &transactionKey, &gameKey, &yahooLeagueID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposal %d: %w", suggestionID, err)
+	}
+	if transactionKey != "" {
+		return nil, fmt.Errorf("proposal %d was already submitted as %s", suggestionID, transactionKey)
+	}
+
+	var details tradeDetails
+	if err := json.Unmarshal([]byte(detailsJSON), &details); err != nil {
+		return nil, fmt.Errorf("failed to decode proposal %d: %w", suggestionID, err)
+	}
+
+	teamAKey, err := s.getYahooTeamKey(ctx, teamAID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team A key: %w", err)
+	}
+
+	teamBKey, err := s.getYahooTeamKey(ctx, teamBID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team B key: %w", err)
+	}
+
+	playerKeys, err := s.getYahooPlayerKeys(ctx, append(append([]int(nil), details.TeamAGives...), details.TeamBGives...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get player keys: %w", err)
+	}
+
+	proposal, err := yahooTradeProposal(details, teamAKey, teamBKey, playerKeys)
+	if err != nil {
+		return nil, fmt.Errorf("proposal %d: %w", suggestionID, err)
+	}
+
+	leagueKey := fmt.Sprintf("%s.l.%s", gameKey, yahooLeagueID)
+	transaction, err := s.yahooClient.ProposeTrade(ctx, leagueKey, proposal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to propose trade: %w", err)
+	}
+
+	// Another submission of the same proposal may have finished while this
+	// one waited on Yahoo; only the first records its transaction key.
+	update := `
+		UPDATE trade_proposals
+		SET yahoo_transaction_key = ?, status = 'pending'
+		WHERE id = ? AND yahoo_transaction_key IS NULL
+	`
+	result, err := s.db.ExecContext(ctx, update, transaction.TransactionKey, suggestionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save transaction key %s: %w", transaction.TransactionKey, err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to save transaction key %s: %w", transaction.TransactionKey, err)
+	}
+	if updated == 0 {
+		return nil, fmt.Errorf("proposal %d was already submitted", suggestionID)
+	}
+
+	return transaction, nil
+}
+
+// yahooTradeProposal translates a proposal's internal player IDs into the
+// Yahoo trade team A offers team B.
+func yahooTradeProposal(details tradeDetails, teamAKey, teamBKey string, playerKeys map[int]string) (yahoo.TradeProposal, error) {
+	if len(details.TeamAGivesPicks) > 0 || len(details.TeamBGivesPicks) > 0 {
+		return yahoo.TradeProposal{}, fmt.Errorf("trades with draft picks cannot be submitted to Yahoo")
+	}
+
+	keys := func(ids []int) ([]string, error) {
+		result := make([]string, 0, len(ids))
+		for _, id := range ids {
+			key, ok := playerKeys[id]
+			if !ok || key == "" {
+				return nil, fmt.Errorf("player %d has no Yahoo player key", id)
+			}
+			result = append(result, key)
+		}
+		return result, nil
+	}
+
+	send, err := keys(details.TeamAGives)
+	if err != nil {
+		return yahoo.TradeProposal{}, err
+	}
+	receive, err := keys(details.TeamBGives)
+	if err != nil {
+		return yahoo.TradeProposal{}, err
+	}
+
+	return yahoo.TradeProposal{
+		TraderTeamKey:     teamAKey,
+		TradeeTeamKey:     teamBKey,
+		SendPlayerKeys:    send,
+		ReceivePlayerKeys: receive,
+	}, nil
+}
+
+func (s *TradeService) getYahooTeamKey(ctx context.Context, teamID int) (string, error) {
+	var key string
+	err := s.db.QueryRowContext(ctx, `SELECT yahoo_team_key FROM fantasy_teams WHERE id = ?`, teamID).Scan(&key)
+	return key, err
+}
+
+func (s *TradeService) getYahooPlayerKeys(ctx context.Context, playerIDs []int) (map[int]string, error) {
+	keys := make(map[int]string, len(playerIDs))
+	for _, id := range playerIDs {
+		var key string
+		err := s.db.QueryRowContext(ctx, `SELECT yahoo_player_key FROM players WHERE id = ?`, id).Scan(&key)
+		if err != nil {
+			return nil, fmt.Errorf("player %d: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return keys, nil
+}
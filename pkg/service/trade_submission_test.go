@@ -0,0 +1,40 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestYahooTradeProposal(t *testing.T) {
+	playerKeys := map[int]string{
+		1: "454.p.1001",
+		2: "454.p.1002",
+		3: "454.p.1003",
+	}
+	details := tradeDetails{TeamAGives: []int{1, 2}, TeamBGives: []int{3}}
+
+	proposal, err := yahooTradeProposal(details, "454.l.1.t.1", "454.l.1.t.2", playerKeys)
+	if err != nil {
+		t.Fatalf("yahooTradeProposal failed: %v", err)
+	}
+
+	if proposal.TraderTeamKey != "454.l.1.t.1" || proposal.TradeeTeamKey != "454.l.1.t.2" {
+		t.Errorf("Team keys = %s, %s", proposal.TraderTeamKey, proposal.TradeeTeamKey)
+	}
+	if !reflect.DeepEqual(proposal.SendPlayerKeys, []string{"454.p.1001", "454.p.1002"}) {
+		t.Errorf("SendPlayerKeys = %v", proposal.SendPlayerKeys)
+	}
+	if !reflect.DeepEqual(proposal.ReceivePlayerKeys, []string{"454.p.1003"}) {
+		t.Errorf("ReceivePlayerKeys = %v", proposal.ReceivePlayerKeys)
+	}
+
+	details.TeamBGives = []int{4}
+	if _, err := yahooTradeProposal(details, "454.l.1.t.1", "454.l.1.t.2", playerKeys); err == nil {
+		t.Error("Expected an error for a player without a Yahoo key")
+	}
+
+	details = tradeDetails{TeamAGives: []int{1}, TeamBGives: []int{3}, TeamBGivesPicks: []DraftPick{{Year: 2027, Round: 1}}}
+	if _, err := yahooTradeProposal(details, "454.l.1.t.1", "454.l.1.t.2", playerKeys); err == nil {
+		t.Error("Expected an error for a trade with draft picks")
+	}
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// categoryNames are the nine categories the services analyze.
+var categoryNames = []string{"PTS", "REB", "AST", "STL", "BLK", "TO", "FG%", "FT%", "3PM"}
+
+// categoryDeclineTolerance is how far, in percent, a category the user did
+// not offer to give up may drop before a trade is rejected.
+const categoryDeclineTolerance = 5.0
+
+// FindTradesForCategories finds trades that improve every category in want
+// while only giving ground in the categories listed in giveUp, e.g. want
+// REB and BLK, give up AST. Suggestions are ranked by how much the wanted
+// categories improve, relative to the team's current totals.
+func (s *TradeService) FindTradesForCategories(ctx context.Context, teamID int, want []string, giveUp []string) (_ []*TradeSuggestion, err error) {
+	ctx, span := startSpan(ctx, "TradeService.FindTradesForCategories",
+		attribute.Int("team.id", teamID),
+		attribute.StringSlice("categories.want", want),
+		attribute.StringSlice("categories.give_up", giveUp),
+	)
+	defer func() { endSpan(span, err) }()
+
+	want, giveUp, err = normalizeCategories(want, giveUp)
+	if err != nil {
+		return nil, err
+	}
+
+	leagueID, err := s.getLeagueIDByTeam(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get league ID: %w", err)
+	}
+
+	current, err := s.evaluator.GetTeamCategoryTotals(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team totals: %w", err)
+	}
+
+	otherTeams, err := s.getOtherTeams(ctx, leagueID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get other teams: %w", err)
+	}
+
+	teamPlayers, err := s.getRosterWithProjections(ctx, leagueID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster: %w", err)
+	}
+	teamSize, err := s.getRosterSize(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster size: %w", err)
+	}
+	teamName, _ := s.getTeamName(ctx, teamID)
+
+	block, err := s.loadTradeBlock(ctx, leagueID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trade block: %w", err)
+	}
+	teamPlayers = block.tradeable(teamPlayers)
+
+	type rankedSuggestion struct {
+		suggestion *TradeSuggestion
+		score      float64
+	}
+	var ranked []rankedSuggestion
+
+	for _, otherTeam := range otherTeams {
+		otherPlayers, err := s.getRosterWithProjections(ctx, leagueID, otherTeam.TeamID)
+		if err != nil {
+			continue
+		}
+		otherSize, err := s.getRosterSize(ctx, otherTeam.TeamID)
+		if err != nil {
+			continue
+		}
+
+		otherPlayers = block.tradeable(otherPlayers)
+		projections, err := s.rosterProjections(ctx, leagueID, teamPlayers, otherPlayers)
+		if err != nil {
+			continue
+		}
+
+		matches := func(pkg tradePackage) bool {
+			_, ok := s.matchCategories(
+				current,
+				lookupProjections(projections, pkg.teamAGives),
+				lookupProjections(projections, pkg.teamBGives),
+				want,
+				giveUp,
+			)
+			return ok
+		}
+		candidates := s.candidatePackages(teamPlayers, otherPlayers, teamSize, otherSize, block.available, matches)
+		if len(candidates) == 0 {
+			continue
+		}
+
+		rosters, err := s.loadTradeRosters(ctx, leagueID, teamID, otherTeam.TeamID)
+		if err != nil {
+			continue
+		}
+
+		for _, pkg := range candidates {
+			warning := rosters.problem(pkg)
+			if warning != "" && !s.config.FlagIllegalRosters {
+				continue
+			}
+
+			score, ok := s.matchCategories(
+				current,
+				lookupProjections(projections, pkg.teamAGives),
+				lookupProjections(projections, pkg.teamBGives),
+				want,
+				giveUp,
+			)
+			if !ok {
+				continue
+			}
+
+			evaluation, err := s.evaluator.EvaluateTrade(
+				ctx,
+				leagueID,
+				teamID,
+				playerIDs(pkg.teamBGives),
+				otherTeam.TeamID,
+				playerIDs(pkg.teamAGives),
+			)
+			if err != nil || !s.isFair(evaluation) {
+				continue
+			}
+
+			ranked = append(ranked, rankedSuggestion{
+				suggestion: &TradeSuggestion{
+					LeagueID:       leagueID,
+					TeamAID:        teamID,
+					TeamAName:      teamName,
+					TeamAGives:     tradePlayers(pkg.teamAGives),
+					TeamBID:        otherTeam.TeamID,
+					TeamBName:      otherTeam.TeamName,
+					TeamBGives:     tradePlayers(pkg.teamBGives),
+					FairnessScore:  evaluation.FairnessScore,
+					TeamABenefit:   s.formatBenefit(evaluation.TeamAImpact),
+					TeamBBenefit:   s.formatBenefit(evaluation.TeamBImpact),
+					Recommendation: evaluation.Recommendation,
+
+					TargetsTradeBlock: pkg.targetsBlock,
+					RosterWarning:     warning,
+				},
+				score: score,
+			})
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+
+	suggestions := make([]*TradeSuggestion, len(ranked))
+	for i, r := range ranked {
+		suggestions[i] = r.suggestion
+	}
+	return suggestions, nil
+}
+
+// normalizeCategories upper-cases the category names and checks that they
+// are known, that want is not empty and that no category is in both lists.
+func normalizeCategories(want, giveUp []string) ([]string, []string, error) {
+	normalize := func(names []string) ([]string, error) {
+		result := make([]string, 0, len(names))
+		for _, name := range names {
+			name = strings.ToUpper(strings.TrimSpace(name))
+			if !contains(categoryNames, name) {
+				return nil, fmt.Errorf("unknown category %q", name)
+			}
+			result = append(result, name)
+		}
+		return result, nil
+	}
+
+	want, err := normalize(want)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(want) == 0 {
+		return nil, nil, fmt.Errorf("at least one wanted category is required")
+	}
+	giveUp, err = normalize(giveUp)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, cat := range giveUp {
+		if contains(want, cat) {
+			return nil, nil, fmt.Errorf("category %s is both wanted and given up", cat)
+		}
+	}
+	return want, giveUp, nil
+}
+
+// matchCategories reports whether trading gives for gets improves every
+// wanted category without dropping any category outside giveUp by more than
+// categoryDeclineTolerance. The score is the summed percent gain of the
+// wanted categories.
+func (s *TradeService) matchCategories(
+	current TeamCategoryTotals,
+	gives []PlayerProjection,
+	gets []PlayerProjection,
+	want []string,
+	giveUp []string,
+) (float64, bool) {
+	gains := s.categoryGains(current, gets, gives)
+
+	score := 0.0
+	for _, cat := range want {
+		if gains[cat] <= 0 {
+			return 0, false
+		}
+		score += gains[cat]
+	}
+
+	for cat, gain := range gains {
+		if contains(want, cat) || contains(giveUp, cat) {
+			continue
+		}
+		if gain < -categoryDeclineTolerance {
+			return 0, false
+		}
+	}
+
+	return score, true
+}
+
+// categoryGains returns the percent change of each category after the
+// trade, signed so that positive is always an improvement. Percentage
+// categories compare the average of the players coming in with the average
+// of those going out.
+func (s *TradeService) categoryGains(current TeamCategoryTotals, playersIn, playersOut []PlayerProjection) map[string]float64 {
+	gains := make(map[string]float64, len(categoryNames))
+	for _, change := range s.evaluator.CategoryChanges(current, playersIn, playersOut) {
+		if change.Category == "TO" {
+			gains[change.Category] = -change.PercentChange
+		} else {
+			gains[change.Category] = change.PercentChange
+		}
+	}
+
+	percentGain := func(before float64, value func(PlayerProjection) float64) float64 {
+		if before == 0 || len(playersIn) == 0 || len(playersOut) == 0 {
+			return 0
+		}
+		avg := func(players []PlayerProjection) float64 {
+			total := 0.0
+			for _, p := range players {
+				total += value(p)
+			}
+			return total / float64(len(players))
+		}
+		return (avg(playersIn) - avg(playersOut)) / before * 100.0
+	}
+	gains["FG%"] = percentGain(current.FGPct, func(p PlayerProjection) float64 { return p.FGPct })
+	gains["FT%"] = percentGain(current.FTPct, func(p PlayerProjection) float64 { return p.FTPct })
+
+	return gains
+}
+
+// rosterProjections loads the category projections of every player in the
+// rosters, by player ID.
+func (s *TradeService) rosterProjections(ctx context.Context, leagueID int, rosters ...[]RosterPlayer) (map[int]PlayerProjection, error) {
+	var ids []int
+	for _, roster := range rosters {
+		ids = append(ids, playerIDs(roster)...)
+	}
+
+	projections, err := s.evaluator.GetPlayerProjections(ctx, leagueID, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]PlayerProjection, len(projections))
+	for _, p := range projections {
+		byID[p.PlayerID] = p
+	}
+	return byID, nil
+}
+
+func lookupProjections(projections map[int]PlayerProjection, players []RosterPlayer) []PlayerProjection {
+	result := make([]PlayerProjection, 0, len(players))
+	for _, p := range players {
+		if projection, ok := projections[p.PlayerID]; ok {
+			result = append(result, projection)
+		}
+	}
+	return result
+}
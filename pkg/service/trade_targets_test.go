@@ -0,0 +1,112 @@
+package service
+
+import "testing"
+
+func TestNormalizeCategories(t *testing.T) {
+	want, giveUp, err := normalizeCategories([]string{"reb", " BLK"}, []string{"ast"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(want) != 2 || want[0] != "REB" || want[1] != "BLK" {
+		t.Errorf("want = %v", want)
+	}
+	if len(giveUp) != 1 || giveUp[0] != "AST" {
+		t.Errorf("giveUp = %v", giveUp)
+	}
+
+	invalid := []struct {
+		name   string
+		want   []string
+		giveUp []string
+	}{
+		{"No wanted categories", nil, []string{"AST"}},
+		{"Unknown category", []string{"DUNKS"}, nil},
+		{"Wanted and given up", []string{"REB"}, []string{"reb"}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := normalizeCategories(tt.want, tt.giveUp); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
+
+func TestMatchCategories(t *testing.T) {
+	service := &TradeService{evaluator: &EvaluationService{}}
+
+	current := TeamCategoryTotals{
+		PTS: 100.0, REB: 40.0, AST: 30.0, STL: 8.0, BLK: 4.0,
+		TO: 15.0, FGPct: 0.46, FTPct: 0.78, TPM: 12.0,
+	}
+
+	guard := PlayerProjection{PTS: 18.0, REB: 3.0, AST: 7.0, STL: 1.2, BLK: 0.2, TO: 2.5, FGPct: 0.45, FTPct: 0.85, TPM: 2.0}
+	big := PlayerProjection{PTS: 17.0, REB: 10.0, AST: 2.0, STL: 1.1, BLK: 1.8, TO: 1.8, FGPct: 0.55, FTPct: 0.83, TPM: 1.8}
+	scorer := PlayerProjection{PTS: 10.0, REB: 11.0, AST: 1.0, STL: 0.5, BLK: 2.0, TO: 1.0, FGPct: 0.58, FTPct: 0.60, TPM: 0.0}
+
+	tests := []struct {
+		name   string
+		gives  PlayerProjection
+		gets   PlayerProjection
+		want   []string
+		giveUp []string
+		match  bool
+	}{
+		{
+			name:   "Guard for big gives up assists only",
+			gives:  guard,
+			gets:   big,
+			want:   []string{"REB", "BLK"},
+			giveUp: []string{"AST"},
+			match:  true,
+		},
+		{
+			name:   "Declines outside the give-up list",
+			gives:  guard,
+			gets:   scorer,
+			want:   []string{"REB", "BLK"},
+			giveUp: []string{"AST"},
+			match:  false,
+		},
+		{
+			name:   "Wanted category does not improve",
+			gives:  big,
+			gets:   guard,
+			want:   []string{"REB"},
+			giveUp: []string{"BLK"},
+			match:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, ok := service.matchCategories(
+				current,
+				[]PlayerProjection{tt.gives},
+				[]PlayerProjection{tt.gets},
+				tt.want,
+				tt.giveUp,
+			)
+			if ok != tt.match {
+				t.Errorf("match = %v, want %v", ok, tt.match)
+			}
+			if ok && score <= 0 {
+				t.Errorf("Expected a positive score, got %.2f", score)
+			}
+		})
+	}
+}
+
+func TestCategoryGainsTurnovers(t *testing.T) {
+	service := &TradeService{evaluator: &EvaluationService{}}
+
+	current := TeamCategoryTotals{TO: 10.0}
+	gains := service.categoryGains(current,
+		[]PlayerProjection{{TO: 1.0}},
+		[]PlayerProjection{{TO: 3.0}},
+	)
+
+	if gains["TO"] != 20.0 {
+		t.Errorf("Fewer turnovers should be a 20%% gain, got %.2f", gains["TO"])
+	}
+}
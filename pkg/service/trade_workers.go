@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"sync"
+)
+
+// tradeSearch holds the candidate packages between the user's team (team A)
+// and one trade partner (team B), ready to be evaluated.
+type tradeSearch struct {
+	leagueID   int
+	teamAID    int
+	teamAName  string
+	teamBID    int
+	teamBName  string
+	candidates []tradeCandidate
+}
+
+// tradeCandidate is a package along with the roster warning it would carry
+// as a suggestion.
+type tradeCandidate struct {
+	pkg     tradePackage
+	warning string
+}
+
+// evaluateSearches evaluates every candidate of every search on the worker
+// pool and returns the fair ones as suggestions, one slice per search in the
+// order of searches. Within a slice, suggestions keep their candidates'
+// order no matter which worker finishes first. Nil searches yield no
+// suggestions.
+func (s *TradeService) evaluateSearches(ctx context.Context, searches []*tradeSearch) [][]*TradeSuggestion {
+	type job struct {
+		search    int
+		candidate int
+	}
+
+	var jobs []job
+	evaluated := make([][]*TradeSuggestion, len(searches))
+	for i, search := range searches {
+		if search == nil {
+			continue
+		}
+		evaluated[i] = make([]*TradeSuggestion, len(search.candidates))
+		for j := range search.candidates {
+			jobs = append(jobs, job{search: i, candidate: j})
+		}
+	}
+
+	s.forEach(ctx, len(jobs), func(k int) {
+		search := searches[jobs[k].search]
+		candidate := search.candidates[jobs[k].candidate]
+		evaluated[jobs[k].search][jobs[k].candidate] = s.evaluateCandidate(ctx, search, candidate)
+	})
+
+	results := make([][]*TradeSuggestion, len(searches))
+	for i, suggestions := range evaluated {
+		for _, suggestion := range suggestions {
+			if suggestion != nil {
+				results[i] = append(results[i], suggestion)
+			}
+		}
+	}
+	return results
+}
+
+// forEach calls fn with every index below n on up to TradeConfig.Workers
+// goroutines, as forEachIndex does.
+func (s *TradeService) forEach(ctx context.Context, n int, fn func(i int)) {
+	forEachIndex(ctx, n, s.config.withDefaults().Workers, fn)
+}
+
+// forEachIndex calls fn with every index below n on up to workers
+// goroutines and waits for them to finish. fn must only write to state
+// owned by its index. Indexes not yet handed out when ctx is done are
+// skipped.
+func forEachIndex(ctx context.Context, n, workers int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+send:
+	for i := 0; i < n && ctx.Err() == nil; i++ {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(indexes)
+	wg.Wait()
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestForEach(t *testing.T) {
+	service := &TradeService{config: TradeConfig{Workers: 3}}
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	visited := make([]bool, 20)
+
+	service.forEach(context.Background(), len(visited), func(i int) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+		visited[i] = true
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+	})
+
+	for i, ok := range visited {
+		if !ok {
+			t.Errorf("Index %d was not visited", i)
+		}
+	}
+	if maxRunning > 3 {
+		t.Errorf("Ran %d at once, want at most 3", maxRunning)
+	}
+}
+
+func TestForEachCanceled(t *testing.T) {
+	service := &TradeService{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	var mu sync.Mutex
+	service.forEach(ctx, 100, func(int) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+	})
+
+	if calls != 0 {
+		t.Errorf("Expected a canceled context to stop the work, got %d calls", calls)
+	}
+}
+
+func TestEvaluateSearchesWithoutCandidates(t *testing.T) {
+	service := &TradeService{}
+	searches := []*tradeSearch{nil, {teamBID: 2}, nil}
+
+	results := service.evaluateSearches(context.Background(), searches)
+
+	if len(results) != len(searches) {
+		t.Fatalf("Expected one result per search, got %d", len(results))
+	}
+	for i, suggestions := range results {
+		if len(suggestions) != 0 {
+			t.Errorf("Search %d: expected no suggestions, got %d", i, len(suggestions))
+		}
+	}
+}
+
+func TestForEachIndexWithoutWorkers(t *testing.T) {
+	visited := make([]bool, 5)
+	forEachIndex(context.Background(), len(visited), 0, func(i int) {
+		visited[i] = true
+	})
+
+	for i, ok := range visited {
+		if !ok {
+			t.Errorf("Index %d was not visited", i)
+		}
+	}
+}
@@ -6,21 +6,44 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"sort"
+	"strings"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type ValuationService struct {
-	db *sql.DB
+	db         *sql.DB
+	playerRepo *repository.PlayerRepository
+	leagueRepo *repository.LeagueRepository
+	// punts are the categories left out of category-league z-score totals.
+	punts []string
 }
 
 type PlayerValue struct {
 	PlayerID         int
 	LeagueID         int
+	Position         string
 	FPG              float64
 	ZScore           float64
+	// PositionRank is the player's rank among players sharing their primary
+	// position, and PositionRanks their rank at each eligible position.
 	PositionRank     int
+	PositionRanks    map[string]int
 	OverallRank      int
+	// ScarcityMultiplier is the multiplier of the player's primary
+	// position. Overall and position ranks are taken on values adjusted by
+	// it; FPG and ZScore are left unadjusted.
 	ScarcityMultiplier float64
 	Projections      CategoryProjections
+	// FGA and FTA are field goal and free throw attempts per game, which
+	// weight FG% and FT% in category z-scores.
+	FGA float64
+	FTA float64
+	// CategoryZScores holds the player's z-score in each category. It is
+	// only filled in for category leagues, where ZScore is their sum.
+	CategoryZScores  CategoryProjections
 }
 
 type CategoryProjections struct {
@@ -47,11 +70,22 @@ type ScoringSettings struct {
 	FTPct float64 `json:"FT%"`
 }
 
-func NewValuationService(db *sql.DB) *ValuationService {
-	return &ValuationService{db: db}
+func NewValuationService(db *sql.DB, opts ...ValuationServiceOption) Valuator {
+	s := &ValuationService{
+		db:         db,
+		playerRepo: repository.NewPlayerRepository(db),
+		leagueRepo: repository.NewLeagueRepository(db),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-func (s *ValuationService) CalculateAllPlayerValues(ctx context.Context, leagueID int) error {
+func (s *ValuationService) CalculateAllPlayerValues(ctx context.Context, leagueID int) (err error) {
+	ctx, span := startSpan(ctx, "ValuationService.CalculateAllPlayerValues", attribute.Int("league.id", leagueID))
+	defer func() { endSpan(span, err) }()
+
 	league, err := s.getLeague(ctx, leagueID)
 	if err != nil {
 		return fmt.Errorf("failed to get league: %w", err)
@@ -67,6 +101,12 @@ func (s *ValuationService) CalculateAllPlayerValues(ctx context.Context, leagueI
 		return fmt.Errorf("failed to get players: %w", err)
 	}
 
+	imported, err := s.getImportedProjections(ctx, leagueID)
+	if err != nil {
+		return fmt.Errorf("failed to get imported projections: %w", err)
+	}
+	applyImportedProjections(players, imported)
+
 	var playerValues []PlayerValue
 	for _, player := range players {
 		value := s.calculatePlayerValue(player, scoringSettings)
@@ -74,17 +114,51 @@ func (s *ValuationService) CalculateAllPlayerValues(ctx context.Context, leagueI
 		playerValues = append(playerValues, value)
 	}
 
-	if err := s.calculateZScores(playerValues); err != nil {
+	// Category leagues rank players on their summed category z-scores;
+	// points leagues on the z-score of their fantasy points.
+	categoryLeague := evaluationModeFor(league.ScoringType) == EvaluationModeCategories
+	if categoryLeague {
+		poolSize, err := s.getDraftablePoolSize(ctx, leagueID)
+		if err != nil {
+			return fmt.Errorf("failed to get roster positions: %w", err)
+		}
+		s.calculateCategoryZScores(playerValues, poolSize)
+	} else if err := s.calculateZScores(playerValues); err != nil {
 		return fmt.Errorf("failed to calculate z-scores: %w", err)
 	}
 
-	s.applyPositionScarcity(ctx, playerValues)
+	depths, err := s.getPositionDepths(ctx, leagueID)
+	if err != nil {
+		return fmt.Errorf("failed to get roster positions: %w", err)
+	}
+	scarcity := s.calculatePositionScarcity(playerValues, depths)
+	s.applyPositionScarcity(playerValues, scarcity)
 
-	s.rankPlayers(playerValues)
+	rankValue := func(p PlayerValue) float64 { return scarcityAdjusted(p.FPG, p.ScarcityMultiplier) }
+	if categoryLeague {
+		rankValue = func(p PlayerValue) float64 { return scarcityAdjusted(p.ZScore, p.ScarcityMultiplier) }
+	}
+	s.rankPlayersBy(playerValues, rankValue)
 
-	if err := s.savePlayerProjections(ctx, playerValues); err != nil {
+	eligible, err := s.playerRepo.GetEligiblePositions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get eligible positions: %w", err)
+	}
+	s.rankPositions(playerValues, eligible, rankValue)
+
+	written, err := s.savePlayerProjections(ctx, leagueID, playerValues)
+	if err != nil {
 		return fmt.Errorf("failed to save projections: %w", err)
 	}
+	span.SetAttributes(attribute.Int("projections.written", written))
+
+	if err := s.savePositionRanks(ctx, leagueID, playerValues); err != nil {
+		return fmt.Errorf("failed to save position ranks: %w", err)
+	}
+
+	if err := s.savePositionScarcity(ctx, leagueID, scarcity); err != nil {
+		return fmt.Errorf("failed to save position scarcity: %w", err)
+	}
 
 	return nil
 }
@@ -101,6 +175,8 @@ type PlayerStats struct {
 	FGPercentage     float64
 	FTPercentage     float64
 	ThreePointersMade float64
+	FGAttempts       float64
+	FTAttempts       float64
 }
 
 func (s *ValuationService) calculatePlayerValue(player PlayerStats, settings ScoringSettings) PlayerValue {
@@ -114,7 +190,10 @@ func (s *ValuationService) calculatePlayerValue(player PlayerStats, settings Sco
 
 	return PlayerValue{
 		PlayerID: player.PlayerID,
+		Position: player.PrimaryPosition,
 		FPG:      fpg,
+		FGA:      player.FGAttempts,
+		FTA:      player.FTAttempts,
 		Projections: CategoryProjections{
 			PTS:   player.PointsPerGame,
 			REB:   player.ReboundsPerGame,
@@ -167,18 +246,16 @@ func (s *ValuationService) calculateStats(players []PlayerValue) (mean, stdDev f
 	return mean, stdDev
 }
 
-func (s *ValuationService) applyPositionScarcity(ctx context.Context, players []PlayerValue) {
-	scarcityMap := map[string]float64{
-		"PG": 1.0,
-		"SG": 1.0,
-		"SF": 1.1,
-		"PF": 1.1,
-		"C":  1.3,
+// applyPositionScarcity sets each player's ScarcityMultiplier to that of
+// their position. Positions without a computed multiplier get 1.
+func (s *ValuationService) applyPositionScarcity(players []PlayerValue, scarcity []PositionScarcity) {
+	multipliers := make(map[string]float64, len(scarcity))
+	for _, ps := range scarcity {
+		multipliers[ps.Position] = ps.Multiplier
 	}
 
 	for i := range players {
-		position := s.getPlayerPosition(ctx, players[i].PlayerID)
-		if multiplier, ok := scarcityMap[position]; ok {
+		if multiplier, ok := multipliers[players[i].Position]; ok {
 			players[i].ScarcityMultiplier = multiplier
 		} else {
 			players[i].ScarcityMultiplier = 1.0
@@ -186,65 +263,147 @@ func (s *ValuationService) applyPositionScarcity(ctx context.Context, players []
 	}
 }
 
+// scarcityAdjusted scales a ranking value by a position multiplier. The
+// adjustment is taken on the value's magnitude, so a multiplier above 1
+// raises negative values too rather than pushing them further down.
+func scarcityAdjusted(value, multiplier float64) float64 {
+	return value + math.Abs(value)*(multiplier-1)
+}
+
 func (s *ValuationService) rankPlayers(players []PlayerValue) {
-	for i := range players {
-		rank := 1
-		for j := range players {
-			if players[j].FPG > players[i].FPG {
-				rank++
-			}
-		}
-		players[i].OverallRank = rank
+	s.rankPlayersBy(players, func(p PlayerValue) float64 { return p.FPG })
+}
+
+// rankPlayersBy sets each player's OverallRank, highest value first.
+// Players with equal values are ranked by player ID.
+func (s *ValuationService) rankPlayersBy(players []PlayerValue, value func(PlayerValue) float64) {
+	values := playerValuesBy(players, value)
+	order := make([]int, len(players))
+	for i := range order {
+		order[i] = i
+	}
+	sortByValue(players, values, order)
+
+	for rank, i := range order {
+		players[i].OverallRank = rank + 1
 	}
 }
 
-func (s *ValuationService) savePlayerProjections(ctx context.Context, players []PlayerValue) error {
+// playerValuesBy returns value for each player, so that sorting calls it
+// once per player rather than once per comparison.
+func playerValuesBy(players []PlayerValue, value func(PlayerValue) float64) []float64 {
+	values := make([]float64, len(players))
+	for i, p := range players {
+		values[i] = value(p)
+	}
+	return values
+}
+
+// sortByValue sorts indexes into players by their values, highest first,
+// breaking ties by player ID so that ranks do not depend on the order
+// players were loaded in.
+func sortByValue(players []PlayerValue, values []float64, indexes []int) {
+	sort.Slice(indexes, func(a, b int) bool {
+		i, j := indexes[a], indexes[b]
+		if values[i] != values[j] {
+			return values[i] > values[j]
+		}
+		return players[i].PlayerID < players[j].PlayerID
+	})
+}
+
+// savePlayerProjections replaces the league's rows in player_projections.
+// The primary position rank and per-category z-scores are stored in their
+// own columns; the z-scores are zero in points leagues:
+//
+//	ALTER TABLE player_projections ADD COLUMN position_rank INTEGER;
+//	ALTER TABLE player_projections ADD COLUMN z_pts REAL DEFAULT 0;
+//	-- and likewise z_reb, z_ast, z_stl, z_blk, z_to, z_fg_pct,
+//	-- z_ft_pct and z_3pm.
+//
+// Rows are inserted projectionBatchSize at a time with multi-row INSERTs.
+// It returns the number of rows written.
+func (s *ValuationService) savePlayerProjections(ctx context.Context, leagueID int, players []PlayerValue) (int, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer tx.Rollback()
 
 	deleteQuery := `DELETE FROM player_projections WHERE league_id = ?`
-	if _, err := tx.ExecContext(ctx, deleteQuery, players[0].LeagueID); err != nil {
-		return err
+	if _, err := tx.ExecContext(ctx, deleteQuery, leagueID); err != nil {
+		return 0, err
 	}
 
-	insertQuery := `
-		INSERT INTO player_projections (
-			player_id, league_id, fpg, proj_pts, proj_reb, proj_ast,
-			proj_stl, proj_blk, proj_to, proj_fg_pct, proj_ft_pct, proj_3pm,
-			z_score, overall_rank, scarcity_multiplier
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
+	written := 0
+	for start := 0; start < len(players); start += projectionBatchSize {
+		end := start + projectionBatchSize
+		if end > len(players) {
+			end = len(players)
+		}
+		batch := players[start:end]
+
+		args := make([]interface{}, 0, len(batch)*len(projectionInsertColumns))
+		for _, p := range batch {
+			args = append(args,
+				p.PlayerID, p.LeagueID, p.FPG,
+				p.Projections.PTS, p.Projections.REB, p.Projections.AST,
+				p.Projections.STL, p.Projections.BLK, p.Projections.TO,
+				p.Projections.FGPct, p.Projections.FTPct, p.Projections.TPM,
+				p.ZScore, p.OverallRank, p.PositionRank, p.ScarcityMultiplier,
+				p.CategoryZScores.PTS, p.CategoryZScores.REB, p.CategoryZScores.AST,
+				p.CategoryZScores.STL, p.CategoryZScores.BLK, p.CategoryZScores.TO,
+				p.CategoryZScores.FGPct, p.CategoryZScores.FTPct, p.CategoryZScores.TPM,
+			)
+		}
 
-	for _, p := range players {
-		_, err := tx.ExecContext(ctx, insertQuery,
-			p.PlayerID, p.LeagueID, p.FPG,
-			p.Projections.PTS, p.Projections.REB, p.Projections.AST,
-			p.Projections.STL, p.Projections.BLK, p.Projections.TO,
-			p.Projections.FGPct, p.Projections.FTPct, p.Projections.TPM,
-			p.ZScore, p.OverallRank, p.ScarcityMultiplier,
-		)
-		if err != nil {
-			return err
+		if _, err := tx.ExecContext(ctx, projectionInsert(len(batch)), args...); err != nil {
+			return written, fmt.Errorf("failed to insert projections %d-%d: %w", start+1, end, err)
 		}
+		written += len(batch)
 	}
 
-	return tx.Commit()
+	return written, tx.Commit()
+}
+
+// projectionBatchSize is how many rows each projection INSERT writes. At 25
+// columns a row, a full batch binds 750 values, under the 999 older SQLite
+// builds allow in one statement.
+const projectionBatchSize = 30
+
+var projectionInsertColumns = []string{
+	"player_id", "league_id", "fpg", "proj_pts", "proj_reb", "proj_ast",
+	"proj_stl", "proj_blk", "proj_to", "proj_fg_pct", "proj_ft_pct", "proj_3pm",
+	"z_score", "overall_rank", "position_rank", "scarcity_multiplier",
+	"z_pts", "z_reb", "z_ast", "z_stl", "z_blk", "z_to", "z_fg_pct", "z_ft_pct", "z_3pm",
+}
+
+// projectionInsert returns an INSERT of rows rows into player_projections.
+func projectionInsert(rows int) string {
+	row := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(projectionInsertColumns)), ", ") + ")"
+	values := strings.TrimSuffix(strings.Repeat(row+", ", rows), ", ")
+	return "INSERT INTO player_projections (" + strings.Join(projectionInsertColumns, ", ") + ") VALUES " + values
 }
 
 func (s *ValuationService) getLeague(ctx context.Context, leagueID int) (*struct {
 	ScoringSettings string
+	ScoringType     string
 }, error) {
-	query := `SELECT scoring_settings FROM fantasy_leagues WHERE id = ?`
+	query := `SELECT scoring_settings, scoring_type FROM fantasy_leagues WHERE id = ?`
 	var league struct {
 		ScoringSettings string
+		ScoringType     string
 	}
-	err := s.db.QueryRowContext(ctx, query, leagueID).Scan(&league.ScoringSettings)
+	err := s.db.QueryRowContext(ctx, query, leagueID).Scan(&league.ScoringSettings, &league.ScoringType)
 	return &league, err
 }
 
+// getActivePlayersWithStats returns the active players and their season
+// averages. Shot attempts, which weight FG% and FT% in category leagues,
+// come from columns added with:
+//
+//	ALTER TABLE nba_player_stats ADD COLUMN field_goal_attempts_per_game REAL;
+//	ALTER TABLE nba_player_stats ADD COLUMN free_throw_attempts_per_game REAL;
 func (s *ValuationService) getActivePlayersWithStats(ctx context.Context) ([]PlayerStats, error) {
 	query := `
 		SELECT p.id, COALESCE(pp.code, 'F') as primary_position,
@@ -256,7 +415,9 @@ func (s *ValuationService) getActivePlayersWithStats(ctx context.Context) ([]Pla
 		       COALESCE(s.turnovers_per_game, 0) as tpg,
 		       COALESCE(s.field_goal_percentage, 0) as fgpct,
 		       COALESCE(s.free_throw_percentage, 0) as ftpct,
-		       COALESCE(s.three_pointers_made, 0) as tpm
+		       COALESCE(s.three_pointers_made, 0) as tpm,
+		       COALESCE(s.field_goal_attempts_per_game, 0) as fga,
+		       COALESCE(s.free_throw_attempts_per_game, 0) as fta
 		FROM players p
 		LEFT JOIN player_positions plp ON p.id = plp.player_id AND plp.is_primary = 1
 		LEFT JOIN positions pp ON plp.position_id = pp.id
@@ -278,6 +439,7 @@ func (s *ValuationService) getActivePlayersWithStats(ctx context.Context) ([]Pla
 			&p.ReboundsPerGame, &p.AssistsPerGame, &p.StealsPerGame,
 			&p.BlocksPerGame, &p.TurnoversPerGame, &p.FGPercentage,
 			&p.FTPercentage, &p.ThreePointersMade,
+			&p.FGAttempts, &p.FTAttempts,
 		)
 		if err != nil {
 			return nil, err
@@ -287,18 +449,3 @@ func (s *ValuationService) getActivePlayersWithStats(ctx context.Context) ([]Pla
 
 	return players, nil
 }
-
-func (s *ValuationService) getPlayerPosition(ctx context.Context, playerID int) string {
-	query := `
-		SELECT pos.code
-		FROM player_positions pp
-		JOIN positions pos ON pp.position_id = pos.id
-		WHERE pp.player_id = ? AND pp.is_primary = 1
-	`
-	var position string
-	s.db.QueryRowContext(ctx, query, playerID).Scan(&position)
-	if position == "" {
-		return "F"
-	}
-	return position
-}
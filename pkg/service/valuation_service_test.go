@@ -1,7 +1,10 @@
 package service
 
 import (
+	"context"
+	"database/sql"
 	"math"
+	"strings"
 	"testing"
 )
 
@@ -174,39 +177,67 @@ func TestCalculateStats(t *testing.T) {
 }
 
 func TestApplyPositionScarcity(t *testing.T) {
-	tests := []struct {
-		position           string
-		expectedMultiplier float64
-	}{
-		{"PG", 1.0},
-		{"SG", 1.0},
-		{"SF", 1.1},
-		{"PF", 1.1},
-		{"C", 1.3},
-		{"G", 1.0},
-		{"F", 1.0},
+	service := &ValuationService{}
+
+	players := []PlayerValue{
+		{PlayerID: 1, Position: "C"},
+		{PlayerID: 2, Position: "PG"},
+		{PlayerID: 3, Position: "G"},
+	}
+	scarcity := []PositionScarcity{
+		{Position: "C", Multiplier: 1.3},
+		{Position: "PG", Multiplier: 0.9},
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.position, func(t *testing.T) {
-			scarcityMap := map[string]float64{
-				"PG": 1.0,
-				"SG": 1.0,
-				"SF": 1.1,
-				"PF": 1.1,
-				"C":  1.3,
-			}
+	service.applyPositionScarcity(players, scarcity)
 
-			multiplier, ok := scarcityMap[tt.position]
-			if !ok {
-				multiplier = 1.0
-			}
+	for i, want := range []float64{1.3, 0.9, 1.0} {
+		if math.Abs(players[i].ScarcityMultiplier-want) > 0.001 {
+			t.Errorf("Player %d multiplier = %.2f, want %.2f", players[i].PlayerID, players[i].ScarcityMultiplier, want)
+		}
+	}
+}
 
-			if math.Abs(multiplier-tt.expectedMultiplier) > 0.01 {
-				t.Errorf("Position %s multiplier incorrect: got %.2f, want %.2f",
-					tt.position, multiplier, tt.expectedMultiplier)
-			}
-		})
+func TestCalculatePositionScarcity(t *testing.T) {
+	service := &ValuationService{}
+
+	// Centers fall off a cliff after the top two; guards stay deep.
+	players := []PlayerValue{
+		{Position: "C", ZScore: 6.0},
+		{Position: "C", ZScore: 4.0},
+		{Position: "C", ZScore: -1.0},
+		{Position: "PG", ZScore: 3.0},
+		{Position: "PG", ZScore: 2.5},
+		{Position: "PG", ZScore: 2.0},
+		{Position: "PG", ZScore: 1.8},
+	}
+
+	scarcity := service.calculatePositionScarcity(players, map[string]int{"": 2, "PG": 4})
+
+	if len(scarcity) != 2 || scarcity[0].Position != "C" || scarcity[1].Position != "PG" {
+		t.Fatalf("Expected C and PG, got %+v", scarcity)
+	}
+
+	center, guard := scarcity[0], scarcity[1]
+	if center.Depth != 2 || guard.Depth != 4 {
+		t.Errorf("Depths = %d, %d, want 2, 4", center.Depth, guard.Depth)
+	}
+	if math.Abs(center.DropOff-1.0) > 0.001 {
+		t.Errorf("Center drop-off = %.3f, want 1.0", center.DropOff)
+	}
+	if math.Abs(guard.DropOff-0.525) > 0.001 {
+		t.Errorf("Guard drop-off = %.3f, want 0.525", guard.DropOff)
+	}
+	if center.Multiplier <= 1.0 || guard.Multiplier >= 1.0 {
+		t.Errorf("Expected centers to be scarcer than guards, got %.2f and %.2f", center.Multiplier, guard.Multiplier)
+	}
+	if center.Multiplier > maxScarcity || guard.Multiplier < minScarcity {
+		t.Errorf("Multipliers out of bounds: %.2f and %.2f", center.Multiplier, guard.Multiplier)
+	}
+
+	flat := service.calculatePositionScarcity([]PlayerValue{{Position: "C", ZScore: 1.0}}, nil)
+	if len(flat) != 1 || flat[0].Multiplier != 1.0 {
+		t.Errorf("A position without drop-off should keep a multiplier of 1, got %+v", flat)
 	}
 }
 
@@ -255,3 +286,261 @@ func TestEmptyPlayerList(t *testing.T) {
 		t.Errorf("Empty list should return 0,0: got mean=%.2f, stdDev=%.2f", mean, stdDev)
 	}
 }
+
+func TestCalculateCategoryZScores(t *testing.T) {
+	players := []PlayerValue{
+		{PlayerID: 1, Projections: CategoryProjections{PTS: 30, REB: 5, AST: 8, STL: 1.5, BLK: 0.3, TO: 3.5, FGPct: 0.47, FTPct: 0.90, TPM: 3.5}, FGA: 21, FTA: 8},
+		{PlayerID: 2, Projections: CategoryProjections{PTS: 20, REB: 12, AST: 2, STL: 0.8, BLK: 2.5, TO: 2.0, FGPct: 0.60, FTPct: 0.60, TPM: 0.2}, FGA: 14, FTA: 6},
+		{PlayerID: 3, Projections: CategoryProjections{PTS: 10, REB: 4, AST: 3, STL: 0.7, BLK: 0.4, TO: 1.0, FGPct: 0.43, FTPct: 0.78, TPM: 1.3}, FGA: 9, FTA: 2},
+	}
+
+	service := &ValuationService{}
+	service.calculateCategoryZScores(players, 0)
+
+	// PTS has mean 20 and standard deviation sqrt(200/3).
+	if z := players[0].CategoryZScores.PTS; math.Abs(z-10/math.Sqrt(200.0/3)) > 0.001 {
+		t.Errorf("Player 1 PTS z-score = %.3f, want %.3f", z, 10/math.Sqrt(200.0/3))
+	}
+	if players[0].CategoryZScores.TO >= 0 || players[2].CategoryZScores.TO <= 0 {
+		t.Errorf("Turnover z-scores should be inverted, got %.3f and %.3f",
+			players[0].CategoryZScores.TO, players[2].CategoryZScores.TO)
+	}
+
+	for _, p := range players {
+		sum := 0.0
+		for _, z := range p.CategoryZScores.byCategory() {
+			sum += z
+		}
+		if math.Abs(p.ZScore-sum) > 0.001 {
+			t.Errorf("Player %d ZScore = %.3f, want the category sum %.3f", p.PlayerID, p.ZScore, sum)
+		}
+	}
+
+	punting := &ValuationService{}
+	WithPuntCategories("ft%", "3PM")(punting)
+	punting.calculateCategoryZScores(players, 0)
+
+	for _, p := range players {
+		want := 0.0
+		for cat, z := range p.CategoryZScores.byCategory() {
+			if cat != "FT%" && cat != "3PM" {
+				want += z
+			}
+		}
+		if math.Abs(p.ZScore-want) > 0.001 {
+			t.Errorf("Player %d punting ZScore = %.3f, want %.3f", p.PlayerID, p.ZScore, want)
+		}
+	}
+	if players[1].ZScore <= players[0].ZScore {
+		t.Error("Punting FT% and 3PM should favor the big man")
+	}
+}
+
+func TestCalculateCategoryZScoresWeightsPercentages(t *testing.T) {
+	// Player 1 shoots 100% on one attempt a game; player 2 shoots 55% on
+	// twenty. The rest of the pool shoots 45% on ten.
+	players := []PlayerValue{
+		{PlayerID: 1, Projections: CategoryProjections{FGPct: 1.0}, FGA: 1},
+		{PlayerID: 2, Projections: CategoryProjections{FGPct: 0.55}, FGA: 20},
+		{PlayerID: 3, Projections: CategoryProjections{FGPct: 0.45}, FGA: 10},
+		{PlayerID: 4, Projections: CategoryProjections{FGPct: 0.45}, FGA: 10},
+	}
+
+	service := &ValuationService{}
+	service.calculateCategoryZScores(players, 0)
+
+	if players[1].CategoryZScores.FGPct <= players[0].CategoryZScores.FGPct {
+		t.Errorf("High-volume shooter FG%% z-score %.3f should beat the 1-for-1 shooter's %.3f",
+			players[1].CategoryZScores.FGPct, players[0].CategoryZScores.FGPct)
+	}
+}
+
+func TestCalculateCategoryZScoresDraftablePool(t *testing.T) {
+	players := []PlayerValue{
+		{PlayerID: 1, Projections: CategoryProjections{PTS: 30}},
+		{PlayerID: 2, Projections: CategoryProjections{PTS: 20}},
+		{PlayerID: 3, Projections: CategoryProjections{PTS: 2}},
+		{PlayerID: 4, Projections: CategoryProjections{PTS: 0}},
+	}
+
+	service := &ValuationService{}
+	service.calculateCategoryZScores(players, 2)
+
+	// Against the top two alone PTS has mean 25 and standard deviation 5.
+	if z := players[0].CategoryZScores.PTS; math.Abs(z-1.0) > 0.001 {
+		t.Errorf("Player 1 PTS z-score = %.3f, want 1.000", z)
+	}
+	if z := players[3].CategoryZScores.PTS; math.Abs(z-(-5.0)) > 0.001 {
+		t.Errorf("Player 4 PTS z-score = %.3f, want -5.000", z)
+	}
+}
+
+func TestRankPlayersBy(t *testing.T) {
+	service := &ValuationService{}
+
+	players := []PlayerValue{
+		{PlayerID: 1, FPG: 50.0, ZScore: -1.0},
+		{PlayerID: 2, FPG: 30.0, ZScore: 4.0},
+		{PlayerID: 3, FPG: 40.0, ZScore: 2.0},
+	}
+
+	service.rankPlayersBy(players, func(p PlayerValue) float64 { return p.ZScore })
+
+	for i, want := range []int{3, 1, 2} {
+		if players[i].OverallRank != want {
+			t.Errorf("Player %d rank = %d, want %d", players[i].PlayerID, players[i].OverallRank, want)
+		}
+	}
+}
+
+func TestRankPlayersTies(t *testing.T) {
+	service := &ValuationService{}
+
+	players := []PlayerValue{
+		{PlayerID: 9, Position: "PG", FPG: 30.0},
+		{PlayerID: 4, Position: "PG", FPG: 30.0},
+		{PlayerID: 7, Position: "PG", FPG: 45.0},
+		{PlayerID: 2, Position: "C", FPG: 30.0},
+	}
+
+	service.rankPlayers(players)
+	service.rankPositions(players, nil, func(p PlayerValue) float64 { return p.FPG })
+
+	for i, want := range []struct{ overall, position int }{{4, 3}, {3, 2}, {1, 1}, {2, 1}} {
+		if players[i].OverallRank != want.overall || players[i].PositionRank != want.position {
+			t.Errorf("Player %d ranks = %d overall, %d at %s, want %d and %d", players[i].PlayerID,
+				players[i].OverallRank, players[i].PositionRank, players[i].Position, want.overall, want.position)
+		}
+	}
+}
+
+func TestRankPositions(t *testing.T) {
+	service := &ValuationService{}
+
+	players := []PlayerValue{
+		{PlayerID: 1, Position: "PG", FPG: 40.0},
+		{PlayerID: 2, Position: "SG", FPG: 45.0},
+		{PlayerID: 3, Position: "PG", FPG: 30.0},
+		{PlayerID: 4, Position: "C", FPG: 35.0},
+	}
+	eligible := map[int][]string{
+		2: {"SG", "PG"},
+		3: {"PG"},
+	}
+
+	service.rankPositions(players, eligible, func(p PlayerValue) float64 { return p.FPG })
+
+	tests := []struct {
+		player   int
+		primary  int
+		position string
+		rank     int
+	}{
+		{0, 2, "PG", 2},
+		{1, 1, "PG", 1},
+		{2, 3, "PG", 3},
+		{3, 1, "C", 1},
+	}
+
+	for _, tt := range tests {
+		p := players[tt.player]
+		if p.PositionRank != tt.primary {
+			t.Errorf("Player %d PositionRank = %d, want %d", p.PlayerID, p.PositionRank, tt.primary)
+		}
+		if p.PositionRanks[tt.position] != tt.rank {
+			t.Errorf("Player %d %s rank = %d, want %d", p.PlayerID, tt.position, p.PositionRanks[tt.position], tt.rank)
+		}
+	}
+
+	if label := players[0].PositionRankLabel(); label != "PG #2" {
+		t.Errorf("PositionRankLabel = %q, want \"PG #2\"", label)
+	}
+	if label := (PlayerValue{Position: "C"}).PositionRankLabel(); label != "" {
+		t.Errorf("Unranked PositionRankLabel = %q, want \"\"", label)
+	}
+}
+
+func TestSavePlayerProjections(t *testing.T) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	schema := "CREATE TABLE player_projections (" + strings.Join(projectionInsertColumns, " REAL, ") + " REAL)"
+	if _, err := db.Exec(schema); err != nil {
+		t.Fatalf("Failed to create schema: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO player_projections (player_id, league_id) VALUES (999, 1), (999, 2)`); err != nil {
+		t.Fatalf("Failed to seed projections: %v", err)
+	}
+
+	// Two full batches and a partial one.
+	players := make([]PlayerValue, 2*projectionBatchSize+5)
+	for i := range players {
+		players[i] = PlayerValue{PlayerID: i + 1, LeagueID: 1, FPG: float64(i), OverallRank: i + 1}
+	}
+
+	service := &ValuationService{db: db}
+	written, err := service.savePlayerProjections(context.Background(), 1, players)
+	if err != nil {
+		t.Fatalf("savePlayerProjections failed: %v", err)
+	}
+	if written != len(players) {
+		t.Errorf("Expected %d rows written, got %d", len(players), written)
+	}
+
+	var count, stale int
+	var lastRank float64
+	err = db.QueryRow(`
+		SELECT COUNT(*), SUM(player_id = 999), MAX(overall_rank)
+		FROM player_projections WHERE league_id = 1
+	`).Scan(&count, &stale, &lastRank)
+	if err != nil {
+		t.Fatalf("Failed to read projections: %v", err)
+	}
+	if count != len(players) || stale != 0 || int(lastRank) != len(players) {
+		t.Errorf("Expected %d fresh rows for league 1, got %d rows, %d stale, max rank %v", len(players), count, stale, lastRank)
+	}
+
+	var other int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM player_projections WHERE league_id = 2`).Scan(&other); err != nil {
+		t.Fatal(err)
+	}
+	if other != 1 {
+		t.Errorf("Expected other leagues' projections to be kept, got %d rows", other)
+	}
+}
+
+func TestPositionDepths(t *testing.T) {
+	slots := map[string]int{"": 10, "PG": 10, "G": 10, "Util": 20, "BN": 0}
+	depths := positionDepths(slots, []string{"PG", "SG", "C"})
+
+	// PG: its own slot, half the G slots and a third of the Util slots.
+	for position, want := range map[string]int{"PG": 22, "SG": 12, "C": 7} {
+		if depths[position] != want {
+			t.Errorf("%s depth = %d, want %d", position, depths[position], want)
+		}
+	}
+}
+
+func TestRankPlayersByScarcity(t *testing.T) {
+	service := &ValuationService{}
+
+	players := []PlayerValue{
+		{PlayerID: 1, Position: "PG", ZScore: 2.0, ScarcityMultiplier: 0.8},
+		{PlayerID: 2, Position: "C", ZScore: 1.8, ScarcityMultiplier: 1.3},
+		{PlayerID: 3, Position: "PG", ZScore: -1.0, ScarcityMultiplier: 0.8},
+		{PlayerID: 4, Position: "C", ZScore: -1.0, ScarcityMultiplier: 1.3},
+	}
+	service.rankPlayersBy(players, func(p PlayerValue) float64 {
+		return scarcityAdjusted(p.ZScore, p.ScarcityMultiplier)
+	})
+
+	for i, want := range []int{2, 1, 4, 3} {
+		if players[i].OverallRank != want {
+			t.Errorf("Player %d rank = %d, want %d", players[i].PlayerID, players[i].OverallRank, want)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/repository"
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/yahoo"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// maxFreeAgents is how many free agents, in Yahoo's default order,
+	// RecommendPickups considers.
+	maxFreeAgents = 100
+	// categoryNeedWeight scales how much a player's z-scores in the team's
+	// weak categories add to their value for that team.
+	categoryNeedWeight = 0.5
+	// faabSharePerZ is the share of the remaining FAAB budget bid for each
+	// point of value gained, up to maxFAABShare.
+	faabSharePerZ = 0.05
+	maxFAABShare  = 0.5
+)
+
+// WaiverService recommends free agent pickups.
+type WaiverService struct {
+	db          *sql.DB
+	yahooClient *yahoo.Client
+	playerRepo  *repository.PlayerRepository
+	valuation   Valuator
+	analysis    Analyzer
+}
+
+// WaiverPlayer is a player in a waiver recommendation. Value is their
+// z-score plus any bonus for helping the team's weak categories.
+type WaiverPlayer struct {
+	PlayerID   int
+	PlayerKey  string
+	PlayerName string
+	Position   string
+	Value      float64
+}
+
+// WaiverRecommendation is one add/drop move.
+type WaiverRecommendation struct {
+	Add  WaiverPlayer
+	Drop WaiverPlayer
+	// ValueGain is how much more the added player is worth to the team than
+	// the dropped one.
+	ValueGain float64
+	// CategoryHelp lists the team's weak categories the added player is
+	// above average in.
+	CategoryHelp []string
+	// FAABBid is the suggested bid, or 0 in leagues without FAAB.
+	FAABBid int
+}
+
+func NewWaiverService(db *sql.DB, yahooClient *yahoo.Client, valuation Valuator, analysis Analyzer) WaiverRecommender {
+	return &WaiverService{
+		db:          db,
+		yahooClient: yahooClient,
+		playerRepo:  repository.NewPlayerRepository(db),
+		valuation:   valuation,
+		analysis:    analysis,
+	}
+}
+
+// RecommendPickups returns up to limit add/drop moves for the team, best
+// first. Free agents come from Yahoo and are valued from the league's
+// player projections, which are calculated with the valuation service
+// first if the league has none. Each move drops a different player, whose
+// primary position the added player is eligible at; moves that would not
+// improve the team are left out.
+func (s *WaiverService) RecommendPickups(ctx context.Context, teamID int, limit int) (_ []WaiverRecommendation, err error) {
+	ctx, span := startSpan(ctx, "WaiverService.RecommendPickups",
+		attribute.Int("team.id", teamID),
+		attribute.Int("limit", limit),
+	)
+	defer func() { endSpan(span, err) }()
+
+	var leagueID int
+	var teamKey, gameKey, yahooLeagueID string
+	query := `
+		SELECT ft.league_id, ft.yahoo_team_key, fl.yahoo_game_key, fl.yahoo_league_id
+		FROM fantasy_teams ft
+		JOIN fantasy_leagues fl ON ft.league_id = fl.id
+		WHERE ft.id = ?
+	`
+	if err := s.db.QueryRowContext(ctx, query, teamID).Scan(&leagueID, &teamKey, &gameKey, &yahooLeagueID); err != nil {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+	leagueKey := fmt.Sprintf("%s.l.%s", gameKey, yahooLeagueID)
+
+	if err := s.ensureValues(ctx, leagueID); err != nil {
+		return nil, err
+	}
+
+	freeAgentKeys, err := getFreeAgentKeys(ctx, s.yahooClient, leagueKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get free agents: %w", err)
+	}
+
+	freeAgents, err := getWaiverCandidates(ctx, s.db, leagueID, freeAgentKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to value free agents: %w", err)
+	}
+
+	roster, err := getDroppablePlayers(ctx, s.db, leagueID, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roster: %w", err)
+	}
+
+	weak, err := s.getWeakCategories(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get weak categories: %w", err)
+	}
+
+	budget, err := s.getFAABBudget(ctx, leagueKey, teamKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get FAAB budget: %w", err)
+	}
+
+	eligible, err := s.playerRepo.GetEligiblePositions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eligible positions: %w", err)
+	}
+
+	return s.recommendPickups(freeAgents, roster, eligible, weak, budget, limit), nil
+}
+
+// waiverCandidate is a player with the projections needed to value them
+// for a team.
+type waiverCandidate struct {
+	player          WaiverPlayer
+	zScore          float64
+	categoryZScores CategoryProjections
+}
+
+// recommendPickups pairs free agents with the roster players they can
+// replace, taking the pair that gains the most value first, so each free
+// agent and each roster player is in at most one move. A free agent can
+// replace players whose primary position they are eligible at; those
+// without eligibility data are taken to play only their primary position,
+// as in findUpgrades. budget is the team's FAAB balance, or -1 in leagues
+// without FAAB.
+func (s *WaiverService) recommendPickups(freeAgents, roster []waiverCandidate, eligible map[int][]string, weak []string, budget int, limit int) []WaiverRecommendation {
+	value := func(c waiverCandidate) (float64, []string) {
+		v := c.zScore
+		var helps []string
+		zScores := c.categoryZScores.byCategory()
+		for _, cat := range weak {
+			if z := zScores[cat]; z > 0 {
+				v += z * categoryNeedWeight
+				helps = append(helps, cat)
+			}
+		}
+		return v, helps
+	}
+
+	drops := make([]WaiverPlayer, len(roster))
+	for i, c := range roster {
+		drops[i] = c.player
+		drops[i].Value, _ = value(c)
+	}
+
+	var candidates []WaiverRecommendation
+	for _, fa := range freeAgents {
+		add := fa.player
+		var helps []string
+		add.Value, helps = value(fa)
+
+		positions := eligible[add.PlayerID]
+		if len(positions) == 0 {
+			positions = []string{add.Position}
+		}
+
+		for _, drop := range drops {
+			gain := add.Value - drop.Value
+			if gain <= 0 || !containsFold(positions, drop.Position) {
+				continue
+			}
+			candidates = append(candidates, WaiverRecommendation{
+				Add:          add,
+				Drop:         drop,
+				ValueGain:    gain,
+				CategoryHelp: helps,
+				FAABBid:      faabBid(gain, budget),
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].ValueGain > candidates[j].ValueGain
+	})
+
+	added := make(map[int]bool)
+	dropped := make(map[int]bool)
+	var recommendations []WaiverRecommendation
+	for _, c := range candidates {
+		if added[c.Add.PlayerID] || dropped[c.Drop.PlayerID] {
+			continue
+		}
+		added[c.Add.PlayerID] = true
+		dropped[c.Drop.PlayerID] = true
+		recommendations = append(recommendations, c)
+
+		if limit > 0 && len(recommendations) == limit {
+			break
+		}
+	}
+	return recommendations
+}
+
+// faabBid suggests a bid for a move gaining the given value: a share of the
+// budget proportional to the gain, at least 1 and at most half the budget.
+func faabBid(gain float64, budget int) int {
+	if budget <= 0 {
+		return 0
+	}
+	share := math.Min(maxFAABShare, gain*faabSharePerZ)
+	return max(1, int(math.Round(float64(budget)*share)))
+}
+
+// ensureValues calculates the league's player values if it has none yet.
+func (s *WaiverService) ensureValues(ctx context.Context, leagueID int) error {
+	var count int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM player_projections WHERE league_id = ?`, leagueID).Scan(&count); err != nil {
+		return fmt.Errorf("failed to check player values: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+	if err := s.valuation.CalculateAllPlayerValues(ctx, leagueID); err != nil {
+		return fmt.Errorf("failed to calculate player values: %w", err)
+	}
+	return nil
+}
+
+// getFreeAgentKeys returns the player keys of the league's first
+// maxFreeAgents free agents, in Yahoo's default order.
+func getFreeAgentKeys(ctx context.Context, client *yahoo.Client, leagueKey string) ([]string, error) {
+	var keys []string
+	for start := 0; start < maxFreeAgents; start += 25 {
+		players, err := client.GetLeaguePlayers(ctx, leagueKey, yahoo.PlayerStatusFreeAgents, start, 25)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range players {
+			keys = append(keys, p.PlayerKey)
+		}
+		if len(players) < 25 {
+			break
+		}
+	}
+	return keys, nil
+}
+
+// getWaiverCandidates returns the players with the given keys that have
+// projections in the league.
+func getWaiverCandidates(ctx context.Context, db *sql.DB, leagueID int, playerKeys []string) ([]waiverCandidate, error) {
+	if len(playerKeys) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(playerKeys)), ", ")
+	query := `
+		SELECT p.id, p.yahoo_player_key, p.full_name, COALESCE(pos.code, 'F'),
+		       pp.z_score, pp.z_pts, pp.z_reb, pp.z_ast, pp.z_stl, pp.z_blk,
+		       pp.z_to, pp.z_fg_pct, pp.z_ft_pct, pp.z_3pm
+		FROM players p
+		JOIN player_projections pp ON p.id = pp.player_id AND pp.league_id = ?
+		LEFT JOIN player_positions plp ON p.id = plp.player_id AND plp.is_primary = 1
+		LEFT JOIN positions pos ON plp.position_id = pos.id
+		WHERE p.yahoo_player_key IN (` + placeholders + `)
+	`
+
+	args := []interface{}{leagueID}
+	for _, key := range playerKeys {
+		args = append(args, key)
+	}
+
+	return queryCandidates(ctx, db, query, args...)
+}
+
+// getDroppablePlayers returns the team's players, leaving out those in
+// reserve slots, who do not take up a roster spot.
+func getDroppablePlayers(ctx context.Context, db *sql.DB, leagueID, teamID int) ([]waiverCandidate, error) {
+	query := `
+		SELECT p.id, p.yahoo_player_key, p.full_name, COALESCE(pos.code, 'F'),
+		       pp.z_score, pp.z_pts, pp.z_reb, pp.z_ast, pp.z_stl, pp.z_blk,
+		       pp.z_to, pp.z_fg_pct, pp.z_ft_pct, pp.z_3pm
+		FROM fantasy_rosters fr
+		JOIN players p ON fr.player_id = p.id
+		JOIN player_projections pp ON p.id = pp.player_id AND pp.league_id = ?
+		LEFT JOIN player_positions plp ON p.id = plp.player_id AND plp.is_primary = 1
+		LEFT JOIN positions pos ON plp.position_id = pos.id
+		WHERE fr.team_id = ? AND COALESCE(fr.selected_position, '') NOT IN ('IL', 'IL+', 'NA')
+	`
+
+	return queryCandidates(ctx, db, query, leagueID, teamID)
+}
+
+// queryCandidates runs a query selecting a player's ID, key, name and
+// primary position, then their z-score and category z-scores.
+func queryCandidates(ctx context.Context, db *sql.DB, query string, args ...interface{}) ([]waiverCandidate, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []waiverCandidate
+	for rows.Next() {
+		var c waiverCandidate
+		z := &c.categoryZScores
+		err := rows.Scan(
+			&c.player.PlayerID, &c.player.PlayerKey, &c.player.PlayerName, &c.player.Position,
+			&c.zScore, &z.PTS, &z.REB, &z.AST, &z.STL, &z.BLK,
+			&z.TO, &z.FGPct, &z.FTPct, &z.TPM,
+		)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
+
+func (s *WaiverService) getWeakCategories(ctx context.Context, teamID int) ([]string, error) {
+	query := `
+		SELECT COALESCE(weakest_cat_1, ''), COALESCE(weakest_cat_2, ''), COALESCE(weakest_cat_3, '')
+		FROM team_analysis
+		WHERE team_id = ?
+	`
+
+	var cats [3]string
+	err := s.db.QueryRowContext(ctx, query, teamID).Scan(&cats[0], &cats[1], &cats[2])
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var weak []string
+	for _, cat := range cats {
+		if cat != "" {
+			weak = append(weak, cat)
+		}
+	}
+	return weak, nil
+}
+
+// getFAABBudget returns the team's FAAB balance, or -1 if the league does
+// not use FAAB.
+func (s *WaiverService) getFAABBudget(ctx context.Context, leagueKey, teamKey string) (int, error) {
+	settings, err := s.yahooClient.GetLeagueSettings(ctx, leagueKey)
+	if err != nil {
+		return 0, err
+	}
+	if !settings.UsesFAAB {
+		return -1, nil
+	}
+
+	teams, err := s.yahooClient.GetLeagueTeams(ctx, leagueKey)
+	if err != nil {
+		return 0, err
+	}
+	for _, team := range teams {
+		if team.YahooTeamKey == teamKey {
+			return team.FAABBalance, nil
+		}
+	}
+	return 0, fmt.Errorf("team %s not found in league %s", teamKey, leagueKey)
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRecommendPickups(t *testing.T) {
+	service := &WaiverService{}
+
+	roster := []waiverCandidate{
+		{player: WaiverPlayer{PlayerID: 1}, zScore: 5.0},
+		{player: WaiverPlayer{PlayerID: 2}, zScore: -0.5},
+		{player: WaiverPlayer{PlayerID: 3}, zScore: 0.5, categoryZScores: CategoryProjections{BLK: 3.0}},
+		{player: WaiverPlayer{PlayerID: 4}, zScore: -0.3},
+	}
+	freeAgents := []waiverCandidate{
+		{player: WaiverPlayer{PlayerID: 10}, zScore: 0.2},
+		{player: WaiverPlayer{PlayerID: 11}, zScore: -1.0},
+		{player: WaiverPlayer{PlayerID: 12}, zScore: 0.0, categoryZScores: CategoryProjections{STL: 2.0, PTS: 1.0}},
+	}
+
+	recommendations := service.recommendPickups(freeAgents, roster, nil, []string{"STL", "BLK"}, 100, 0)
+
+	if len(recommendations) != 2 {
+		t.Fatalf("Expected 2 recommendations, got %+v", recommendations)
+	}
+
+	// The stealer is worth 0 + 2*0.5 to a team weak in steals.
+	first := recommendations[0]
+	if first.Add.PlayerID != 12 || first.Drop.PlayerID != 2 {
+		t.Errorf("First move = add %d drop %d, want add 12 drop 2", first.Add.PlayerID, first.Drop.PlayerID)
+	}
+	if math.Abs(first.ValueGain-1.5) > 0.001 {
+		t.Errorf("ValueGain = %.2f, want 1.5", first.ValueGain)
+	}
+	if len(first.CategoryHelp) != 1 || first.CategoryHelp[0] != "STL" {
+		t.Errorf("CategoryHelp = %v, want [STL]", first.CategoryHelp)
+	}
+	if first.FAABBid != 8 {
+		t.Errorf("FAABBid = %d, want 8", first.FAABBid)
+	}
+
+	// Player 2 is already dropped for the stealer.
+	if second := recommendations[1]; second.Add.PlayerID != 10 || second.Drop.PlayerID != 4 {
+		t.Errorf("Second move = add %d drop %d, want add 10 drop 4", second.Add.PlayerID, second.Drop.PlayerID)
+	}
+
+	limited := service.recommendPickups(freeAgents, roster, nil, nil, -1, 1)
+	if len(limited) != 1 || limited[0].FAABBid != 0 {
+		t.Errorf("Expected one move without a bid, got %+v", limited)
+	}
+}
+
+func TestRecommendPickupsPositions(t *testing.T) {
+	service := &WaiverService{}
+
+	roster := []waiverCandidate{
+		{player: WaiverPlayer{PlayerID: 1, Position: "PG"}, zScore: -1.0},
+		{player: WaiverPlayer{PlayerID: 2, Position: "C"}, zScore: 0.0},
+	}
+	freeAgents := []waiverCandidate{
+		{player: WaiverPlayer{PlayerID: 10, Position: "C"}, zScore: 1.0},
+		{player: WaiverPlayer{PlayerID: 11, Position: "C"}, zScore: 0.5},
+	}
+	eligible := map[int][]string{11: {"PG", "C"}}
+
+	recommendations := service.recommendPickups(freeAgents, roster, eligible, nil, -1, 0)
+
+	if len(recommendations) != 2 {
+		t.Fatalf("Expected 2 recommendations, got %+v", recommendations)
+	}
+	if first := recommendations[0]; first.Add.PlayerID != 11 || first.Drop.PlayerID != 1 {
+		t.Errorf("First move = add %d drop %d, want add 11 drop 1", first.Add.PlayerID, first.Drop.PlayerID)
+	}
+	if second := recommendations[1]; second.Add.PlayerID != 10 || second.Drop.PlayerID != 2 {
+		t.Errorf("Second move = add %d drop %d, want the center for the center", second.Add.PlayerID, second.Drop.PlayerID)
+	}
+}
+
+func TestFAABBid(t *testing.T) {
+	tests := []struct {
+		gain     float64
+		budget   int
+		expected int
+	}{
+		{2.0, 100, 10},
+		{20.0, 100, 50},
+		{0.1, 100, 1},
+		{2.0, 0, 0},
+		{2.0, -1, 0},
+	}
+
+	for _, tt := range tests {
+		if bid := faabBid(tt.gain, tt.budget); bid != tt.expected {
+			t.Errorf("faabBid(%.1f, %d) = %d, want %d", tt.gain, tt.budget, bid, tt.expected)
+		}
+	}
+}
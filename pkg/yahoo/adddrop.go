@@ -0,0 +1,83 @@
+package yahoo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AddDropOption configures an add or add/drop transaction.
+type AddDropOption func(*addDropRequest)
+
+type addDropRequest struct {
+	faabBid *int
+}
+
+// WithFAABBid bids the given amount of free agent budget on the added
+// player. It is required when claiming a player off waivers in a FAAB league.
+func WithFAABBid(bid int) AddDropOption {
+	return func(r *addDropRequest) {
+		r.faabBid = &bid
+	}
+}
+
+func addPayloadPlayer(teamKey, playerKey string) transactionPayloadPlayer {
+	p := transactionPayloadPlayer{PlayerKey: playerKey}
+	p.TransactionData.Type = TransactionAdd
+	p.TransactionData.DestinationTeamKey = teamKey
+	return p
+}
+
+func dropPayloadPlayer(teamKey, playerKey string) transactionPayloadPlayer {
+	p := transactionPayloadPlayer{PlayerKey: playerKey}
+	p.TransactionData.Type = TransactionDrop
+	p.TransactionData.SourceTeamKey = teamKey
+	return p
+}
+
+// AddPlayer adds a free agent or waiver player to the team. A waiver claim
+// comes back with Status "pending" until Yahoo processes it.
+func (c *Client) AddPlayer(ctx context.Context, leagueKey, teamKey, playerKey string, opts ...AddDropOption) (*Transaction, error) {
+	var payload transactionPayload
+	payload.Transaction.Type = TransactionAdd
+	add := addPayloadPlayer(teamKey, playerKey)
+	payload.Transaction.Player = &add
+	return c.postTransaction(ctx, leagueKey, teamKey, payload, opts)
+}
+
+// DropPlayer releases a player from the team.
+func (c *Client) DropPlayer(ctx context.Context, leagueKey, teamKey, playerKey string) (*Transaction, error) {
+	var payload transactionPayload
+	payload.Transaction.Type = TransactionDrop
+	drop := dropPayloadPlayer(teamKey, playerKey)
+	payload.Transaction.Player = &drop
+	return c.postTransaction(ctx, leagueKey, teamKey, payload, nil)
+}
+
+// AddDropPlayers adds addPlayerKey and drops dropPlayerKey in a single
+// transaction, so the roster never exceeds its limit.
+func (c *Client) AddDropPlayers(ctx context.Context, leagueKey, teamKey, addPlayerKey, dropPlayerKey string, opts ...AddDropOption) (*Transaction, error) {
+	var payload transactionPayload
+	payload.Transaction.Type = TransactionAddDrop
+	payload.Transaction.Players = &transactionPayloadPlayers{Player: []transactionPayloadPlayer{
+		addPayloadPlayer(teamKey, addPlayerKey),
+		dropPayloadPlayer(teamKey, dropPlayerKey),
+	}}
+	return c.postTransaction(ctx, leagueKey, teamKey, payload, opts)
+}
+
+// postTransaction submits an add, drop or add/drop to the league's
+// transactions collection.
+func (c *Client) postTransaction(ctx context.Context, leagueKey, teamKey string, payload transactionPayload, opts []AddDropOption) (*Transaction, error) {
+	var req addDropRequest
+	for _, opt := range opts {
+		opt(&req)
+	}
+	payload.Transaction.FAABBid = req.faabBid
+
+	return c.submitTransaction(ctx, http.MethodPost, fmt.Sprintf("league/%s/transactions", leagueKey), &payload,
+		fmt.Sprintf("team:%s:roster", teamKey),
+		fmt.Sprintf("league:%s:transactions", leagueKey),
+		fmt.Sprintf("league:%s:players", leagueKey),
+	)
+}
@@ -0,0 +1,151 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const addDropResponseXML = `<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng">
+  <transaction>
+    <transaction_key>454.l.1.tr.42</transaction_key>
+    <transaction_id>42</transaction_id>
+    <type>add/drop</type>
+    <status>successful</status>
+    <timestamp>1737000000</timestamp>
+    <players count="2">
+      <player><player_key>454.p.1</player_key><transaction_data><type>add</type><source_type>freeagents</source_type><destination_type>team</destination_type><destination_team_key>454.l.1.t.1</destination_team_key></transaction_data></player>
+      <player><player_key>454.p.2</player_key><transaction_data><type>drop</type><source_type>team</source_type><source_team_key>454.l.1.t.1</source_team_key><destination_type>waivers</destination_type></transaction_data></player>
+    </players>
+  </transaction>
+</fantasy_content>`
+
+func TestAddDropPlayers(t *testing.T) {
+	var method, path, contentType, payload string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, contentType = r.Method, r.URL.Path, r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		payload = string(body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(addDropResponseXML))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	trans, err := client.AddDropPlayers(context.Background(), "454.l.1", "454.l.1.t.1", "454.p.1", "454.p.2", WithFAABBid(7))
+	if err != nil {
+		t.Fatalf("AddDropPlayers() error = %v", err)
+	}
+
+	if method != http.MethodPost || path != "/league/454.l.1/transactions" || contentType != "application/xml" {
+		t.Errorf("request = %s %s (%s)", method, path, contentType)
+	}
+	for _, want := range []string{
+		"<type>add/drop</type>",
+		"<faab_bid>7</faab_bid>",
+		"<player><player_key>454.p.1</player_key><transaction_data><type>add</type><destination_team_key>454.l.1.t.1</destination_team_key></transaction_data></player>",
+		"<player><player_key>454.p.2</player_key><transaction_data><type>drop</type><source_team_key>454.l.1.t.1</source_team_key></transaction_data></player>",
+	} {
+		if !strings.Contains(payload, want) {
+			t.Errorf("payload = %s, want it to contain %s", payload, want)
+		}
+	}
+
+	if trans.TransactionKey != "454.l.1.tr.42" || trans.Type != TransactionAddDrop || trans.Status != "successful" || len(trans.Players) != 2 {
+		t.Errorf("transaction = %+v", trans)
+	}
+}
+
+func TestDropPlayerPayload(t *testing.T) {
+	var payload string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		payload = string(body)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`<fantasy_content><transaction><type>drop</type><status>successful</status></transaction></fantasy_content>`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	if _, err := client.DropPlayer(context.Background(), "454.l.1", "454.l.1.t.1", "454.p.2"); err != nil {
+		t.Fatalf("DropPlayer() error = %v", err)
+	}
+	want := "<fantasy_content><transaction><type>drop</type><player><player_key>454.p.2</player_key><transaction_data><type>drop</type><source_team_key>454.l.1.t.1</source_team_key></transaction_data></player></transaction></fantasy_content>"
+	if payload != want {
+		t.Errorf("payload = %s, want %s", payload, want)
+	}
+}
+
+func TestAddPlayerErrorMapping(t *testing.T) {
+	tests := []struct {
+		description string
+		want        error
+	}{
+		{"You cannot add a player to your roster because it is full.", ErrRosterFull},
+		{"The player you are trying to add is not available.", ErrPlayerNotAvailable},
+		{"You have reached the maximum number of adds for this week.", ErrTransactionLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want.Error(), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`<error><description>` + tt.description + `</description></error>`))
+			}))
+			defer server.Close()
+
+			client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+			client.baseURL = server.URL
+
+			_, err := client.AddPlayer(context.Background(), "454.l.1", "454.l.1.t.1", "454.p.1")
+			if !errors.Is(err, tt.want) {
+				t.Errorf("AddPlayer() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddPlayerInvalidatesRosterCache(t *testing.T) {
+	roster, err := os.ReadFile(filepath.Join("testdata", "roster.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rosterRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`<fantasy_content><transaction><type>add</type><status>successful</status></transaction></fantasy_content>`))
+			return
+		}
+		rosterRequests++
+		w.Write(roster)
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil,
+		WithInitialToken(Token{AccessToken: "token"}),
+		WithCache(NewMemoryCache(0, 0)))
+	client.baseURL = server.URL
+	ctx := context.Background()
+
+	client.GetTeamRoster(ctx, "454.l.1.t.1")
+	client.GetTeamRoster(ctx, "454.l.1.t.1")
+	if _, err := client.AddPlayer(ctx, "454.l.1", "454.l.1.t.1", "454.p.1"); err != nil {
+		t.Fatalf("AddPlayer() error = %v", err)
+	}
+	client.GetTeamRoster(ctx, "454.l.1.t.1")
+
+	if rosterRequests != 2 {
+		t.Errorf("roster requests = %d, want 2 (one before and one after the add)", rosterRequests)
+	}
+}
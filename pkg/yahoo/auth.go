@@ -0,0 +1,135 @@
+package yahoo
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const defaultAuthURL = "https://api.login.yahoo.com/oauth2/request_auth"
+
+// Token is the OAuth2 token set issued by Yahoo.
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	TokenType    string    `json:"token_type"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// GetAuthorizationURL returns the Yahoo consent page URL for the authorization
+// code flow. Use "oob" as redirectURI for out-of-band (copy/paste) codes.
+func (c *Client) GetAuthorizationURL(redirectURI, state string) string {
+	if redirectURI == "" {
+		redirectURI = "oob"
+	}
+	c.tokenMutex.Lock()
+	c.redirectURI = redirectURI
+	c.tokenMutex.Unlock()
+
+	params := url.Values{}
+	params.Set("client_id", c.apiKey)
+	params.Set("redirect_uri", redirectURI)
+	params.Set("response_type", "code")
+	if state != "" {
+		params.Set("state", state)
+	}
+
+	return c.authURL + "?" + params.Encode()
+}
+
+// ExchangeAuthorizationCode trades an authorization code for an access and
+// refresh token, stores them on the client and returns them so callers can
+// persist the token set.
+func (c *Client) ExchangeAuthorizationCode(ctx context.Context, code string) (*Token, error) {
+	if code == "" {
+		return nil, fmt.Errorf("authorization code is empty")
+	}
+
+	redirectURI := c.currentRedirectURI()
+	if redirectURI == "" {
+		redirectURI = "oob"
+	}
+
+	data := url.Values{}
+	data.Set("grant_type", "authorization_code")
+	data.Set("code", code)
+	data.Set("redirect_uri", redirectURI)
+
+	tokenResp, err := c.requestToken(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	token := tokenResp.toToken()
+
+	c.tokenMutex.Lock()
+	c.accessToken = token.AccessToken
+	c.refreshToken = token.RefreshToken
+	c.tokenExpiry = token.Expiry
+	c.tokenMutex.Unlock()
+
+	return token, nil
+}
+
+// Token returns a copy of the client's current token set.
+func (c *Client) Token() Token {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+
+	return Token{
+		AccessToken:  c.accessToken,
+		RefreshToken: c.refreshToken,
+		TokenType:    "bearer",
+		Expiry:       c.tokenExpiry,
+	}
+}
+
+func (c *Client) requestToken(ctx context.Context, data url.Values) (*tokenResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", c.tokenURL, bytes.NewBufferString(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+
+	authHeader := base64.StdEncoding.EncodeToString([]byte(c.apiKey + ":" + c.apiSecret))
+	req.Header.Set("Authorization", "Basic "+authHeader)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token request failed: %w", newYahooAPIError(resp.StatusCode, body))
+	}
+
+	var tokenResp tokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if tokenResp.AccessToken == "" {
+		return nil, fmt.Errorf("token response did not contain an access token")
+	}
+
+	return &tokenResp, nil
+}
+
+func (t *tokenResponse) toToken() *Token {
+	token := &Token{
+		AccessToken:  t.AccessToken,
+		RefreshToken: t.RefreshToken,
+		TokenType:    t.TokenType,
+	}
+	if t.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+	return token
+}
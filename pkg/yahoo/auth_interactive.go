@@ -0,0 +1,159 @@
+package yahoo
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+const defaultInteractiveRedirectURL = "http://localhost:8080/callback"
+
+// InteractiveAuthOptions configures AuthorizeInteractive.
+type InteractiveAuthOptions struct {
+	ConsumerKey    string
+	ConsumerSecret string
+	DB             *sql.DB
+
+	// RedirectURL must match a redirect URI registered on the Yahoo app.
+	// The callback listener binds to its host and port.
+	RedirectURL string
+
+	// OpenBrowser opens the consent URL; defaults to the system browser.
+	OpenBrowser func(authURL string) error
+
+	// Timeout bounds how long to wait for the user to approve access.
+	Timeout time.Duration
+
+	ClientOptions []ClientOption
+}
+
+// AuthorizeInteractive runs the authorization code flow for CLI tools: it
+// starts a temporary local callback server, opens the Yahoo consent page,
+// waits for the redirect and exchanges the code for tokens.
+func AuthorizeInteractive(ctx context.Context, opts InteractiveAuthOptions) (*Client, error) {
+	redirectURL := opts.RedirectURL
+	if redirectURL == "" {
+		redirectURL = defaultInteractiveRedirectURL
+	}
+	redirect, err := url.Parse(redirectURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redirect URL: %w", err)
+	}
+	if redirect.Path == "" {
+		redirect.Path = "/"
+	}
+
+	timeout := opts.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	listener, err := net.Listen("tcp", redirect.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start callback listener: %w", err)
+	}
+	defer listener.Close()
+	if redirect.Port() == "0" {
+		redirect.Host = listener.Addr().String()
+	}
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+
+	type callbackResult struct {
+		code string
+		err  error
+	}
+	results := make(chan callbackResult, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(redirect.Path, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var result callbackResult
+		switch {
+		case query.Get("error") != "":
+			result.err = fmt.Errorf("authorization denied: %s", query.Get("error"))
+		case query.Get("state") != state:
+			result.err = fmt.Errorf("authorization callback state mismatch")
+		case query.Get("code") == "":
+			result.err = fmt.Errorf("authorization callback did not include a code")
+		default:
+			result.code = query.Get("code")
+		}
+
+		if result.err != nil {
+			http.Error(w, result.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Yahoo authorization complete. You can close this window.")
+		}
+
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	client := NewClient(opts.ConsumerKey, opts.ConsumerSecret, opts.DB, opts.ClientOptions...)
+	authURL := client.GetAuthorizationURL(redirect.String(), state)
+
+	openBrowser := opts.OpenBrowser
+	if openBrowser == nil {
+		openBrowser = openSystemBrowser
+	}
+	if err := openBrowser(authURL); err != nil {
+		fmt.Printf("Open this URL in your browser to authorize access:\n%s\n", authURL)
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("timed out waiting for authorization: %w", ctx.Err())
+	case result := <-results:
+		if result.err != nil {
+			return nil, result.err
+		}
+		if _, err := client.ExchangeAuthorizationCode(ctx, result.code); err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+}
+
+func randomState() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate state: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func openSystemBrowser(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	case "linux", "freebsd", "openbsd", "netbsd":
+		cmd = exec.Command("xdg-open", target)
+	default:
+		return errors.New("unsupported platform for opening a browser")
+	}
+	return cmd.Start()
+}
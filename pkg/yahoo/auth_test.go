@@ -0,0 +1,223 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestGetAuthorizationURL(t *testing.T) {
+	client := &Client{apiKey: "consumer-key", authURL: defaultAuthURL}
+
+	authURL := client.GetAuthorizationURL("https://example.com/callback", "xyz")
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("failed to parse authorization URL: %v", err)
+	}
+	query := parsed.Query()
+
+	if query.Get("client_id") != "consumer-key" {
+		t.Errorf("client_id = %v, want %v", query.Get("client_id"), "consumer-key")
+	}
+	if query.Get("redirect_uri") != "https://example.com/callback" {
+		t.Errorf("redirect_uri = %v, want %v", query.Get("redirect_uri"), "https://example.com/callback")
+	}
+	if query.Get("response_type") != "code" {
+		t.Errorf("response_type = %v, want %v", query.Get("response_type"), "code")
+	}
+	if query.Get("state") != "xyz" {
+		t.Errorf("state = %v, want %v", query.Get("state"), "xyz")
+	}
+}
+
+func TestGetAuthorizationURLConcurrentWithClone(t *testing.T) {
+	client := &Client{apiKey: "consumer-key", authURL: defaultAuthURL}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			client.GetAuthorizationURL("https://example.com/callback", "")
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		client.WithToken(Token{AccessToken: "access"})
+	}
+	<-done
+
+	if got := client.WithToken(Token{}).currentRedirectURI(); got != "https://example.com/callback" {
+		t.Errorf("clone redirect URI = %v, want %v", got, "https://example.com/callback")
+	}
+}
+
+func TestExchangeAuthorizationCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("failed to parse form: %v", err)
+		}
+		if r.Form.Get("grant_type") != "authorization_code" {
+			t.Errorf("grant_type = %v, want %v", r.Form.Get("grant_type"), "authorization_code")
+		}
+		if r.Form.Get("code") != "abc123" {
+			t.Errorf("code = %v, want %v", r.Form.Get("code"), "abc123")
+		}
+		if r.Form.Get("redirect_uri") != "https://example.com/callback" {
+			t.Errorf("redirect_uri = %v, want %v", r.Form.Get("redirect_uri"), "https://example.com/callback")
+		}
+		if user, _, ok := r.BasicAuth(); !ok || user != "consumer-key" {
+			t.Errorf("basic auth user = %v, want %v", user, "consumer-key")
+		}
+		w.Write([]byte(`{"access_token":"access","refresh_token":"refresh","expires_in":3600,"token_type":"bearer"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{
+		apiKey:     "consumer-key",
+		apiSecret:  "consumer-secret",
+		httpClient: server.Client(),
+		tokenURL:   server.URL,
+		authURL:    defaultAuthURL,
+	}
+	client.GetAuthorizationURL("https://example.com/callback", "")
+
+	token, err := client.ExchangeAuthorizationCode(context.Background(), "abc123")
+	if err != nil {
+		t.Fatalf("ExchangeAuthorizationCode() error = %v", err)
+	}
+
+	if token.AccessToken != "access" {
+		t.Errorf("AccessToken = %v, want %v", token.AccessToken, "access")
+	}
+	if token.RefreshToken != "refresh" {
+		t.Errorf("RefreshToken = %v, want %v", token.RefreshToken, "refresh")
+	}
+	if token.Expiry.IsZero() {
+		t.Error("Expiry should be set from expires_in")
+	}
+	if client.accessToken != "access" || client.refreshToken != "refresh" {
+		t.Error("client tokens were not populated from the token response")
+	}
+}
+
+func TestExchangeAuthorizationCodeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"invalid_grant"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client(), tokenURL: server.URL}
+
+	if _, err := client.ExchangeAuthorizationCode(context.Background(), "bad"); err == nil {
+		t.Error("expected error for rejected authorization code")
+	}
+	if _, err := client.ExchangeAuthorizationCode(context.Background(), ""); err == nil {
+		t.Error("expected error for empty authorization code")
+	}
+}
+
+func newTokenTestServer(t *testing.T, validToken string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			w.Write([]byte(`{"access_token":"` + validToken + `","refresh_token":"refresh","expires_in":3600}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer "+validToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"description":"Please provide valid credentials"}}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+}
+
+func TestProactiveTokenRefresh(t *testing.T) {
+	server := newTokenTestServer(t, "fresh")
+	defer server.Close()
+
+	var refreshed []Token
+	client := NewClient("key", "secret", nil,
+		WithInitialToken(Token{AccessToken: "stale", RefreshToken: "refresh", Expiry: time.Now().Add(10 * time.Second)}),
+		WithTokenRefreshSkew(time.Minute),
+	)
+	client.baseURL = server.URL
+	client.tokenURL = server.URL + "/token"
+	client.onTokenRefresh = func(token Token) { refreshed = append(refreshed, token) }
+
+	if _, err := client.makeRequest(context.Background(), "league/1"); err != nil {
+		t.Fatalf("makeRequest() error = %v", err)
+	}
+
+	if len(refreshed) != 1 {
+		t.Fatalf("expected exactly one proactive refresh, got %d", len(refreshed))
+	}
+	if token := client.Token(); token.AccessToken != "fresh" || time.Until(token.Expiry) < 59*time.Minute {
+		t.Errorf("token after refresh = %+v, want fresh token expiring in ~1h", token)
+	}
+}
+
+func TestRefreshOnAnyUnauthorizedResponse(t *testing.T) {
+	server := newTokenTestServer(t, "fresh")
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "stale", RefreshToken: "refresh"}))
+	client.baseURL = server.URL
+	client.tokenURL = server.URL + "/token"
+
+	if _, err := client.makeRequest(context.Background(), "league/1"); err != nil {
+		t.Fatalf("makeRequest() error = %v", err)
+	}
+	if client.Token().AccessToken != "fresh" {
+		t.Errorf("AccessToken = %v, want %v", client.Token().AccessToken, "fresh")
+	}
+}
+
+func TestAuthorizeInteractive(t *testing.T) {
+	tokenServer := newTokenTestServer(t, "interactive-token")
+	defer tokenServer.Close()
+
+	openBrowser := func(authURL string) error {
+		parsed, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+		callback, err := url.Parse(parsed.Query().Get("redirect_uri"))
+		if err != nil {
+			return err
+		}
+		q := callback.Query()
+		q.Set("code", "the-code")
+		q.Set("state", parsed.Query().Get("state"))
+		callback.RawQuery = q.Encode()
+
+		go func() {
+			resp, err := http.Get(callback.String())
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	client, err := AuthorizeInteractive(context.Background(), InteractiveAuthOptions{
+		ConsumerKey:    "key",
+		ConsumerSecret: "secret",
+		RedirectURL:    "http://127.0.0.1:0/callback",
+		OpenBrowser:    openBrowser,
+		Timeout:        5 * time.Second,
+		ClientOptions: []ClientOption{func(c *Client) {
+			c.tokenURL = tokenServer.URL + "/token"
+		}},
+	})
+	if err != nil {
+		t.Fatalf("AuthorizeInteractive() error = %v", err)
+	}
+	if client.Token().AccessToken != "interactive-token" {
+		t.Errorf("AccessToken = %v, want %v", client.Token().AccessToken, "interactive-token")
+	}
+}
@@ -0,0 +1,127 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// LeagueResource names a league sub-resource that can be requested in a
+// combined league;out=... call.
+type LeagueResource string
+
+const (
+	LeagueResourceSettings     LeagueResource = "settings"
+	LeagueResourceStandings    LeagueResource = "standings"
+	LeagueResourceScoreboard   LeagueResource = "scoreboard"
+	LeagueResourceDraftResults LeagueResource = "draftresults"
+	LeagueResourceTransactions LeagueResource = "transactions"
+)
+
+// LeagueBatch holds the parts of a league returned by BatchLeagueFetch.
+// Fields for resources that were not requested are left empty.
+type LeagueBatch struct {
+	LeagueKey    string
+	Name         string
+	CurrentWeek  int
+	Settings     *LeagueSettings
+	Standings    *Standings
+	Matchups     []Matchup
+	DraftResults []DraftResult
+	Transactions []Transaction
+}
+
+type yahooLeagueBatchResponse struct {
+	FantasyContent struct {
+		League yahooObject[struct {
+			LeagueKey   string                          `json:"league_key"`
+			Name        string                          `json:"name"`
+			CurrentWeek json.Number                     `json:"current_week"`
+			Settings    *yahooObject[yahooSettingsData] `json:"settings"`
+			Standings   *yahooObject[struct {
+				Teams yahooList[struct {
+					Team yahooObject[yahooStandingsTeamData] `json:"team"`
+				}] `json:"teams"`
+			}] `json:"standings"`
+			Scoreboard *yahooObject[struct {
+				Matchups yahooList[struct {
+					Matchup yahooMatchupData `json:"matchup"`
+				}] `json:"matchups"`
+			}] `json:"scoreboard"`
+			DraftResults yahooList[struct {
+				DraftResult yahooDraftResultData `json:"draft_result"`
+			}] `json:"draft_results"`
+			Transactions yahooList[struct {
+				Transaction yahooObject[yahooTransactionData] `json:"transaction"`
+			}] `json:"transactions"`
+		}] `json:"league"`
+	} `json:"fantasy_content"`
+}
+
+// BatchLeagueFetch retrieves several league sub-resources in a single
+// request using Yahoo's ;out= syntax, e.g. standings, settings and the
+// current scoreboard in one round trip instead of three. Results are not
+// cached.
+func (c *Client) BatchLeagueFetch(ctx context.Context, leagueKey string, resources ...LeagueResource) (*LeagueBatch, error) {
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("BatchLeagueFetch requires at least one resource")
+	}
+
+	seen := make(map[LeagueResource]bool)
+	var out []string
+	for _, r := range resources {
+		if !seen[r] {
+			seen[r] = true
+			out = append(out, string(r))
+		}
+	}
+
+	endpoint := fmt.Sprintf("league/%s;out=%s", leagueKey, strings.Join(out, ","))
+	data, err := c.makeRequest(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp yahooLeagueBatchResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse league batch response: %w", err)
+	}
+
+	league := resp.FantasyContent.League.Value
+	currentWeek, _ := strconv.Atoi(league.CurrentWeek.String())
+	batch := &LeagueBatch{
+		LeagueKey:   league.LeagueKey,
+		Name:        league.Name,
+		CurrentWeek: currentWeek,
+	}
+
+	if league.Settings != nil {
+		settings := convertYahooSettings(league.Settings.Value)
+		batch.Settings = &settings
+	}
+
+	if league.Standings != nil {
+		batch.Standings = &Standings{}
+		for _, item := range league.Standings.Value.Teams {
+			batch.Standings.Teams = append(batch.Standings.Teams, convertYahooStandingsTeam(item.Team.Value))
+		}
+	}
+
+	if league.Scoreboard != nil {
+		for _, item := range league.Scoreboard.Value.Matchups {
+			batch.Matchups = append(batch.Matchups, convertYahooMatchup(item.Matchup))
+		}
+	}
+
+	for _, item := range league.DraftResults {
+		batch.DraftResults = append(batch.DraftResults, convertYahooDraftResult(item.DraftResult))
+	}
+
+	for _, item := range league.Transactions {
+		batch.Transactions = append(batch.Transactions, convertYahooTransaction(item.Transaction.Value))
+	}
+
+	return batch, nil
+}
@@ -0,0 +1,60 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func TestBatchLeagueFetch(t *testing.T) {
+	body, err := os.ReadFile("testdata/league_batch.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	batch, err := client.BatchLeagueFetch(context.Background(), "454.l.1",
+		LeagueResourceSettings, LeagueResourceStandings, LeagueResourceScoreboard, LeagueResourceStandings)
+	if err != nil {
+		t.Fatalf("BatchLeagueFetch() error = %v", err)
+	}
+
+	if want := "/league/454.l.1;out=settings,standings,scoreboard"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+	if batch.Name != "Hoops" || batch.CurrentWeek != 5 {
+		t.Errorf("league = %q week %d", batch.Name, batch.CurrentWeek)
+	}
+	if batch.Settings == nil || !batch.Settings.UsesFAAB || batch.Settings.PlayoffStartWeek != 21 {
+		t.Errorf("Settings = %+v", batch.Settings)
+	}
+	if batch.Standings == nil || len(batch.Standings.Teams) != 2 {
+		t.Fatalf("Standings = %+v", batch.Standings)
+	}
+	if top := batch.Standings.Teams[0]; top.Name != "Bricks" || top.TeamStandings.OutcomeTotals.Wins != 8 || top.ManagerNickname != "Alex" {
+		t.Errorf("Standings.Teams[0] = %+v", top)
+	}
+	if len(batch.Matchups) != 1 || len(batch.Matchups[0].Teams) != 2 {
+		t.Errorf("Matchups = %+v", batch.Matchups)
+	}
+	if batch.DraftResults != nil || batch.Transactions != nil {
+		t.Error("unrequested resources should be empty")
+	}
+}
+
+func TestBatchLeagueFetchRequiresResources(t *testing.T) {
+	client := NewClient("key", "secret", nil)
+	if _, err := client.BatchLeagueFetch(context.Background(), "454.l.1"); err == nil {
+		t.Error("expected error when no resources are requested")
+	}
+}
@@ -0,0 +1,417 @@
+package yahoo
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Cache stores serialized API responses for the client. Implementations
+// must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key and when it expires, or
+	// ErrCacheMiss. Expired entries may still be returned until Clean removes
+	// them; the client checks expiry itself so that it can serve stale data
+	// with ServeStaleOnError.
+	Get(key string) (value []byte, expiresAt time.Time, err error)
+	// Set stores value under key for ttl, replacing any existing entry.
+	Set(key string, value []byte, ttl time.Duration) error
+	// Delete removes the entry for key, if any.
+	Delete(key string) error
+	// Clean removes all expired entries.
+	Clean() error
+}
+
+// APICache is a Cache backed by the yahoo_api_cache table of a SQLite
+// database, or of any database opened with dialect.Open:
+//
+//	CREATE TABLE yahoo_api_cache (
+//		cache_key   TEXT PRIMARY KEY,
+//		cache_value TEXT NOT NULL,
+//		expires_at  DATETIME NOT NULL
+//	);
+type APICache struct {
+	db *sql.DB
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// CacheStats summarizes the contents and use of a cache. Hits count lookups
+// that found an unexpired entry; all other lookups count as misses.
+type CacheStats struct {
+	Entries      int
+	Bytes        int64
+	Hits         uint64
+	Misses       uint64
+	OldestExpiry time.Time
+	NewestExpiry time.Time
+}
+
+// newDefaultCache returns the cache NewClient uses: the database if there is
+// one, and otherwise a MemoryCache.
+func newDefaultCache(db *sql.DB) Cache {
+	if db == nil {
+		return NewMemoryCache(defaultMemoryCacheEntries, defaultMemoryCacheBytes)
+	}
+	return NewAPICache(db)
+}
+
+// NewAPICache returns a Cache that stores entries in db.
+func NewAPICache(db *sql.DB) *APICache {
+	return &APICache{db: db}
+}
+
+// ResponseMetadata describes where the data returned by a Get* call came
+// from. Attach one to a context with WithResponseMetadata and inspect it after
+// the call returns.
+//
+// When several cached requests share the context, e.g. the batches of
+// GetPlayersStats, the fields accumulate: FromCache and Stale report whether
+// any response was served from the cache or was stale, and StaleErr holds the
+// most recent fetch error that caused stale data to be served.
+type ResponseMetadata struct {
+	FromCache bool
+	Stale     bool
+	ExpiresAt time.Time
+	StaleErr  error
+
+	mu sync.Mutex
+}
+
+type responseMetadataKey struct{}
+
+type cacheModeKey struct{}
+
+type cacheMode int
+
+const (
+	cacheDefault cacheMode = iota
+	// cacheBypass neither reads nor writes the cache.
+	cacheBypass
+	// cacheRefresh skips cache reads but stores the fresh response.
+	cacheRefresh
+)
+
+// WithCacheBypass returns a context whose requests skip the cache entirely:
+// cached data is not read, and responses are not stored.
+func WithCacheBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheModeKey{}, cacheBypass)
+}
+
+// WithForceRefresh returns a context whose requests always go to Yahoo and
+// replace the cached entry with the response, e.g. to see a lineup change
+// straight away.
+func WithForceRefresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheModeKey{}, cacheRefresh)
+}
+
+func cacheModeFrom(ctx context.Context) cacheMode {
+	mode, _ := ctx.Value(cacheModeKey{}).(cacheMode)
+	return mode
+}
+
+// WithResponseMetadata returns a context that records ResponseMetadata for
+// the requests made with it.
+func WithResponseMetadata(ctx context.Context) (context.Context, *ResponseMetadata) {
+	meta := &ResponseMetadata{}
+	return context.WithValue(ctx, responseMetadataKey{}, meta), meta
+}
+
+func recordCacheHit(ctx context.Context, expiresAt time.Time, staleErr error) {
+	meta, ok := ctx.Value(responseMetadataKey{}).(*ResponseMetadata)
+	if !ok {
+		return
+	}
+	meta.mu.Lock()
+	defer meta.mu.Unlock()
+	meta.FromCache = true
+	if meta.ExpiresAt.IsZero() || expiresAt.Before(meta.ExpiresAt) {
+		meta.ExpiresAt = expiresAt
+	}
+	if staleErr != nil {
+		meta.Stale = true
+		meta.StaleErr = staleErr
+	}
+}
+
+// cachedFetch returns the fresh cached value for key if there is one, and
+// otherwise calls fetch and caches its result for the TTL the cache policy
+// sets for resource. With ServeStaleOnError a failed fetch falls back to an
+// expired entry for key.
+func cachedFetch[T any](ctx context.Context, c *Client, resource CacheResource, key string, fetch func() (T, error)) (T, error) {
+	ttl := c.cachePolicy.TTL(resource)
+	if ttl <= 0 {
+		return fetch()
+	}
+	key = c.cacheNamespace() + key
+
+	var cached T
+	if c.cacheGet(ctx, key, &cached) {
+		return cached, nil
+	}
+
+	value, err := fetch()
+	if err != nil {
+		var stale T
+		if c.cacheGetStale(ctx, key, &stale, err) {
+			return stale, nil
+		}
+		return value, err
+	}
+
+	c.cacheSet(ctx, key, value, ttl)
+	return value, nil
+}
+
+// Cache returns the cache the client stores responses in, e.g. to inspect
+// it with the Stats and ListKeys methods of APICache and MemoryCache.
+func (c *Client) Cache() Cache {
+	return c.cache
+}
+
+// cacheNamespace returns the prefix that keeps one user's cache entries apart
+// from another's when clients share a cache: the user's GUID if the client
+// knows it, and otherwise a fingerprint of its refresh (or access) token.
+func (c *Client) cacheNamespace() string {
+	c.tokenMutex.Lock()
+	guid, token := c.userGUID, c.refreshToken
+	if token == "" {
+		token = c.accessToken
+	}
+	c.tokenMutex.Unlock()
+
+	switch {
+	case guid != "":
+		return userCachePrefix(guid)
+	case token != "":
+		sum := sha256.Sum256([]byte(token))
+		return "token:" + hex.EncodeToString(sum[:8]) + "/"
+	default:
+		return ""
+	}
+}
+
+func userCachePrefix(guid string) string {
+	return "guid:" + guid + "/"
+}
+
+// prefixDeleter is implemented by caches that can delete every entry whose
+// key starts with a prefix.
+type prefixDeleter interface {
+	DeletePrefix(prefix string) error
+}
+
+// ClearUserCache removes every cached response made on behalf of the user
+// with the given GUID, e.g. by a ClientManager client. It fails if the cache
+// does not support deleting by prefix.
+func (c *Client) ClearUserCache(guid string) error {
+	if guid == "" {
+		return fmt.Errorf("ClearUserCache requires a user GUID")
+	}
+	deleter, ok := c.cache.(prefixDeleter)
+	if !ok {
+		return fmt.Errorf("cache %T does not support clearing a user's entries", c.cache)
+	}
+	return deleter.DeletePrefix(userCachePrefix(guid))
+}
+
+// invalidateCache removes the cached responses a write has made out of date:
+// every entry of this client's user whose key starts with one of prefixes,
+// or, when the cache cannot delete by prefix, the entry with exactly that key.
+func (c *Client) invalidateCache(prefixes ...string) {
+	if !c.cacheEnabled {
+		return
+	}
+	ns := c.cacheNamespace()
+	deleter, canDeletePrefix := c.cache.(prefixDeleter)
+	for _, prefix := range prefixes {
+		var err error
+		if canDeletePrefix {
+			err = deleter.DeletePrefix(ns + prefix)
+		} else {
+			err = c.cache.Delete(ns + prefix)
+		}
+		if err != nil {
+			c.logger.Warn("failed to invalidate cache entries", "prefix", ns+prefix, "error", err)
+		}
+	}
+}
+
+// cacheGet decodes the cached value for key into v, reporting whether a
+// fresh entry was found. It is a no-op when caching is disabled or ctx asks
+// for live data.
+func (c *Client) cacheGet(ctx context.Context, key string, v interface{}) bool {
+	if !c.cacheEnabled || cacheModeFrom(ctx) != cacheDefault {
+		return false
+	}
+	_, span := c.tracer.Start(ctx, "yahoo.cache.get", trace.WithAttributes(attribute.String("yahoo.cache.key", key)))
+	defer span.End()
+
+	cached, expiresAt, err := c.cache.Get(key)
+	hit := err == nil && time.Now().Before(expiresAt) && json.Unmarshal(cached, v) == nil
+	span.SetAttributes(attribute.Bool("yahoo.cache.hit", hit))
+	c.metrics.observeCache(hit)
+	if !hit {
+		c.logger.Debug("cache miss", "key", key)
+		return false
+	}
+	c.logger.Debug("cache hit", "key", key)
+	recordCacheHit(ctx, expiresAt, nil)
+	return true
+}
+
+// cacheGetStale decodes the cached value for key into v regardless of its
+// expiry, reporting whether one was found. It only applies when the client
+// serves stale data on error, ctx does not bypass the cache and fetchErr was
+// not caused by ctx ending.
+func (c *Client) cacheGetStale(ctx context.Context, key string, v interface{}, fetchErr error) bool {
+	if !c.cacheEnabled || !c.serveStale || cacheModeFrom(ctx) == cacheBypass || ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(fetchErr, context.Canceled) || errors.Is(fetchErr, context.DeadlineExceeded) {
+		return false
+	}
+	_, span := c.tracer.Start(ctx, "yahoo.cache.get_stale", trace.WithAttributes(attribute.String("yahoo.cache.key", key)))
+	defer span.End()
+
+	cached, expiresAt, err := c.cache.Get(key)
+	found := err == nil && json.Unmarshal(cached, v) == nil
+	span.SetAttributes(attribute.Bool("yahoo.cache.hit", found))
+	if !found {
+		return false
+	}
+	c.logger.Warn("serving stale cache entry", "key", key, "expires_at", expiresAt, "error", fetchErr)
+	recordCacheHit(ctx, expiresAt, fetchErr)
+	return true
+}
+
+func (c *Client) cacheSet(ctx context.Context, key string, value interface{}, ttl time.Duration) {
+	if !c.cacheEnabled || cacheModeFrom(ctx) == cacheBypass {
+		return
+	}
+	_, span := c.tracer.Start(ctx, "yahoo.cache.set", trace.WithAttributes(attribute.String("yahoo.cache.key", key)))
+	data, err := json.Marshal(value)
+	if err == nil {
+		err = c.cache.Set(key, data, ttl)
+	}
+	endSpan(span, err)
+	if err != nil {
+		c.logger.Warn("failed to write cache entry", "key", key, "error", err)
+	}
+}
+
+// Get returns the entry for key, including an expired one.
+func (c *APICache) Get(key string) ([]byte, time.Time, error) {
+	var value string
+	var expiresAt time.Time
+
+	query := `SELECT cache_value, expires_at FROM yahoo_api_cache WHERE cache_key = ?`
+	err := c.db.QueryRow(query, key).Scan(&value, &expiresAt)
+	if err == nil && time.Now().Before(expiresAt) {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, time.Time{}, ErrCacheMiss
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return []byte(value), expiresAt, nil
+}
+
+func (c *APICache) Set(key string, value []byte, ttl time.Duration) error {
+	expiresAt := time.Now().Add(ttl)
+
+	query := dialect.For(c.db).Upsert("yahoo_api_cache",
+		[]string{"cache_key", "cache_value", "expires_at"},
+		[]string{"cache_key"},
+		[]string{"cache_value", "expires_at"},
+	)
+	_, err := c.db.Exec(query, key, string(value), expiresAt)
+	return err
+}
+
+func (c *APICache) Delete(key string) error {
+	query := `DELETE FROM yahoo_api_cache WHERE cache_key = ?`
+	_, err := c.db.Exec(query, key)
+	return err
+}
+
+// DeletePrefix removes every entry whose key starts with prefix.
+func (c *APICache) DeletePrefix(prefix string) error {
+	query := `DELETE FROM yahoo_api_cache WHERE substr(cache_key, 1, length(?)) = ?`
+	_, err := c.db.Exec(query, prefix, prefix)
+	return err
+}
+
+func (c *APICache) Clean() error {
+	query := `DELETE FROM yahoo_api_cache WHERE expires_at < ?`
+	_, err := c.db.Exec(query, time.Now())
+	return err
+}
+
+// Stats returns the number and total size of the cached entries, their
+// expiry range and the lookup counters since the cache was created.
+func (c *APICache) Stats() (CacheStats, error) {
+	stats := CacheStats{Hits: c.hits.Load(), Misses: c.misses.Load()}
+
+	query := `SELECT COUNT(*), COALESCE(SUM(length(cache_key) + length(cache_value)), 0) FROM yahoo_api_cache`
+	if err := c.db.QueryRow(query).Scan(&stats.Entries, &stats.Bytes); err != nil {
+		return CacheStats{}, err
+	}
+	if stats.Entries == 0 {
+		return stats, nil
+	}
+
+	query = `SELECT expires_at FROM yahoo_api_cache ORDER BY expires_at LIMIT 1`
+	if err := c.db.QueryRow(query).Scan(&stats.OldestExpiry); err != nil {
+		return CacheStats{}, err
+	}
+	query = `SELECT expires_at FROM yahoo_api_cache ORDER BY expires_at DESC LIMIT 1`
+	if err := c.db.QueryRow(query).Scan(&stats.NewestExpiry); err != nil {
+		return CacheStats{}, err
+	}
+	return stats, nil
+}
+
+// ListKeys returns the keys that start with prefix, sorted, including those
+// of expired entries.
+func (c *APICache) ListKeys(prefix string) ([]string, error) {
+	query := `SELECT cache_key FROM yahoo_api_cache WHERE substr(cache_key, 1, length(?)) = ? ORDER BY cache_key`
+	rows, err := c.db.Query(query, prefix, prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// CleanExpired removes all expired entries.
+//
+// Deprecated: Use Clean.
+func (c *APICache) CleanExpired() error {
+	return c.Clean()
+}
@@ -0,0 +1,55 @@
+package yahoo
+
+import "time"
+
+// CacheResource identifies a kind of cached response in a CachePolicy.
+type CacheResource string
+
+const (
+	CacheLeagues      CacheResource = "leagues"
+	CacheTeams        CacheResource = "teams"
+	CacheRosters      CacheResource = "rosters"
+	CachePlayers      CacheResource = "players"
+	CachePlayerStats  CacheResource = "player_stats"
+	CacheScoreboard   CacheResource = "scoreboard"
+	CacheStandings    CacheResource = "standings"
+	CacheDraftResults CacheResource = "draft_results"
+	CacheTransactions CacheResource = "transactions"
+	CacheSettings     CacheResource = "settings"
+	CacheGames        CacheResource = "games"
+)
+
+var defaultCacheTTLs = map[CacheResource]time.Duration{
+	CacheLeagues:      24 * time.Hour,
+	CacheTeams:        6 * time.Hour,
+	CacheRosters:      1 * time.Hour,
+	CachePlayers:      1 * time.Hour,
+	CachePlayerStats:  2 * time.Hour,
+	CacheScoreboard:   1 * time.Hour,
+	CacheStandings:    6 * time.Hour,
+	CacheDraftResults: 24 * time.Hour,
+	CacheTransactions: 30 * time.Minute,
+	CacheSettings:     24 * time.Hour,
+	CacheGames:        24 * time.Hour,
+}
+
+// CachePolicy sets how long each kind of response is cached. A resource's
+// TTL is taken from TTLs, then Default, then the built-in TTL for that
+// resource. A TTL of zero or less in TTLs, or a negative Default, disables
+// caching for the resources it applies to. The zero CachePolicy uses the
+// built-in TTLs.
+type CachePolicy struct {
+	Default time.Duration
+	TTLs    map[CacheResource]time.Duration
+}
+
+// TTL returns how long responses for resource are cached.
+func (p CachePolicy) TTL(resource CacheResource) time.Duration {
+	if ttl, ok := p.TTLs[resource]; ok {
+		return ttl
+	}
+	if p.Default != 0 {
+		return p.Default
+	}
+	return defaultCacheTTLs[resource]
+}
@@ -0,0 +1,62 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCachePolicyTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   CachePolicy
+		resource CacheResource
+		want     time.Duration
+	}{
+		{"built-in", CachePolicy{}, CacheLeagues, 24 * time.Hour},
+		{"override", CachePolicy{TTLs: map[CacheResource]time.Duration{CacheRosters: 5 * time.Minute}}, CacheRosters, 5 * time.Minute},
+		{"override leaves others built-in", CachePolicy{TTLs: map[CacheResource]time.Duration{CacheRosters: 5 * time.Minute}}, CacheTeams, 6 * time.Hour},
+		{"global default", CachePolicy{Default: 10 * time.Minute}, CacheStandings, 10 * time.Minute},
+		{"override beats default", CachePolicy{Default: 10 * time.Minute, TTLs: map[CacheResource]time.Duration{CacheStandings: time.Hour}}, CacheStandings, time.Hour},
+		{"disabled", CachePolicy{TTLs: map[CacheResource]time.Duration{CacheScoreboard: 0}}, CacheScoreboard, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.TTL(tt.resource); got != tt.want {
+				t.Errorf("TTL(%s) = %v, want %v", tt.resource, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCachePolicyAppliesToClient(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(teamsFixture))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(0, 0)
+	client := NewClient("key", "secret", nil,
+		WithInitialToken(Token{AccessToken: "token"}),
+		WithCache(cache),
+		WithCachePolicy(CachePolicy{TTLs: map[CacheResource]time.Duration{CacheTeams: 0}}))
+	client.baseURL = server.URL
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetLeagueTeams(context.Background(), "nba.l.1"); err != nil {
+			t.Fatalf("GetLeagueTeams() error = %v", err)
+		}
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("upstream requests = %d, want 2 with teams caching disabled", n)
+	}
+	if n := cache.Len(); n != 0 {
+		t.Errorf("cache entries = %d, want 0", n)
+	}
+}
@@ -0,0 +1,315 @@
+package yahoo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func newTestCacheDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`CREATE TABLE yahoo_api_cache (
+		cache_key   TEXT PRIMARY KEY,
+		cache_value TEXT NOT NULL,
+		expires_at  DATETIME NOT NULL
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// newCachingClient returns a client with caching enabled whose requests are
+// answered by handler.
+func newCachingClient(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	opts = append([]ClientOption{WithInitialToken(Token{AccessToken: "token"}), WithRetryPolicy(NoRetry())}, opts...)
+	client := NewClient("key", "secret", newTestCacheDB(t), opts...)
+	client.baseURL = server.URL
+	client.cacheEnabled = true
+	return client
+}
+
+const teamsFixture = `{"fantasy_content":{"league":{"teams":{"0":{"team":{"team_key":"nba.l.1.t.1","team_id":"1","name":"Stale Team"}},"count":1}}}}`
+
+func TestServeStaleOnErrorReturnsExpiredEntry(t *testing.T) {
+	var failing atomic.Bool
+	client := newCachingClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(teamsFixture))
+	}, ServeStaleOnError())
+
+	if _, err := client.GetLeagueTeams(context.Background(), "nba.l.1"); err != nil {
+		t.Fatalf("GetLeagueTeams() error = %v", err)
+	}
+	if _, err := client.cache.(*APICache).db.Exec(`UPDATE yahoo_api_cache SET expires_at = ?`, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	failing.Store(true)
+
+	ctx, meta := WithResponseMetadata(context.Background())
+	teams, err := client.GetLeagueTeams(ctx, "nba.l.1")
+	if err != nil {
+		t.Fatalf("GetLeagueTeams() error = %v, want stale data", err)
+	}
+	if len(teams) != 1 || teams[0].TeamName != "Stale Team" {
+		t.Errorf("teams = %+v, want the cached team", teams)
+	}
+	if !meta.FromCache || !meta.Stale {
+		t.Errorf("meta = %+v, want FromCache and Stale", meta)
+	}
+	var apiErr *YahooAPIError
+	if !errors.As(meta.StaleErr, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("meta.StaleErr = %v, want the 503 that caused the fallback", meta.StaleErr)
+	}
+}
+
+func TestStaleEntryNotServedByDefault(t *testing.T) {
+	var failing atomic.Bool
+	client := newCachingClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(teamsFixture))
+	})
+
+	if _, err := client.GetLeagueTeams(context.Background(), "nba.l.1"); err != nil {
+		t.Fatalf("GetLeagueTeams() error = %v", err)
+	}
+	if _, err := client.cache.(*APICache).db.Exec(`UPDATE yahoo_api_cache SET expires_at = ?`, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	failing.Store(true)
+
+	if _, err := client.GetLeagueTeams(context.Background(), "nba.l.1"); err == nil {
+		t.Fatal("GetLeagueTeams() error = nil, want the fetch error")
+	}
+}
+
+func TestResponseMetadataReportsFreshCacheHit(t *testing.T) {
+	var requests atomic.Int32
+	client := newCachingClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(teamsFixture))
+	})
+
+	ctx, meta := WithResponseMetadata(context.Background())
+	if _, err := client.GetLeagueTeams(ctx, "nba.l.1"); err != nil {
+		t.Fatalf("GetLeagueTeams() error = %v", err)
+	}
+	if meta.FromCache {
+		t.Errorf("first call: meta.FromCache = true, want false")
+	}
+
+	ctx, meta = WithResponseMetadata(context.Background())
+	if _, err := client.GetLeagueTeams(ctx, "nba.l.1"); err != nil {
+		t.Fatalf("GetLeagueTeams() error = %v", err)
+	}
+	if !meta.FromCache || meta.Stale || meta.ExpiresAt.Before(time.Now()) {
+		t.Errorf("second call: meta = %+v, want fresh cache hit", meta)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("upstream requests = %d, want 1", n)
+	}
+}
+
+func TestForceRefreshSkipsCacheReadAndUpdatesEntry(t *testing.T) {
+	var requests atomic.Int32
+	client := newCachingClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(teamsFixture))
+	})
+
+	ctx := context.Background()
+	if _, err := client.GetLeagueTeams(ctx, "nba.l.1"); err != nil {
+		t.Fatalf("GetLeagueTeams() error = %v", err)
+	}
+	if _, err := client.cache.(*APICache).db.Exec(`UPDATE yahoo_api_cache SET cache_value = '[]'`); err != nil {
+		t.Fatal(err)
+	}
+
+	teams, err := client.GetLeagueTeams(WithForceRefresh(ctx), "nba.l.1")
+	if err != nil {
+		t.Fatalf("GetLeagueTeams() error = %v", err)
+	}
+	if len(teams) != 1 {
+		t.Errorf("force refresh returned %d teams, want live data", len(teams))
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("upstream requests = %d, want 2", n)
+	}
+
+	teams, err = client.GetLeagueTeams(ctx, "nba.l.1")
+	if err != nil {
+		t.Fatalf("GetLeagueTeams() error = %v", err)
+	}
+	if len(teams) != 1 || requests.Load() != 2 {
+		t.Errorf("cache was not updated by the forced refresh")
+	}
+}
+
+func TestCacheBypassLeavesCacheUntouched(t *testing.T) {
+	var requests atomic.Int32
+	client := newCachingClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(teamsFixture))
+	})
+
+	ctx := WithCacheBypass(context.Background())
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetLeagueTeams(ctx, "nba.l.1"); err != nil {
+			t.Fatalf("GetLeagueTeams() error = %v", err)
+		}
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("upstream requests = %d, want 2", n)
+	}
+
+	var entries int
+	if err := client.cache.(*APICache).db.QueryRow(`SELECT COUNT(*) FROM yahoo_api_cache`).Scan(&entries); err != nil {
+		t.Fatal(err)
+	}
+	if entries != 0 {
+		t.Errorf("cache entries = %d, want 0", entries)
+	}
+}
+
+func TestCacheIsNamespacedPerUser(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(teamsFixture))
+	}))
+	defer server.Close()
+
+	base := NewClient("key", "secret", nil, WithCache(NewMemoryCache(0, 0)))
+	base.baseURL = server.URL
+	alice := base.WithToken(Token{AccessToken: "alice", RefreshToken: "alice-refresh"})
+	bob := base.WithToken(Token{AccessToken: "bob", RefreshToken: "bob-refresh"})
+
+	ctx := context.Background()
+	for _, client := range []*Client{alice, bob, alice, bob} {
+		if _, err := client.GetLeagueTeams(ctx, "nba.l.1"); err != nil {
+			t.Fatalf("GetLeagueTeams() error = %v", err)
+		}
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("upstream requests = %d, want one per user", n)
+	}
+}
+
+func TestClearUserCacheRemovesOnlyThatUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(teamsFixture))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(0, 0)
+	base := NewClient("key", "secret", nil, WithCache(cache))
+	base.baseURL = server.URL
+
+	ctx := context.Background()
+	for _, guid := range []string{"ALICE", "BOB"} {
+		client := base.WithToken(Token{AccessToken: guid})
+		client.userGUID = guid
+		if _, err := client.GetLeagueTeams(ctx, "nba.l.1"); err != nil {
+			t.Fatalf("GetLeagueTeams() error = %v", err)
+		}
+	}
+
+	if err := base.ClearUserCache("ALICE"); err != nil {
+		t.Fatalf("ClearUserCache() error = %v", err)
+	}
+	if _, _, err := cache.Get("guid:ALICE/league:nba.l.1:teams"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("alice's entry survived ClearUserCache: err = %v", err)
+	}
+	if _, _, err := cache.Get("guid:BOB/league:nba.l.1:teams"); err != nil {
+		t.Errorf("bob's entry was removed: err = %v", err)
+	}
+}
+
+func TestAPICacheDeletePrefix(t *testing.T) {
+	cache := NewAPICache(newTestCacheDB(t))
+	for _, key := range []string{"guid:A/x", "guid:A/y", "guid:AB/x"} {
+		if err := cache.Set(key, []byte(`{}`), time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := cache.DeletePrefix("guid:A/"); err != nil {
+		t.Fatalf("DeletePrefix() error = %v", err)
+	}
+	for key, want := range map[string]bool{"guid:A/x": false, "guid:A/y": false, "guid:AB/x": true} {
+		_, _, err := cache.Get(key)
+		if got := err == nil; got != want {
+			t.Errorf("%s present = %v, want %v (err = %v)", key, got, want, err)
+		}
+	}
+}
+
+func TestCacheStatsAndListKeys(t *testing.T) {
+	caches := map[string]interface {
+		Cache
+		Stats() (CacheStats, error)
+		ListKeys(prefix string) ([]string, error)
+	}{
+		"sqlite": NewAPICache(newTestCacheDB(t)),
+		"memory": NewMemoryCache(0, 0),
+	}
+
+	for name, cache := range caches {
+		t.Run(name, func(t *testing.T) {
+			cache.Set("league:1:teams", []byte("abc"), time.Hour)
+			cache.Set("league:1:standings", []byte("de"), 2*time.Hour)
+			cache.Set("team:1:roster", []byte("f"), -time.Minute)
+
+			cache.Get("league:1:teams")
+			cache.Get("team:1:roster")
+			cache.Get("missing")
+
+			stats, err := cache.Stats()
+			if err != nil {
+				t.Fatalf("Stats() error = %v", err)
+			}
+			wantBytes := int64(len("league:1:teams") + 3 + len("league:1:standings") + 2 + len("team:1:roster") + 1)
+			if stats.Entries != 3 || stats.Bytes != wantBytes {
+				t.Errorf("Entries, Bytes = %d, %d, want 3, %d", stats.Entries, stats.Bytes, wantBytes)
+			}
+			if stats.Hits != 1 || stats.Misses != 2 {
+				t.Errorf("Hits, Misses = %d, %d, want 1, 2", stats.Hits, stats.Misses)
+			}
+			if !stats.OldestExpiry.Before(time.Now()) || stats.NewestExpiry.Before(time.Now().Add(time.Hour)) {
+				t.Errorf("expiry range = %v..%v", stats.OldestExpiry, stats.NewestExpiry)
+			}
+
+			keys, err := cache.ListKeys("league:")
+			if err != nil {
+				t.Fatalf("ListKeys() error = %v", err)
+			}
+			if len(keys) != 2 || keys[0] != "league:1:standings" || keys[1] != "league:1:teams" {
+				t.Errorf("ListKeys(league:) = %v", keys)
+			}
+		})
+	}
+}
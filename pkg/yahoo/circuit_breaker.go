@@ -0,0 +1,82 @@
+package yahoo
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker stops requests to Yahoo after threshold consecutive server
+// errors or timeouts. While open, requests fail fast with ErrCircuitOpen;
+// after cooldown a single trial request is let through, which closes the
+// circuit on success or re-opens it on failure. A nil *circuitBreaker allows
+// everything.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	if threshold < 1 {
+		threshold = 1
+	}
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a request may be sent.
+func (b *circuitBreaker) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openedAt.IsZero() {
+		return true
+	}
+	if b.now().Sub(b.openedAt) < b.cooldown || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record notes the outcome of a request and reports whether it opened the
+// circuit.
+func (b *circuitBreaker) record(failed bool) (opened bool) {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbing := b.probing
+	b.probing = false
+	if !failed {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		return false
+	}
+
+	b.failures++
+	if wasProbing || (b.openedAt.IsZero() && b.failures >= b.threshold) {
+		b.openedAt = b.now()
+		return true
+	}
+	return false
+}
+
+// release ends a request without an outcome, such as one whose context was
+// cancelled, so another trial request can be let through.
+func (b *circuitBreaker) release() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
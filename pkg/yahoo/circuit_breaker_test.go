@@ -0,0 +1,97 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerStates(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newCircuitBreaker(3, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.record(true)
+	b.record(true)
+	b.record(false)
+	b.record(true)
+	b.record(true)
+	if !b.allow() {
+		t.Fatal("a success should reset the consecutive failure count")
+	}
+	if !b.record(true) {
+		t.Fatal("third consecutive failure should open the circuit")
+	}
+	if b.allow() {
+		t.Fatal("open circuit should reject requests")
+	}
+
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("circuit should allow a trial request after the cooldown")
+	}
+	if b.allow() {
+		t.Fatal("only one trial request should be allowed while half-open")
+	}
+	if !b.record(true) {
+		t.Fatal("failed trial should re-open the circuit")
+	}
+	if b.allow() {
+		t.Fatal("re-opened circuit should reject requests")
+	}
+
+	now = now.Add(time.Minute)
+	b.allow()
+	b.record(false)
+	if !b.allow() || !b.allow() {
+		t.Fatal("successful trial should close the circuit")
+	}
+}
+
+func TestClientCircuitBreakerShortCircuits(t *testing.T) {
+	server, calls := newFlakyServer(100, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil,
+		WithInitialToken(Token{AccessToken: "token"}),
+		WithRetryPolicy(testRetryPolicy(5)),
+		WithCircuitBreaker(2, time.Hour),
+	)
+	client.baseURL = server.URL
+
+	if _, err := client.makeRequest(context.Background(), "league/1"); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("error = %v, want ErrCircuitOpen once retries trip the breaker", err)
+	}
+	if *calls != 2 {
+		t.Errorf("calls = %d, want 2", *calls)
+	}
+
+	if _, err := client.makeRequest(context.Background(), "league/2"); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("error = %v, want ErrCircuitOpen", err)
+	}
+	if *calls != 2 {
+		t.Errorf("calls = %d, open circuit must not reach Yahoo", *calls)
+	}
+}
+
+func TestCircuitBreakerReleasedProbe(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newCircuitBreaker(1, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.record(true)
+	now = now.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("circuit should allow a trial request after the cooldown")
+	}
+
+	b.release()
+	if !b.allow() {
+		t.Fatal("a released trial should let another through")
+	}
+	if b.allow() {
+		t.Fatal("a released trial should not close the circuit")
+	}
+}
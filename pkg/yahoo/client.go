@@ -4,8 +4,8 @@ import (
 	"bytes"
 	"context"
 	"database/sql"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -14,6 +14,9 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Client struct {
@@ -21,16 +24,31 @@ type Client struct {
 	apiSecret    string
 	accessToken  string
 	refreshToken string
+	userGUID     string
+	tokenExpiry  time.Time
+	refreshSkew  time.Duration
 	httpClient   *http.Client
+	limiter      *rateLimiter
+	breaker      *circuitBreaker
+	retryPolicy  RetryPolicy
 	baseURL      string
 	tokenURL     string
-	cache        *APICache
+	authURL      string
+	userInfoURL  string
+	redirectURI  string
+	cache        Cache
 	tokenMutex   sync.Mutex
 	cacheEnabled bool
-}
+	serveStale   bool
+	cachePolicy  CachePolicy
+	logger       Logger
+	tracer       trace.Tracer
+	metrics      *Metrics
+	inflight     *requestGroup
 
-type APICache struct {
-	db *sql.DB
+	responseFormat ResponseFormat
+
+	onTokenRefresh func(Token)
 }
 
 type League struct {
@@ -41,6 +59,11 @@ type League struct {
 	ScoringType   string
 	NumTeams      int
 	CurrentWeek   int
+	// Renew is the league key of the season this league was renewed from,
+	// and Renewed that of the season it was renewed into. Each is empty
+	// when there is no such season.
+	Renew   string
+	Renewed string
 }
 
 type Team struct {
@@ -48,10 +71,13 @@ type Team struct {
 	YahooTeamKey  string
 	TeamName      string
 	ManagerName   string
+	// ManagerGUID identifies the manager across seasons and name changes.
+	ManagerGUID   string
 	Wins          int
 	Losses        int
 	Ties          int
 	Rank          int
+	TeamMetadata
 }
 
 type Roster struct {
@@ -61,15 +87,31 @@ type Roster struct {
 	Position     string
 	SelectedPos  string
 	IsStarting   bool
+	// EligiblePositions lists every position the player can fill; Position
+	// is the first of them.
+	EligiblePositions []string
+	// Name and EditorialTeamAbbr identify the player well enough to store
+	// them without a separate player lookup.
+	Name              PlayerName
+	EditorialTeamAbbr string
+}
+
+// DailyRoster is a team's roster on a single date, as used by daily sports
+// such as NBA, MLB and NHL.
+type DailyRoster struct {
+	TeamKey string
+	// Date is the coverage date Yahoo reported for the roster.
+	Date    time.Time
+	Players []Roster
 }
 
 type yahooLeaguesResponse struct {
 	Fantasy_Content struct {
-		Users []struct {
-			User []struct {
-				Games []struct {
-					Game []struct {
-						Leagues []struct {
+		Users yahooList[struct {
+			User yahooList[struct {
+				Games yahooList[struct {
+					Game yahooList[struct {
+						Leagues yahooList[struct {
 							League struct {
 								League_Key  string `json:"league_key"`
 								League_ID   string `json:"league_id"`
@@ -78,28 +120,32 @@ type yahooLeaguesResponse struct {
 								Scoring_Type string `json:"scoring_type"`
 								Num_Teams   int    `json:"num_teams"`
 								Current_Week int   `json:"current_week"`
+								Renew       string `json:"renew"`
+								Renewed     string `json:"renewed"`
 							} `json:"league"`
-						} `json:"leagues"`
-					} `json:"game"`
-				} `json:"games"`
-			} `json:"user"`
-		} `json:"users"`
+						}] `json:"leagues"`
+					}] `json:"game"`
+				}] `json:"games"`
+			}] `json:"user"`
+		}] `json:"users"`
 	} `json:"fantasy_content"`
 }
 
 type yahooTeamsResponse struct {
 	Fantasy_Content struct {
-		League struct {
-			Teams []struct {
-				Team struct {
+		League yahooObject[struct {
+			Teams yahooList[struct {
+				Team yahooObject[struct {
+					yahooTeamMetadata
 					Team_Key    string `json:"team_key"`
 					Team_ID     string `json:"team_id"`
 					Name        string `json:"name"`
-					Managers    []struct {
+					Managers    yahooList[struct {
 						Manager struct {
 							Nickname string `json:"nickname"`
+							Guid     string `json:"guid"`
 						} `json:"manager"`
-					} `json:"managers"`
+					}] `json:"managers"`
 					Team_Standings struct {
 						Rank           int `json:"rank"`
 						Outcome_Totals struct {
@@ -108,9 +154,9 @@ type yahooTeamsResponse struct {
 							Ties   int `json:"ties"`
 						} `json:"outcome_totals"`
 					} `json:"team_standings"`
-				} `json:"team"`
-			} `json:"teams"`
-		} `json:"league"`
+				}] `json:"team"`
+			}] `json:"teams"`
+		}] `json:"league"`
 	} `json:"fantasy_content"`
 }
 
@@ -118,18 +164,23 @@ type yahooRosterResponse struct {
 	Fantasy_Content struct {
 		Team struct {
 			Roster struct {
-				Players []struct {
+				Coverage_Type string `json:"coverage_type"`
+				Date          string `json:"date"`
+
+				Players yahooList[struct {
 					Player struct {
 						Player_Key        string `json:"player_key"`
 						Player_ID         string `json:"player_id"`
-						Eligible_Positions []struct {
+						Name              PlayerName `json:"name"`
+						Editorial_Team_Abbr string `json:"editorial_team_abbr"`
+						Eligible_Positions yahooList[struct {
 							Position string `json:"position"`
-						} `json:"eligible_positions"`
+						}] `json:"eligible_positions"`
 						Selected_Position struct {
 							Position string `json:"position"`
 						} `json:"selected_position"`
 					} `json:"player"`
-				} `json:"players"`
+				}] `json:"players"`
 			} `json:"roster"`
 		} `json:"team"`
 	} `json:"fantasy_content"`
@@ -142,7 +193,7 @@ type tokenResponse struct {
 	TokenType    string `json:"token_type"`
 }
 
-func NewClient(apiKey, apiSecret string, db *sql.DB) *Client {
+func NewClient(apiKey, apiSecret string, db *sql.DB, opts ...ClientOption) *Client {
 	if apiKey == "" {
 		apiKey = os.Getenv("YAHOO_CONSUMER_KEY")
 	}
@@ -161,191 +212,399 @@ func NewClient(apiKey, apiSecret string, db *sql.DB) *Client {
 
 	tokenURL := "https://api.login.yahoo.com/oauth2/get_token"
 
-	return &Client{
+	client := &Client{
 		apiKey:       apiKey,
 		apiSecret:    apiSecret,
 		accessToken:  accessToken,
 		refreshToken: refreshToken,
+		refreshSkew:  defaultTokenRefreshSkew,
 		httpClient:   &http.Client{Timeout: 30 * time.Second},
+		retryPolicy:  DefaultRetryPolicy(),
 		baseURL:      baseURL,
 		tokenURL:     tokenURL,
-		cache:        &APICache{db: db},
+		authURL:      defaultAuthURL,
+		userInfoURL:  defaultUserInfoURL,
+		redirectURI:  os.Getenv("YAHOO_REDIRECT_URI"),
+		cache:        newDefaultCache(db),
 		cacheEnabled: cacheEnabled,
-	}
-}
-
-func (c *Client) GetUserLeagues(ctx context.Context, gameKey string) ([]League, error) {
-	cacheKey := fmt.Sprintf("user:leagues:%s", gameKey)
+		logger:       noopLogger{},
+		tracer:       defaultTracer(),
+		inflight:     newRequestGroup(),
 
-	if c.cacheEnabled {
-		if cached, err := c.cache.Get(cacheKey); err == nil {
-			var leagues []League
-			if json.Unmarshal([]byte(cached), &leagues) == nil {
-				return leagues, nil
-			}
-		}
+		responseFormat: FormatJSON,
 	}
 
-	leagues, err := c.fetchLeagues(ctx, gameKey)
-	if err != nil {
-		return nil, err
+	for _, opt := range opts {
+		opt(client)
 	}
+	return client
+}
 
-	if c.cacheEnabled {
-		c.cache.Set(cacheKey, leagues, 24*time.Hour)
+// WithToken returns a shallow clone of c that shares its transport, cache and
+// configuration but carries its own token set, so one shared client can make
+// calls on behalf of different users. Refreshing the clone's token does not
+// affect c.
+func (c *Client) WithToken(token Token) *Client {
+	return &Client{
+		apiKey:       c.apiKey,
+		apiSecret:    c.apiSecret,
+		accessToken:  token.AccessToken,
+		refreshToken: token.RefreshToken,
+		tokenExpiry:  token.Expiry,
+		refreshSkew:  c.refreshSkew,
+		httpClient:   c.httpClient,
+		limiter:      c.limiter,
+		breaker:      c.breaker,
+		retryPolicy:  c.retryPolicy,
+		baseURL:      c.baseURL,
+		tokenURL:     c.tokenURL,
+		authURL:      c.authURL,
+		userInfoURL:  c.userInfoURL,
+		redirectURI:  c.currentRedirectURI(),
+		cache:        c.cache,
+		cacheEnabled: c.cacheEnabled,
+		serveStale:   c.serveStale,
+		cachePolicy:  c.cachePolicy,
+		logger:       c.logger,
+		tracer:       c.tracer,
+		metrics:      c.metrics,
+		inflight:     c.inflight,
+
+		responseFormat: c.responseFormat,
 	}
-	return leagues, nil
 }
 
-func (c *Client) GetLeagueTeams(ctx context.Context, leagueKey string) ([]Team, error) {
-	cacheKey := fmt.Sprintf("league:%s:teams", leagueKey)
+func (c *Client) GetUserLeagues(ctx context.Context, gameKey string) ([]League, error) {
+	cacheKey := fmt.Sprintf("user:leagues:%s", gameKey)
 
-	if c.cacheEnabled {
-		if cached, err := c.cache.Get(cacheKey); err == nil {
-			var teams []Team
-			if json.Unmarshal([]byte(cached), &teams) == nil {
-				return teams, nil
-			}
-		}
-	}
+	return cachedFetch(ctx, c, CacheLeagues, cacheKey, func() ([]League, error) {
+		return c.fetchLeagues(ctx, gameKey)
+	})
+}
 
-	teams, err := c.fetchTeams(ctx, leagueKey)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) GetLeagueTeams(ctx context.Context, leagueKey string) ([]Team, error) {
+	cacheKey := fmt.Sprintf("league:%s:teams", leagueKey)
 
-	if c.cacheEnabled {
-		c.cache.Set(cacheKey, teams, 6*time.Hour)
-	}
-	return teams, nil
+	return cachedFetch(ctx, c, CacheTeams, cacheKey, func() ([]Team, error) {
+		return c.fetchTeams(ctx, leagueKey)
+	})
 }
 
 func (c *Client) GetTeamRoster(ctx context.Context, teamKey string) ([]Roster, error) {
 	cacheKey := fmt.Sprintf("team:%s:roster", teamKey)
 
-	if c.cacheEnabled {
-		if cached, err := c.cache.Get(cacheKey); err == nil {
-			var roster []Roster
-			if json.Unmarshal([]byte(cached), &roster) == nil {
-				return roster, nil
-			}
-		}
-	}
+	return cachedFetch(ctx, c, CacheRosters, cacheKey, func() ([]Roster, error) {
+		return c.fetchRoster(ctx, teamKey)
+	})
+}
 
-	roster, err := c.fetchRoster(ctx, teamKey)
-	if err != nil {
-		return nil, err
-	}
+// GetTeamRosterForDate returns the team's roster on the given date. Only the
+// calendar date of date is used.
+func (c *Client) GetTeamRosterForDate(ctx context.Context, teamKey string, date time.Time) (*DailyRoster, error) {
+	day := date.Format(rosterDateLayout)
+	cacheKey := fmt.Sprintf("team:%s:roster:date_%s", teamKey, day)
 
-	if c.cacheEnabled {
-		c.cache.Set(cacheKey, roster, 1*time.Hour)
-	}
-	return roster, nil
+	return cachedFetch(ctx, c, CacheRosters, cacheKey, func() (*DailyRoster, error) {
+		return c.fetchRosterForDate(ctx, teamKey, day)
+	})
+}
+
+// currentAccessToken returns the access token under tokenMutex; token fields
+// must never be read directly while requests may be in flight.
+func (c *Client) currentAccessToken() string {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+	return c.accessToken
 }
 
-func (c *Client) refreshAccessToken() error {
+// currentRedirectURI returns the redirect URI under tokenMutex, since
+// GetAuthorizationURL may set it while a code exchange reads it.
+func (c *Client) currentRedirectURI() string {
 	c.tokenMutex.Lock()
 	defer c.tokenMutex.Unlock()
+	return c.redirectURI
+}
+
+func (c *Client) canRefresh() bool {
+	c.tokenMutex.Lock()
+	defer c.tokenMutex.Unlock()
+	return c.refreshToken != ""
+}
+
+// ensureFreshToken refreshes the access token ahead of time when its expiry
+// is known and falls within the refresh skew.
+func (c *Client) ensureFreshToken(ctx context.Context) error {
+	c.tokenMutex.Lock()
+	accessToken := c.accessToken
+	expiry := c.tokenExpiry
+	canRefresh := c.refreshToken != ""
+	c.tokenMutex.Unlock()
+
+	if expiry.IsZero() || !canRefresh || time.Until(expiry) > c.refreshSkew {
+		return nil
+	}
+	return c.refreshAccessToken(ctx, accessToken)
+}
+
+// refreshAccessToken exchanges the refresh token for a new access token.
+// staleToken is the access token the caller found to be expired; if another
+// goroutine already replaced it, the refresh is skipped.
+func (c *Client) refreshAccessToken(ctx context.Context, staleToken string) error {
+	c.tokenMutex.Lock()
+
+	if staleToken != "" && c.accessToken != staleToken {
+		c.tokenMutex.Unlock()
+		return nil
+	}
 
 	if c.refreshToken == "" {
-		return fmt.Errorf("no refresh token available")
+		c.tokenMutex.Unlock()
+		return &TokenRefreshError{Err: fmt.Errorf("%w: no refresh token available", ErrTokenExpired)}
 	}
 
 	data := url.Values{}
 	data.Set("grant_type", "refresh_token")
 	data.Set("refresh_token", c.refreshToken)
 
-	req, err := http.NewRequest("POST", c.tokenURL, bytes.NewBufferString(data.Encode()))
+	tokenResp, err := c.requestToken(ctx, data)
 	if err != nil {
-		return fmt.Errorf("failed to create token request: %w", err)
+		c.tokenMutex.Unlock()
+		return &TokenRefreshError{Err: err}
+	}
+
+	c.accessToken = tokenResp.AccessToken
+	if tokenResp.RefreshToken != "" {
+		c.refreshToken = tokenResp.RefreshToken
 	}
+	c.tokenExpiry = tokenResp.toToken().Expiry
+	refreshed := Token{
+		AccessToken:  c.accessToken,
+		RefreshToken: c.refreshToken,
+		TokenType:    tokenResp.TokenType,
+		Expiry:       c.tokenExpiry,
+	}
+	c.tokenMutex.Unlock()
 
-	authHeader := base64.StdEncoding.EncodeToString([]byte(c.apiKey + ":" + c.apiSecret))
-	req.Header.Set("Authorization", "Basic "+authHeader)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	c.metrics.observeTokenRefresh()
+	c.logger.Info("refreshed yahoo access token", "expires_in", tokenResp.ExpiresIn)
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to refresh token: %w", err)
+	if c.onTokenRefresh != nil {
+		c.onTokenRefresh(refreshed)
 	}
-	defer resp.Body.Close()
+	return nil
+}
+
+func (c *Client) makeRequest(ctx context.Context, endpoint string) ([]byte, error) {
+	return c.makeFormattedRequest(ctx, endpoint, FormatJSON)
+}
+
+func (c *Client) makeFormattedRequest(ctx context.Context, endpoint string, format ResponseFormat) (body []byte, err error) {
+	ctx, span := c.tracer.Start(ctx, "yahoo.request",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(endpointAttributes(endpoint)...),
+	)
+	start := time.Now()
+	defer func() {
+		c.metrics.observeRequest(endpoint, time.Since(start), err)
+		endSpan(span, err)
+	}()
+
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+	return c.getShared(ctx, fmt.Sprintf("%s/%s%sformat=%s", c.baseURL, endpoint, separator, format))
+}
 
-	body, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("token refresh failed (status %d): %s", resp.StatusCode, string(body))
+// makeWriteRequest sends an XML payload to endpoint and returns Yahoo's XML
+// response. Writes are neither retried nor coalesced, since repeating one
+// could apply it twice.
+func (c *Client) makeWriteRequest(ctx context.Context, method, endpoint string, payload []byte) (body []byte, err error) {
+	ctx, span := c.tracer.Start(ctx, "yahoo.request",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(endpointAttributes(endpoint)...),
+		trace.WithAttributes(attribute.String("http.request.method", method)),
+	)
+	start := time.Now()
+	defer func() {
+		c.metrics.observeRequest(endpoint, time.Since(start), err)
+		endSpan(span, err)
+	}()
+
+	if c.currentAccessToken() == "" {
+		return nil, fmt.Errorf("%w: access token not configured - set YAHOO_ACCESS_TOKEN environment variable", ErrUnauthorized)
+	}
+	if !c.breaker.allow() {
+		return nil, ErrCircuitOpen
+	}
+
+	url := fmt.Sprintf("%s/%s?format=%s", c.baseURL, endpoint, FormatXML)
+	body, status, err := c.sendOnce(ctx, method, url, payload)
+	var refreshErr *TokenRefreshError
+	if err != nil && (ctx.Err() != nil || errors.As(err, &refreshErr)) {
+		c.breaker.release()
+	} else if c.breaker.record(err != nil && status >= 500) {
+		c.logger.Warn("yahoo circuit breaker opened", "url", url, "status", status, "error", err)
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", status))
+	if err != nil {
+		c.logger.Error("yahoo write failed", "url", url, "method", method, "status", status, "error", err)
+		return nil, err
 	}
+	c.logger.Debug("yahoo write", "url", url, "method", method, "status", status, "duration", time.Since(start))
+	return body, nil
+}
+
+// getShared coalesces concurrent identical requests made with the same access
+// token into one upstream call.
+func (c *Client) getShared(ctx context.Context, url string) ([]byte, error) {
+	key := url + "\x00" + c.currentAccessToken()
+	return c.inflight.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+		return c.doAuthorizedGet(ctx, url)
+	})
+}
 
-	var tokenResp tokenResponse
-	if err := json.Unmarshal(body, &tokenResp); err != nil {
-		return fmt.Errorf("failed to parse token response: %w", err)
+// doAuthorizedGet issues a bearer-authenticated GET against an absolute URL,
+// refreshing the access token when it is expiring or rejected and retrying
+// transient failures according to the client's retry policy.
+func (c *Client) doAuthorizedGet(ctx context.Context, url string) ([]byte, error) {
+	if c.currentAccessToken() == "" {
+		return nil, fmt.Errorf("%w: access token not configured - set YAHOO_ACCESS_TOKEN environment variable", ErrUnauthorized)
 	}
 
-	c.accessToken = tokenResp.AccessToken
-	if tokenResp.RefreshToken != "" {
-		c.refreshToken = tokenResp.RefreshToken
+	for attempt := 1; ; attempt++ {
+		if !c.breaker.allow() {
+			return nil, ErrCircuitOpen
+		}
+
+		start := time.Now()
+		body, status, err := c.getOnce(ctx, url)
+		var refreshErr *TokenRefreshError
+		if err != nil && (ctx.Err() != nil || errors.As(err, &refreshErr)) {
+			// Cancelled attempts and token refresh failures say nothing
+			// about Yahoo's API health.
+			c.breaker.release()
+		} else if c.breaker.record(err != nil && (status >= 500 || status == 0)) {
+			c.logger.Warn("yahoo circuit breaker opened", "url", url, "status", status, "error", err)
+		}
+		trace.SpanFromContext(ctx).SetAttributes(
+			attribute.Int("http.response.status_code", status),
+			attribute.Int("yahoo.attempts", attempt),
+		)
+		if err == nil {
+			c.logger.Debug("yahoo request", "url", url, "status", status, "attempt", attempt, "duration", time.Since(start))
+			return body, nil
+		}
+		if attempt >= c.retryPolicy.MaxAttempts || !c.retryPolicy.shouldRetry(ctx, status, err) {
+			c.logger.Error("yahoo request failed", "url", url, "status", status, "attempt", attempt, "error", err)
+			return nil, err
+		}
+		wait := c.retryPolicy.backoff(attempt)
+		c.logger.Warn("retrying yahoo request", "url", url, "status", status, "attempt", attempt, "backoff", wait, "error", err)
+		if waitErr := sleepContext(ctx, wait); waitErr != nil {
+			return nil, err
+		}
 	}
+}
 
-	fmt.Printf("✅ Refreshed Yahoo access token (expires in %d seconds)\n", tokenResp.ExpiresIn)
-	return nil
+// getOnce performs a single attempt and reports the HTTP status, or 0 when
+// no response was received.
+func (c *Client) getOnce(ctx context.Context, url string) ([]byte, int, error) {
+	return c.sendOnce(ctx, http.MethodGet, url, nil)
 }
 
-func (c *Client) makeRequest(ctx context.Context, endpoint string) ([]byte, error) {
-	if c.accessToken == "" {
-		return nil, fmt.Errorf("Yahoo access token not configured - set YAHOO_ACCESS_TOKEN environment variable")
+// sendOnce performs a single attempt of a request with an optional XML body,
+// retrying it once with a refreshed token if Yahoo rejects the token.
+func (c *Client) sendOnce(ctx context.Context, method, url string, payload []byte) ([]byte, int, error) {
+	if err := c.ensureFreshToken(ctx); err != nil {
+		return nil, 0, fmt.Errorf("expiring access token: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/%s?format=json", c.baseURL, endpoint)
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	usedToken := c.currentAccessToken()
+	req, err := newAuthorizedRequest(ctx, method, url, payload, usedToken)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
-	req.Header.Set("Accept", "application/json")
-
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return nil, 0, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusUnauthorized {
-		body, _ := io.ReadAll(resp.Body)
-		if strings.Contains(string(body), "token_expired") {
-			if err := c.refreshAccessToken(); err != nil {
-				return nil, fmt.Errorf("failed to refresh expired token: %w", err)
-			}
+	if resp.StatusCode == http.StatusUnauthorized && c.canRefresh() {
+		if err := c.refreshAccessToken(ctx, usedToken); err != nil {
+			return nil, resp.StatusCode, fmt.Errorf("%w: %w", ErrTokenExpired, err)
+		}
 
-			req, err = http.NewRequestWithContext(ctx, "GET", url, nil)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create retry request: %w", err)
-			}
-			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.accessToken))
-			req.Header.Set("Accept", "application/json")
+		req, err = newAuthorizedRequest(ctx, method, url, payload, c.currentAccessToken())
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to create retry request: %w", err)
+		}
 
-			resp, err = c.httpClient.Do(req)
-			if err != nil {
-				return nil, fmt.Errorf("failed to retry request: %w", err)
-			}
-			defer resp.Body.Close()
+		resp, err = c.do(req)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to retry request: %w", err)
 		}
+		defer resp.Body.Close()
 	}
 
-	if resp.StatusCode != http.StatusOK {
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("Yahoo API error (status %d): %s", resp.StatusCode, string(body))
+		return nil, resp.StatusCode, newYahooAPIError(resp.StatusCode, body)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read response: %w", err)
+	}
+	return body, resp.StatusCode, nil
+}
+
+func newAuthorizedRequest(ctx context.Context, method, url string, payload []byte, token string) (*http.Request, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Accept", acceptHeader(url))
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/xml")
 	}
+	return req, nil
+}
 
-	return io.ReadAll(resp.Body)
+// acceptHeader matches the Accept header to the format query parameter.
+func acceptHeader(url string) string {
+	if strings.Contains(url, "format=xml") {
+		return "application/xml"
+	}
+	return "application/json"
+}
+
+// do sends req once the rate limiter admits it.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if err := c.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return c.httpClient.Do(req)
 }
 
 func (c *Client) fetchLeagues(ctx context.Context, gameKey string) ([]League, error) {
 	endpoint := fmt.Sprintf("users;use_login=1/games;game_keys=%s/leagues", gameKey)
-	data, err := c.makeRequest(ctx, endpoint)
+	data, err := c.makeFormattedRequest(ctx, endpoint, c.responseFormat)
 	if err != nil {
 		return nil, err
 	}
 
+	if c.responseFormat == FormatXML {
+		return decodeLeaguesXML(data, gameKey)
+	}
+
 	var resp yahooLeaguesResponse
 	if err := json.Unmarshal(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to parse leagues response: %w", err)
@@ -368,6 +627,8 @@ func (c *Client) fetchLeagues(ctx context.Context, gameKey string) ([]League, er
 							ScoringType:   l.Scoring_Type,
 							NumTeams:      l.Num_Teams,
 							CurrentWeek:   l.Current_Week,
+							Renew:         renewLeagueKey(l.Renew),
+							Renewed:       renewLeagueKey(l.Renewed),
 						})
 					}
 				}
@@ -391,21 +652,24 @@ func (c *Client) fetchTeams(ctx context.Context, leagueKey string) ([]Team, erro
 	}
 
 	var teams []Team
-	for _, teamItem := range resp.Fantasy_Content.League.Teams {
-		t := teamItem.Team
-		managerName := ""
+	for _, teamItem := range resp.Fantasy_Content.League.Value.Teams {
+		t := teamItem.Team.Value
+		managerName, managerGUID := "", ""
 		if len(t.Managers) > 0 {
 			managerName = t.Managers[0].Manager.Nickname
+			managerGUID = t.Managers[0].Manager.Guid
 		}
 		teams = append(teams, Team{
 			YahooTeamID:  t.Team_ID,
 			YahooTeamKey: t.Team_Key,
 			TeamName:     t.Name,
 			ManagerName:  managerName,
+			ManagerGUID:  managerGUID,
 			Wins:         t.Team_Standings.Outcome_Totals.Wins,
 			Losses:       t.Team_Standings.Outcome_Totals.Losses,
 			Ties:         t.Team_Standings.Outcome_Totals.Ties,
 			Rank:         t.Team_Standings.Rank,
+			TeamMetadata: t.toMetadata(),
 		})
 	}
 
@@ -413,7 +677,37 @@ func (c *Client) fetchTeams(ctx context.Context, leagueKey string) ([]Team, erro
 }
 
 func (c *Client) fetchRoster(ctx context.Context, teamKey string) ([]Roster, error) {
-	endpoint := fmt.Sprintf("team/%s/roster", teamKey)
+	resp, err := c.requestRoster(ctx, fmt.Sprintf("team/%s/roster", teamKey))
+	if err != nil {
+		return nil, err
+	}
+	return convertYahooRoster(resp), nil
+}
+
+const rosterDateLayout = "2006-01-02"
+
+func (c *Client) fetchRosterForDate(ctx context.Context, teamKey, day string) (*DailyRoster, error) {
+	resp, err := c.requestRoster(ctx, fmt.Sprintf("team/%s/roster;date=%s", teamKey, day))
+	if err != nil {
+		return nil, err
+	}
+
+	if d := resp.Fantasy_Content.Team.Roster.Date; d != "" {
+		day = d
+	}
+	date, err := time.Parse(rosterDateLayout, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse roster date %q: %w", day, err)
+	}
+
+	return &DailyRoster{
+		TeamKey: teamKey,
+		Date:    date,
+		Players: convertYahooRoster(resp),
+	}, nil
+}
+
+func (c *Client) requestRoster(ctx context.Context, endpoint string) (*yahooRosterResponse, error) {
 	data, err := c.makeRequest(ctx, endpoint)
 	if err != nil {
 		return nil, err
@@ -423,7 +717,10 @@ func (c *Client) fetchRoster(ctx context.Context, teamKey string) ([]Roster, err
 	if err := json.Unmarshal(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to parse roster response: %w", err)
 	}
+	return &resp, nil
+}
 
+func convertYahooRoster(resp *yahooRosterResponse) []Roster {
 	var roster []Roster
 	for _, playerItem := range resp.Fantasy_Content.Team.Roster.Players {
 		p := playerItem.Player
@@ -431,212 +728,163 @@ func (c *Client) fetchRoster(ctx context.Context, teamKey string) ([]Roster, err
 		if len(p.Eligible_Positions) > 0 {
 			eligiblePos = p.Eligible_Positions[0].Position
 		}
+		var eligible []string
+		for _, pos := range p.Eligible_Positions {
+			eligible = append(eligible, pos.Position)
+		}
 		roster = append(roster, Roster{
-			PlayerID:    p.Player_ID,
-			PlayerKey:   p.Player_Key,
-			Position:    eligiblePos,
-			SelectedPos: p.Selected_Position.Position,
-			IsStarting:  p.Selected_Position.Position != "BN",
+			PlayerID:          p.Player_ID,
+			PlayerKey:         p.Player_Key,
+			Position:          eligiblePos,
+			SelectedPos:       p.Selected_Position.Position,
+			IsStarting:        p.Selected_Position.Position != "BN",
+			EligiblePositions: eligible,
+			Name:              p.Name,
+			EditorialTeamAbbr: p.Editorial_Team_Abbr,
 		})
 	}
 
-	return roster, nil
+	return roster
 }
 
-func (c *APICache) Get(key string) (string, error) {
-	var value string
-	var expiresAt time.Time
-
-	query := `SELECT cache_value, expires_at FROM yahoo_api_cache WHERE cache_key = ?`
-	err := c.db.QueryRow(query, key).Scan(&value, &expiresAt)
-	if err != nil {
-		return "", err
-	}
-
-	if time.Now().After(expiresAt) {
-		c.Delete(key)
-		return "", fmt.Errorf("cache expired")
-	}
-
-	return value, nil
+// GetLeaguePlayers returns count of the league's players with the given
+// status, starting at start. Use QueryLeaguePlayers to filter by position or
+// sort the results.
+func (c *Client) GetLeaguePlayers(ctx context.Context, leagueKey string, status PlayerStatus, start, count int) ([]Player, error) {
+	return c.QueryLeaguePlayers(ctx, leagueKey, PlayerQuery{Status: status, Start: start, Count: count})
 }
 
-func (c *APICache) Set(key string, value interface{}, ttl time.Duration) error {
-	jsonValue, err := json.Marshal(value)
-	if err != nil {
-		return err
+func (c *Client) GetPlayerStats(ctx context.Context, leagueKey, playerKey string, weekNum int) (*Player, error) {
+	weekStr := "season"
+	if weekNum > 0 {
+		weekStr = fmt.Sprintf("week_%d", weekNum)
 	}
+	cacheKey := fmt.Sprintf("player:%s:stats:%s:%s", playerKey, leagueKey, weekStr)
 
-	expiresAt := time.Now().Add(ttl)
-
-	query := `INSERT OR REPLACE INTO yahoo_api_cache (cache_key, cache_value, expires_at) VALUES (?, ?, ?)`
-	_, err = c.db.Exec(query, key, string(jsonValue), expiresAt)
-	return err
-}
-
-func (c *APICache) Delete(key string) error {
-	query := `DELETE FROM yahoo_api_cache WHERE cache_key = ?`
-	_, err := c.db.Exec(query, key)
-	return err
-}
-
-func (c *APICache) CleanExpired() error {
-	query := `DELETE FROM yahoo_api_cache WHERE expires_at < datetime('now')`
-	_, err := c.db.Exec(query)
-	return err
+	return cachedFetch(ctx, c, CachePlayerStats, cacheKey, func() (*Player, error) {
+		return c.fetchPlayerStats(ctx, leagueKey, playerKey, weekNum)
+	})
 }
 
-func (c *Client) GetLeaguePlayers(ctx context.Context, leagueKey string, status PlayerStatus, start, count int) ([]Player, error) {
-	cacheKey := fmt.Sprintf("league:%s:players:%s:%d:%d", leagueKey, status, start, count)
+// GetPlayer returns a player by player key using the /player resource, which
+// is not scoped to a league, optionally including sub-resources such as stats
+// and ownership.
+func (c *Client) GetPlayer(ctx context.Context, playerKey string, out ...PlayerSubresource) (*Player, error) {
+	var parts []string
+	for _, sub := range out {
+		parts = append(parts, string(sub))
+	}
+	endpoint := "player/" + playerKey
+	if len(parts) > 0 {
+		endpoint += ";out=" + strings.Join(parts, ",")
+	}
+	cacheKey := "player:" + playerKey + ":" + strings.Join(parts, ",")
 
-	if c.cacheEnabled {
-		if cached, err := c.cache.Get(cacheKey); err == nil {
-			var players []Player
-			if json.Unmarshal([]byte(cached), &players) == nil {
-				return players, nil
-			}
+	return cachedFetch(ctx, c, CachePlayers, cacheKey, func() (*Player, error) {
+		data, err := c.makeRequest(ctx, endpoint)
+		if err != nil {
+			return nil, err
 		}
-	}
 
-	players, err := c.fetchLeaguePlayers(ctx, leagueKey, status, start, count)
-	if err != nil {
-		return nil, err
-	}
+		var resp yahooPlayerResourceResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse player response: %w", err)
+		}
 
-	if c.cacheEnabled {
-		c.cache.Set(cacheKey, players, 1*time.Hour)
-	}
-	return players, nil
+		player := convertYahooPlayerToPlayer(resp.FantasyContent.Player.Value)
+		if player.PlayerKey == "" {
+			return nil, fmt.Errorf("player response did not contain %s", playerKey)
+		}
+		return &player, nil
+	})
 }
 
-func (c *Client) GetPlayerStats(ctx context.Context, leagueKey, playerKey string, weekNum int) (*Player, error) {
+// GetPlayersStats returns stats for several players in as few requests as
+// possible: Yahoo accepts up to 25 player keys per request, so larger sets are
+// split into batches. weekNum 0 requests season stats. Players are returned
+// in the order Yahoo lists them.
+func (c *Client) GetPlayersStats(ctx context.Context, leagueKey string, playerKeys []string, weekNum int) ([]Player, error) {
 	weekStr := "season"
 	if weekNum > 0 {
 		weekStr = fmt.Sprintf("week_%d", weekNum)
 	}
-	cacheKey := fmt.Sprintf("player:%s:stats:%s:%s", playerKey, leagueKey, weekStr)
 
-	if c.cacheEnabled {
-		if cached, err := c.cache.Get(cacheKey); err == nil {
-			var player Player
-			if json.Unmarshal([]byte(cached), &player) == nil {
-				return &player, nil
-			}
-		}
-	}
+	var players []Player
+	for start := 0; start < len(playerKeys); start += playersPageSize {
+		batch := playerKeys[start:min(start+playersPageSize, len(playerKeys))]
+		cacheKey := fmt.Sprintf("players:%s:stats:%s:%s", strings.Join(batch, ","), leagueKey, weekStr)
 
-	player, err := c.fetchPlayerStats(ctx, leagueKey, playerKey, weekNum)
-	if err != nil {
-		return nil, err
+		batchPlayers, err := cachedFetch(ctx, c, CachePlayerStats, cacheKey, func() ([]Player, error) {
+			return c.fetchPlayersStats(ctx, leagueKey, batch, weekNum)
+		})
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, batchPlayers...)
 	}
 
-	if c.cacheEnabled {
-		c.cache.Set(cacheKey, player, 2*time.Hour)
-	}
-	return player, nil
+	return players, nil
 }
 
 func (c *Client) GetLeagueStandings(ctx context.Context, leagueKey string) (*Standings, error) {
 	cacheKey := fmt.Sprintf("league:%s:standings", leagueKey)
 
-	if c.cacheEnabled {
-		if cached, err := c.cache.Get(cacheKey); err == nil {
-			var standings Standings
-			if json.Unmarshal([]byte(cached), &standings) == nil {
-				return &standings, nil
-			}
-		}
-	}
-
-	standings, err := c.fetchStandings(ctx, leagueKey)
-	if err != nil {
-		return nil, err
-	}
-
-	if c.cacheEnabled {
-		c.cache.Set(cacheKey, standings, 6*time.Hour)
-	}
-	return standings, nil
+	return cachedFetch(ctx, c, CacheStandings, cacheKey, func() (*Standings, error) {
+		return c.fetchStandings(ctx, leagueKey)
+	})
 }
 
+// GetLeagueMatchups returns the league's matchups for weekNum. weekNum 0
+// requests the current week.
 func (c *Client) GetLeagueMatchups(ctx context.Context, leagueKey string, weekNum int) ([]Matchup, error) {
 	cacheKey := fmt.Sprintf("league:%s:matchups:week_%d", leagueKey, weekNum)
 
-	if c.cacheEnabled {
-		if cached, err := c.cache.Get(cacheKey); err == nil {
-			var matchups []Matchup
-			if json.Unmarshal([]byte(cached), &matchups) == nil {
-				return matchups, nil
-			}
-		}
-	}
-
-	matchups, err := c.fetchMatchups(ctx, leagueKey, weekNum)
-	if err != nil {
-		return nil, err
-	}
-
-	if c.cacheEnabled {
-		c.cache.Set(cacheKey, matchups, 1*time.Hour)
-	}
-	return matchups, nil
+	return cachedFetch(ctx, c, CacheScoreboard, cacheKey, func() ([]Matchup, error) {
+		return c.fetchMatchups(ctx, leagueKey, weekNum)
+	})
 }
 
 func (c *Client) GetLeagueDraftResults(ctx context.Context, leagueKey string) ([]DraftResult, error) {
 	cacheKey := fmt.Sprintf("league:%s:draft_results", leagueKey)
 
-	if c.cacheEnabled {
-		if cached, err := c.cache.Get(cacheKey); err == nil {
-			var results []DraftResult
-			if json.Unmarshal([]byte(cached), &results) == nil {
-				return results, nil
-			}
-		}
-	}
+	return cachedFetch(ctx, c, CacheDraftResults, cacheKey, func() ([]DraftResult, error) {
+		return c.fetchDraftResults(ctx, leagueKey)
+	})
+}
 
-	results, err := c.fetchDraftResults(ctx, leagueKey)
-	if err != nil {
-		return nil, err
+// GetLeagueTransactions returns the league's transactions, newest first. With
+// no options it returns every transaction Yahoo reports; options filter by
+// type or team and page through the results.
+func (c *Client) GetLeagueTransactions(ctx context.Context, leagueKey string, opts ...TransactionOption) ([]Transaction, error) {
+	var q transactionQuery
+	for _, opt := range opts {
+		opt(&q)
 	}
+	params := q.params()
+	cacheKey := fmt.Sprintf("league:%s:transactions%s", leagueKey, params)
 
-	if c.cacheEnabled {
-		c.cache.Set(cacheKey, results, 24*time.Hour)
-	}
-	return results, nil
+	return cachedFetch(ctx, c, CacheTransactions, cacheKey, func() ([]Transaction, error) {
+		return c.fetchTransactions(ctx, leagueKey, params)
+	})
 }
 
-func (c *Client) GetLeagueTransactions(ctx context.Context, leagueKey string) ([]Transaction, error) {
-	cacheKey := fmt.Sprintf("league:%s:transactions", leagueKey)
-
-	if c.cacheEnabled {
-		if cached, err := c.cache.Get(cacheKey); err == nil {
-			var transactions []Transaction
-			if json.Unmarshal([]byte(cached), &transactions) == nil {
-				return transactions, nil
-			}
-		}
-	}
+// GetPendingTransactions returns the team's outstanding waiver claims and
+// the trades it has proposed or been offered.
+func (c *Client) GetPendingTransactions(ctx context.Context, leagueKey, teamKey string) ([]Transaction, error) {
+	return c.GetLeagueTransactions(ctx, leagueKey,
+		WithTransactionTypes(TransactionWaiver, TransactionPendingTrade),
+		WithTransactionTeam(teamKey))
+}
 
-	transactions, err := c.fetchTransactions(ctx, leagueKey)
+func (c *Client) fetchLeaguePlayers(ctx context.Context, leagueKey, params string) ([]Player, error) {
+	endpoint := fmt.Sprintf("league/%s/players%s", leagueKey, params)
+	data, err := c.makeFormattedRequest(ctx, endpoint, c.responseFormat)
 	if err != nil {
 		return nil, err
 	}
 
-	if c.cacheEnabled {
-		c.cache.Set(cacheKey, transactions, 30*time.Minute)
-	}
-	return transactions, nil
-}
-
-func (c *Client) fetchLeaguePlayers(ctx context.Context, leagueKey string, status PlayerStatus, start, count int) ([]Player, error) {
-	statusParam := ""
-	if status != "" {
-		statusParam = fmt.Sprintf(";status=%s", status)
-	}
-	endpoint := fmt.Sprintf("league/%s/players%s;start=%d;count=%d", leagueKey, statusParam, start, count)
-	data, err := c.makeRequest(ctx, endpoint)
-	if err != nil {
-		return nil, err
+	if c.responseFormat == FormatXML {
+		return decodePlayersXML(data)
 	}
 
 	var resp yahooPlayerResponse
@@ -645,8 +893,8 @@ func (c *Client) fetchLeaguePlayers(ctx context.Context, leagueKey string, statu
 	}
 
 	var players []Player
-	for _, item := range resp.FantasyContent.League.Players {
-		players = append(players, convertYahooPlayerToPlayer(item.Player))
+	for _, item := range resp.FantasyContent.League.Value.Players {
+		players = append(players, convertYahooPlayerToPlayer(item.Player.Value))
 	}
 
 	return players, nil
@@ -658,11 +906,22 @@ func (c *Client) fetchPlayerStats(ctx context.Context, leagueKey, playerKey stri
 		statsParam = fmt.Sprintf(";type=week;week=%d", weekNum)
 	}
 	endpoint := fmt.Sprintf("league/%s/players;player_keys=%s/stats%s", leagueKey, playerKey, statsParam)
-	data, err := c.makeRequest(ctx, endpoint)
+	data, err := c.makeFormattedRequest(ctx, endpoint, c.responseFormat)
 	if err != nil {
 		return nil, err
 	}
 
+	if c.responseFormat == FormatXML {
+		players, err := decodePlayersXML(data)
+		if err != nil {
+			return nil, err
+		}
+		if len(players) == 0 {
+			return nil, fmt.Errorf("player stats response did not contain %s", playerKey)
+		}
+		return &players[0], nil
+	}
+
 	var resp yahooSinglePlayerResponse
 	if err := json.Unmarshal(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to parse player stats response: %w", err)
@@ -672,13 +931,45 @@ func (c *Client) fetchPlayerStats(ctx context.Context, leagueKey, playerKey stri
 	return &player, nil
 }
 
+func (c *Client) fetchPlayersStats(ctx context.Context, leagueKey string, playerKeys []string, weekNum int) ([]Player, error) {
+	statsParam := ""
+	if weekNum > 0 {
+		statsParam = fmt.Sprintf(";type=week;week=%d", weekNum)
+	}
+	endpoint := fmt.Sprintf("league/%s/players;player_keys=%s/stats%s", leagueKey, strings.Join(playerKeys, ","), statsParam)
+	data, err := c.makeFormattedRequest(ctx, endpoint, c.responseFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.responseFormat == FormatXML {
+		return decodePlayersXML(data)
+	}
+
+	var resp yahooPlayerResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse players stats response: %w", err)
+	}
+
+	var players []Player
+	for _, item := range resp.FantasyContent.League.Value.Players {
+		players = append(players, convertYahooPlayerToPlayer(item.Player.Value))
+	}
+
+	return players, nil
+}
+
 func (c *Client) fetchStandings(ctx context.Context, leagueKey string) (*Standings, error) {
 	endpoint := fmt.Sprintf("league/%s/standings", leagueKey)
-	data, err := c.makeRequest(ctx, endpoint)
+	data, err := c.makeFormattedRequest(ctx, endpoint, c.responseFormat)
 	if err != nil {
 		return nil, err
 	}
 
+	if c.responseFormat == FormatXML {
+		return decodeStandingsXML(data)
+	}
+
 	var resp yahooStandingsResponse
 	if err := json.Unmarshal(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to parse standings response: %w", err)
@@ -693,7 +984,10 @@ func (c *Client) fetchStandings(ctx context.Context, leagueKey string) (*Standin
 }
 
 func (c *Client) fetchMatchups(ctx context.Context, leagueKey string, weekNum int) ([]Matchup, error) {
-	endpoint := fmt.Sprintf("league/%s/scoreboard;week=%d", leagueKey, weekNum)
+	endpoint := fmt.Sprintf("league/%s/scoreboard", leagueKey)
+	if weekNum > 0 {
+		endpoint += fmt.Sprintf(";week=%d", weekNum)
+	}
 	data, err := c.makeRequest(ctx, endpoint)
 	if err != nil {
 		return nil, err
@@ -732,21 +1026,25 @@ func (c *Client) fetchDraftResults(ctx context.Context, leagueKey string) ([]Dra
 	return results, nil
 }
 
-func (c *Client) fetchTransactions(ctx context.Context, leagueKey string) ([]Transaction, error) {
-	endpoint := fmt.Sprintf("league/%s/transactions", leagueKey)
-	data, err := c.makeRequest(ctx, endpoint)
+func (c *Client) fetchTransactions(ctx context.Context, leagueKey, params string) ([]Transaction, error) {
+	endpoint := fmt.Sprintf("league/%s/transactions%s", leagueKey, params)
+	data, err := c.makeFormattedRequest(ctx, endpoint, c.responseFormat)
 	if err != nil {
 		return nil, err
 	}
 
+	if c.responseFormat == FormatXML {
+		return decodeTransactionsXML(data)
+	}
+
 	var resp yahooTransactionsResponse
 	if err := json.Unmarshal(data, &resp); err != nil {
 		return nil, fmt.Errorf("failed to parse transactions response: %w", err)
 	}
 
 	var transactions []Transaction
-	for _, item := range resp.FantasyContent.League.Transactions {
-		transactions = append(transactions, convertYahooTransaction(item.Transaction))
+	for _, item := range resp.FantasyContent.League.Value.Transactions {
+		transactions = append(transactions, convertYahooTransaction(item.Transaction.Value))
 	}
 
 	return transactions, nil
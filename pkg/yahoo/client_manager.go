@@ -0,0 +1,98 @@
+package yahoo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+)
+
+// ClientManager hands out per-user clients for apps serving many Yahoo
+// accounts. All clients share the manager's HTTP transport and cache; token
+// sets are loaded from, and refreshed tokens written back to, the TokenStore.
+type ClientManager struct {
+	base    *Client
+	store   TokenStore
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// NewClientManager creates a manager whose clients are configured with opts;
+// rate limits set via WithRateLimit apply across all users combined.
+func NewClientManager(apiKey, apiSecret string, db *sql.DB, store TokenStore, opts ...ClientOption) *ClientManager {
+	base := NewClient(apiKey, apiSecret, db, opts...)
+	base.accessToken = ""
+	base.refreshToken = ""
+
+	return &ClientManager{
+		base:    base,
+		store:   store,
+		clients: make(map[string]*Client),
+	}
+}
+
+// Client returns the client scoped to userGUID, loading its token set from
+// the store on first use.
+func (m *ClientManager) Client(ctx context.Context, userGUID string) (*Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if client, ok := m.clients[userGUID]; ok {
+		return client, nil
+	}
+
+	token, err := m.store.Load(ctx, userGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load token for user %s: %w", userGUID, err)
+	}
+
+	client := m.newUserClient(userGUID, *token)
+	m.clients[userGUID] = client
+	return client, nil
+}
+
+// LinkUser stores a freshly issued token set (e.g. from
+// ExchangeAuthorizationCode) and returns the user's client.
+func (m *ClientManager) LinkUser(ctx context.Context, userGUID string, token *Token) (*Client, error) {
+	if err := m.store.Save(ctx, userGUID, token); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	client := m.newUserClient(userGUID, *token)
+	m.clients[userGUID] = client
+	return client, nil
+}
+
+// UnlinkUser forgets the user's client, deletes the stored token set and
+// clears the user's cached responses.
+func (m *ClientManager) UnlinkUser(ctx context.Context, userGUID string) error {
+	m.mu.Lock()
+	delete(m.clients, userGUID)
+	m.mu.Unlock()
+
+	if err := m.store.Delete(ctx, userGUID); err != nil {
+		return err
+	}
+	if _, ok := m.base.cache.(prefixDeleter); ok {
+		return m.base.ClearUserCache(userGUID)
+	}
+	return nil
+}
+
+// AuthorizationClient returns a client with no tokens, for running the
+// authorization code flow for a user who has not linked an account yet.
+func (m *ClientManager) AuthorizationClient() *Client {
+	return m.base.WithToken(Token{})
+}
+
+func (m *ClientManager) newUserClient(userGUID string, token Token) *Client {
+	client := m.base.WithToken(token)
+	client.userGUID = userGUID
+	client.onTokenRefresh = func(refreshed Token) {
+		m.store.Save(context.Background(), userGUID, &refreshed)
+	}
+	return client
+}
@@ -0,0 +1,149 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type memoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]Token
+}
+
+func newMemoryTokenStore() *memoryTokenStore {
+	return &memoryTokenStore{tokens: make(map[string]Token)}
+}
+
+func (s *memoryTokenStore) Load(ctx context.Context, userGUID string) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[userGUID]
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	return &token, nil
+}
+
+func (s *memoryTokenStore) Save(ctx context.Context, userGUID string, token *Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[userGUID] = *token
+	return nil
+}
+
+func (s *memoryTokenStore) Delete(ctx context.Context, userGUID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tokens, userGUID)
+	return nil
+}
+
+func TestClientManagerScopesTokensPerUser(t *testing.T) {
+	store := newMemoryTokenStore()
+	store.Save(context.Background(), "guid-a", &Token{AccessToken: "token-a", RefreshToken: "refresh-a"})
+	store.Save(context.Background(), "guid-b", &Token{AccessToken: "token-b", RefreshToken: "refresh-b"})
+
+	manager := NewClientManager("key", "secret", nil, store)
+
+	clientA, err := manager.Client(context.Background(), "guid-a")
+	if err != nil {
+		t.Fatalf("Client(guid-a) error = %v", err)
+	}
+	clientB, err := manager.Client(context.Background(), "guid-b")
+	if err != nil {
+		t.Fatalf("Client(guid-b) error = %v", err)
+	}
+
+	if clientA.accessToken != "token-a" || clientB.accessToken != "token-b" {
+		t.Errorf("clients carry wrong tokens: a=%q b=%q", clientA.accessToken, clientB.accessToken)
+	}
+	if clientA.httpClient != clientB.httpClient {
+		t.Error("clients should share the HTTP client")
+	}
+	if clientA.cache != clientB.cache {
+		t.Error("clients should share the cache")
+	}
+
+	again, _ := manager.Client(context.Background(), "guid-a")
+	if again != clientA {
+		t.Error("expected the same client instance for repeated lookups")
+	}
+
+	if _, err := manager.Client(context.Background(), "unknown"); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("expected ErrTokenNotFound for unknown user, got %v", err)
+	}
+}
+
+func TestClientManagerPersistsRefreshedTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"new-access","refresh_token":"new-refresh","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	store := newMemoryTokenStore()
+	manager := NewClientManager("key", "secret", nil, store)
+	manager.base.tokenURL = server.URL
+	manager.base.httpClient = server.Client()
+
+	client, err := manager.LinkUser(context.Background(), "guid-a", &Token{AccessToken: "old", RefreshToken: "old-refresh"})
+	if err != nil {
+		t.Fatalf("LinkUser() error = %v", err)
+	}
+
+	if err := client.refreshAccessToken(context.Background(), ""); err != nil {
+		t.Fatalf("refreshAccessToken() error = %v", err)
+	}
+
+	stored, _ := store.Load(context.Background(), "guid-a")
+	if stored.AccessToken != "new-access" || stored.RefreshToken != "new-refresh" {
+		t.Errorf("stored token = %+v, want refreshed values", stored)
+	}
+
+	if err := manager.UnlinkUser(context.Background(), "guid-a"); err != nil {
+		t.Fatalf("UnlinkUser() error = %v", err)
+	}
+	if _, err := store.Load(context.Background(), "guid-a"); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("expected token to be deleted, got %v", err)
+	}
+}
+
+func TestClientWithTokenIsolatesTokens(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen[r.Header.Get("Authorization")]++
+		mu.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	shared := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "shared"}))
+	shared.baseURL = server.URL
+
+	alice := shared.WithToken(Token{AccessToken: "alice"})
+	bob := shared.WithToken(Token{AccessToken: "bob"})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() { defer wg.Done(); alice.makeRequest(context.Background(), "league/1") }()
+		go func() { defer wg.Done(); bob.makeRequest(context.Background(), "league/1") }()
+	}
+	wg.Wait()
+
+	// Concurrent identical requests may be coalesced, but only per token.
+	if len(seen) != 2 || seen["Bearer alice"] == 0 || seen["Bearer bob"] == 0 {
+		t.Errorf("unexpected authorization headers: %v", seen)
+	}
+	if shared.Token().AccessToken != "shared" {
+		t.Error("cloning must not change the original client's token")
+	}
+	if alice.httpClient != shared.httpClient {
+		t.Error("clone should share the HTTP client")
+	}
+}
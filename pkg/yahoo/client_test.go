@@ -0,0 +1,253 @@
+package yahoo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// Run with -race: concurrent calls must not race on token state while one of
+// them refreshes the expired access token.
+func TestConcurrentGetUserLeaguesIsRaceFree(t *testing.T) {
+	var refreshes int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			atomic.AddInt32(&refreshes, 1)
+			w.Write([]byte(`{"access_token":"fresh","refresh_token":"refresh","expires_in":3600}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":{"description":"token_expired"}}`))
+			return
+		}
+		w.Write([]byte(`{"fantasy_content":{"users":[{"user":[{"games":[{"game":[{"leagues":[{"league":{"league_id":"1","name":"Test","season":"2024"}}]}]}]}]}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "stale", RefreshToken: "refresh"}))
+	client.baseURL = server.URL
+	client.tokenURL = server.URL + "/token"
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			leagues, err := client.GetUserLeagues(context.Background(), "nba")
+			if err != nil {
+				errs <- err
+				return
+			}
+			if len(leagues) != 1 {
+				t.Errorf("expected 1 league, got %d", len(leagues))
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("GetUserLeagues() error = %v", err)
+	}
+	if n := atomic.LoadInt32(&refreshes); n != 1 {
+		t.Errorf("expected a single token refresh shared by all goroutines, got %d", n)
+	}
+}
+
+func TestGetPlayersStatsBatchesKeys(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, rest, _ := strings.Cut(r.URL.Path, "player_keys=")
+		keys, _, _ := strings.Cut(rest, "/")
+
+		var items []string
+		for i, key := range strings.Split(keys, ",") {
+			items = append(items, fmt.Sprintf(`"%d":{"player":{"player_key":%q,"player_stats":{"coverage_type":"week","week":"3","stats":{"stat":[{"stat_id":12,"value":"20"}]}}}}`, i, key))
+		}
+		fmt.Fprintf(w, `{"fantasy_content":{"league":{"players":{%s,"count":%d}}}}`, strings.Join(items, ","), len(items))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	var keys []string
+	for i := 0; i < 30; i++ {
+		keys = append(keys, fmt.Sprintf("nba.p.%d", i))
+	}
+
+	players, err := client.GetPlayersStats(context.Background(), "nba.l.1", keys, 3)
+	if err != nil {
+		t.Fatalf("GetPlayersStats() error = %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("requests = %d, want 2", len(paths))
+	}
+	if !strings.HasSuffix(paths[0], "/stats;type=week;week=3") {
+		t.Errorf("path = %q, want weekly stats", paths[0])
+	}
+	if len(players) != 30 || players[29].PlayerKey != "nba.p.29" {
+		t.Fatalf("got %d players", len(players))
+	}
+	if players[0].PlayerStats == nil || players[0].PlayerStats.Week != 3 {
+		t.Errorf("PlayerStats = %+v", players[0].PlayerStats)
+	}
+}
+
+func TestConcurrentIdenticalRequestsAreCoalesced(t *testing.T) {
+	var requests int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		w.Write([]byte(`{"fantasy_content":{"league":{"teams":[]}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetLeagueTeams(context.Background(), "nba.l.1"); err != nil {
+				t.Errorf("GetLeagueTeams() error = %v", err)
+			}
+		}()
+	}
+	for atomic.LoadInt32(&requests) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("upstream requests = %d, want 1", n)
+	}
+}
+
+func TestCoalescedRequestSurvivesLeaderCancellation(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderDone := make(chan error, 1)
+	go func() {
+		_, err := client.makeRequest(leaderCtx, "league/1")
+		leaderDone <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	followerDone := make(chan error, 1)
+	go func() {
+		_, err := client.makeRequest(context.Background(), "league/1")
+		followerDone <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	if err := <-leaderDone; err == nil {
+		t.Error("leader should return its context error")
+	}
+	close(release)
+	if err := <-followerDone; err != nil {
+		t.Errorf("follower error = %v, want nil", err)
+	}
+}
+
+func TestGetPlayer(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "player.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	player, err := client.GetPlayer(context.Background(), "454.p.6583",
+		PlayerSubresourceStats, PlayerSubresourceOwnership, PlayerSubresourcePercentOwned)
+	if err != nil {
+		t.Fatalf("GetPlayer() error = %v", err)
+	}
+
+	if !strings.HasSuffix(gotPath, "/player/454.p.6583;out=stats,ownership,percent_owned") {
+		t.Errorf("request path = %s", gotPath)
+	}
+	if player.Name.Full != "Stephen Curry" || player.Status != "GTD" || player.InjuryNote != "Ankle" || player.UniformNumber != "30" {
+		t.Errorf("player = %+v", player)
+	}
+	if len(player.EligiblePositions) != 3 || player.Headshot["url"] == "" {
+		t.Errorf("positions, headshot = %v, %v", player.EligiblePositions, player.Headshot)
+	}
+	if player.PlayerStats == nil || len(player.PlayerStats.Stats) != 2 {
+		t.Errorf("PlayerStats = %+v", player.PlayerStats)
+	}
+	if player.Ownership == nil || player.Ownership.OwnerTeamName != "Splash" {
+		t.Errorf("Ownership = %+v", player.Ownership)
+	}
+	if player.PercentOwned == nil || player.PercentOwned.Value != 100 || player.PercentOwned.Week != 5 {
+		t.Errorf("PercentOwned = %+v", player.PercentOwned)
+	}
+}
+
+func TestGetTeamRosterForDate(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "roster_date.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	date := time.Date(2025, time.January, 15, 19, 30, 0, 0, time.UTC)
+	roster, err := client.GetTeamRosterForDate(context.Background(), "454.l.1.t.1", date)
+	if err != nil {
+		t.Fatalf("GetTeamRosterForDate() error = %v", err)
+	}
+
+	if !strings.HasSuffix(gotPath, "/team/454.l.1.t.1/roster;date=2025-01-15") {
+		t.Errorf("request path = %s", gotPath)
+	}
+	if want := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC); !roster.Date.Equal(want) {
+		t.Errorf("Date = %v, want %v", roster.Date, want)
+	}
+	if roster.TeamKey != "454.l.1.t.1" || len(roster.Players) != 2 {
+		t.Fatalf("roster = %+v", roster)
+	}
+	if !roster.Players[0].IsStarting || roster.Players[1].IsStarting {
+		t.Errorf("players = %+v", roster.Players)
+	}
+}
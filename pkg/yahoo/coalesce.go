@@ -0,0 +1,79 @@
+package yahoo
+
+import (
+	"bytes"
+	"context"
+	"sync"
+)
+
+// requestGroup coalesces concurrent calls with the same key into one
+// execution, like singleflight, but detaches the shared call from any single
+// caller's cancellation: it is cancelled only once every waiting caller has
+// given up.
+type requestGroup struct {
+	mu    sync.Mutex
+	calls map[string]*sharedCall
+}
+
+type sharedCall struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+	body    []byte
+	err     error
+}
+
+func newRequestGroup() *requestGroup {
+	return &requestGroup{calls: make(map[string]*sharedCall)}
+}
+
+// do runs fn for key unless a call for key is already in flight, in which
+// case it waits for that call's result. Each caller receives its own copy of
+// the body.
+func (g *requestGroup) do(ctx context.Context, key string, fn func(context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	call, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &sharedCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+		go g.run(callCtx, key, call, fn)
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		if call.err != nil {
+			return nil, call.err
+		}
+		return bytes.Clone(call.body), nil
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			g.forget(key, call)
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+func (g *requestGroup) run(ctx context.Context, key string, call *sharedCall, fn func(context.Context) ([]byte, error)) {
+	call.body, call.err = fn(ctx)
+	call.cancel()
+
+	g.mu.Lock()
+	g.forget(key, call)
+	g.mu.Unlock()
+	close(call.done)
+}
+
+// forget removes call from the group if it is still the in-flight call for
+// key; g.mu must be held.
+func (g *requestGroup) forget(key string, call *sharedCall) {
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+}
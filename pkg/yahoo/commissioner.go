@@ -0,0 +1,95 @@
+package yahoo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// IsCommissioner reports whether the logged-in user is a commissioner of
+// the league, according to the managers listed in its standings.
+func (c *Client) IsCommissioner(ctx context.Context, leagueKey string) (bool, error) {
+	login, err := c.currentLogin(ctx, leagueKey)
+	if err != nil {
+		return false, err
+	}
+	return login.commissioner, nil
+}
+
+// AllowTrade lets a trade awaiting commissioner review go through. Only a
+// commissioner may allow trades; others get ErrNotCommissioner.
+func (c *Client) AllowTrade(ctx context.Context, transactionKey string) (*Transaction, error) {
+	return c.reviewTrade(ctx, transactionKey, "allow")
+}
+
+// DisallowTrade vetoes a trade awaiting commissioner review. Only a
+// commissioner may disallow trades; others get ErrNotCommissioner.
+func (c *Client) DisallowTrade(ctx context.Context, transactionKey string) (*Transaction, error) {
+	return c.reviewTrade(ctx, transactionKey, "disallow")
+}
+
+// VoteAgainstTrade casts voterTeamKey's vote against a trade in a league
+// where managers vote on trades. The logged-in user must manage the voting
+// team.
+func (c *Client) VoteAgainstTrade(ctx context.Context, transactionKey, voterTeamKey string) (*Transaction, error) {
+	leagueKey := leagueKeyOfTransaction(transactionKey)
+	if leagueKey == "" {
+		return nil, fmt.Errorf("invalid transaction key %q", transactionKey)
+	}
+	login, err := c.currentLogin(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(login.teamKeys, voterTeamKey) {
+		return nil, fmt.Errorf("cannot vote as %s: team is not managed by the logged-in user", voterTeamKey)
+	}
+
+	payload := tradeActionPayload(transactionKey, "vote_against")
+	payload.Transaction.VoterTeamKey = voterTeamKey
+	return c.submitTransaction(ctx, http.MethodPut, "transaction/"+transactionKey, &payload,
+		transactionInvalidations(transactionKey)...)
+}
+
+func (c *Client) reviewTrade(ctx context.Context, transactionKey, action string) (*Transaction, error) {
+	leagueKey := leagueKeyOfTransaction(transactionKey)
+	if leagueKey == "" {
+		return nil, fmt.Errorf("invalid transaction key %q", transactionKey)
+	}
+	login, err := c.currentLogin(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	if !login.commissioner {
+		return nil, fmt.Errorf("cannot %s trade in %s: %w", action, leagueKey, ErrNotCommissioner)
+	}
+
+	payload := tradeActionPayload(transactionKey, action)
+	return c.submitTransaction(ctx, http.MethodPut, "transaction/"+transactionKey, &payload,
+		transactionInvalidations(transactionKey)...)
+}
+
+// leagueLogin describes the logged-in user's place in a league.
+type leagueLogin struct {
+	teamKeys     []string
+	commissioner bool
+}
+
+func (c *Client) currentLogin(ctx context.Context, leagueKey string) (leagueLogin, error) {
+	standings, err := c.GetLeagueStandings(ctx, leagueKey)
+	if err != nil {
+		return leagueLogin{}, fmt.Errorf("failed to load league managers: %w", err)
+	}
+
+	var login leagueLogin
+	for _, team := range standings.Teams {
+		for _, manager := range team.Managers {
+			if !manager.IsCurrentLogin {
+				continue
+			}
+			login.teamKeys = append(login.teamKeys, team.TeamKey)
+			login.commissioner = login.commissioner || manager.IsCommissioner
+		}
+	}
+	return login, nil
+}
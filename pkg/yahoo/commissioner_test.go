@@ -0,0 +1,97 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newCommissionerTestClient serves standings in which the logged-in user
+// manages team 1, as commissioner if commissioner is set, and records
+// writes.
+func newCommissionerTestClient(t *testing.T, commissioner bool) (*Client, *[]recordedRequest) {
+	t.Helper()
+	flag := "0"
+	if commissioner {
+		flag = "1"
+	}
+	standings := fmt.Sprintf(`{"fantasy_content":{"league":{"standings":{"teams":{
+		"0":{"team":{"team_key":"454.l.1.t.1","name":"Mine","managers":{"0":{"manager":{"manager_id":"1","is_current_login":"1","is_commissioner":%q}},"count":1}}},
+		"1":{"team":{"team_key":"454.l.1.t.2","name":"Theirs","managers":{"0":{"manager":{"manager_id":"2"}},"count":1}}},
+		"count":2}}}}}`, flag)
+
+	var writes []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(standings))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		writes = append(writes, recordedRequest{r.Method, r.URL.Path, string(body)})
+		w.Write([]byte(`<fantasy_content><transaction><transaction_key>454.l.1.pt.3</transaction_key><type>pending_trade</type><status>accepted</status></transaction></fantasy_content>`))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+	return client, &writes
+}
+
+func TestCommissionerTradeReview(t *testing.T) {
+	client, writes := newCommissionerTestClient(t, true)
+	ctx := context.Background()
+
+	if ok, err := client.IsCommissioner(ctx, "454.l.1"); err != nil || !ok {
+		t.Fatalf("IsCommissioner() = %v, %v, want true", ok, err)
+	}
+	if _, err := client.AllowTrade(ctx, "454.l.1.pt.3"); err != nil {
+		t.Fatalf("AllowTrade() error = %v", err)
+	}
+	if _, err := client.DisallowTrade(ctx, "454.l.1.pt.3"); err != nil {
+		t.Fatalf("DisallowTrade() error = %v", err)
+	}
+
+	got := *writes
+	if len(got) != 2 || got[0].method != http.MethodPut || got[0].path != "/transaction/454.l.1.pt.3" {
+		t.Fatalf("writes = %+v", got)
+	}
+	if !strings.Contains(got[0].body, "<action>allow</action>") || !strings.Contains(got[1].body, "<action>disallow</action>") {
+		t.Errorf("writes = %+v", got)
+	}
+}
+
+func TestTradeReviewRequiresCommissioner(t *testing.T) {
+	client, writes := newCommissionerTestClient(t, false)
+	ctx := context.Background()
+
+	if _, err := client.AllowTrade(ctx, "454.l.1.pt.3"); !errors.Is(err, ErrNotCommissioner) {
+		t.Errorf("AllowTrade() error = %v, want ErrNotCommissioner", err)
+	}
+	if _, err := client.DisallowTrade(ctx, "454.l.1.pt.3"); !errors.Is(err, ErrNotCommissioner) {
+		t.Errorf("DisallowTrade() error = %v, want ErrNotCommissioner", err)
+	}
+	if len(*writes) != 0 {
+		t.Errorf("non-commissioner reviews reached Yahoo: %v", *writes)
+	}
+}
+
+func TestVoteAgainstTrade(t *testing.T) {
+	client, writes := newCommissionerTestClient(t, false)
+	ctx := context.Background()
+
+	if _, err := client.VoteAgainstTrade(ctx, "454.l.1.pt.3", "454.l.1.t.2"); err == nil {
+		t.Error("VoteAgainstTrade() as another manager's team succeeded, want error")
+	}
+	if _, err := client.VoteAgainstTrade(ctx, "454.l.1.pt.3", "454.l.1.t.1"); err != nil {
+		t.Fatalf("VoteAgainstTrade() error = %v", err)
+	}
+
+	if len(*writes) != 1 || !strings.Contains((*writes)[0].body, "<action>vote_against</action><voter_team_key>454.l.1.t.1</voter_team_key>") {
+		t.Errorf("writes = %+v", *writes)
+	}
+}
@@ -2,6 +2,7 @@ package yahoo
 
 import (
 	"strconv"
+	"strings"
 )
 
 func convertYahooPlayerToPlayer(yp yahooPlayerData) Player {
@@ -48,7 +49,7 @@ func convertYahooPlayerToPlayer(yp yahooPlayerData) Player {
 		player.PlayerStats = &PlayerStats{
 			CoverageType: yp.PlayerStats.CoverageType,
 			Week:         weekNum,
-			Stats:        stats,
+			Stats:        normalizeStats(stats),
 		}
 	}
 
@@ -70,6 +71,31 @@ func convertYahooPlayerToPlayer(yp yahooPlayerData) Player {
 		}
 	}
 
+	player.Ownership = yp.Ownership
+	if yp.PercentOwned != nil {
+		po := yp.PercentOwned.Value
+		player.PercentOwned = &PercentOwned{
+			CoverageType: po.CoverageType,
+			Week:         po.Week.Int(),
+			Value:        po.Value.Float(),
+			Delta:        po.Delta.Float(),
+		}
+	}
+
+	player.Status = yp.Status
+	player.StatusFull = yp.StatusFull
+	player.InjuryNote = yp.InjuryNote
+	player.HasPlayerNotes = yp.HasPlayerNotes.Bool()
+	player.PlayerNotesLastTimestamp = int64(yp.PlayerNotesLastTimestamp.Int())
+	player.UniformNumber = string(yp.UniformNumber)
+	player.ImageURL = yp.ImageURL
+	if yp.Headshot != nil && yp.Headshot.URL != "" {
+		player.Headshot = map[string]string{"url": yp.Headshot.URL, "size": yp.Headshot.Size}
+	}
+	if yp.ByeWeeks != nil && yp.ByeWeeks.Week != "" {
+		player.ByeWeeks = map[string]int{"week": yp.ByeWeeks.Week.Int()}
+	}
+
 	return player
 }
 
@@ -89,9 +115,10 @@ func convertYahooStandingsTeam(yt yahooStandingsTeamData) StandingsTeam {
 	pointsAgainst, _ := strconv.ParseFloat(yt.TeamStandings.PointsAgainst, 64)
 
 	team := StandingsTeam{
-		TeamKey: yt.TeamKey,
-		TeamID:  yt.TeamID,
-		Name:    yt.Name,
+		TeamKey:      yt.TeamKey,
+		TeamID:       yt.TeamID,
+		Name:         yt.Name,
+		TeamMetadata: yt.toMetadata(),
 		TeamStandings: TeamStandings{
 			Rank:        rank,
 			PlayoffSeed: playoffSeed,
@@ -107,6 +134,10 @@ func convertYahooStandingsTeam(yt yahooStandingsTeamData) StandingsTeam {
 		},
 	}
 
+	if d := yt.TeamStandings.DivisionalOutcomeTotals; d != nil {
+		team.TeamStandings.DivisionalOutcomeTotals = newOutcomeTotals(d.Wins.Int(), d.Losses.Int(), d.Ties.Int())
+	}
+
 	if yt.TeamStandings.Streak != nil {
 		streakVal, _ := strconv.Atoi(yt.TeamStandings.Streak.Value)
 		team.TeamStandings.Streak = &Streak{
@@ -135,20 +166,27 @@ func convertYahooStandingsTeam(yt yahooStandingsTeamData) StandingsTeam {
 }
 
 func convertYahooMatchup(ym yahooMatchupData) Matchup {
-	weekNum, _ := strconv.Atoi(ym.Week)
-	isPlayoffs := ym.IsPlayoffs == "1"
-	isConsolation := ym.IsConsolation == "1"
-	isTied := ym.IsTied == "1"
-
 	matchup := Matchup{
-		Week:          weekNum,
+		Week:          ym.Week.Int(),
 		WeekStart:     ym.WeekStart,
 		WeekEnd:       ym.WeekEnd,
 		Status:        ym.Status,
-		IsPlayoffs:    isPlayoffs,
-		IsConsolation: isConsolation,
-		IsTied:        isTied,
+		IsPlayoffs:    ym.IsPlayoffs.Bool(),
+		IsConsolation: ym.IsConsolation.Bool(),
+		IsTied:        ym.IsTied.Bool(),
 		WinnerTeamKey: ym.WinnerTeamKey,
+
+		IsMatchupRecapAvailable: ym.IsMatchupRecapAvailable.Bool(),
+		MatchupRecapURL:         ym.MatchupRecapURL,
+	}
+
+	for _, item := range ym.StatWinners {
+		sw := item.StatWinner
+		matchup.StatWinners = append(matchup.StatWinners, StatWinner{
+			StatID:        sw.StatID.Int(),
+			WinnerTeamKey: sw.WinnerTeamKey,
+			IsTied:        sw.IsTied.Bool(),
+		})
 	}
 
 	for _, t := range ym.Teams.Team {
@@ -183,7 +221,7 @@ func convertYahooMatchup(ym yahooMatchupData) Matchup {
 					Value:  s.Value,
 				})
 			}
-			team.Stats = stats
+			team.Stats = normalizeStats(stats)
 		}
 
 		matchup.Teams = append(matchup.Teams, team)
@@ -193,56 +231,112 @@ func convertYahooMatchup(ym yahooMatchupData) Matchup {
 }
 
 func convertYahooDraftResult(ydr yahooDraftResultData) DraftResult {
-	pick, _ := strconv.Atoi(ydr.Pick)
-	round, _ := strconv.Atoi(ydr.Round)
-
-	return DraftResult{
-		Pick:      pick,
-		Round:     round,
+	result := DraftResult{
+		Pick:      ydr.Pick.Int(),
+		Round:     ydr.Round.Int(),
+		Cost:      ydr.Cost.Int(),
 		TeamKey:   ydr.TeamKey,
 		PlayerKey: ydr.Players.Player.PlayerKey,
 		Player:    convertYahooPlayerToPlayer(ydr.Players.Player),
 	}
+	if result.PlayerKey == "" {
+		result.PlayerKey = ydr.PlayerKey
+	}
+	return result
 }
 
 func convertYahooTransaction(yt yahooTransactionData) Transaction {
 	timestamp, _ := strconv.ParseInt(yt.Timestamp, 10, 64)
-	faabBid := 0
-	if yt.FAABBid != "" {
-		faabBid, _ = strconv.Atoi(yt.FAABBid)
-	}
-
 	trans := Transaction{
 		TransactionKey: yt.TransactionKey,
 		TransactionID:  yt.TransactionID,
-		Type:           yt.Type,
+		Type:           TransactionType(yt.Type),
 		Status:         yt.Status,
 		Timestamp:      timestamp,
-		FAABBid:        faabBid,
+		FAABBid:        yt.FAABBid.Int(),
+
+		WaiverTeamKey:     yt.WaiverTeamKey,
+		WaiverDate:        yt.WaiverDate,
+		WaiverPriority:    yt.WaiverPriority.Int(),
+		TraderTeamKey:     yt.TraderTeamKey,
+		TraderTeamName:    yt.TraderTeamName,
+		TradeeTeamKey:     yt.TradeeTeamKey,
+		TradeeTeamName:    yt.TradeeTeamName,
+		TradeProposedTime: int64(yt.TradeProposedTime.Int()),
+		TradeNote:         yt.TradeNote,
 	}
 
-	for _, p := range yt.Players {
+	for _, item := range yt.Players {
+		p := item.Player.Value
 		trans.Players = append(trans.Players, TransactionPlayer{
-			PlayerKey: p.Player.PlayerKey,
-			PlayerID:  p.Player.PlayerID,
+			PlayerKey: p.PlayerKey,
+			PlayerID:  p.PlayerID,
 			Name: PlayerName{
-				Full:       p.Player.Name.Full,
-				First:      p.Player.Name.First,
-				Last:       p.Player.Name.Last,
-				ASCIIFirst: p.Player.Name.ASCIIFirst,
-				ASCIILast:  p.Player.Name.ASCIILast,
+				Full:       p.Name.Full,
+				First:      p.Name.First,
+				Last:       p.Name.Last,
+				ASCIIFirst: p.Name.ASCIIFirst,
+				ASCIILast:  p.Name.ASCIILast,
 			},
 			TransactionData: TransactionData{
-				Type:                p.Player.TransactionData.Type,
-				SourceType:          p.Player.TransactionData.SourceType,
-				SourceTeamKey:       p.Player.TransactionData.SourceTeamKey,
-				SourceTeamName:      p.Player.TransactionData.SourceTeamName,
-				DestinationType:     p.Player.TransactionData.DestinationType,
-				DestinationTeamKey:  p.Player.TransactionData.DestinationTeamKey,
-				DestinationTeamName: p.Player.TransactionData.DestinationTeamName,
+				Type:                p.TransactionData.Type,
+				SourceType:          p.TransactionData.SourceType,
+				SourceTeamKey:       p.TransactionData.SourceTeamKey,
+				SourceTeamName:      p.TransactionData.SourceTeamName,
+				DestinationType:     p.TransactionData.DestinationType,
+				DestinationTeamKey:  p.TransactionData.DestinationTeamKey,
+				DestinationTeamName: p.TransactionData.DestinationTeamName,
 			},
 		})
 	}
 
 	return trans
 }
+
+// compoundStats maps stat IDs whose values can be "made/attempted", such as
+// "7/15", to the IDs of the made and attempted stats they combine.
+var compoundStats = map[int][2]int{
+	StatIDFGMFGACompound: {StatIDFGM, StatIDFGA},
+	StatIDFTMFTACompound: {StatIDFTM, StatIDFTA},
+	StatID3PM3PACompound: {StatID3PM, StatID3PA},
+	StatIDFGM:            {StatIDFGM, StatIDFGA},
+	StatIDFTM:            {StatIDFTM, StatIDFTA},
+	StatID3PM:            {StatID3PM, StatID3PA},
+}
+
+// normalizeStats splits compound "made/attempted" values into separate made
+// and attempted stats, so consumers never have to parse them. The compound
+// stat itself is kept, since leagues list it as a display category, unless
+// it sat in the made stat's own ID, in which case it is replaced by the made
+// count. Stats already present are not overwritten.
+func normalizeStats(stats []Stat) []Stat {
+	present := make(map[int]bool, len(stats))
+	for _, stat := range stats {
+		present[stat.StatID] = !strings.Contains(stat.Value, "/")
+	}
+
+	for i := 0; i < len(stats); i++ {
+		ids, ok := compoundStats[stats[i].StatID]
+		if !ok {
+			continue
+		}
+		made, attempted, ok := strings.Cut(stats[i].Value, "/")
+		if !ok {
+			continue
+		}
+		made, attempted = strings.TrimSpace(made), strings.TrimSpace(attempted)
+		if stats[i].StatID == ids[0] {
+			stats[i].Value = made
+			present[ids[0]] = true
+		}
+		if !present[ids[0]] {
+			stats = append(stats, Stat{StatID: ids[0], Value: made})
+			present[ids[0]] = true
+		}
+		if !present[ids[1]] {
+			stats = append(stats, Stat{StatID: ids[1], Value: attempted})
+			present[ids[1]] = true
+		}
+	}
+	return stats
+}
@@ -1,6 +1,7 @@
 package yahoo
 
 import (
+	"encoding/json"
 	"testing"
 )
 
@@ -136,3 +137,45 @@ func TestConvertYahooMatchup(t *testing.T) {
 		t.Errorf("WinnerTeamKey = %v, want %v", matchup.WinnerTeamKey, "423.l.12345.t.1")
 	}
 }
+
+func TestNormalizeStats(t *testing.T) {
+	stats := normalizeStats([]Stat{
+		{StatID: StatIDFGMFGACompound, Value: "7/15"},
+		{StatID: StatIDFTM, Value: "4/5"},
+		{StatID: StatIDFTA, Value: "6"},
+		{StatID: StatIDPoints, Value: "21"},
+	})
+
+	want := map[int]string{
+		StatIDFGMFGACompound: "7/15",
+		StatIDFGM:            "7",
+		StatIDFGA:            "15",
+		StatIDFTM:            "4",
+		StatIDFTA:            "6",
+		StatIDPoints:         "21",
+	}
+	if len(stats) != len(want) {
+		t.Fatalf("normalizeStats() = %+v", stats)
+	}
+	for _, stat := range stats {
+		if want[stat.StatID] != stat.Value {
+			t.Errorf("stat %d = %q, want %q", stat.StatID, stat.Value, want[stat.StatID])
+		}
+	}
+}
+
+func TestConvertYahooPlayerNormalizesStats(t *testing.T) {
+	var yp yahooPlayerData
+	if err := json.Unmarshal([]byte(`{"player_key": "454.p.1", "player_stats": {"coverage_type": "season", "stats": {"stat": [
+		{"stat_id": 9004003, "value": "120/250"},
+		{"stat_id": 12, "value": "330"}
+	]}}}`), &yp); err != nil {
+		t.Fatal(err)
+	}
+
+	player := convertYahooPlayerToPlayer(yp)
+	nbaStats, _ := ParseNBAStats(player.PlayerStats.Stats)
+	if nbaStats.FGM != 120 || nbaStats.FGA != 250 || nbaStats.FGPercent != 0.48 {
+		t.Errorf("NBAStats = %+v", nbaStats)
+	}
+}
@@ -0,0 +1,88 @@
+package yahoo
+
+import "sort"
+
+// Division is one of a league's divisions, as listed in its settings.
+type Division struct {
+	DivisionID int    `json:"division_id"`
+	Name       string `json:"name"`
+}
+
+// DivisionStandings is the slice of a league's standings belonging to one
+// division.
+type DivisionStandings struct {
+	DivisionID int             `json:"division_id"`
+	Name       string          `json:"name,omitempty"`
+	Teams      []StandingsTeam `json:"teams"`
+}
+
+// GamesBack returns how many games the team trails the division leader by,
+// counting a tie as half a game. Teams are compared on their divisional
+// record when Yahoo provides one and on their overall record otherwise.
+func (d DivisionStandings) GamesBack(teamKey string) float64 {
+	if len(d.Teams) == 0 {
+		return 0
+	}
+	leader := divisionRecord(d.Teams[0])
+	for _, team := range d.Teams {
+		if team.TeamKey == teamKey {
+			record := divisionRecord(team)
+			return float64((leader.Wins-record.Wins)-(leader.Losses-record.Losses)) / 2
+		}
+	}
+	return 0
+}
+
+// ByDivision groups the standings by TeamMetadata.DivisionID, ordered by
+// division ID. Within a division, teams are ordered by divisional winning
+// percentage and then by league rank. Division names are taken from
+// divisions, typically LeagueSettings.Divisions. Leagues without divisions
+// return a single group with DivisionID 0.
+func (s *Standings) ByDivision(divisions ...Division) []DivisionStandings {
+	names := make(map[int]string, len(divisions))
+	for _, d := range divisions {
+		names[d.DivisionID] = d.Name
+	}
+
+	index := make(map[int]int)
+	var groups []DivisionStandings
+	for _, team := range s.Teams {
+		i, ok := index[team.DivisionID]
+		if !ok {
+			i = len(groups)
+			index[team.DivisionID] = i
+			groups = append(groups, DivisionStandings{DivisionID: team.DivisionID, Name: names[team.DivisionID]})
+		}
+		groups[i].Teams = append(groups[i].Teams, team)
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i].DivisionID < groups[j].DivisionID })
+	for _, g := range groups {
+		sort.SliceStable(g.Teams, func(i, j int) bool {
+			pi, pj := divisionRecord(g.Teams[i]).Percentage, divisionRecord(g.Teams[j]).Percentage
+			if pi != pj {
+				return pi > pj
+			}
+			return g.Teams[i].TeamStandings.Rank < g.Teams[j].TeamStandings.Rank
+		})
+	}
+	return groups
+}
+
+func divisionRecord(team StandingsTeam) OutcomeTotals {
+	if d := team.TeamStandings.DivisionalOutcomeTotals; d != nil {
+		return *d
+	}
+	return team.TeamStandings.OutcomeTotals
+}
+
+// newOutcomeTotals builds a record from its counts, computing the winning
+// percentage with ties as half a win since Yahoo omits it for divisional
+// records.
+func newOutcomeTotals(wins, losses, ties int) *OutcomeTotals {
+	totals := &OutcomeTotals{Wins: wins, Losses: losses, Ties: ties}
+	if games := wins + losses + ties; games > 0 {
+		totals.Percentage = (float64(wins) + float64(ties)/2) / float64(games)
+	}
+	return totals
+}
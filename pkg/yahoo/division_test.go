@@ -0,0 +1,76 @@
+package yahoo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestStandingsDivisionalRecord(t *testing.T) {
+	standings, err := newFixtureClient(t, "standings.json").GetLeagueStandings(context.Background(), "454.l.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := standings.Teams[1].TeamStandings.DivisionalOutcomeTotals
+	if got == nil || *got != (OutcomeTotals{Wins: 2, Losses: 1, Ties: 1, Percentage: 0.625}) {
+		t.Errorf("DivisionalOutcomeTotals = %+v", got)
+	}
+
+	groups := standings.ByDivision(Division{DivisionID: 1, Name: "East"}, Division{DivisionID: 2, Name: "West"})
+	if len(groups) != 2 || groups[0].Name != "East" || groups[1].Teams[0].TeamKey != "454.l.1.t.1" {
+		t.Errorf("ByDivision() = %+v", groups)
+	}
+}
+
+func TestStandingsByDivision(t *testing.T) {
+	team := func(key string, division, rank, wins, losses int, divisional *OutcomeTotals) StandingsTeam {
+		return StandingsTeam{
+			TeamKey:      key,
+			TeamMetadata: TeamMetadata{DivisionID: division},
+			TeamStandings: TeamStandings{
+				Rank:                    rank,
+				OutcomeTotals:           *newOutcomeTotals(wins, losses, 0),
+				DivisionalOutcomeTotals: divisional,
+			},
+		}
+	}
+	standings := &Standings{Teams: []StandingsTeam{
+		team("t.1", 2, 1, 10, 2, newOutcomeTotals(2, 2, 0)),
+		team("t.2", 1, 2, 9, 3, newOutcomeTotals(3, 1, 0)),
+		team("t.3", 2, 3, 8, 4, newOutcomeTotals(4, 0, 0)),
+		team("t.4", 1, 4, 6, 6, newOutcomeTotals(3, 1, 0)),
+	}}
+
+	groups := standings.ByDivision()
+	if len(groups) != 2 || groups[0].DivisionID != 1 || groups[1].DivisionID != 2 {
+		t.Fatalf("ByDivision() = %+v", groups)
+	}
+
+	var keys []string
+	for _, g := range groups {
+		for _, team := range g.Teams {
+			keys = append(keys, team.TeamKey)
+		}
+	}
+	want := []string{"t.2", "t.4", "t.3", "t.1"}
+	for i := range want {
+		if keys[i] != want[i] {
+			t.Fatalf("team order = %v, want %v", keys, want)
+		}
+	}
+
+	if gb := groups[1].GamesBack("t.1"); gb != 2 {
+		t.Errorf("GamesBack(t.1) = %v, want 2", gb)
+	}
+	if gb := groups[0].GamesBack("t.4"); gb != 0 {
+		t.Errorf("GamesBack(t.4) = %v, want 0", gb)
+	}
+}
+
+func TestStandingsByDivisionWithoutDivisions(t *testing.T) {
+	standings := &Standings{Teams: []StandingsTeam{{TeamKey: "t.1"}, {TeamKey: "t.2"}}}
+	groups := standings.ByDivision()
+	if len(groups) != 1 || groups[0].DivisionID != 0 || len(groups[0].Teams) != 2 {
+		t.Errorf("ByDivision() = %+v", groups)
+	}
+}
@@ -1,5 +1,12 @@
 package yahoo
 
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
 type DraftResult struct {
 	Pick      int    `json:"pick"`
 	Round     int    `json:"round"`
@@ -7,14 +14,16 @@ type DraftResult struct {
 	TeamName  string `json:"team_name,omitempty"`
 	PlayerKey string `json:"player_key"`
 	Player    Player `json:"player"`
+	// Cost is the winning bid in auction drafts and zero in snake drafts.
+	Cost int `json:"cost,omitempty"`
 }
 
 type yahooDraftResultsResponse struct {
 	FantasyContent struct {
 		League struct {
-			DraftResults []struct {
+			DraftResults yahooList[struct {
 				DraftResult yahooDraftResultData `json:"draft_result"`
-			} `json:"draft_results"`
+			}] `json:"draft_results"`
 		} `json:"league"`
 	} `json:"fantasy_content"`
 }
@@ -22,18 +31,123 @@ type yahooDraftResultsResponse struct {
 type yahooTeamDraftResultsResponse struct {
 	FantasyContent struct {
 		Team struct {
-			DraftResults []struct {
+			DraftResults yahooList[struct {
 				DraftResult yahooDraftResultData `json:"draft_result"`
-			} `json:"draft_results"`
+			}] `json:"draft_results"`
 		} `json:"team"`
 	} `json:"fantasy_content"`
 }
 
 type yahooDraftResultData struct {
-	Pick    string `json:"pick"`
-	Round   string `json:"round"`
-	TeamKey string `json:"team_key"`
-	Players struct {
+	Pick    yahooNumber `json:"pick"`
+	Round   yahooNumber `json:"round"`
+	Cost    yahooNumber `json:"cost"`
+	TeamKey string      `json:"team_key"`
+	// PlayerKey is set directly on the pick unless players are requested
+	// with it, and is empty for picks not yet made.
+	PlayerKey string `json:"player_key"`
+	Players   struct {
 		Player yahooPlayerData `json:"player"`
 	} `json:"players"`
 }
+
+// DraftState is the stage a league's draft is in.
+type DraftState string
+
+const (
+	DraftStatePreDraft   DraftState = "predraft"
+	DraftStateInProgress DraftState = "draft"
+	DraftStatePostDraft  DraftState = "postdraft"
+)
+
+// DraftStatus is a snapshot of a league's draft: its stage and the picks
+// made so far, in pick order.
+type DraftStatus struct {
+	State DraftState
+	Picks []DraftResult
+}
+
+// IsLive reports whether the draft is in progress.
+func (s *DraftStatus) IsLive() bool {
+	return s.State == DraftStateInProgress
+}
+
+type yahooDraftStatusResponse struct {
+	FantasyContent struct {
+		League yahooObject[struct {
+			DraftStatus  string `json:"draft_status"`
+			DraftResults yahooList[struct {
+				DraftResult yahooDraftResultData `json:"draft_result"`
+			}] `json:"draft_results"`
+		}] `json:"league"`
+	} `json:"fantasy_content"`
+}
+
+// GetLeagueDraftStatus returns the league's draft stage and the picks made
+// so far. It is always fetched live, since it changes with every pick.
+func (c *Client) GetLeagueDraftStatus(ctx context.Context, leagueKey string) (*DraftStatus, error) {
+	data, err := c.makeRequest(ctx, fmt.Sprintf("league/%s/draftresults", leagueKey))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp yahooDraftStatusResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse draft status response: %w", err)
+	}
+
+	league := resp.FantasyContent.League.Value
+	status := &DraftStatus{State: DraftState(league.DraftStatus)}
+	for _, item := range league.DraftResults {
+		pick := convertYahooDraftResult(item.DraftResult)
+		if pick.PlayerKey != "" {
+			status.Picks = append(status.Picks, pick)
+		}
+	}
+	return status, nil
+}
+
+// PollDraft checks the league's draft every interval and sends each new
+// pick on the returned channel, in pick order, until the draft is over or
+// ctx is done. Before the draft starts it keeps waiting for the first pick.
+// Both channels are closed when polling stops; a failed check stops it and
+// its error is sent on the error channel first.
+func (c *Client) PollDraft(ctx context.Context, leagueKey string, interval time.Duration) (<-chan DraftResult, <-chan error) {
+	picks := make(chan DraftResult)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(picks)
+
+		seen := make(map[int]bool)
+		for {
+			status, err := c.GetLeagueDraftStatus(ctx, leagueKey)
+			if err != nil {
+				if ctx.Err() == nil {
+					errs <- err
+				}
+				return
+			}
+			for _, pick := range status.Picks {
+				if seen[pick.Pick] {
+					continue
+				}
+				select {
+				case picks <- pick:
+					seen[pick.Pick] = true
+				case <-ctx.Done():
+					return
+				}
+			}
+			if status.State == DraftStatePostDraft {
+				return
+			}
+			if sleepContext(ctx, interval) != nil {
+				return
+			}
+		}
+	}()
+
+	return picks, errs
+}
@@ -0,0 +1,99 @@
+package yahoo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// draftSnapshot renders a draftresults response with the given state and
+// the first made of three picks filled in.
+func draftSnapshot(state string, made int) string {
+	var items []string
+	for i := 1; i <= 3; i++ {
+		playerKey := ""
+		if i <= made {
+			playerKey = fmt.Sprintf("454.p.%d", i)
+		}
+		items = append(items, fmt.Sprintf(`"%d":{"draft_result":{"pick":%d,"round":1,"team_key":"454.l.1.t.%d","player_key":%q}}`, i-1, i, i, playerKey))
+	}
+	return fmt.Sprintf(`{"fantasy_content":{"league":[{"league_key":"454.l.1","draft_status":%q},{"draft_results":{%s,"count":3}}]}}`, state, strings.Join(items, ","))
+}
+
+func TestGetLeagueDraftStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(draftSnapshot("draft", 2)))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	status, err := client.GetLeagueDraftStatus(context.Background(), "454.l.1")
+	if err != nil {
+		t.Fatalf("GetLeagueDraftStatus() error = %v", err)
+	}
+	if !status.IsLive() || len(status.Picks) != 2 || status.Picks[1].PlayerKey != "454.p.2" {
+		t.Errorf("status = %+v", status)
+	}
+}
+
+func TestPollDraft(t *testing.T) {
+	snapshots := []string{
+		draftSnapshot("predraft", 0),
+		draftSnapshot("draft", 1),
+		draftSnapshot("draft", 1),
+		draftSnapshot("draft", 2),
+		draftSnapshot("postdraft", 3),
+	}
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&calls, 1)) - 1
+		w.Write([]byte(snapshots[min(n, len(snapshots)-1)]))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	picks, errs := client.PollDraft(ctx, "454.l.1", time.Millisecond)
+
+	var got []int
+	for pick := range picks {
+		got = append(got, pick.Pick)
+	}
+	if err := <-errs; err != nil {
+		t.Fatalf("PollDraft() error = %v", err)
+	}
+	if fmt.Sprint(got) != "[1 2 3]" {
+		t.Errorf("picks = %v, want [1 2 3]", got)
+	}
+	if n := atomic.LoadInt32(&calls); n != int32(len(snapshots)) {
+		t.Errorf("polls = %d, want %d", n, len(snapshots))
+	}
+}
+
+func TestPollDraftReportsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	picks, errs := client.PollDraft(context.Background(), "454.l.1", time.Millisecond)
+	for range picks {
+		t.Error("unexpected pick")
+	}
+	if err := <-errs; err == nil {
+		t.Error("PollDraft() error = nil, want the failed check's error")
+	}
+}
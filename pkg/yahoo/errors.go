@@ -0,0 +1,142 @@
+package yahoo
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// statusRequestDenied is the non-standard status Yahoo returns when a
+// consumer key exceeds its request quota.
+const statusRequestDenied = 999
+
+var (
+	ErrUnauthorized   = errors.New("yahoo API request unauthorized")
+	ErrTokenExpired   = errors.New("yahoo access token expired")
+	ErrNotFound       = errors.New("yahoo resource not found")
+	ErrRateLimited    = errors.New("yahoo API rate limit exceeded")
+	ErrLeagueNotFound = errors.New("yahoo league not found")
+	ErrCircuitOpen    = errors.New("yahoo API circuit breaker open")
+	ErrCacheMiss      = errors.New("yahoo cache entry not found")
+
+	// Roster move rejections, matched against Yahoo's error description.
+	ErrPlayerNotAvailable = errors.New("yahoo player not available")
+	ErrRosterFull         = errors.New("yahoo roster full")
+	ErrTransactionLimit   = errors.New("yahoo transaction limit reached")
+
+	// ErrIneligiblePosition is returned by SetLineup, before anything is
+	// sent to Yahoo, for a move into a slot the player cannot fill.
+	ErrIneligiblePosition = errors.New("player not eligible for position")
+
+	// ErrNotCommissioner is returned by commissioner-only actions when the
+	// logged-in user does not manage the league.
+	ErrNotCommissioner = errors.New("yahoo user is not the league commissioner")
+)
+
+// YahooAPIError is returned for any non-200 response from Yahoo. It matches
+// the sentinel errors above with errors.Is according to its status code and
+// description.
+type YahooAPIError struct {
+	StatusCode  int
+	Description string
+	Body        string
+}
+
+func newYahooAPIError(statusCode int, body []byte) *YahooAPIError {
+	return &YahooAPIError{
+		StatusCode:  statusCode,
+		Description: parseErrorDescription(body),
+		Body:        string(body),
+	}
+}
+
+func (e *YahooAPIError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("Yahoo API error (status %d): %s", e.StatusCode, e.Description)
+	}
+	return fmt.Sprintf("Yahoo API error (status %d): %s", e.StatusCode, e.Body)
+}
+
+func (e *YahooAPIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrTokenExpired:
+		return e.StatusCode == http.StatusUnauthorized && e.mentions("token_expired")
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.isLeagueNotFound()
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests || e.StatusCode == statusRequestDenied
+	case ErrLeagueNotFound:
+		return e.isLeagueNotFound()
+	case ErrPlayerNotAvailable:
+		return e.mentions("not available") || e.mentions("already on") || e.mentions("not on your roster")
+	case ErrRosterFull:
+		return e.mentions("roster") && (e.mentions("full") || e.mentions("too many"))
+	case ErrTransactionLimit:
+		return e.mentions("maximum") && (e.mentions("add") || e.mentions("transaction"))
+	}
+	return false
+}
+
+// TokenRefreshError is returned when the access token could not be
+// refreshed before or after a request. Retrying the request would not help,
+// and it says nothing about Yahoo's API, so such failures are neither
+// retried nor counted by the circuit breaker.
+type TokenRefreshError struct {
+	Err error
+}
+
+func (e *TokenRefreshError) Error() string {
+	return fmt.Sprintf("failed to refresh token: %v", e.Err)
+}
+
+func (e *TokenRefreshError) Unwrap() error {
+	return e.Err
+}
+
+// isLeagueNotFound recognises Yahoo's "League key 123.l.456 does not exist."
+// responses, which arrive as 400 rather than 404.
+func (e *YahooAPIError) isLeagueNotFound() bool {
+	return e.mentions("league") && (e.mentions("does not exist") || e.mentions("not found"))
+}
+
+func (e *YahooAPIError) mentions(s string) bool {
+	return strings.Contains(strings.ToLower(e.Description+" "+e.Body), s)
+}
+
+// parseErrorDescription extracts the message from Yahoo's JSON or XML error
+// envelopes and from OAuth token endpoint errors.
+func parseErrorDescription(body []byte) string {
+	var jsonErr struct {
+		Error            json.RawMessage `json:"error"`
+		ErrorDescription string          `json:"error_description"`
+	}
+	if json.Unmarshal(body, &jsonErr) == nil {
+		if jsonErr.ErrorDescription != "" {
+			return jsonErr.ErrorDescription
+		}
+		var nested struct {
+			Description string `json:"description"`
+		}
+		if json.Unmarshal(jsonErr.Error, &nested) == nil && nested.Description != "" {
+			return nested.Description
+		}
+		var code string
+		if json.Unmarshal(jsonErr.Error, &code) == nil {
+			return code
+		}
+	}
+
+	var xmlErr struct {
+		Description string `xml:"description"`
+	}
+	if xml.Unmarshal(body, &xmlErr) == nil && xmlErr.Description != "" {
+		return xmlErr.Description
+	}
+
+	return ""
+}
@@ -0,0 +1,111 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestYahooAPIErrorIs(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		is     []error
+		isNot  []error
+	}{
+		{
+			name:   "expired token",
+			status: http.StatusUnauthorized,
+			body:   `{"error":{"description":"token_expired"}}`,
+			is:     []error{ErrUnauthorized, ErrTokenExpired},
+			isNot:  []error{ErrNotFound, ErrRateLimited},
+		},
+		{
+			name:   "invalid credentials",
+			status: http.StatusUnauthorized,
+			body:   `{"error":{"description":"Please provide valid credentials"}}`,
+			is:     []error{ErrUnauthorized},
+			isNot:  []error{ErrTokenExpired},
+		},
+		{
+			name:   "league does not exist",
+			status: http.StatusBadRequest,
+			body:   `{"error":{"lang":"en-US","description":"League key 466.l.99 does not exist."}}`,
+			is:     []error{ErrLeagueNotFound, ErrNotFound},
+			isNot:  []error{ErrUnauthorized},
+		},
+		{
+			name:   "not found",
+			status: http.StatusNotFound,
+			body:   `<error><description>Resource not found</description></error>`,
+			is:     []error{ErrNotFound},
+			isNot:  []error{ErrLeagueNotFound},
+		},
+		{
+			name:   "request denied",
+			status: statusRequestDenied,
+			body:   "Request denied",
+			is:     []error{ErrRateLimited},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", newYahooAPIError(tt.status, []byte(tt.body)))
+
+			for _, target := range tt.is {
+				if !errors.Is(err, target) {
+					t.Errorf("errors.Is(%v) = false, want true", target)
+				}
+			}
+			for _, target := range tt.isNot {
+				if errors.Is(err, target) {
+					t.Errorf("errors.Is(%v) = true, want false", target)
+				}
+			}
+
+			var apiErr *YahooAPIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("errors.As did not yield a YahooAPIError with status %d", tt.status)
+			}
+		})
+	}
+}
+
+func TestParseErrorDescription(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"error":{"description":"League key 1 does not exist."}}`, "League key 1 does not exist."},
+		{`{"error":"invalid_grant","error_description":"Invalid refresh token"}`, "Invalid refresh token"},
+		{`<?xml version="1.0"?><error><description>bad</description></error>`, "bad"},
+		{`Request denied`, ""},
+	}
+
+	for _, tt := range tests {
+		if got := parseErrorDescription([]byte(tt.body)); got != tt.want {
+			t.Errorf("parseErrorDescription(%q) = %q, want %q", tt.body, got, tt.want)
+		}
+	}
+}
+
+func TestMakeRequestReturnsYahooAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"description":"League key 466.l.1 does not exist."}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	_, err := client.GetLeagueStandings(context.Background(), "466.l.1")
+	if !errors.Is(err, ErrLeagueNotFound) {
+		t.Errorf("error = %v, want ErrLeagueNotFound", err)
+	}
+}
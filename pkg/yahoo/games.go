@@ -1,10 +1,129 @@
 package yahoo
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
+	"sync"
 )
 
+// Game is a Yahoo fantasy game: one sport in one season.
+type Game struct {
+	GameKey            string `json:"game_key"`
+	GameID             string `json:"game_id"`
+	Name               string `json:"name"`
+	Code               string `json:"code"`
+	Type               string `json:"type"`
+	URL                string `json:"url"`
+	Season             int    `json:"season"`
+	IsRegistrationOver bool   `json:"is_registration_over"`
+	IsGameOver         bool   `json:"is_game_over"`
+	IsOffseason        bool   `json:"is_offseason"`
+}
+
+type yahooGameData struct {
+	GameKey            string      `json:"game_key"`
+	GameID             yahooNumber `json:"game_id"`
+	Name               string      `json:"name"`
+	Code               string      `json:"code"`
+	Type               string      `json:"type"`
+	URL                string      `json:"url"`
+	Season             yahooNumber `json:"season"`
+	IsRegistrationOver yahooNumber `json:"is_registration_over"`
+	IsGameOver         yahooNumber `json:"is_game_over"`
+	IsOffseason        yahooNumber `json:"is_offseason"`
+}
+
+type yahooGamesResponse struct {
+	FantasyContent struct {
+		Games yahooList[struct {
+			Game yahooObject[yahooGameData] `json:"game"`
+		}] `json:"games"`
+	} `json:"fantasy_content"`
+}
+
+type yahooGameResponse struct {
+	FantasyContent struct {
+		Game yahooObject[yahooGameData] `json:"game"`
+	} `json:"fantasy_content"`
+}
+
+func convertYahooGame(yg yahooGameData) Game {
+	return Game{
+		GameKey:            yg.GameKey,
+		GameID:             string(yg.GameID),
+		Name:               yg.Name,
+		Code:               yg.Code,
+		Type:               yg.Type,
+		URL:                yg.URL,
+		Season:             yg.Season.Int(),
+		IsRegistrationOver: yg.IsRegistrationOver.Bool(),
+		IsGameOver:         yg.IsGameOver.Bool(),
+		IsOffseason:        yg.IsOffseason.Bool(),
+	}
+}
+
+// PositionType is a group of positions in a game, e.g. "B" (batters) and
+// "P" (pitchers) in MLB.
+type PositionType struct {
+	Type        string `json:"type"`
+	DisplayName string `json:"display_name"`
+}
+
+type yahooGameStatCategoriesResponse struct {
+	FantasyContent struct {
+		Game yahooObject[struct {
+			StatCategories struct {
+				Stats yahooList[struct {
+					Stat yahooStatCategoryData `json:"stat"`
+				}] `json:"stats"`
+			} `json:"stat_categories"`
+		}] `json:"game"`
+	} `json:"fantasy_content"`
+}
+
+type yahooGamePositionTypesResponse struct {
+	FantasyContent struct {
+		Game yahooObject[struct {
+			PositionTypes yahooList[struct {
+				PositionType PositionType `json:"position_type"`
+			}] `json:"position_types"`
+		}] `json:"game"`
+	} `json:"fantasy_content"`
+}
+
+// gameCodes are the sports GetGameID knows about.
+var gameCodes = []string{"mlb", "nba", "nfl", "nhl"}
+
+// learnedGameIDs holds game IDs fetched from Yahoo for seasons missing from
+// gameIDMap, so that GetGameID keeps working for new seasons.
+var (
+	learnedGameIDsMu sync.RWMutex
+	learnedGameIDs   = make(map[string]map[int]int)
+)
+
+func rememberGame(game Game) {
+	id, err := strconv.Atoi(game.GameID)
+	if err != nil || game.Code == "" || game.Season == 0 {
+		return
+	}
+	learnedGameIDsMu.Lock()
+	defer learnedGameIDsMu.Unlock()
+	if learnedGameIDs[game.Code] == nil {
+		learnedGameIDs[game.Code] = make(map[int]int)
+	}
+	learnedGameIDs[game.Code][game.Season] = id
+}
+
+func learnedGameID(gameCode string, season int) (int, bool) {
+	learnedGameIDsMu.RLock()
+	defer learnedGameIDsMu.RUnlock()
+	id, ok := learnedGameIDs[gameCode][season]
+	return id, ok
+}
+
 var gameIDMap = map[string]map[string]int{
 	"mlb": {
 		"2001": 12, "2002": 39, "2003": 74, "2004": 98, "2005": 113,
@@ -36,6 +155,9 @@ var gameIDMap = map[string]map[string]int{
 	},
 }
 
+// GetGameID returns the Yahoo game ID for a sport and season from a built-in
+// table. Seasons missing from it are also found once a Client has fetched
+// them with GetGames, GetGame or ResolveGameKey.
 func GetGameID(gameCode string, season int) (int, error) {
 	seasonStr := strconv.Itoa(season)
 
@@ -45,6 +167,9 @@ func GetGameID(gameCode string, season int) (int, error) {
 	}
 
 	gameID, ok := seasons[seasonStr]
+	if !ok {
+		gameID, ok = learnedGameID(gameCode, season)
+	}
 	if !ok {
 		return 0, fmt.Errorf("invalid season %d for %s", season, gameCode)
 	}
@@ -59,3 +184,141 @@ func GetGameKey(gameCode string, season int) (string, error) {
 	}
 	return strconv.Itoa(gameID), nil
 }
+
+// GetGames returns the current game of each supported sport.
+func (c *Client) GetGames(ctx context.Context) ([]Game, error) {
+	games, err := cachedFetch(ctx, c, CacheGames, "games", func() ([]Game, error) {
+		return c.fetchGames(ctx, "games;game_codes="+strings.Join(gameCodes, ","))
+	})
+	for _, game := range games {
+		rememberGame(game)
+	}
+	return games, err
+}
+
+// GetGame returns the current game for gameCode (e.g. "nba") or the game
+// with the given game key.
+func (c *Client) GetGame(ctx context.Context, gameCode string) (*Game, error) {
+	cacheKey := fmt.Sprintf("game:%s", gameCode)
+
+	game, err := cachedFetch(ctx, c, CacheGames, cacheKey, func() (*Game, error) {
+		return c.fetchGame(ctx, gameCode)
+	})
+	if err != nil {
+		return nil, err
+	}
+	rememberGame(*game)
+	return game, nil
+}
+
+// ResolveGameKey returns the game key for a sport and season like
+// GetGameKey, asking Yahoo for seasons missing from the built-in table.
+func (c *Client) ResolveGameKey(ctx context.Context, gameCode string, season int) (string, error) {
+	if key, err := GetGameKey(gameCode, season); err == nil {
+		return key, nil
+	}
+
+	cacheKey := fmt.Sprintf("games:%s:%d", gameCode, season)
+	games, err := cachedFetch(ctx, c, CacheGames, cacheKey, func() ([]Game, error) {
+		return c.fetchGames(ctx, fmt.Sprintf("games;game_codes=%s;seasons=%d", gameCode, season))
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, game := range games {
+		rememberGame(game)
+		if game.Code == gameCode && game.Season == season {
+			return game.GameKey, nil
+		}
+	}
+	return "", fmt.Errorf("no %s game found for season %d", gameCode, season)
+}
+
+// GetGameStatCategories returns every stat Yahoo tracks for a game, with the
+// position types each applies to and, for composite stats, their base stats.
+func (c *Client) GetGameStatCategories(ctx context.Context, gameKey string) ([]StatCategory, error) {
+	cacheKey := fmt.Sprintf("game:%s:stat_categories", gameKey)
+
+	return cachedFetch(ctx, c, CacheGames, cacheKey, func() ([]StatCategory, error) {
+		return c.fetchGameStatCategories(ctx, gameKey)
+	})
+}
+
+// GetGamePositionTypes returns the position types of a game, e.g. offense,
+// defense and kickers in NFL.
+func (c *Client) GetGamePositionTypes(ctx context.Context, gameKey string) ([]PositionType, error) {
+	cacheKey := fmt.Sprintf("game:%s:position_types", gameKey)
+
+	return cachedFetch(ctx, c, CacheGames, cacheKey, func() ([]PositionType, error) {
+		return c.fetchGamePositionTypes(ctx, gameKey)
+	})
+}
+
+func (c *Client) fetchGameStatCategories(ctx context.Context, gameKey string) ([]StatCategory, error) {
+	data, err := c.makeRequest(ctx, fmt.Sprintf("game/%s/stat_categories", gameKey))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp yahooGameStatCategoriesResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse game stat categories response: %w", err)
+	}
+
+	var categories []StatCategory
+	for _, item := range resp.FantasyContent.Game.Value.StatCategories.Stats {
+		categories = append(categories, convertYahooStatCategory(item.Stat))
+	}
+	return categories, nil
+}
+
+func (c *Client) fetchGamePositionTypes(ctx context.Context, gameKey string) ([]PositionType, error) {
+	data, err := c.makeRequest(ctx, fmt.Sprintf("game/%s/position_types", gameKey))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp yahooGamePositionTypesResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse game position types response: %w", err)
+	}
+
+	var types []PositionType
+	for _, item := range resp.FantasyContent.Game.Value.PositionTypes {
+		types = append(types, item.PositionType)
+	}
+	return types, nil
+}
+
+func (c *Client) fetchGames(ctx context.Context, endpoint string) ([]Game, error) {
+	data, err := c.makeRequest(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp yahooGamesResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse games response: %w", err)
+	}
+
+	var games []Game
+	for _, item := range resp.FantasyContent.Games {
+		games = append(games, convertYahooGame(item.Game.Value))
+	}
+	return games, nil
+}
+
+func (c *Client) fetchGame(ctx context.Context, gameCode string) (*Game, error) {
+	data, err := c.makeRequest(ctx, "game/"+gameCode)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp yahooGameResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse game response: %w", err)
+	}
+
+	game := convertYahooGame(resp.FantasyContent.Game.Value)
+	return &game, nil
+}
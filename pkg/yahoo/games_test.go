@@ -1,6 +1,7 @@
 package yahoo
 
 import (
+	"context"
 	"testing"
 )
 
@@ -117,3 +118,63 @@ func TestGetGameKey(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveGameKeyLearnsUnknownSeason(t *testing.T) {
+	if _, err := GetGameKey("nba", 2031); err == nil {
+		t.Fatal("season 2031 unexpectedly in the built-in table")
+	}
+
+	key, err := newFixtureClient(t, "games.json").ResolveGameKey(context.Background(), "nba", 2031)
+	if err != nil {
+		t.Fatalf("ResolveGameKey() error = %v", err)
+	}
+	if key != "470" {
+		t.Errorf("ResolveGameKey() = %q, want 470", key)
+	}
+
+	id, err := GetGameID("nba", 2031)
+	if err != nil || id != 470 {
+		t.Errorf("GetGameID() after fetch = %d, %v, want 470", id, err)
+	}
+}
+
+func TestGetGames(t *testing.T) {
+	games, err := newFixtureClient(t, "games.json").GetGames(context.Background())
+	if err != nil {
+		t.Fatalf("GetGames() error = %v", err)
+	}
+	if len(games) != 1 {
+		t.Fatalf("games = %+v", games)
+	}
+	game := games[0]
+	if game.Code != "nba" || game.Season != 2031 || !game.IsOffseason || game.IsGameOver {
+		t.Errorf("game = %+v", game)
+	}
+}
+
+func TestGetGameStatCategories(t *testing.T) {
+	categories, err := newFixtureClient(t, "game_stat_categories.json").GetGameStatCategories(context.Background(), "454")
+	if err != nil {
+		t.Fatalf("GetGameStatCategories() error = %v", err)
+	}
+	if len(categories) != 3 {
+		t.Fatalf("categories = %+v", categories)
+	}
+	fgma := categories[1]
+	if !fgma.IsCompositeStat || len(fgma.BaseStatIDs) != 2 || fgma.BaseStatIDs[0] != StatIDFGM || fgma.BaseStatIDs[1] != StatIDFGA {
+		t.Errorf("composite category = %+v", fgma)
+	}
+	if categories[2].PositionType != "P" || len(categories[2].PositionTypes) != 1 || categories[2].SortOrder != 0 {
+		t.Errorf("turnovers category = %+v", categories[2])
+	}
+}
+
+func TestGetGamePositionTypes(t *testing.T) {
+	types, err := newFixtureClient(t, "game_position_types.json").GetGamePositionTypes(context.Background(), "449")
+	if err != nil {
+		t.Fatalf("GetGamePositionTypes() error = %v", err)
+	}
+	if len(types) != 3 || types[0] != (PositionType{Type: "O", DisplayName: "Offense"}) || types[2].Type != "DT" {
+		t.Errorf("position types = %+v", types)
+	}
+}
@@ -0,0 +1,196 @@
+package yahoo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// yahooList decodes a Yahoo collection in any of the shapes fantasy_content
+// uses for it: a JSON array, an object keyed by "0", "1", ... next to a
+// "count" member, or a bare object when the collection has a single member.
+type yahooList[T any] []T
+
+func (l *yahooList[T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) || bytes.Equal(data, []byte(`""`)) {
+		*l = nil
+		return nil
+	}
+
+	if data[0] == '[' {
+		var items []T
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		*l = items
+		return nil
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	var indices []int
+	for key := range members {
+		if i, err := strconv.Atoi(key); err == nil {
+			indices = append(indices, i)
+		}
+	}
+
+	if len(indices) == 0 {
+		delete(members, "count")
+		if len(members) == 0 {
+			*l = nil
+			return nil
+		}
+		var item T
+		if err := json.Unmarshal(data, &item); err != nil {
+			return err
+		}
+		*l = yahooList[T]{item}
+		return nil
+	}
+
+	sort.Ints(indices)
+	items := make([]T, 0, len(indices))
+	for _, i := range indices {
+		var item T
+		if err := json.Unmarshal(members[strconv.Itoa(i)], &item); err != nil {
+			return fmt.Errorf("collection item %d: %w", i, err)
+		}
+		items = append(items, item)
+	}
+	*l = items
+	return nil
+}
+
+// yahooObject decodes a single Yahoo resource that may arrive either as an
+// object or split into fragments: an array of partial objects, or an object
+// whose "0", "1", ... members hold parts of it. Fragments are merged before
+// decoding into Value.
+type yahooObject[T any] struct {
+	Value T
+}
+
+func (o *yahooObject[T]) UnmarshalJSON(data []byte) error {
+	merged, err := mergeFragments(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(merged, &o.Value)
+}
+
+func mergeFragments(data []byte) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || (data[0] != '[' && data[0] != '{') {
+		return data, nil
+	}
+
+	merged := make(map[string]json.RawMessage)
+	if data[0] == '[' {
+		var parts []json.RawMessage
+		if err := json.Unmarshal(data, &parts); err != nil {
+			return nil, err
+		}
+		for _, part := range parts {
+			if err := mergeInto(merged, part); err != nil {
+				return nil, err
+			}
+		}
+		return json.Marshal(merged)
+	}
+
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	var indices []int
+	for key := range merged {
+		if i, err := strconv.Atoi(key); err == nil {
+			indices = append(indices, i)
+		}
+	}
+	sort.Ints(indices)
+	for _, i := range indices {
+		key := strconv.Itoa(i)
+		part := merged[key]
+		delete(merged, key)
+		if err := mergeInto(merged, part); err != nil {
+			return nil, err
+		}
+	}
+	delete(merged, "count")
+	return json.Marshal(merged)
+}
+
+// mergeInto copies the members of a fragment into dst; non-object fragments
+// are ignored.
+func mergeInto(dst map[string]json.RawMessage, part json.RawMessage) error {
+	part, err := mergeFragments(part)
+	if err != nil {
+		return err
+	}
+	if len(part) == 0 || part[0] != '{' {
+		return nil
+	}
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(part, &members); err != nil {
+		return err
+	}
+	for key, value := range members {
+		dst[key] = value
+	}
+	return nil
+}
+
+// yahooNumber decodes a numeric field that Yahoo sends either as a JSON
+// number or as a string, possibly empty; flags may also arrive as JSON
+// booleans. Missing and unparsable values read as zero.
+type yahooNumber string
+
+func (n *yahooNumber) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*n = ""
+		return nil
+	case bytes.Equal(data, []byte("true")):
+		*n = "1"
+		return nil
+	case bytes.Equal(data, []byte("false")):
+		*n = "0"
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*n = yahooNumber(s)
+		return nil
+	}
+	*n = yahooNumber(data)
+	return nil
+}
+
+func (n yahooNumber) Int() int {
+	i, err := strconv.Atoi(string(n))
+	if err != nil {
+		return int(n.Float())
+	}
+	return i
+}
+
+func (n yahooNumber) Float() float64 {
+	f, _ := strconv.ParseFloat(string(n), 64)
+	return f
+}
+
+// Bool reports whether the value is a non-zero number, as in Yahoo's "1"/"0"
+// flags.
+func (n yahooNumber) Bool() bool {
+	return n.Float() != 0
+}
@@ -0,0 +1,167 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestYahooListUnmarshal(t *testing.T) {
+	type item struct {
+		ID int `json:"id"`
+	}
+	tests := []struct {
+		name string
+		json string
+		want []int
+	}{
+		{"array", `[{"id":1},{"id":2}]`, []int{1, 2}},
+		{"numeric keys", `{"1":{"id":2},"0":{"id":1},"count":2}`, []int{1, 2}},
+		{"numeric keys sort numerically", `{"10":{"id":11},"2":{"id":3},"0":{"id":1},"count":3}`, []int{1, 3, 11}},
+		{"single object", `{"id":7}`, []int{7}},
+		{"count only", `{"count":0}`, nil},
+		{"null", `null`, nil},
+		{"empty string", `""`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var list yahooList[item]
+			if err := json.Unmarshal([]byte(tt.json), &list); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			var got []int
+			for _, it := range list {
+				got = append(got, it.ID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// newFixtureClient returns a client whose requests are all answered with
+// testdata/name.
+func newFixtureClient(t *testing.T, name string) *Client {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+	return client
+}
+
+func TestNumericKeyedFixtures(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("leagues", func(t *testing.T) {
+		leagues, err := newFixtureClient(t, "leagues.json").GetUserLeagues(ctx, "nba")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(leagues) != 2 || leagues[1].LeagueName != "Dynasty" || leagues[0].NumTeams != 12 {
+			t.Errorf("leagues = %+v", leagues)
+		}
+	})
+
+	t.Run("teams", func(t *testing.T) {
+		teams, err := newFixtureClient(t, "teams.json").GetLeagueTeams(ctx, "454.l.1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(teams) != 2 || teams[1].ManagerName != "Alex" || teams[1].ManagerGUID != "ABC123" || teams[1].Wins != 8 {
+			t.Errorf("teams = %+v", teams)
+		}
+	})
+
+	t.Run("roster", func(t *testing.T) {
+		roster, err := newFixtureClient(t, "roster.json").GetTeamRoster(ctx, "454.l.1.t.1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(roster) != 2 || roster[0].Position != "PG" || roster[1].IsStarting {
+			t.Errorf("roster = %+v", roster)
+		}
+		if roster[0].Name.Full != "Trae Young" || roster[0].EditorialTeamAbbr != "ATL" {
+			t.Errorf("Name, EditorialTeamAbbr = %+v, %q", roster[0].Name, roster[0].EditorialTeamAbbr)
+		}
+	})
+
+	t.Run("players", func(t *testing.T) {
+		players, err := newFixtureClient(t, "players.json").GetLeaguePlayers(ctx, "454.l.1", PlayerStatusAll, 0, 25)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(players) != 2 {
+			t.Fatalf("expected 2 players, got %d", len(players))
+		}
+		if got := players[0].EligiblePositions; len(got) != 2 || got[1] != "G" {
+			t.Errorf("EligiblePositions = %v", got)
+		}
+		if players[0].PlayerStats == nil || len(players[0].PlayerStats.Stats) != 2 {
+			t.Errorf("PlayerStats = %+v", players[0].PlayerStats)
+		}
+	})
+
+	t.Run("standings", func(t *testing.T) {
+		standings, err := newFixtureClient(t, "standings.json").GetLeagueStandings(ctx, "454.l.1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(standings.Teams) != 2 || standings.Teams[0].ManagerNickname != "Alex" {
+			t.Errorf("standings = %+v", standings)
+		}
+		if standings.TeamByManagerGUID("GUID1") == nil {
+			t.Error("TeamByManagerGUID(GUID1) = nil")
+		}
+	})
+
+	t.Run("scoreboard", func(t *testing.T) {
+		matchups, err := newFixtureClient(t, "scoreboard.json").GetLeagueMatchups(ctx, "454.l.1", 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(matchups) != 1 || len(matchups[0].Teams) != 2 || !matchups[0].Teams[1].IsWinner {
+			t.Errorf("matchups = %+v", matchups)
+		}
+	})
+
+	t.Run("draft results", func(t *testing.T) {
+		results, err := newFixtureClient(t, "draftresults.json").GetLeagueDraftResults(ctx, "454.l.1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != 2 || results[0].PlayerKey != "454.p.6014" || results[1].Cost != 47 {
+			t.Errorf("results = %+v", results)
+		}
+	})
+
+	t.Run("transactions", func(t *testing.T) {
+		transactions, err := newFixtureClient(t, "transactions.json").GetLeagueTransactions(ctx, "454.l.1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(transactions) != 1 || len(transactions[0].Players) != 2 {
+			t.Fatalf("transactions = %+v", transactions)
+		}
+		if transactions[0].Players[1].TransactionData.DestinationType != "waivers" {
+			t.Errorf("Players[1] = %+v", transactions[0].Players[1])
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type yahooLeagueResponse struct {
+	FantasyContent struct {
+		League yahooObject[struct {
+			LeagueKey   string      `json:"league_key"`
+			LeagueID    string      `json:"league_id"`
+			Name        string      `json:"name"`
+			Season      yahooNumber `json:"season"`
+			ScoringType string      `json:"scoring_type"`
+			NumTeams    yahooNumber `json:"num_teams"`
+			CurrentWeek yahooNumber `json:"current_week"`
+			Renew       string      `json:"renew"`
+			Renewed     string      `json:"renewed"`
+		}] `json:"league"`
+	} `json:"fantasy_content"`
+}
+
+// GetLeague returns a league's metadata by key. Unlike GetUserLeagues it
+// can look up any season the user played in, such as the ones a league's
+// Renew key leads back to.
+func (c *Client) GetLeague(ctx context.Context, leagueKey string) (*League, error) {
+	cacheKey := fmt.Sprintf("league:%s:metadata", leagueKey)
+
+	return cachedFetch(ctx, c, CacheLeagues, cacheKey, func() (*League, error) {
+		data, err := c.makeRequest(ctx, fmt.Sprintf("league/%s", leagueKey))
+		if err != nil {
+			return nil, err
+		}
+
+		var resp yahooLeagueResponse
+		if err := json.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse league response: %w", err)
+		}
+
+		l := resp.FantasyContent.League.Value
+		if l.LeagueKey == "" {
+			return nil, fmt.Errorf("%w: %s", ErrLeagueNotFound, leagueKey)
+		}
+		gameKey, _, _ := strings.Cut(l.LeagueKey, ".")
+		return &League{
+			YahooLeagueID: l.LeagueID,
+			YahooGameKey:  gameKey,
+			LeagueName:    l.Name,
+			SeasonYear:    l.Season.Int(),
+			ScoringType:   l.ScoringType,
+			NumTeams:      l.NumTeams.Int(),
+			CurrentWeek:   l.CurrentWeek.Int(),
+			Renew:         renewLeagueKey(l.Renew),
+			Renewed:       renewLeagueKey(l.Renewed),
+		}, nil
+	})
+}
+
+// renewLeagueKey converts the "402_12345" form Yahoo uses in a league's
+// renew and renewed fields into the league key "402.l.12345".
+func renewLeagueKey(renew string) string {
+	gameKey, leagueID, ok := strings.Cut(renew, "_")
+	if !ok || gameKey == "" || leagueID == "" {
+		return ""
+	}
+	return gameKey + ".l." + leagueID
+}
@@ -0,0 +1,41 @@
+package yahoo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetLeague(t *testing.T) {
+	league, err := newFixtureClient(t, "league.json").GetLeague(context.Background(), "454.l.7")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := League{
+		YahooLeagueID: "7",
+		YahooGameKey:  "454",
+		LeagueName:    "Hoops",
+		SeasonYear:    2024,
+		ScoringType:   "head",
+		NumTeams:      12,
+		CurrentWeek:   21,
+		Renew:         "428.l.31",
+	}
+	if *league != want {
+		t.Errorf("GetLeague() = %+v, want %+v", *league, want)
+	}
+}
+
+func TestRenewLeagueKey(t *testing.T) {
+	tests := map[string]string{
+		"428_31": "428.l.31",
+		"":       "",
+		"428":    "",
+		"_31":    "",
+	}
+	for renew, want := range tests {
+		if got := renewLeagueKey(renew); got != want {
+			t.Errorf("renewLeagueKey(%q) = %q, want %q", renew, got, want)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// RosterCoverage is the period a lineup change applies to: a week for
+// weekly sports such as NFL, or a date for daily sports.
+type RosterCoverage struct {
+	Week int
+	Date time.Time
+}
+
+// CoverageWeek returns the coverage for the given week.
+func CoverageWeek(week int) RosterCoverage {
+	return RosterCoverage{Week: week}
+}
+
+// CoverageDate returns the coverage for the calendar date of date.
+func CoverageDate(date time.Time) RosterCoverage {
+	return RosterCoverage{Date: date}
+}
+
+// PositionChange moves a player into a lineup slot, e.g. "PG", "UTIL" or
+// "BN" for the bench.
+type PositionChange struct {
+	PlayerKey string
+	Position  string
+}
+
+// benchPositions can be filled by any rostered player.
+var benchPositions = []string{"BN"}
+
+type lineupPayload struct {
+	XMLName xml.Name `xml:"fantasy_content"`
+	Roster  struct {
+		CoverageType string                `xml:"coverage_type"`
+		Week         int                   `xml:"week,omitempty"`
+		Date         string                `xml:"date,omitempty"`
+		Players      []lineupPayloadPlayer `xml:"players>player"`
+	} `xml:"roster"`
+}
+
+type lineupPayloadPlayer struct {
+	PlayerKey string `xml:"player_key"`
+	Position  string `xml:"position"`
+}
+
+// SetLineup moves players between starting slots and the bench for the
+// given week or date. Each change is first checked against the team's
+// roster: a player who is not on it, or a position outside the player's
+// eligible positions, fails with ErrIneligiblePosition and nothing is sent.
+func (c *Client) SetLineup(ctx context.Context, teamKey string, coverage RosterCoverage, changes []PositionChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	if coverage.Week <= 0 && coverage.Date.IsZero() {
+		return fmt.Errorf("lineup coverage requires a week or a date")
+	}
+
+	roster, err := c.lineupRoster(ctx, teamKey, coverage)
+	if err != nil {
+		return fmt.Errorf("failed to load roster to validate lineup: %w", err)
+	}
+	eligible := make(map[string][]string, len(roster))
+	for _, player := range roster {
+		eligible[player.PlayerKey] = player.EligiblePositions
+	}
+
+	var payload lineupPayload
+	if coverage.Week > 0 {
+		payload.Roster.CoverageType = "week"
+		payload.Roster.Week = coverage.Week
+	} else {
+		payload.Roster.CoverageType = "date"
+		payload.Roster.Date = coverage.Date.Format(rosterDateLayout)
+	}
+	for _, change := range changes {
+		positions, onRoster := eligible[change.PlayerKey]
+		if !onRoster {
+			return fmt.Errorf("%w: %s is not on %s", ErrIneligiblePosition, change.PlayerKey, teamKey)
+		}
+		if !slices.Contains(benchPositions, change.Position) && !slices.Contains(positions, change.Position) {
+			return fmt.Errorf("%w: %s cannot play %s (eligible: %v)", ErrIneligiblePosition, change.PlayerKey, change.Position, positions)
+		}
+		payload.Roster.Players = append(payload.Roster.Players, lineupPayloadPlayer{change.PlayerKey, change.Position})
+	}
+
+	body, err := xml.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode lineup: %w", err)
+	}
+	if _, err := c.makeWriteRequest(ctx, http.MethodPut, fmt.Sprintf("team/%s/roster", teamKey), body); err != nil {
+		return fmt.Errorf("set lineup failed: %w", err)
+	}
+	c.invalidateCache(fmt.Sprintf("team:%s:roster", teamKey))
+	return nil
+}
+
+func (c *Client) lineupRoster(ctx context.Context, teamKey string, coverage RosterCoverage) ([]Roster, error) {
+	if coverage.Week > 0 {
+		return c.GetTeamRoster(ctx, teamKey)
+	}
+	daily, err := c.GetTeamRosterForDate(ctx, teamKey, coverage.Date)
+	if err != nil {
+		return nil, err
+	}
+	return daily.Players, nil
+}
@@ -0,0 +1,87 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newLineupTestClient(t *testing.T, rosterFixture string) (*Client, *[]recordedRequest) {
+	t.Helper()
+	roster, err := os.ReadFile(filepath.Join("testdata", rosterFixture))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var writes []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write(roster)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		writes = append(writes, recordedRequest{r.Method, r.URL.Path, string(body)})
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+	return client, &writes
+}
+
+func TestSetLineupForWeek(t *testing.T) {
+	client, writes := newLineupTestClient(t, "roster.json")
+
+	err := client.SetLineup(context.Background(), "454.l.1.t.1", CoverageWeek(7), []PositionChange{
+		{PlayerKey: "454.p.5352", Position: "BN"},
+		{PlayerKey: "454.p.6014", Position: "C"},
+	})
+	if err != nil {
+		t.Fatalf("SetLineup() error = %v", err)
+	}
+
+	if len(*writes) != 1 {
+		t.Fatalf("writes = %v", *writes)
+	}
+	got := (*writes)[0]
+	want := "<fantasy_content><roster><coverage_type>week</coverage_type><week>7</week><players><player><player_key>454.p.5352</player_key><position>BN</position></player><player><player_key>454.p.6014</player_key><position>C</position></player></players></roster></fantasy_content>"
+	if got.method != http.MethodPut || got.path != "/team/454.l.1.t.1/roster" || got.body != want {
+		t.Errorf("write = %+v, want PUT with %s", got, want)
+	}
+}
+
+func TestSetLineupForDate(t *testing.T) {
+	client, writes := newLineupTestClient(t, "roster_date.json")
+
+	date := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+	if err := client.SetLineup(context.Background(), "454.l.1.t.1", CoverageDate(date), []PositionChange{{PlayerKey: "454.p.5352", Position: "PG"}}); err != nil {
+		t.Fatalf("SetLineup() error = %v", err)
+	}
+	want := "<fantasy_content><roster><coverage_type>date</coverage_type><date>2025-01-15</date><players><player><player_key>454.p.5352</player_key><position>PG</position></player></players></roster></fantasy_content>"
+	if len(*writes) != 1 || (*writes)[0].body != want {
+		t.Errorf("writes = %+v, want body %s", *writes, want)
+	}
+}
+
+func TestSetLineupValidatesEligibility(t *testing.T) {
+	client, writes := newLineupTestClient(t, "roster.json")
+	ctx := context.Background()
+
+	for _, change := range []PositionChange{
+		{PlayerKey: "454.p.6014", Position: "PG"},
+		{PlayerKey: "454.p.9999", Position: "BN"},
+	} {
+		err := client.SetLineup(ctx, "454.l.1.t.1", CoverageWeek(7), []PositionChange{change})
+		if !errors.Is(err, ErrIneligiblePosition) {
+			t.Errorf("SetLineup(%+v) error = %v, want ErrIneligiblePosition", change, err)
+		}
+	}
+	if len(*writes) != 0 {
+		t.Errorf("invalid lineups reached Yahoo: %v", *writes)
+	}
+}
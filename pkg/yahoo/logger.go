@@ -0,0 +1,24 @@
+package yahoo
+
+// Logger receives structured log events from the client and services. Its
+// method set matches *slog.Logger, so a slog logger can be passed directly
+// to WithLogger; args are alternating key/value pairs.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...any) {}
+func (noopLogger) Info(string, ...any)  {}
+func (noopLogger) Warn(string, ...any)  {}
+func (noopLogger) Error(string, ...any) {}
+
+// Logger returns the client's logger so services built on top of it can log
+// through the same sink. It is never nil.
+func (c *Client) Logger() Logger {
+	return c.logger
+}
@@ -0,0 +1,41 @@
+package yahoo
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestWithLoggerAcceptsSlog(t *testing.T) {
+	server := newTokenTestServer(t, "fresh")
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	client := NewClient("key", "secret", nil,
+		WithLogger(logger),
+		WithInitialToken(Token{AccessToken: "stale", RefreshToken: "refresh"}),
+	)
+	client.baseURL = server.URL
+	client.tokenURL = server.URL + "/token"
+
+	if _, err := client.makeRequest(context.Background(), "game/nba"); err != nil {
+		t.Fatalf("makeRequest() error = %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"refreshed yahoo access token", "yahoo request"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestClientLoggerDefaultsToNoop(t *testing.T) {
+	if NewClient("key", "secret", nil).Logger() == nil {
+		t.Error("Logger() = nil, want no-op logger")
+	}
+}
@@ -1,5 +1,10 @@
 package yahoo
 
+import "time"
+
+// matchupDateLayout is the format of Matchup.WeekStart and WeekEnd.
+const matchupDateLayout = "2006-01-02"
+
 type Week struct {
 	WeekNum   int       `json:"week"`
 	StartDate string    `json:"start"`
@@ -17,6 +22,65 @@ type Matchup struct {
 	IsTied            bool          `json:"is_tied"`
 	WinnerTeamKey     string        `json:"winner_team_key,omitempty"`
 	Teams             []MatchupTeam `json:"teams"`
+	IsMatchupRecapAvailable bool `json:"is_matchup_recap_available"`
+	MatchupRecapURL   string        `json:"matchup_recap_url,omitempty"`
+	// StatWinners lists who won each category in head-to-head category
+	// leagues. It is empty in points leagues.
+	StatWinners       []StatWinner  `json:"stat_winners,omitempty"`
+}
+
+// NumWeeks returns how many fantasy weeks the matchup spans. Playoff
+// rounds in some NBA and NHL leagues last two or more weeks, with WeekStart
+// and WeekEnd covering the whole round; regular season matchups span one.
+func (m Matchup) NumWeeks() int {
+	start, err := time.Parse(matchupDateLayout, m.WeekStart)
+	if err != nil {
+		return 1
+	}
+	end, err := time.Parse(matchupDateLayout, m.WeekEnd)
+	if err != nil || !end.After(start) {
+		return 1
+	}
+	days := int(end.Sub(start).Hours()/24) + 1
+	return (days + 6) / 7
+}
+
+// IsMultiWeek reports whether the matchup spans more than one week.
+func (m Matchup) IsMultiWeek() bool {
+	return m.NumWeeks() > 1
+}
+
+// Weeks returns the week numbers the matchup covers, starting at Week.
+func (m Matchup) Weeks() []int {
+	weeks := make([]int, m.NumWeeks())
+	for i := range weeks {
+		weeks[i] = m.Week + i
+	}
+	return weeks
+}
+
+// StatWinner is the result of one category in a matchup. WinnerTeamKey is
+// empty when IsTied is set.
+type StatWinner struct {
+	StatID        int    `json:"stat_id"`
+	WinnerTeamKey string `json:"winner_team_key,omitempty"`
+	IsTied        bool   `json:"is_tied"`
+}
+
+// CategoryRecord returns the team's category wins, losses and ties in the
+// matchup.
+func (m Matchup) CategoryRecord(teamKey string) (wins, losses, ties int) {
+	for _, sw := range m.StatWinners {
+		switch {
+		case sw.IsTied:
+			ties++
+		case sw.WinnerTeamKey == teamKey:
+			wins++
+		default:
+			losses++
+		}
+	}
+	return wins, losses, ties
 }
 
 type MatchupTeam struct {
@@ -48,25 +112,34 @@ type yahooScoreboardResponse struct {
 		League struct {
 			Scoreboard struct {
 				Week     string `json:"week"`
-				Matchups []struct {
+				Matchups yahooList[struct {
 					Matchup yahooMatchupData `json:"matchup"`
-				} `json:"matchups"`
+				}] `json:"matchups"`
 			} `json:"scoreboard"`
 		} `json:"league"`
 	} `json:"fantasy_content"`
 }
 
 type yahooMatchupData struct {
-	Week      string `json:"week"`
+	Week      yahooNumber `json:"week"`
 	WeekStart string `json:"week_start"`
 	WeekEnd   string `json:"week_end"`
 	Status    string `json:"status"`
-	IsPlayoffs string `json:"is_playoffs"`
-	IsConsolation string `json:"is_consolation"`
-	IsTied    string `json:"is_tied"`
+	IsPlayoffs yahooNumber `json:"is_playoffs"`
+	IsConsolation yahooNumber `json:"is_consolation"`
+	IsTied    yahooNumber `json:"is_tied"`
 	WinnerTeamKey string `json:"winner_team_key,omitempty"`
+	IsMatchupRecapAvailable yahooNumber `json:"is_matchup_recap_available"`
+	MatchupRecapURL         string      `json:"matchup_recap_url"`
+	StatWinners yahooList[struct {
+		StatWinner struct {
+			StatID        yahooNumber `json:"stat_id"`
+			WinnerTeamKey string      `json:"winner_team_key"`
+			IsTied        yahooNumber `json:"is_tied"`
+		} `json:"stat_winner"`
+	}] `json:"stat_winners"`
 	Teams     struct {
-		Team []struct {
+		Team yahooList[struct {
 			TeamKey  string `json:"team_key"`
 			TeamID   string `json:"team_id"`
 			Name     string `json:"name"`
@@ -85,12 +158,12 @@ type yahooMatchupData struct {
 				CoverageType string `json:"coverage_type"`
 				Week         string `json:"week,omitempty"`
 				Stats        struct {
-					Stat []struct {
+					Stat yahooList[struct {
 						StatID int    `json:"stat_id"`
 						Value  string `json:"value"`
-					} `json:"stat"`
+					}] `json:"stat"`
 				} `json:"stats"`
 			} `json:"team_stats,omitempty"`
-		} `json:"team"`
+		}] `json:"team"`
 	} `json:"teams"`
 }
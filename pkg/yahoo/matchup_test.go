@@ -0,0 +1,78 @@
+package yahoo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMatchupStatWinners(t *testing.T) {
+	matchups, err := newFixtureClient(t, "scoreboard_categories.json").GetLeagueMatchups(context.Background(), "454.l.1", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matchups) != 1 {
+		t.Fatalf("expected 1 matchup, got %d", len(matchups))
+	}
+	m := matchups[0]
+
+	want := []StatWinner{
+		{StatID: 5, WinnerTeamKey: "454.l.1.t.1"},
+		{StatID: 8, WinnerTeamKey: "454.l.1.t.2"},
+		{StatID: 12, WinnerTeamKey: "454.l.1.t.1"},
+		{StatID: 19, IsTied: true},
+	}
+	if len(m.StatWinners) != len(want) {
+		t.Fatalf("StatWinners = %+v", m.StatWinners)
+	}
+	for i := range want {
+		if m.StatWinners[i] != want[i] {
+			t.Errorf("StatWinners[%d] = %+v, want %+v", i, m.StatWinners[i], want[i])
+		}
+	}
+
+	if w, l, tie := m.CategoryRecord("454.l.1.t.1"); w != 2 || l != 1 || tie != 1 {
+		t.Errorf("CategoryRecord(t.1) = %d-%d-%d, want 2-1-1", w, l, tie)
+	}
+	if w, l, tie := m.CategoryRecord("454.l.1.t.2"); w != 1 || l != 2 || tie != 1 {
+		t.Errorf("CategoryRecord(t.2) = %d-%d-%d, want 1-2-1", w, l, tie)
+	}
+}
+
+func TestMultiWeekPlayoffMatchups(t *testing.T) {
+	matchups, err := newFixtureClient(t, "scoreboard_playoffs.json").GetLeagueMatchups(context.Background(), "454.l.1", 21)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matchups) != 2 {
+		t.Fatalf("expected 2 matchups, got %d", len(matchups))
+	}
+
+	final := matchups[0]
+	if !final.IsPlayoffs || final.IsConsolation || !final.IsMatchupRecapAvailable {
+		t.Errorf("flags = %+v", final)
+	}
+	if final.MatchupRecapURL != "https://basketball.fantasysports.yahoo.com/nba/1/recap?week=21&mid1=1&mid2=2" {
+		t.Errorf("MatchupRecapURL = %q", final.MatchupRecapURL)
+	}
+	if !final.IsMultiWeek() || final.NumWeeks() != 2 {
+		t.Errorf("NumWeeks() = %d, want 2", final.NumWeeks())
+	}
+	if weeks := final.Weeks(); len(weeks) != 2 || weeks[0] != 21 || weeks[1] != 22 {
+		t.Errorf("Weeks() = %v, want [21 22]", weeks)
+	}
+
+	consolation := matchups[1]
+	if !consolation.IsConsolation || consolation.IsMatchupRecapAvailable || consolation.MatchupRecapURL != "" {
+		t.Errorf("consolation = %+v", consolation)
+	}
+	if consolation.IsMultiWeek() {
+		t.Errorf("NumWeeks() = %d, want 1", consolation.NumWeeks())
+	}
+}
+
+func TestMatchupNumWeeksWithoutDates(t *testing.T) {
+	m := Matchup{Week: 5}
+	if m.NumWeeks() != 1 || len(m.Weeks()) != 1 || m.Weeks()[0] != 5 {
+		t.Errorf("NumWeeks() = %d, Weeks() = %v", m.NumWeeks(), m.Weeks())
+	}
+}
@@ -0,0 +1,185 @@
+package yahoo
+
+import (
+	"container/list"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMemoryCacheEntries = 10000
+	defaultMemoryCacheBytes   = 64 << 20
+)
+
+// MemoryCache is an in-process Cache that evicts the least recently used
+// entries once it holds more than maxEntries entries or maxBytes bytes of
+// keys and values.
+type MemoryCache struct {
+	maxEntries int
+	maxBytes   int64
+
+	mu     sync.Mutex
+	bytes  int64
+	hits   uint64
+	misses uint64
+	order  *list.List
+	items  map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func (e *memoryCacheEntry) size() int64 {
+	return int64(len(e.key) + len(e.value))
+}
+
+// NewMemoryCache returns an empty MemoryCache. A limit of zero or less
+// disables that limit.
+func NewMemoryCache(maxEntries int, maxBytes int64) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		order:      list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		c.misses++
+		return nil, time.Time{}, ErrCacheMiss
+	}
+	c.order.MoveToFront(elem)
+	entry := elem.Value.(*memoryCacheEntry)
+	if time.Now().Before(entry.expiresAt) {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	return entry.value, entry.expiresAt, nil
+}
+
+// Set stores value under key. A value too large to ever fit within maxBytes
+// is not stored.
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) error {
+	entry := &memoryCacheEntry{key: key, value: value, expiresAt: time.Now().Add(ttl)}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+	if c.maxBytes > 0 && entry.size() > c.maxBytes {
+		return nil
+	}
+
+	c.items[key] = c.order.PushFront(entry)
+	c.bytes += entry.size()
+	for (c.maxEntries > 0 && c.order.Len() > c.maxEntries) || (c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		c.remove(c.order.Back())
+	}
+	return nil
+}
+
+func (c *MemoryCache) Delete(key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+	return nil
+}
+
+// DeletePrefix removes every entry whose key starts with prefix.
+func (c *MemoryCache) DeletePrefix(prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, elem := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(elem)
+		}
+	}
+	return nil
+}
+
+func (c *MemoryCache) Clean() error {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for elem := c.order.Front(); elem != nil; {
+		next := elem.Next()
+		if elem.Value.(*memoryCacheEntry).expiresAt.Before(now) {
+			c.remove(elem)
+		}
+		elem = next
+	}
+	return nil
+}
+
+// Stats returns the number and total size of the cached entries, their
+// expiry range and the lookup counters since the cache was created. It never
+// fails; the error is for parity with APICache.
+func (c *MemoryCache) Stats() (CacheStats, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := CacheStats{
+		Entries: c.order.Len(),
+		Bytes:   c.bytes,
+		Hits:    c.hits,
+		Misses:  c.misses,
+	}
+	for _, elem := range c.items {
+		expiresAt := elem.Value.(*memoryCacheEntry).expiresAt
+		if stats.OldestExpiry.IsZero() || expiresAt.Before(stats.OldestExpiry) {
+			stats.OldestExpiry = expiresAt
+		}
+		if expiresAt.After(stats.NewestExpiry) {
+			stats.NewestExpiry = expiresAt
+		}
+	}
+	return stats, nil
+}
+
+// ListKeys returns the keys that start with prefix, sorted, including those
+// of expired entries.
+func (c *MemoryCache) ListKeys(prefix string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var keys []string
+	for key := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// Len returns the number of entries in the cache.
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *MemoryCache) remove(elem *list.Element) {
+	entry := c.order.Remove(elem).(*memoryCacheEntry)
+	delete(c.items, entry.key)
+	c.bytes -= entry.size()
+}
@@ -0,0 +1,90 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewMemoryCache(2, 0)
+	cache.Set("a", []byte("1"), time.Hour)
+	cache.Set("b", []byte("2"), time.Hour)
+	cache.Get("a")
+	cache.Set("c", []byte("3"), time.Hour)
+
+	if _, _, err := cache.Get("b"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get(b) error = %v, want ErrCacheMiss", err)
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, _, err := cache.Get(key); err != nil {
+			t.Errorf("Get(%s) error = %v", key, err)
+		}
+	}
+}
+
+func TestMemoryCacheEnforcesMaxBytes(t *testing.T) {
+	cache := NewMemoryCache(0, 10)
+	cache.Set("a", []byte("1234"), time.Hour)
+	cache.Set("b", []byte("1234"), time.Hour)
+	cache.Set("c", []byte("1234"), time.Hour)
+
+	if n := cache.Len(); n != 2 {
+		t.Errorf("Len() = %d, want 2", n)
+	}
+	if _, _, err := cache.Get("a"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get(a) error = %v, want ErrCacheMiss", err)
+	}
+
+	cache.Set("huge", make([]byte, 100), time.Hour)
+	if _, _, err := cache.Get("huge"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("oversized value was stored")
+	}
+	if n := cache.Len(); n != 2 {
+		t.Errorf("Len() after oversized Set = %d, want 2", n)
+	}
+}
+
+func TestMemoryCacheCleanRemovesExpired(t *testing.T) {
+	cache := NewMemoryCache(0, 0)
+	cache.Set("old", []byte("1"), -time.Minute)
+	cache.Set("new", []byte("2"), time.Hour)
+
+	if _, _, err := cache.Get("old"); err != nil {
+		t.Errorf("Get(old) before Clean error = %v, want expired entry", err)
+	}
+	cache.Clean()
+	if _, _, err := cache.Get("old"); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("Get(old) after Clean error = %v, want ErrCacheMiss", err)
+	}
+	if _, _, err := cache.Get("new"); err != nil {
+		t.Errorf("Get(new) error = %v", err)
+	}
+}
+
+func TestClientWithMemoryCacheNeedsNoDatabase(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(teamsFixture))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil,
+		WithInitialToken(Token{AccessToken: "token"}),
+		WithCache(NewMemoryCache(100, 0)))
+	client.baseURL = server.URL
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetLeagueTeams(context.Background(), "nba.l.1"); err != nil {
+			t.Fatalf("GetLeagueTeams() error = %v", err)
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("upstream requests = %d, want 1", n)
+	}
+}
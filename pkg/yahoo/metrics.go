@@ -0,0 +1,131 @@
+package yahoo
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics collects request, error, cache and token refresh metrics for the
+// clients it is attached to with WithMetrics. It implements
+// prometheus.Collector, so apps register it alongside their own metrics:
+//
+//	metrics := yahoo.NewMetrics()
+//	prometheus.MustRegister(metrics)
+//	client := yahoo.NewClient("", "", db, yahoo.WithMetrics(metrics))
+type Metrics struct {
+	requests       *prometheus.CounterVec
+	duration       *prometheus.HistogramVec
+	errors         *prometheus.CounterVec
+	cache          *prometheus.CounterVec
+	tokenRefreshes prometheus.Counter
+}
+
+// NewMetrics creates an unregistered set of SDK metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "yahoo_api_requests_total",
+			Help: "Yahoo Fantasy API requests by endpoint and HTTP status.",
+		}, []string{"endpoint", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "yahoo_api_request_duration_seconds",
+			Help:    "Yahoo Fantasy API request latency, including retries.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "yahoo_api_errors_total",
+			Help: "Failed Yahoo Fantasy API requests by HTTP status (0 when no response was received).",
+		}, []string{"status"}),
+		cache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "yahoo_cache_requests_total",
+			Help: "Response cache lookups by result (hit or miss).",
+		}, []string{"result"}),
+		tokenRefreshes: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "yahoo_token_refreshes_total",
+			Help: "OAuth access token refreshes.",
+		}),
+	}
+}
+
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.duration.Describe(ch)
+	m.errors.Describe(ch)
+	m.cache.Describe(ch)
+	m.tokenRefreshes.Describe(ch)
+}
+
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.duration.Collect(ch)
+	m.errors.Collect(ch)
+	m.cache.Collect(ch)
+	m.tokenRefreshes.Collect(ch)
+}
+
+// observeRequest records a finished request. It is a no-op on a nil Metrics.
+func (m *Metrics) observeRequest(endpoint string, elapsed time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	label := endpointLabel(endpoint)
+	status := strconv.Itoa(statusFromError(err))
+	m.requests.WithLabelValues(label, status).Inc()
+	m.duration.WithLabelValues(label).Observe(elapsed.Seconds())
+	if err != nil {
+		m.errors.WithLabelValues(status).Inc()
+	}
+}
+
+func (m *Metrics) observeCache(hit bool) {
+	if m == nil {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cache.WithLabelValues(result).Inc()
+}
+
+func (m *Metrics) observeTokenRefresh() {
+	if m == nil {
+		return
+	}
+	m.tokenRefreshes.Inc()
+}
+
+// statusFromError maps a request outcome to an HTTP status, 0 meaning no
+// response was received.
+func statusFromError(err error) int {
+	if err == nil {
+		return 200
+	}
+	var apiErr *YahooAPIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// endpointLabel reduces an endpoint to a low-cardinality label by dropping
+// matrix parameters and replacing resource keys, so
+// "league/nba.l.123/players;status=A" becomes "league/{key}/players".
+func endpointLabel(endpoint string) string {
+	segments := strings.Split(endpoint, "/")
+	for i, segment := range segments {
+		if j := strings.Index(segment, ";"); j >= 0 {
+			segment = segment[:j]
+		}
+		if strings.Contains(segment, ".") || strings.IndexFunc(segment, unicode.IsDigit) >= 0 {
+			segment = "{key}"
+		}
+		segments[i] = segment
+	}
+	return strings.Join(segments, "/")
+}
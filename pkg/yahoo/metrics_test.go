@@ -0,0 +1,54 @@
+package yahoo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsRecordsRequestsAndRefreshes(t *testing.T) {
+	server := newTokenTestServer(t, "fresh")
+	defer server.Close()
+
+	metrics := NewMetrics()
+	client := NewClient("key", "secret", nil,
+		WithMetrics(metrics),
+		WithRetryPolicy(NoRetry()),
+		WithInitialToken(Token{AccessToken: "stale", RefreshToken: "refresh"}),
+	)
+	client.baseURL = server.URL
+	client.tokenURL = server.URL + "/token"
+
+	if _, err := client.makeRequest(context.Background(), "league/nba.l.1/standings"); err != nil {
+		t.Fatalf("makeRequest() error = %v", err)
+	}
+
+	if got := testutil.ToFloat64(metrics.requests.WithLabelValues("league/{key}/standings", "200")); got != 1 {
+		t.Errorf("requests{league/{key}/standings,200} = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.tokenRefreshes); got != 1 {
+		t.Errorf("token refreshes = %v, want 1", got)
+	}
+	problems, err := testutil.CollectAndLint(metrics)
+	if err != nil {
+		t.Fatalf("CollectAndLint() error = %v", err)
+	}
+	for _, p := range problems {
+		t.Errorf("metric %s: %s", p.Metric, p.Text)
+	}
+}
+
+func TestEndpointLabel(t *testing.T) {
+	tests := map[string]string{
+		"league/nba.l.123/players;status=A;start=0;count=25": "league/{key}/players",
+		"users;use_login=1/games;game_keys=nba/leagues":      "users/games/leagues",
+		"team/nba.l.1.t.2/roster":                            "team/{key}/roster",
+		"game/nba":                                           "game/nba",
+	}
+	for endpoint, want := range tests {
+		if got := endpointLabel(endpoint); got != want {
+			t.Errorf("endpointLabel(%q) = %q, want %q", endpoint, got, want)
+		}
+	}
+}
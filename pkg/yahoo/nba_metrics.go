@@ -0,0 +1,146 @@
+package yahoo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// parseMinutes reads a minutes-played value, either as a number such as
+// "1,234" or "33.5" or as "mm:ss". Unparseable values return 0.
+func parseMinutes(value string) float64 {
+	value = strings.ReplaceAll(strings.TrimSpace(value), ",", "")
+	if mins, secs, ok := strings.Cut(value, ":"); ok {
+		m, err1 := strconv.Atoi(mins)
+		s, err2 := strconv.Atoi(secs)
+		if err1 != nil || err2 != nil {
+			return 0
+		}
+		return float64(m) + float64(s)/60
+	}
+	minutes, _ := strconv.ParseFloat(value, 64)
+	return minutes
+}
+
+// defensiveRebounds returns DefensiveRebounds, deriving it from total and
+// offensive rebounds when Yahoo only reported those.
+func (n *NBAStats) defensiveRebounds() int {
+	if n.DefensiveRebounds == 0 && n.Rebounds > n.OffensiveRebounds {
+		return n.Rebounds - n.OffensiveRebounds
+	}
+	return n.DefensiveRebounds
+}
+
+// StatLine returns the stats keyed by Yahoo's NBA display names, e.g. "PTS",
+// "ST" and "3PTM", with FG%, FT% and 3PT% as rates.
+func (n *NBAStats) StatLine() StatLine {
+	return StatLine{
+		"GP":   float64(n.GamesPlayed),
+		"MIN":  n.Minutes,
+		"FGM":  float64(n.FGM),
+		"FGA":  float64(n.FGA),
+		"FG%":  n.FGPercent,
+		"FTM":  float64(n.FTM),
+		"FTA":  float64(n.FTA),
+		"FT%":  n.FTPercent,
+		"3PTM": float64(n.ThreePointsMade),
+		"3PTA": float64(n.ThreePointsAttempt),
+		"3PT%": n.ThreePPercent,
+		"PTS":  float64(n.Points),
+		"OREB": float64(n.OffensiveRebounds),
+		"DREB": float64(n.defensiveRebounds()),
+		"REB":  float64(n.Rebounds),
+		"AST":  float64(n.Assists),
+		"ST":   float64(n.Steals),
+		"BLK":  float64(n.Blocks),
+		"TO":   float64(n.Turnovers),
+		"PF":   float64(n.PersonalFouls),
+	}
+}
+
+// Per36 scales the counting stats to 36 minutes played, leaving rates as
+// they are. It returns an empty line when Minutes is unknown.
+func (n *NBAStats) Per36() StatLine {
+	if n.Minutes <= 0 {
+		return StatLine{}
+	}
+	line := n.StatLine()
+	scale := 36 / n.Minutes
+	for name, value := range line {
+		if name != "GP" && !isRateStat(name) {
+			line[name] = value * scale
+		}
+	}
+	return line
+}
+
+// AssistTurnoverRatio returns assists per turnover, or 0 without turnovers.
+func (n *NBAStats) AssistTurnoverRatio() float64 {
+	if n.Turnovers == 0 {
+		return 0.0
+	}
+	return float64(n.Assists) / float64(n.Turnovers)
+}
+
+// GameScore returns John Hollinger's game score, averaged over GamesPlayed
+// when the stats span more than one game.
+func (n *NBAStats) GameScore() float64 {
+	score := float64(n.Points) +
+		0.4*float64(n.FGM) -
+		0.7*float64(n.FGA) -
+		0.4*float64(n.FTA-n.FTM) +
+		0.7*float64(n.OffensiveRebounds) +
+		0.3*float64(n.defensiveRebounds()) +
+		float64(n.Steals) +
+		0.7*float64(n.Assists) +
+		0.7*float64(n.Blocks) -
+		0.4*float64(n.PersonalFouls) -
+		float64(n.Turnovers)
+	if n.GamesPlayed > 1 {
+		score /= float64(n.GamesPlayed)
+	}
+	return score
+}
+
+// UsageRate returns the percentage of team plays the player used while on
+// the floor, given the team's totals over the same games. team.Minutes is
+// the team's total player minutes, five times the game minutes.
+func (n *NBAStats) UsageRate(team *NBAStats) float64 {
+	teamPlays := float64(team.FGA) + 0.44*float64(team.FTA) + float64(team.Turnovers)
+	if n.Minutes <= 0 || teamPlays == 0 {
+		return 0.0
+	}
+	plays := float64(n.FGA) + 0.44*float64(n.FTA) + float64(n.Turnovers)
+	return 100 * plays * (team.Minutes / 5) / (n.Minutes * teamPlays)
+}
+
+// PERApprox approximates Hollinger's player efficiency rating with a
+// linear-weights formula that needs no league or team adjustments. Like PER,
+// a league-average player scores about 15. It returns 0 when Minutes is
+// unknown.
+func (n *NBAStats) PERApprox() float64 {
+	if n.Minutes <= 0 {
+		return 0.0
+	}
+	total := 85.910*float64(n.FGM) +
+		53.897*float64(n.Steals) +
+		51.757*float64(n.ThreePointsMade) +
+		46.845*float64(n.FTM) +
+		39.190*float64(n.Blocks) +
+		39.190*float64(n.OffensiveRebounds) +
+		34.677*float64(n.Assists) +
+		14.707*float64(n.defensiveRebounds()) -
+		17.174*float64(n.PersonalFouls) -
+		20.091*float64(n.FTA-n.FTM) -
+		39.190*float64(n.FGA-n.FGM) -
+		53.897*float64(n.Turnovers)
+	return total / n.Minutes
+}
+
+// PerGame averages the counting stats over GamesPlayed, leaving rates as
+// they are. It returns an empty line when GamesPlayed is zero.
+func (n *NBAStats) PerGame() StatLine {
+	if n.GamesPlayed <= 0 {
+		return StatLine{}
+	}
+	return n.StatLine().PerGame(n.GamesPlayed)
+}
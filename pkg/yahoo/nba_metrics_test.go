@@ -0,0 +1,83 @@
+package yahoo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNBAAdvancedMetrics(t *testing.T) {
+	stats := []Stat{
+		{StatID: StatIDGamesPlayed, Value: "1"},
+		{StatID: StatIDMinutesPlayed, Value: "36:00"},
+		{StatID: StatIDFGM, Value: "10"},
+		{StatID: StatIDFGA, Value: "20"},
+		{StatID: StatIDFTM, Value: "8"},
+		{StatID: StatIDFTA, Value: "10"},
+		{StatID: StatID3PM, Value: "3"},
+		{StatID: StatID3PA, Value: "9"},
+		{StatID: StatIDPoints, Value: "31"},
+		{StatID: StatIDOffensiveRebounds, Value: "2"},
+		{StatID: StatIDRebounds, Value: "10"},
+		{StatID: StatIDAssists, Value: "6"},
+		{StatID: StatIDSteals, Value: "2"},
+		{StatID: StatIDBlocks, Value: "1"},
+		{StatID: StatIDTurnovers, Value: "3"},
+		{StatID: StatIDPersonalFouls, Value: "2"},
+	}
+	n, err := ParseNBAStats(stats)
+	if err != nil {
+		t.Fatalf("ParseNBAStats failed: %v", err)
+	}
+
+	team := &NBAStats{Minutes: 240, FGA: 85, FTA: 20, Turnovers: 13}
+	tests := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"Minutes", n.Minutes, 36},
+		{"AssistTurnoverRatio", n.AssistTurnoverRatio(), 2},
+		{"GameScore", n.GameScore(), 27.1},
+		{"PERApprox", n.PERApprox(), 36.447},
+		{"UsageRate", n.UsageRate(team), 34.2072},
+		{"Per36 PTS", n.Per36()["PTS"], 31},
+		{"Per36 DREB", n.Per36()["DREB"], 8},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.got-tt.want) > 1e-3 {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestNBAMetricsZeroGuards(t *testing.T) {
+	n := &NBAStats{Points: 20, Assists: 4}
+	if n.AssistTurnoverRatio() != 0 || n.PERApprox() != 0 || n.UsageRate(&NBAStats{}) != 0 {
+		t.Errorf("expected zero metrics without turnovers or minutes")
+	}
+	if len(n.Per36()) != 0 {
+		t.Errorf("Per36() = %v, want empty", n.Per36())
+	}
+}
+
+func TestNBAStatsPer36(t *testing.T) {
+	n := &NBAStats{GamesPlayed: 2, Minutes: 48, Points: 40, Rebounds: 12, FGPercent: 0.45}
+	per36 := n.Per36()
+	if per36["PTS"] != 30 || per36["REB"] != 9 || per36["FG%"] != 0.45 || per36["GP"] != 2 {
+		t.Errorf("Per36() = %v", per36)
+	}
+}
+
+func TestParseMinutes(t *testing.T) {
+	tests := map[string]float64{
+		"34:30": 34.5,
+		"1,234": 1234,
+		"33.5":  33.5,
+		"-":     0,
+	}
+	for value, want := range tests {
+		if got := parseMinutes(value); got != want {
+			t.Errorf("parseMinutes(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package yahoo
+
+// Yahoo NFL stat IDs. Offensive players, kickers and team defenses share one
+// ID space, so a player's stats only contain the IDs relevant to them.
+const (
+	StatIDNFLGamesPlayed        = 0
+	StatIDNFLPassAttempts       = 1
+	StatIDNFLCompletions        = 2
+	StatIDNFLIncompletions      = 3
+	StatIDNFLPassYards          = 4
+	StatIDNFLPassTD             = 5
+	StatIDNFLInterceptions      = 6
+	StatIDNFLSacksTaken         = 7
+	StatIDNFLRushAttempts       = 8
+	StatIDNFLRushYards          = 9
+	StatIDNFLRushTD             = 10
+	StatIDNFLReceptions         = 11
+	StatIDNFLReceivingYards     = 12
+	StatIDNFLReceivingTD        = 13
+	StatIDNFLReturnYards        = 14
+	StatIDNFLReturnTD           = 15
+	StatIDNFLTwoPointConversion = 16
+	StatIDNFLFumbles            = 17
+	StatIDNFLFumblesLost        = 18
+
+	StatIDNFLFGMade0To19    = 19
+	StatIDNFLFGMade20To29   = 20
+	StatIDNFLFGMade30To39   = 21
+	StatIDNFLFGMade40To49   = 22
+	StatIDNFLFGMade50Plus   = 23
+	StatIDNFLFGMissed0To19  = 24
+	StatIDNFLFGMissed20To29 = 25
+	StatIDNFLFGMissed30To39 = 26
+	StatIDNFLFGMissed40To49 = 27
+	StatIDNFLFGMissed50Plus = 28
+	StatIDNFLPATMade        = 29
+	StatIDNFLPATMissed      = 30
+
+	StatIDNFLPointsAllowed        = 31
+	StatIDNFLDefenseSacks         = 32
+	StatIDNFLDefenseInterceptions = 33
+	StatIDNFLFumbleRecoveries     = 34
+	StatIDNFLDefenseTD            = 35
+	StatIDNFLSafeties             = 36
+	StatIDNFLBlockedKicks         = 37
+	StatIDNFLKickPuntReturnTD     = 49
+
+	// Points-allowed tiers count the games a defense held its opponent to
+	// each range; leagues attach a modifier to each tier.
+	StatIDNFLPointsAllowed0      = 50
+	StatIDNFLPointsAllowed1To6   = 51
+	StatIDNFLPointsAllowed7To13  = 52
+	StatIDNFLPointsAllowed14To20 = 53
+	StatIDNFLPointsAllowed21To27 = 54
+	StatIDNFLPointsAllowed28To34 = 55
+	StatIDNFLPointsAllowed35Plus = 56
+
+	StatIDNFLOffensiveFumbleReturnTD = 57
+	StatIDNFLTargets                 = 78
+)
+
+// NFLStats is a typed view of an NFL player's or team defense's stats.
+type NFLStats struct {
+	GamesPlayed int
+
+	PassAttempts  int
+	Completions   int
+	Incompletions int
+	PassYards     int
+	PassTD        int
+	Interceptions int
+	SacksTaken    int
+
+	RushAttempts int
+	RushYards    int
+	RushTD       int
+
+	Targets        int
+	Receptions     int
+	ReceivingYards int
+	ReceivingTD    int
+
+	ReturnYards int
+	ReturnTD    int
+
+	TwoPointConversions     int
+	Fumbles                 int
+	FumblesLost             int
+	OffensiveFumbleReturnTD int
+
+	// FGMade and FGMissed are indexed by distance: 0-19, 20-29, 30-39,
+	// 40-49 and 50+ yards.
+	FGMade    [5]int
+	FGMissed  [5]int
+	PATMade   int
+	PATMissed int
+
+	PointsAllowed        int
+	DefenseSacks         int
+	DefenseInterceptions int
+	FumbleRecoveries     int
+	DefenseTD            int
+	Safeties             int
+	BlockedKicks         int
+	KickPuntReturnTD     int
+	// PointsAllowedTiers is indexed like the tier stat IDs: 0, 1-6, 7-13,
+	// 14-20, 21-27, 28-34 and 35+ points.
+	PointsAllowedTiers [7]int
+}
+
+// ParseNFLStats reads the NFL stats it recognizes; missing or non-numeric
+// values are left at zero.
+func ParseNFLStats(stats []Stat) (*NFLStats, error) {
+	sh := NewStatHelper(stats)
+	nflStats := &NFLStats{}
+	for statID, field := range nflStats.fields() {
+		if val, err := sh.GetIntByID(statID); err == nil {
+			*field = val
+		}
+	}
+	return nflStats, nil
+}
+
+// FantasyPoints recalculates the stat line's fantasy points from a points
+// league's stat modifiers (see LeagueSettings.StatModifiers). Stats without
+// a modifier score nothing.
+func (n *NFLStats) FantasyPoints(modifiers map[int]float64) float64 {
+	var total float64
+	for statID, field := range n.fields() {
+		total += float64(*field) * modifiers[statID]
+	}
+	return total
+}
+
+func (n *NFLStats) fields() map[int]*int {
+	return map[int]*int{
+		StatIDNFLGamesPlayed:        &n.GamesPlayed,
+		StatIDNFLPassAttempts:       &n.PassAttempts,
+		StatIDNFLCompletions:        &n.Completions,
+		StatIDNFLIncompletions:      &n.Incompletions,
+		StatIDNFLPassYards:          &n.PassYards,
+		StatIDNFLPassTD:             &n.PassTD,
+		StatIDNFLInterceptions:      &n.Interceptions,
+		StatIDNFLSacksTaken:         &n.SacksTaken,
+		StatIDNFLRushAttempts:       &n.RushAttempts,
+		StatIDNFLRushYards:          &n.RushYards,
+		StatIDNFLRushTD:             &n.RushTD,
+		StatIDNFLTargets:            &n.Targets,
+		StatIDNFLReceptions:         &n.Receptions,
+		StatIDNFLReceivingYards:     &n.ReceivingYards,
+		StatIDNFLReceivingTD:        &n.ReceivingTD,
+		StatIDNFLReturnYards:        &n.ReturnYards,
+		StatIDNFLReturnTD:           &n.ReturnTD,
+		StatIDNFLTwoPointConversion: &n.TwoPointConversions,
+		StatIDNFLFumbles:            &n.Fumbles,
+		StatIDNFLFumblesLost:        &n.FumblesLost,
+
+		StatIDNFLOffensiveFumbleReturnTD: &n.OffensiveFumbleReturnTD,
+
+		StatIDNFLFGMade0To19:    &n.FGMade[0],
+		StatIDNFLFGMade20To29:   &n.FGMade[1],
+		StatIDNFLFGMade30To39:   &n.FGMade[2],
+		StatIDNFLFGMade40To49:   &n.FGMade[3],
+		StatIDNFLFGMade50Plus:   &n.FGMade[4],
+		StatIDNFLFGMissed0To19:  &n.FGMissed[0],
+		StatIDNFLFGMissed20To29: &n.FGMissed[1],
+		StatIDNFLFGMissed30To39: &n.FGMissed[2],
+		StatIDNFLFGMissed40To49: &n.FGMissed[3],
+		StatIDNFLFGMissed50Plus: &n.FGMissed[4],
+		StatIDNFLPATMade:        &n.PATMade,
+		StatIDNFLPATMissed:      &n.PATMissed,
+
+		StatIDNFLPointsAllowed:        &n.PointsAllowed,
+		StatIDNFLDefenseSacks:         &n.DefenseSacks,
+		StatIDNFLDefenseInterceptions: &n.DefenseInterceptions,
+		StatIDNFLFumbleRecoveries:     &n.FumbleRecoveries,
+		StatIDNFLDefenseTD:            &n.DefenseTD,
+		StatIDNFLSafeties:             &n.Safeties,
+		StatIDNFLBlockedKicks:         &n.BlockedKicks,
+		StatIDNFLKickPuntReturnTD:     &n.KickPuntReturnTD,
+
+		StatIDNFLPointsAllowed0:      &n.PointsAllowedTiers[0],
+		StatIDNFLPointsAllowed1To6:   &n.PointsAllowedTiers[1],
+		StatIDNFLPointsAllowed7To13:  &n.PointsAllowedTiers[2],
+		StatIDNFLPointsAllowed14To20: &n.PointsAllowedTiers[3],
+		StatIDNFLPointsAllowed21To27: &n.PointsAllowedTiers[4],
+		StatIDNFLPointsAllowed28To34: &n.PointsAllowedTiers[5],
+		StatIDNFLPointsAllowed35Plus: &n.PointsAllowedTiers[6],
+	}
+}
+
+// TotalFGMade returns the field goals made at any distance.
+func (n *NFLStats) TotalFGMade() int {
+	total := 0
+	for _, made := range n.FGMade {
+		total += made
+	}
+	return total
+}
+
+// TotalTD returns passing, rushing, receiving and return touchdowns.
+func (n *NFLStats) TotalTD() int {
+	return n.PassTD + n.RushTD + n.ReceivingTD + n.ReturnTD + n.OffensiveFumbleReturnTD
+}
+
+// StatLine returns the stats keyed by Yahoo's NFL display names, e.g.
+// "Pass Yds" or "Rec". Team defense stats that share a name with an
+// offensive one are prefixed with "DEF ", as in "DEF Int".
+func (n *NFLStats) StatLine() StatLine {
+	return StatLine{
+		"GP":        float64(n.GamesPlayed),
+		"Pass Att":  float64(n.PassAttempts),
+		"Comp":      float64(n.Completions),
+		"Inc":       float64(n.Incompletions),
+		"Pass Yds":  float64(n.PassYards),
+		"Pass TD":   float64(n.PassTD),
+		"Int":       float64(n.Interceptions),
+		"Sack":      float64(n.SacksTaken),
+		"Rush Att":  float64(n.RushAttempts),
+		"Rush Yds":  float64(n.RushYards),
+		"Rush TD":   float64(n.RushTD),
+		"Targets":   float64(n.Targets),
+		"Rec":       float64(n.Receptions),
+		"Rec Yds":   float64(n.ReceivingYards),
+		"Rec TD":    float64(n.ReceivingTD),
+		"Ret Yds":   float64(n.ReturnYards),
+		"Ret TD":    float64(n.ReturnTD),
+		"2-PT":      float64(n.TwoPointConversions),
+		"Fum":       float64(n.Fumbles),
+		"Fum Lost":  float64(n.FumblesLost),
+		"FG Made":   float64(n.TotalFGMade()),
+		"PAT Made":  float64(n.PATMade),
+		"PAT Miss":  float64(n.PATMissed),
+		"Pts Allow": float64(n.PointsAllowed),
+		"DEF Sack":  float64(n.DefenseSacks),
+		"DEF Int":   float64(n.DefenseInterceptions),
+		"Fum Rec":   float64(n.FumbleRecoveries),
+		"DEF TD":    float64(n.DefenseTD),
+		"Safe":      float64(n.Safeties),
+		"Blk Kick":  float64(n.BlockedKicks),
+	}
+}
+
+// PerGame averages the stats over GamesPlayed. It returns an empty line when
+// GamesPlayed is zero.
+func (n *NFLStats) PerGame() StatLine {
+	if n.GamesPlayed <= 0 {
+		return StatLine{}
+	}
+	return n.StatLine().PerGame(n.GamesPlayed)
+}
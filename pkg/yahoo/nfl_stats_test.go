@@ -0,0 +1,83 @@
+package yahoo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseNFLStats(t *testing.T) {
+	stats := []Stat{
+		{StatID: 0, Value: "1"},   // GP
+		{StatID: 4, Value: "312"}, // Pass Yds
+		{StatID: 5, Value: "3"},   // Pass TD
+		{StatID: 6, Value: "1"},   // Int
+		{StatID: 9, Value: "24"},  // Rush Yds
+		{StatID: 10, Value: "1"},  // Rush TD
+		{StatID: 16, Value: "1"},  // 2-PT
+		{StatID: 18, Value: "1"},  // Fum Lost
+		{StatID: 22, Value: "2"},  // FG 40-49
+		{StatID: 23, Value: "1"},  // FG 50+
+		{StatID: 78, Value: "-"},  // Targets, not tracked
+	}
+
+	nflStats, err := ParseNFLStats(stats)
+	if err != nil {
+		t.Fatalf("ParseNFLStats failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{"GamesPlayed", nflStats.GamesPlayed, 1},
+		{"PassYards", nflStats.PassYards, 312},
+		{"PassTD", nflStats.PassTD, 3},
+		{"Interceptions", nflStats.Interceptions, 1},
+		{"RushYards", nflStats.RushYards, 24},
+		{"RushTD", nflStats.RushTD, 1},
+		{"TwoPointConversions", nflStats.TwoPointConversions, 1},
+		{"FumblesLost", nflStats.FumblesLost, 1},
+		{"FGMade 40-49", nflStats.FGMade[3], 2},
+		{"TotalFGMade", nflStats.TotalFGMade(), 3},
+		{"TotalTD", nflStats.TotalTD(), 4},
+		{"Targets", nflStats.Targets, 0},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestNFLStatsFantasyPoints(t *testing.T) {
+	modifiers := map[int]float64{
+		StatIDNFLPassYards:            0.04,
+		StatIDNFLPassTD:               4,
+		StatIDNFLInterceptions:        -1,
+		StatIDNFLRushYards:            0.1,
+		StatIDNFLRushTD:               6,
+		StatIDNFLReceptions:           0.5,
+		StatIDNFLTwoPointConversion:   2,
+		StatIDNFLFumblesLost:          -2,
+		StatIDNFLPointsAllowed7To13:   4,
+		StatIDNFLDefenseSacks:         1,
+		StatIDNFLDefenseInterceptions: 2,
+	}
+
+	qb := &NFLStats{PassYards: 312, PassTD: 3, Interceptions: 1, RushYards: 24, RushTD: 1, TwoPointConversions: 1, FumblesLost: 1}
+	// 12.48 + 12 - 1 + 2.4 + 6 + 2 - 2
+	if got := qb.FantasyPoints(modifiers); math.Abs(got-31.88) > 1e-9 {
+		t.Errorf("QB FantasyPoints() = %v, want 31.88", got)
+	}
+
+	dst := &NFLStats{PointsAllowed: 10, DefenseSacks: 4, DefenseInterceptions: 2}
+	dst.PointsAllowedTiers[2] = 1
+	if got := dst.FantasyPoints(modifiers); math.Abs(got-12) > 1e-9 {
+		t.Errorf("DST FantasyPoints() = %v, want 12", got)
+	}
+
+	if got := qb.FantasyPoints(nil); got != 0 {
+		t.Errorf("FantasyPoints(nil) = %v, want 0", got)
+	}
+}
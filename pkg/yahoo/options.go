@@ -0,0 +1,106 @@
+package yahoo
+
+import "time"
+
+const defaultTokenRefreshSkew = 60 * time.Second
+
+// ClientOption configures optional Client behaviour in NewClient.
+type ClientOption func(*Client)
+
+// WithTokenRefreshSkew sets how long before the access token expires the
+// client refreshes it proactively. Defaults to one minute.
+func WithTokenRefreshSkew(skew time.Duration) ClientOption {
+	return func(c *Client) {
+		c.refreshSkew = skew
+	}
+}
+
+// WithInitialToken seeds the client with a token set, including its expiry,
+// instead of the YAHOO_ACCESS_TOKEN/YAHOO_REFRESH_TOKEN environment variables.
+func WithInitialToken(token Token) ClientOption {
+	return func(c *Client) {
+		c.accessToken = token.AccessToken
+		c.refreshToken = token.RefreshToken
+		c.tokenExpiry = token.Expiry
+	}
+}
+
+// WithRateLimit throttles all requests made by the client (and clients
+// derived from it) to rps requests per second with bursts of up to burst.
+func WithRateLimit(rps float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(rps, burst)
+	}
+}
+
+// WithRetryPolicy replaces the default retry policy. Use NoRetry() to disable
+// retries entirely.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithLogger routes request, cache and token refresh events to logger, e.g.
+// a *slog.Logger. Logging is disabled by default.
+func WithLogger(logger Logger) ClientOption {
+	return func(c *Client) {
+		if logger == nil {
+			logger = noopLogger{}
+		}
+		c.logger = logger
+	}
+}
+
+// WithMetrics records request, cache and token refresh metrics into m. The
+// same Metrics may be shared by several clients.
+func WithMetrics(m *Metrics) ClientOption {
+	return func(c *Client) {
+		c.metrics = m
+	}
+}
+
+// WithResponseFormat selects the format requested from Yahoo for endpoints
+// with XML decoders (leagues, players, standings and transactions). Other
+// endpoints always use JSON. Defaults to FormatJSON.
+func WithResponseFormat(format ResponseFormat) ClientOption {
+	return func(c *Client) {
+		c.responseFormat = format
+	}
+}
+
+// WithCircuitBreaker stops sending requests for cooldown after threshold
+// consecutive 5xx responses or timeouts, failing them with ErrCircuitOpen
+// instead. The breaker is shared by clients derived with WithToken.
+func WithCircuitBreaker(threshold int, cooldown time.Duration) ClientOption {
+	return func(c *Client) {
+		c.breaker = newCircuitBreaker(threshold, cooldown)
+	}
+}
+
+// ServeStaleOnError makes cached Get* calls return an expired cache entry,
+// when one exists, instead of failing if the request to Yahoo fails. Use
+// WithResponseMetadata to find out whether the data returned was stale.
+// Has no effect unless caching is enabled.
+func ServeStaleOnError() ClientOption {
+	return func(c *Client) {
+		c.serveStale = true
+	}
+}
+
+// WithCache stores responses in cache, e.g. a MemoryCache, instead of the
+// database passed to NewClient, and enables caching regardless of
+// YAHOO_ENABLE_CACHE.
+func WithCache(cache Cache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheEnabled = true
+	}
+}
+
+// WithCachePolicy overrides how long each kind of response is cached.
+func WithCachePolicy(policy CachePolicy) ClientOption {
+	return func(c *Client) {
+		c.cachePolicy = policy
+	}
+}
@@ -0,0 +1,58 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GetPlayersPercentOwned returns players with their Ownership and
+// PercentOwned fields populated, in batches of up to 25 player keys per
+// request. weekNum 0 requests the current percent owned; PercentOwned.Delta
+// holds the change from the previous period.
+func (c *Client) GetPlayersPercentOwned(ctx context.Context, leagueKey string, playerKeys []string, weekNum int) ([]Player, error) {
+	weekStr := "current"
+	if weekNum > 0 {
+		weekStr = fmt.Sprintf("week_%d", weekNum)
+	}
+
+	var players []Player
+	for start := 0; start < len(playerKeys); start += playersPageSize {
+		batch := playerKeys[start:min(start+playersPageSize, len(playerKeys))]
+		cacheKey := fmt.Sprintf("players:%s:percent_owned:%s:%s", strings.Join(batch, ","), leagueKey, weekStr)
+
+		batchPlayers, err := cachedFetch(ctx, c, CachePlayers, cacheKey, func() ([]Player, error) {
+			return c.fetchPlayersPercentOwned(ctx, leagueKey, batch, weekNum)
+		})
+		if err != nil {
+			return nil, err
+		}
+		players = append(players, batchPlayers...)
+	}
+
+	return players, nil
+}
+
+func (c *Client) fetchPlayersPercentOwned(ctx context.Context, leagueKey string, playerKeys []string, weekNum int) ([]Player, error) {
+	weekParam := ""
+	if weekNum > 0 {
+		weekParam = fmt.Sprintf(";type=week;week=%d", weekNum)
+	}
+	endpoint := fmt.Sprintf("league/%s/players;player_keys=%s;out=ownership,percent_owned%s", leagueKey, strings.Join(playerKeys, ","), weekParam)
+	data, err := c.makeRequest(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp yahooPlayerResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse percent owned response: %w", err)
+	}
+
+	var players []Player
+	for _, item := range resp.FantasyContent.League.Value.Players {
+		players = append(players, convertYahooPlayerToPlayer(item.Player.Value))
+	}
+	return players, nil
+}
@@ -0,0 +1,28 @@
+package yahoo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetPlayersPercentOwned(t *testing.T) {
+	players, err := newFixtureClient(t, "percent_owned.json").GetPlayersPercentOwned(context.Background(), "454.l.1",
+		[]string{"454.p.6583", "454.p.6700"}, 5)
+	if err != nil {
+		t.Fatalf("GetPlayersPercentOwned() error = %v", err)
+	}
+	if len(players) != 2 {
+		t.Fatalf("players = %+v", players)
+	}
+
+	if players[0].Ownership == nil || players[0].Ownership.OwnerTeamKey != "454.l.1.t.1" {
+		t.Errorf("Ownership = %+v", players[0].Ownership)
+	}
+	waiver := players[1]
+	if waiver.Ownership == nil || waiver.Ownership.OwnershipType != "freeagents" {
+		t.Errorf("Ownership = %+v", waiver.Ownership)
+	}
+	if waiver.PercentOwned == nil || waiver.PercentOwned.Value != 23 || waiver.PercentOwned.Delta != 7 || waiver.PercentOwned.Week != 5 {
+		t.Errorf("PercentOwned = %+v", waiver.PercentOwned)
+	}
+}
@@ -0,0 +1,110 @@
+package yahoo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PlayerSort orders a players collection: by rank, by name, or by a stat
+// (see PlayerSortByStat).
+type PlayerSort string
+
+const (
+	PlayerSortActualRank  PlayerSort = "AR"
+	PlayerSortOverallRank PlayerSort = "OR"
+	PlayerSortName        PlayerSort = "NAME"
+)
+
+// PlayerSortByStat sorts players by the given stat ID, best first.
+func PlayerSortByStat(statID int) PlayerSort {
+	return PlayerSort(strconv.Itoa(statID))
+}
+
+// PlayerSortType is the period a PlayerSort is computed over.
+type PlayerSortType string
+
+const (
+	PlayerSortSeason    PlayerSortType = "season"
+	PlayerSortWeek      PlayerSortType = "week"
+	PlayerSortLastWeek  PlayerSortType = "lastweek"
+	PlayerSortLastMonth PlayerSortType = "lastmonth"
+)
+
+// PlayerQuery filters and sorts a league's players collection. Zero fields
+// are left out of the request, so the zero PlayerQuery returns the first
+// page of all players in Yahoo's default order.
+type PlayerQuery struct {
+	// Position limits results to players eligible at a position, e.g. "PG".
+	Position string
+	Status   PlayerStatus
+	Sort     PlayerSort
+	SortType PlayerSortType
+	// Week is the week to sort by when SortType is PlayerSortWeek.
+	Week int
+	// Start and Count page through the results; Count defaults to, and is
+	// capped by Yahoo at, 25.
+	Start int
+	Count int
+}
+
+// params renders the query as Yahoo matrix parameters, e.g.
+// ";position=PG;status=FA;sort=AR;sort_type=week;sort_week=5;start=0;count=25".
+func (q PlayerQuery) params() string {
+	var b strings.Builder
+	if q.Position != "" {
+		b.WriteString(";position=" + q.Position)
+	}
+	if q.Status != "" {
+		b.WriteString(";status=" + string(q.Status))
+	}
+	if q.Sort != "" {
+		b.WriteString(";sort=" + string(q.Sort))
+	}
+	if q.SortType != "" {
+		b.WriteString(";sort_type=" + string(q.SortType))
+		if q.SortType == PlayerSortWeek && q.Week > 0 {
+			fmt.Fprintf(&b, ";sort_week=%d", q.Week)
+		}
+	}
+	count := q.Count
+	if count <= 0 {
+		count = playersPageSize
+	}
+	fmt.Fprintf(&b, ";start=%d;count=%d", q.Start, count)
+	return b.String()
+}
+
+// QueryLeaguePlayers returns one page of the league's players matching
+// query.
+func (c *Client) QueryLeaguePlayers(ctx context.Context, leagueKey string, query PlayerQuery) ([]Player, error) {
+	params := query.params()
+	cacheKey := fmt.Sprintf("league:%s:players%s", leagueKey, params)
+
+	return cachedFetch(ctx, c, CachePlayers, cacheKey, func() ([]Player, error) {
+		return c.fetchLeaguePlayers(ctx, leagueKey, params)
+	})
+}
+
+// GetPlayersWithNotes pages through the league's players matching query and
+// returns those whose Yahoo notes were updated at or after since, e.g. to
+// surface injury news. Every page is a request, so narrow the query, for
+// instance to PlayerStatusTaken or a position.
+func (c *Client) GetPlayersWithNotes(ctx context.Context, leagueKey string, query PlayerQuery, since time.Time) ([]Player, error) {
+	var players []Player
+	pager := c.NewPlayersQueryPager(leagueKey, query)
+	for pager.More() {
+		page, err := pager.Next(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, player := range page {
+			if player.HasPlayerNotes && player.PlayerNotesLastTimestamp >= since.Unix() {
+				players = append(players, player)
+			}
+		}
+	}
+	return players, nil
+}
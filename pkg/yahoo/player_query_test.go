@@ -0,0 +1,89 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlayerQueryParams(t *testing.T) {
+	tests := []struct {
+		name  string
+		query PlayerQuery
+		want  string
+	}{
+		{"zero", PlayerQuery{}, ";start=0;count=25"},
+		{"status and page", PlayerQuery{Status: PlayerStatusFreeAgents, Start: 25, Count: 10}, ";status=FA;start=25;count=10"},
+		{
+			"full",
+			PlayerQuery{Position: "PG", Status: PlayerStatusWaivers, Sort: PlayerSortByStat(12), SortType: PlayerSortWeek, Week: 5},
+			";position=PG;status=W;sort=12;sort_type=week;sort_week=5;start=0;count=25",
+		},
+		{"week ignored for season", PlayerQuery{Sort: PlayerSortActualRank, SortType: PlayerSortLastMonth, Week: 5}, ";sort=AR;sort_type=lastmonth;start=0;count=25"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.params(); got != tt.want {
+				t.Errorf("params() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryLeaguePlayersRequest(t *testing.T) {
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write([]byte(`{"fantasy_content":{"league":{"players":{"0":{"player":{"player_key":"454.p.1"}},"count":1}}}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	players, err := client.QueryLeaguePlayers(context.Background(), "454.l.1", PlayerQuery{
+		Position: "C",
+		Status:   PlayerStatusFreeAgents,
+		Sort:     PlayerSortOverallRank,
+		SortType: PlayerSortSeason,
+	})
+	if err != nil {
+		t.Fatalf("QueryLeaguePlayers() error = %v", err)
+	}
+	if want := "/league/454.l.1/players;position=C;status=FA;sort=OR;sort_type=season;start=0;count=25"; !strings.HasSuffix(gotPath, want) {
+		t.Errorf("request path = %s, want suffix %s", gotPath, want)
+	}
+	if len(players) != 1 || players[0].PlayerKey != "454.p.1" {
+		t.Errorf("players = %+v", players)
+	}
+}
+
+func TestGetPlayersWithNotes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"fantasy_content":{"league":[{"league_key":"454.l.1"},{"players":{
+			"0":{"player":[[{"player_key":"454.p.1"},{"name":{"full":"Fresh News"}},{"status":"GTD"},{"status_full":"Game Time Decision"},{"injury_note":"Ankle"},{"has_player_notes":1},{"player_notes_last_timestamp":1737000000}]]},
+			"1":{"player":[[{"player_key":"454.p.2"},{"name":{"full":"Old News"}},{"has_player_notes":1},{"player_notes_last_timestamp":1600000000}]]},
+			"2":{"player":[[{"player_key":"454.p.3"},{"name":{"full":"No News"}}]]},
+			"count":3}}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	players, err := client.GetPlayersWithNotes(context.Background(), "454.l.1",
+		PlayerQuery{Status: PlayerStatusTaken}, time.Unix(1700000000, 0))
+	if err != nil {
+		t.Fatalf("GetPlayersWithNotes() error = %v", err)
+	}
+	if len(players) != 1 {
+		t.Fatalf("players = %+v", players)
+	}
+	p := players[0]
+	if p.PlayerKey != "454.p.1" || p.Status != "GTD" || p.StatusFull != "Game Time Decision" || p.InjuryNote != "Ankle" || !p.HasPlayerNotes {
+		t.Errorf("player = %+v", p)
+	}
+}
@@ -0,0 +1,85 @@
+package yahoo
+
+import (
+	"context"
+	"iter"
+)
+
+// playersPageSize is the largest page Yahoo returns from a players
+// collection.
+const playersPageSize = 25
+
+// PlayersPager walks a league's players collection one page at a time:
+//
+//	pager := client.NewPlayersPager(leagueKey, yahoo.PlayerStatusFreeAgents)
+//	for pager.More() {
+//		players, err := pager.Next(ctx)
+//		...
+//	}
+//
+// Each page is a separate request subject to the client's rate limit, retry
+// policy and cache.
+type PlayersPager struct {
+	client    *Client
+	leagueKey string
+	query     PlayerQuery
+	done      bool
+}
+
+// NewPlayersPager returns a pager over leagueKey's players with the given
+// status; an empty status includes all players.
+func (c *Client) NewPlayersPager(leagueKey string, status PlayerStatus) *PlayersPager {
+	return c.NewPlayersQueryPager(leagueKey, PlayerQuery{Status: status})
+}
+
+// NewPlayersQueryPager returns a pager over leagueKey's players matching
+// query, starting at query.Start. The page size is always 25.
+func (c *Client) NewPlayersQueryPager(leagueKey string, query PlayerQuery) *PlayersPager {
+	query.Count = playersPageSize
+	return &PlayersPager{client: c, leagueKey: leagueKey, query: query}
+}
+
+// More reports whether Next may return further players.
+func (p *PlayersPager) More() bool {
+	return !p.done
+}
+
+// Next fetches the next page. A short or empty page marks the end of the
+// collection. After an error the pager can be retried from the same page.
+func (p *PlayersPager) Next(ctx context.Context) ([]Player, error) {
+	if p.done {
+		return nil, nil
+	}
+
+	players, err := p.client.QueryLeaguePlayers(ctx, p.leagueKey, p.query)
+	if err != nil {
+		return nil, err
+	}
+
+	p.query.Start += len(players)
+	if len(players) < playersPageSize {
+		p.done = true
+	}
+	return players, nil
+}
+
+// AllLeaguePlayers iterates over every player in leagueKey with the given
+// status, paging transparently. Iteration stops after the first error, which
+// is yielded with a zero Player.
+func (c *Client) AllLeaguePlayers(ctx context.Context, leagueKey string, status PlayerStatus) iter.Seq2[Player, error] {
+	return func(yield func(Player, error) bool) {
+		pager := c.NewPlayersPager(leagueKey, status)
+		for pager.More() {
+			players, err := pager.Next(ctx)
+			if err != nil {
+				yield(Player{}, err)
+				return
+			}
+			for _, player := range players {
+				if !yield(player, nil) {
+					return
+				}
+			}
+		}
+	}
+}
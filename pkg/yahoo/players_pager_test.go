@@ -0,0 +1,94 @@
+package yahoo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newPlayersServer serves a players collection of total players, honouring
+// the ;start= and ;count= matrix parameters.
+func newPlayersServer(t *testing.T, total int, requests *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		params := map[string]int{}
+		for _, part := range strings.Split(r.URL.Path, ";")[1:] {
+			if k, v, ok := strings.Cut(part, "="); ok {
+				params[k], _ = strconv.Atoi(v)
+			}
+		}
+
+		var items []string
+		for i := params["start"]; i < total && i < params["start"]+params["count"]; i++ {
+			items = append(items, fmt.Sprintf(`"%d":{"player":{"player_key":"nba.p.%d"}}`, len(items), i))
+		}
+		items = append(items, fmt.Sprintf(`"count":%d`, len(items)))
+		fmt.Fprintf(w, `{"fantasy_content":{"league":{"players":{%s}}}}`, strings.Join(items, ","))
+	}))
+}
+
+func TestPlayersPager(t *testing.T) {
+	var requests int32
+	server := newPlayersServer(t, 60, &requests)
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	pager := client.NewPlayersPager("nba.l.1", PlayerStatusFreeAgents)
+	var sizes []int
+	for pager.More() {
+		players, err := pager.Next(context.Background())
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		sizes = append(sizes, len(players))
+	}
+
+	if fmt.Sprint(sizes) != "[25 25 10]" {
+		t.Errorf("page sizes = %v, want [25 25 10]", sizes)
+	}
+	if atomic.LoadInt32(&requests) != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
+
+func TestAllLeaguePlayers(t *testing.T) {
+	var requests int32
+	server := newPlayersServer(t, 50, &requests)
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	var keys []string
+	for player, err := range client.AllLeaguePlayers(context.Background(), "nba.l.1", "") {
+		if err != nil {
+			t.Fatalf("AllLeaguePlayers() error = %v", err)
+		}
+		keys = append(keys, player.PlayerKey)
+	}
+
+	if len(keys) != 50 || keys[49] != "nba.p.49" {
+		t.Errorf("got %d players, last %q", len(keys), keys[len(keys)-1])
+	}
+	// An exact multiple of the page size needs one extra request to see the
+	// empty page.
+	if atomic.LoadInt32(&requests) != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+
+	atomic.StoreInt32(&requests, 0)
+	for range client.AllLeaguePlayers(context.Background(), "nba.l.1", "") {
+		break
+	}
+	if atomic.LoadInt32(&requests) != 1 {
+		t.Errorf("early break made %d requests, want 1", requests)
+	}
+}
@@ -0,0 +1,68 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Prefetch warms the cache for a league by fetching its standings, teams,
+// every team's roster and the current week's scoreboard in parallel, so that
+// later Get* calls for them are served from the cache. It returns the errors
+// of all fetches that failed, joined; the others are cached regardless.
+// Prefetch does nothing useful when caching is disabled.
+func (c *Client) Prefetch(ctx context.Context, leagueKey string) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	run := func(what string, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("prefetch %s: %w", what, err))
+				mu.Unlock()
+			}
+		}()
+	}
+
+	run("standings", func() error {
+		_, err := c.GetLeagueStandings(ctx, leagueKey)
+		return err
+	})
+
+	run("scoreboard", func() error {
+		matchups, err := c.GetLeagueMatchups(ctx, leagueKey, 0)
+		if err != nil || len(matchups) == 0 || matchups[0].Week == 0 {
+			return err
+		}
+		// Also cache the current week under its number, which is how
+		// interactive callers usually ask for it.
+		cacheKey := fmt.Sprintf("league:%s:matchups:week_%d", leagueKey, matchups[0].Week)
+		_, err = cachedFetch(ctx, c, CacheScoreboard, cacheKey, func() ([]Matchup, error) {
+			return matchups, nil
+		})
+		return err
+	})
+
+	run("teams", func() error {
+		teams, err := c.GetLeagueTeams(ctx, leagueKey)
+		if err != nil {
+			return err
+		}
+		for _, team := range teams {
+			run("roster "+team.YahooTeamKey, func() error {
+				_, err := c.GetTeamRoster(ctx, team.YahooTeamKey)
+				return err
+			})
+		}
+		return nil
+	})
+
+	wg.Wait()
+	return errors.Join(errs...)
+}
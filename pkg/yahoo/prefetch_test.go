@@ -0,0 +1,77 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestPrefetchWarmsLeagueCache(t *testing.T) {
+	fixtures := map[string]string{
+		"/standings":  "standings.json",
+		"/scoreboard": "scoreboard.json",
+		"/teams":      "teams.json",
+		"/roster":     "roster.json",
+	}
+
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		for suffix, name := range fixtures {
+			if strings.HasSuffix(r.URL.Path, suffix) {
+				body, err := os.ReadFile(filepath.Join("testdata", name))
+				if err != nil {
+					t.Errorf("failed to read fixture: %v", err)
+				}
+				w.Write(body)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil,
+		WithInitialToken(Token{AccessToken: "token"}),
+		WithCache(NewMemoryCache(0, 0)))
+	client.baseURL = server.URL
+
+	ctx := context.Background()
+	if err := client.Prefetch(ctx, "454.l.1"); err != nil {
+		t.Fatalf("Prefetch() error = %v", err)
+	}
+
+	mu.Lock()
+	for _, path := range []string{"/league/454.l.1/standings", "/league/454.l.1/scoreboard", "/league/454.l.1/teams", "/team/454.l.1.t.1/roster", "/team/454.l.1.t.2/roster"} {
+		if requests[path] != 1 {
+			t.Errorf("requests to %s = %d, want 1", path, requests[path])
+		}
+	}
+	before := len(requests)
+	mu.Unlock()
+
+	client.GetLeagueStandings(ctx, "454.l.1")
+	client.GetLeagueTeams(ctx, "454.l.1")
+	client.GetTeamRoster(ctx, "454.l.1.t.2")
+	if _, err := client.GetLeagueMatchups(ctx, "454.l.1", 5); err != nil {
+		t.Fatalf("GetLeagueMatchups() error = %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	total := 0
+	for _, n := range requests {
+		total += n
+	}
+	if len(requests) != before || total != 5 {
+		t.Errorf("requests after Prefetch = %v, want all reads served from the cache", requests)
+	}
+}
@@ -0,0 +1,71 @@
+package yahoo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket refilled at rps tokens per second up to
+// burst tokens. A nil *rateLimiter never blocks.
+type rateLimiter struct {
+	mu       sync.Mutex
+	rps      float64
+	burst    float64
+	tokens   float64
+	lastFill time.Time
+}
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		rps:      rps,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		lastFill: time.Now(),
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil || l.rps <= 0 {
+		return nil
+	}
+
+	for {
+		delay := l.reserve()
+		if delay == 0 {
+			return nil
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// reserve takes a token if one is available and otherwise returns how long
+// until the next token is due.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += now.Sub(l.lastFill).Seconds() * l.rps
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.lastFill = now
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rps * float64(time.Second))
+}
@@ -0,0 +1,64 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurstThenThrottle(t *testing.T) {
+	limiter := newRateLimiter(20, 2)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+	elapsed := time.Since(start)
+
+	if elapsed < 40*time.Millisecond {
+		t.Errorf("third request should wait for a token, elapsed %v", elapsed)
+	}
+}
+
+func TestRateLimiterRespectsContext(t *testing.T) {
+	limiter := newRateLimiter(0.1, 1)
+	limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() error = %v, want deadline exceeded", err)
+	}
+}
+
+func TestNilRateLimiterNeverBlocks(t *testing.T) {
+	var limiter *rateLimiter
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Errorf("Wait() error = %v", err)
+	}
+}
+
+func TestRateLimitedResponses(t *testing.T) {
+	for _, status := range []int{http.StatusTooManyRequests, statusRequestDenied} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			w.Write([]byte("Request denied"))
+		}))
+
+		client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+		client.baseURL = server.URL
+
+		_, err := client.makeRequest(context.Background(), "league/1")
+		if !errors.Is(err, ErrRateLimited) {
+			t.Errorf("status %d: error = %v, want ErrRateLimited", status, err)
+		}
+		server.Close()
+	}
+}
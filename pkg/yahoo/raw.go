@@ -0,0 +1,31 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GetRaw requests an arbitrary Fantasy API resource and returns the JSON
+// body, for sub-resources the SDK does not model yet. endpoint is relative to
+// the API base URL, e.g. "league/nba.l.12345;out=settings,standings".
+// Authentication, retries and rate limiting apply as for typed calls;
+// responses are not cached.
+func (c *Client) GetRaw(ctx context.Context, endpoint string) ([]byte, error) {
+	return c.makeRequest(ctx, strings.TrimPrefix(endpoint, "/"))
+}
+
+// GetJSON requests endpoint like GetRaw and decodes the response into v.
+// Collections arrive in Yahoo's numeric-keyed object form, so v usually
+// needs maps or json.RawMessage where the typed methods use slices.
+func (c *Client) GetJSON(ctx context.Context, endpoint string, v any) error {
+	data, err := c.GetRaw(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", endpoint, err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetJSON(t *testing.T) {
+	var gotPath, gotFormat string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotFormat = r.URL.Query().Get("format")
+		w.Write([]byte(`{"fantasy_content":{"league":[{"league_key":"nba.l.1"},{"settings":[{"draft_type":"live"}]}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	var resp struct {
+		FantasyContent struct {
+			League []json.RawMessage `json:"league"`
+		} `json:"fantasy_content"`
+	}
+	if err := client.GetJSON(context.Background(), "/league/nba.l.1;out=settings", &resp); err != nil {
+		t.Fatalf("GetJSON() error = %v", err)
+	}
+	if gotPath != "/league/nba.l.1;out=settings" || gotFormat != "json" {
+		t.Errorf("requested path %q format %q", gotPath, gotFormat)
+	}
+	if len(resp.FantasyContent.League) != 2 {
+		t.Errorf("expected 2 league fragments, got %d", len(resp.FantasyContent.League))
+	}
+}
+
+func TestGetRawReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"description":"Resource not found"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	if _, err := client.GetRaw(context.Background(), "game/nba/unknown"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetRaw() error = %v, want ErrNotFound", err)
+	}
+}
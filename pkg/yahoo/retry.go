@@ -0,0 +1,88 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how transient failures (network errors and the
+// configured status codes) are retried. Backoff grows exponentially from
+// InitialBackoff up to MaxBackoff with full jitter.
+type RetryPolicy struct {
+	MaxAttempts          int
+	InitialBackoff       time.Duration
+	MaxBackoff           time.Duration
+	RetryableStatusCodes []int
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		RetryableStatusCodes: []int{
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// NoRetry disables retries.
+func NoRetry() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 1}
+}
+
+// shouldRetry reports whether a failed attempt with the given status (0 when
+// no response was received) and error may be retried. Token refresh
+// failures are not.
+func (p RetryPolicy) shouldRetry(ctx context.Context, status int, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var refreshErr *TokenRefreshError
+	if errors.As(err, &refreshErr) {
+		return false
+	}
+	if status == 0 {
+		return true
+	}
+	for _, code := range p.RetryableStatusCodes {
+		if code == status {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the retry that follows attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	delay := p.InitialBackoff << (attempt - 1)
+	if p.MaxBackoff > 0 && (delay > p.MaxBackoff || delay <= 0) {
+		delay = p.MaxBackoff
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
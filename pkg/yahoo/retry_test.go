@@ -0,0 +1,156 @@
+package yahoo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newFlakyServer(failures int32, failStatus int) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(failStatus)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	return server, &calls
+}
+
+func testRetryPolicy(maxAttempts int) RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.MaxAttempts = maxAttempts
+	policy.InitialBackoff = time.Millisecond
+	policy.MaxBackoff = 5 * time.Millisecond
+	return policy
+}
+
+func TestRetryTransientServerErrors(t *testing.T) {
+	server, calls := newFlakyServer(2, http.StatusServiceUnavailable)
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil,
+		WithInitialToken(Token{AccessToken: "token"}),
+		WithRetryPolicy(testRetryPolicy(3)),
+	)
+	client.baseURL = server.URL
+
+	if _, err := client.makeRequest(context.Background(), "league/1"); err != nil {
+		t.Fatalf("makeRequest() error = %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("calls = %d, want 3", *calls)
+	}
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	server, calls := newFlakyServer(10, http.StatusBadGateway)
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil,
+		WithInitialToken(Token{AccessToken: "token"}),
+		WithRetryPolicy(testRetryPolicy(2)),
+	)
+	client.baseURL = server.URL
+
+	if _, err := client.makeRequest(context.Background(), "league/1"); err == nil {
+		t.Fatal("expected error after exhausting retries")
+	}
+	if *calls != 2 {
+		t.Errorf("calls = %d, want 2", *calls)
+	}
+}
+
+func TestNoRetryOnClientErrors(t *testing.T) {
+	server, calls := newFlakyServer(10, http.StatusNotFound)
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil,
+		WithInitialToken(Token{AccessToken: "token"}),
+		WithRetryPolicy(testRetryPolicy(5)),
+	)
+	client.baseURL = server.URL
+
+	client.makeRequest(context.Background(), "league/1")
+	if *calls != 1 {
+		t.Errorf("calls = %d, want 1", *calls)
+	}
+}
+
+func TestRetryStopsWhenContextDone(t *testing.T) {
+	server, calls := newFlakyServer(10, http.StatusInternalServerError)
+	defer server.Close()
+
+	policy := testRetryPolicy(10)
+	policy.InitialBackoff = time.Hour
+	policy.MaxBackoff = time.Hour
+
+	client := NewClient("key", "secret", nil,
+		WithInitialToken(Token{AccessToken: "token"}),
+		WithRetryPolicy(policy),
+	)
+	client.baseURL = server.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := client.makeRequest(ctx, "league/1"); err == nil {
+		t.Fatal("expected error when context expires during backoff")
+	}
+	if *calls > 2 {
+		t.Errorf("calls = %d, retries should stop once the context is done", *calls)
+	}
+}
+
+func TestNoRetryOnTokenRefreshFailure(t *testing.T) {
+	var calls, refreshes int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			atomic.AddInt32(&refreshes, 1)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		atomic.AddInt32(&calls, 1)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil,
+		WithInitialToken(Token{AccessToken: "stale", RefreshToken: "refresh", Expiry: time.Now().Add(10 * time.Second)}),
+		WithTokenRefreshSkew(time.Minute),
+		WithRetryPolicy(testRetryPolicy(3)),
+		WithCircuitBreaker(1, time.Hour),
+	)
+	client.baseURL = server.URL
+	client.tokenURL = server.URL + "/token"
+
+	_, err := client.makeRequest(context.Background(), "league/1")
+	var refreshErr *TokenRefreshError
+	if !errors.As(err, &refreshErr) {
+		t.Fatalf("error = %v, want a TokenRefreshError", err)
+	}
+	if n := atomic.LoadInt32(&refreshes); n != 1 {
+		t.Errorf("refreshes = %d, a failed refresh should not be retried", n)
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("calls = %d, want none without a fresh token", n)
+	}
+	if !client.breaker.allow() {
+		t.Error("a failed refresh should not open the circuit")
+	}
+}
+
+func TestRetryBackoffIsBounded(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 4 * time.Second}
+	for attempt := 1; attempt < 70; attempt++ {
+		if d := policy.backoff(attempt); d < 0 || d > 4*time.Second {
+			t.Fatalf("backoff(%d) = %v, want within [0, 4s]", attempt, d)
+		}
+	}
+}
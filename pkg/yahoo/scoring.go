@@ -0,0 +1,69 @@
+package yahoo
+
+import "strconv"
+
+// Scoring computes fantasy points the way a points league does, from the
+// stat modifiers in its settings. It works for any sport: stats are matched
+// to modifiers by stat ID.
+type Scoring struct {
+	modifiers map[int]float64
+	registry  *StatRegistry
+}
+
+// NewScoring returns the scoring rules of the league whose settings are
+// given. Category leagues have no stat modifiers, so every stat line scores
+// zero; check IsPointsLeague before relying on the result.
+func NewScoring(settings *LeagueSettings) *Scoring {
+	return &Scoring{
+		modifiers: settings.StatModifiers,
+		registry:  NewStatRegistry(settings.StatCategories),
+	}
+}
+
+// IsPointsLeague reports whether the league awards points per stat.
+func (s *Scoring) IsPointsLeague() bool {
+	return len(s.modifiers) > 0
+}
+
+// Modifier returns the points one unit of the stat is worth.
+func (s *Scoring) Modifier(statID int) float64 {
+	return s.modifiers[statID]
+}
+
+// ModifierByName returns the points one unit of the named stat is worth,
+// resolving names like "3PM" or "STL" through the league's categories.
+func (s *Scoring) ModifierByName(name string) (float64, bool) {
+	statID, ok := s.registry.Lookup(name)
+	if !ok {
+		return 0, false
+	}
+	modifier, ok := s.modifiers[statID]
+	return modifier, ok
+}
+
+// Points returns the fantasy points the stats are worth. Stats without a
+// modifier and non-numeric values such as "-" score nothing.
+func (s *Scoring) Points(stats []Stat) float64 {
+	var total float64
+	for _, stat := range stats {
+		modifier, ok := s.modifiers[stat.StatID]
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(stat.Value, 64)
+		if err != nil {
+			continue
+		}
+		total += value * modifier
+	}
+	return total
+}
+
+// PlayerPoints returns the fantasy points of the player's stats, or 0 if
+// the player was fetched without stats.
+func (s *Scoring) PlayerPoints(player *Player) float64 {
+	if player == nil || player.PlayerStats == nil {
+		return 0
+	}
+	return s.Points(player.PlayerStats.Stats)
+}
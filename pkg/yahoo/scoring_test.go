@@ -0,0 +1,52 @@
+package yahoo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestScoringPoints(t *testing.T) {
+	settings, err := newFixtureClient(t, "settings.json").GetLeagueSettings(context.Background(), "454.l.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scoring := NewScoring(settings)
+	if !scoring.IsPointsLeague() {
+		t.Fatal("IsPointsLeague() = false, want true")
+	}
+
+	stats := []Stat{
+		{StatID: StatIDPoints, Value: "30"},
+		{StatID: StatIDTurnovers, Value: "4"},
+		{StatID: StatIDBlocks, Value: "3"}, // no modifier
+		{StatID: StatIDFGPercent, Value: "-"},
+	}
+	if got := scoring.Points(stats); got != 24 {
+		t.Errorf("Points() = %v, want 24", got)
+	}
+
+	player := &Player{PlayerStats: &PlayerStats{Stats: stats}}
+	if got := scoring.PlayerPoints(player); got != 24 {
+		t.Errorf("PlayerPoints() = %v, want 24", got)
+	}
+	if got := scoring.PlayerPoints(&Player{}); got != 0 {
+		t.Errorf("PlayerPoints() without stats = %v, want 0", got)
+	}
+
+	if got, ok := scoring.ModifierByName("TO"); !ok || got != -1.5 {
+		t.Errorf("ModifierByName(TO) = %v, %v, want -1.5, true", got, ok)
+	}
+	if _, ok := scoring.ModifierByName("BLK"); ok {
+		t.Error("ModifierByName(BLK) found a modifier")
+	}
+}
+
+func TestScoringCategoryLeague(t *testing.T) {
+	scoring := NewScoring(&LeagueSettings{StatCategories: []StatCategory{{StatID: StatIDPoints, DisplayName: "PTS"}}})
+	if scoring.IsPointsLeague() {
+		t.Error("IsPointsLeague() = true, want false")
+	}
+	if got := scoring.Points([]Stat{{StatID: StatIDPoints, Value: "30"}}); got != 0 {
+		t.Errorf("Points() = %v, want 0", got)
+	}
+}
@@ -0,0 +1,274 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// LeagueSettings holds a league's draft, roster, scoring, playoff, waiver and
+// trade rules.
+type LeagueSettings struct {
+	DraftType        string `json:"draft_type"`
+	IsAuctionDraft   bool   `json:"is_auction_draft"`
+	ScoringType      string `json:"scoring_type"`
+	MaxTeams         int    `json:"max_teams"`
+	UsesPlayoff      bool   `json:"uses_playoff"`
+	PlayoffStartWeek int    `json:"playoff_start_week,omitempty"`
+	NumPlayoffTeams  int    `json:"num_playoff_teams,omitempty"`
+	WaiverType       string `json:"waiver_type"`
+	WaiverRule       string `json:"waiver_rule"`
+	WaiverTime       int    `json:"waiver_time,omitempty"`
+	UsesFAAB         bool   `json:"uses_faab"`
+	TradeEndDate     string `json:"trade_end_date,omitempty"`
+	TradeRatifyType  string `json:"trade_ratify_type,omitempty"`
+	TradeRejectTime  int    `json:"trade_reject_time,omitempty"`
+
+	HasPlayoffConsolationGames bool `json:"has_playoff_consolation_games,omitempty"`
+
+	// MaxAdds and MaxWeeklyAdds limit roster moves per season and per week;
+	// zero means no limit.
+	MaxAdds       int `json:"max_adds,omitempty"`
+	MaxWeeklyAdds int `json:"max_weekly_adds,omitempty"`
+
+	// Divisions is empty unless the league uses divisions.
+	Divisions []Division `json:"divisions,omitempty"`
+
+	RosterPositions []RosterPosition `json:"roster_positions,omitempty"`
+	StatCategories  []StatCategory   `json:"stat_categories,omitempty"`
+	// StatModifiers maps stat IDs to the points each unit is worth in
+	// points leagues.
+	StatModifiers map[int]float64 `json:"stat_modifiers,omitempty"`
+}
+
+// RosterPosition is a lineup slot and how many of it a team has.
+type RosterPosition struct {
+	Position           string `json:"position"`
+	PositionType       string `json:"position_type,omitempty"`
+	Count              int    `json:"count"`
+	IsStartingPosition bool   `json:"is_starting_position"`
+}
+
+// ScoredCategories returns the stat categories that count toward scoring,
+// leaving out display-only stats such as FGM/A.
+func (s *LeagueSettings) ScoredCategories() []StatCategory {
+	var scored []StatCategory
+	for _, cat := range s.StatCategories {
+		if cat.Enabled && !cat.IsOnlyDisplayStat {
+			scored = append(scored, cat)
+		}
+	}
+	return scored
+}
+
+// StartingSlots returns the total number of starting lineup slots.
+func (s *LeagueSettings) StartingSlots() int {
+	total := 0
+	for _, pos := range s.RosterPositions {
+		if pos.IsStartingPosition {
+			total += pos.Count
+		}
+	}
+	return total
+}
+
+type yahooSettingsResponse struct {
+	FantasyContent struct {
+		League yahooObject[struct {
+			Settings yahooObject[yahooSettingsData] `json:"settings"`
+		}] `json:"league"`
+	} `json:"fantasy_content"`
+}
+
+type yahooSettingsData struct {
+	DraftType        string `json:"draft_type"`
+	IsAuctionDraft   string `json:"is_auction_draft"`
+	ScoringType      string `json:"scoring_type"`
+	MaxTeams         string `json:"max_teams"`
+	UsesPlayoff      string `json:"uses_playoff"`
+	PlayoffStartWeek string `json:"playoff_start_week"`
+	NumPlayoffTeams  string `json:"num_playoff_teams"`
+	WaiverType       string `json:"waiver_type"`
+	WaiverRule       string `json:"waiver_rule"`
+	UsesFAAB         string `json:"uses_faab"`
+	TradeEndDate     string `json:"trade_end_date"`
+	TradeRatifyType  string `json:"trade_ratify_type"`
+
+	WaiverTime                 yahooNumber `json:"waiver_time"`
+	TradeRejectTime            yahooNumber `json:"trade_reject_time"`
+	HasPlayoffConsolationGames yahooNumber `json:"has_playoff_consolation_games"`
+	MaxAdds                    yahooNumber `json:"max_adds"`
+	MaxWeeklyAdds              yahooNumber `json:"max_weekly_adds"`
+
+	Divisions yahooList[struct {
+		Division struct {
+			DivisionID yahooNumber `json:"division_id"`
+			Name       string      `json:"name"`
+		} `json:"division"`
+	}] `json:"divisions"`
+
+	RosterPositions yahooList[struct {
+		RosterPosition struct {
+			Position           string      `json:"position"`
+			PositionType       string      `json:"position_type"`
+			Count              yahooNumber `json:"count"`
+			IsStartingPosition yahooNumber `json:"is_starting_position"`
+		} `json:"roster_position"`
+	}] `json:"roster_positions"`
+
+	StatCategories struct {
+		Stats yahooList[struct {
+			Stat yahooStatCategoryData `json:"stat"`
+		}] `json:"stats"`
+	} `json:"stat_categories"`
+
+	StatModifiers struct {
+		Stats yahooList[struct {
+			Stat struct {
+				StatID yahooNumber `json:"stat_id"`
+				Value  yahooNumber `json:"value"`
+			} `json:"stat"`
+		}] `json:"stats"`
+	} `json:"stat_modifiers"`
+}
+
+type yahooStatCategoryData struct {
+	StatID            yahooNumber `json:"stat_id"`
+	Enabled           yahooNumber `json:"enabled"`
+	Name              string      `json:"name"`
+	DisplayName       string      `json:"display_name"`
+	SortOrder         yahooNumber `json:"sort_order"`
+	PositionType      string      `json:"position_type"`
+	IsOnlyDisplayStat yahooNumber `json:"is_only_display_stat"`
+
+	// Game-level categories list every position type they apply to and,
+	// for composite stats such as FGM/A, the stats they are made of.
+	PositionTypes yahooList[struct {
+		PositionType string `json:"position_type"`
+	}] `json:"position_types"`
+	IsCompositeStat yahooNumber `json:"is_composite_stat"`
+	BaseStats       yahooList[struct {
+		BaseStat struct {
+			StatID yahooNumber `json:"stat_id"`
+		} `json:"base_stat"`
+	}] `json:"base_stats"`
+}
+
+func convertYahooStatCategory(ys yahooStatCategoryData) StatCategory {
+	cat := StatCategory{
+		StatID:            ys.StatID.Int(),
+		Name:              ys.Name,
+		DisplayName:       ys.DisplayName,
+		SortOrder:         ys.SortOrder.Int(),
+		PositionType:      ys.PositionType,
+		Enabled:           ys.Enabled == "" || ys.Enabled.Bool(),
+		IsOnlyDisplayStat: ys.IsOnlyDisplayStat.Bool(),
+		IsCompositeStat:   ys.IsCompositeStat.Bool(),
+	}
+	for _, item := range ys.PositionTypes {
+		cat.PositionTypes = append(cat.PositionTypes, item.PositionType)
+	}
+	if cat.PositionType == "" && len(cat.PositionTypes) > 0 {
+		cat.PositionType = cat.PositionTypes[0]
+	}
+	for _, item := range ys.BaseStats {
+		cat.BaseStatIDs = append(cat.BaseStatIDs, item.BaseStat.StatID.Int())
+	}
+	return cat
+}
+
+func convertYahooSettings(ys yahooSettingsData) LeagueSettings {
+	maxTeams, _ := strconv.Atoi(ys.MaxTeams)
+	playoffStartWeek, _ := strconv.Atoi(ys.PlayoffStartWeek)
+	numPlayoffTeams, _ := strconv.Atoi(ys.NumPlayoffTeams)
+
+	settings := LeagueSettings{
+		DraftType:        ys.DraftType,
+		IsAuctionDraft:   ys.IsAuctionDraft == "1",
+		ScoringType:      ys.ScoringType,
+		MaxTeams:         maxTeams,
+		UsesPlayoff:      ys.UsesPlayoff == "1",
+		PlayoffStartWeek: playoffStartWeek,
+		NumPlayoffTeams:  numPlayoffTeams,
+		WaiverType:       ys.WaiverType,
+		WaiverRule:       ys.WaiverRule,
+		WaiverTime:       ys.WaiverTime.Int(),
+		UsesFAAB:         ys.UsesFAAB == "1",
+		TradeEndDate:     ys.TradeEndDate,
+		TradeRatifyType:  ys.TradeRatifyType,
+		TradeRejectTime:  ys.TradeRejectTime.Int(),
+
+		HasPlayoffConsolationGames: ys.HasPlayoffConsolationGames.Bool(),
+
+		MaxAdds:       ys.MaxAdds.Int(),
+		MaxWeeklyAdds: ys.MaxWeeklyAdds.Int(),
+	}
+
+	for _, item := range ys.Divisions {
+		settings.Divisions = append(settings.Divisions, Division{
+			DivisionID: item.Division.DivisionID.Int(),
+			Name:       item.Division.Name,
+		})
+	}
+
+	for _, item := range ys.RosterPositions {
+		rp := item.RosterPosition
+		settings.RosterPositions = append(settings.RosterPositions, RosterPosition{
+			Position:           rp.Position,
+			PositionType:       rp.PositionType,
+			Count:              rp.Count.Int(),
+			IsStartingPosition: rp.IsStartingPosition.Bool(),
+		})
+	}
+
+	for _, item := range ys.StatCategories.Stats {
+		settings.StatCategories = append(settings.StatCategories, convertYahooStatCategory(item.Stat))
+	}
+
+	for _, item := range ys.StatModifiers.Stats {
+		if settings.StatModifiers == nil {
+			settings.StatModifiers = make(map[int]float64)
+		}
+		settings.StatModifiers[item.Stat.StatID.Int()] = item.Stat.Value.Float()
+	}
+
+	return settings
+}
+
+// GetLeagueSettings returns the league's rules, including its roster
+// positions, stat categories and, for points leagues, stat modifiers.
+func (c *Client) GetLeagueSettings(ctx context.Context, leagueKey string) (*LeagueSettings, error) {
+	cacheKey := fmt.Sprintf("league:%s:settings", leagueKey)
+
+	return cachedFetch(ctx, c, CacheSettings, cacheKey, func() (*LeagueSettings, error) {
+		return c.fetchSettings(ctx, leagueKey)
+	})
+}
+
+// GetLeagueStatCategories returns the stat categories the league displays,
+// in Yahoo's order. They come from the league settings and share their cache
+// entry.
+func (c *Client) GetLeagueStatCategories(ctx context.Context, leagueKey string) ([]StatCategory, error) {
+	settings, err := c.GetLeagueSettings(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	return settings.StatCategories, nil
+}
+
+func (c *Client) fetchSettings(ctx context.Context, leagueKey string) (*LeagueSettings, error) {
+	endpoint := fmt.Sprintf("league/%s/settings", leagueKey)
+	data, err := c.makeRequest(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp yahooSettingsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse settings response: %w", err)
+	}
+
+	settings := convertYahooSettings(resp.FantasyContent.League.Value.Settings.Value)
+	return &settings, nil
+}
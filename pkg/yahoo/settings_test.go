@@ -0,0 +1,56 @@
+package yahoo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetLeagueSettings(t *testing.T) {
+	settings, err := newFixtureClient(t, "settings.json").GetLeagueSettings(context.Background(), "454.l.1")
+	if err != nil {
+		t.Fatalf("GetLeagueSettings() error = %v", err)
+	}
+
+	if settings.MaxTeams != 12 || settings.PlayoffStartWeek != 21 || !settings.HasPlayoffConsolationGames {
+		t.Errorf("playoff settings = %+v", settings)
+	}
+	if settings.WaiverTime != 2 || settings.TradeEndDate != "2025-03-06" || settings.TradeRejectTime != 2 {
+		t.Errorf("waiver/trade settings = %+v", settings)
+	}
+	if settings.MaxAdds != 0 || settings.MaxWeeklyAdds != 4 {
+		t.Errorf("MaxAdds, MaxWeeklyAdds = %d, %d, want 0, 4", settings.MaxAdds, settings.MaxWeeklyAdds)
+	}
+
+	if len(settings.Divisions) != 2 || settings.Divisions[1] != (Division{DivisionID: 2, Name: "West"}) {
+		t.Errorf("Divisions = %+v", settings.Divisions)
+	}
+
+	if len(settings.RosterPositions) != 5 || settings.RosterPositions[2].Position != "Util" || settings.RosterPositions[2].Count != 3 {
+		t.Errorf("RosterPositions = %+v", settings.RosterPositions)
+	}
+	if got := settings.StartingSlots(); got != 5 {
+		t.Errorf("StartingSlots() = %d, want 5", got)
+	}
+
+	if len(settings.StatCategories) != 4 {
+		t.Fatalf("StatCategories = %+v", settings.StatCategories)
+	}
+	scored := settings.ScoredCategories()
+	if len(scored) != 3 || scored[0].DisplayName != "FG%" || scored[2].SortOrder != 0 {
+		t.Errorf("ScoredCategories() = %+v", scored)
+	}
+
+	if settings.StatModifiers[12] != 1 || settings.StatModifiers[19] != -1.5 {
+		t.Errorf("StatModifiers = %v", settings.StatModifiers)
+	}
+}
+
+func TestGetLeagueStatCategories(t *testing.T) {
+	categories, err := newFixtureClient(t, "settings.json").GetLeagueStatCategories(context.Background(), "454.l.1")
+	if err != nil {
+		t.Fatalf("GetLeagueStatCategories() error = %v", err)
+	}
+	if len(categories) != 4 || categories[2].StatID != StatID3PM || categories[2].DisplayName != "3PTM" {
+		t.Errorf("categories = %+v", categories)
+	}
+}
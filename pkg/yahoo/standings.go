@@ -11,12 +11,16 @@ type StandingsTeam struct {
 	TeamStandings  TeamStandings  `json:"team_standings"`
 	ManagerNickname string        `json:"manager_nickname,omitempty"`
 	Managers       []Manager      `json:"managers,omitempty"`
+	TeamMetadata
 }
 
 type TeamStandings struct {
 	Rank            int            `json:"rank"`
 	PlayoffSeed     int            `json:"playoff_seed,omitempty"`
 	OutcomeTotals   OutcomeTotals  `json:"outcome_totals"`
+	// DivisionalOutcomeTotals is the team's record against its own
+	// division. It is nil in leagues without divisions.
+	DivisionalOutcomeTotals *OutcomeTotals `json:"divisional_outcome_totals,omitempty"`
 	PointsFor       float64        `json:"points_for"`
 	PointsAgainst   float64        `json:"points_against"`
 	GamesBack       string         `json:"games_back,omitempty"`
@@ -49,19 +53,20 @@ type yahooStandingsResponse struct {
 	FantasyContent struct {
 		League struct {
 			Standings struct {
-				Teams []struct {
+				Teams yahooList[struct {
 					Team yahooStandingsTeamData `json:"team"`
-				} `json:"teams"`
+				}] `json:"teams"`
 			} `json:"standings"`
 		} `json:"league"`
 	} `json:"fantasy_content"`
 }
 
 type yahooStandingsTeamData struct {
+	yahooTeamMetadata
 	TeamKey  string `json:"team_key"`
 	TeamID   string `json:"team_id"`
 	Name     string `json:"name"`
-	Managers []struct {
+	Managers yahooList[struct {
 		Manager struct {
 			ManagerID      string `json:"manager_id"`
 			Nickname       string `json:"nickname"`
@@ -69,7 +74,7 @@ type yahooStandingsTeamData struct {
 			IsCommissioner string `json:"is_commissioner"`
 			IsCurrentLogin string `json:"is_current_login"`
 		} `json:"manager"`
-	} `json:"managers"`
+	}] `json:"managers"`
 	TeamStandings struct {
 		Rank          string `json:"rank"`
 		PlayoffSeed   string `json:"playoff_seed,omitempty"`
@@ -79,6 +84,11 @@ type yahooStandingsTeamData struct {
 			Ties       string `json:"ties"`
 			Percentage string `json:"percentage"`
 		} `json:"outcome_totals"`
+		DivisionalOutcomeTotals *struct {
+			Wins   yahooNumber `json:"wins"`
+			Losses yahooNumber `json:"losses"`
+			Ties   yahooNumber `json:"ties"`
+		} `json:"divisional_outcome_totals,omitempty"`
 		PointsFor     string `json:"points_for"`
 		PointsAgainst string `json:"points_against"`
 		GamesBack     string `json:"games_back,omitempty"`
@@ -88,3 +98,15 @@ type yahooStandingsTeamData struct {
 		} `json:"streak,omitempty"`
 	} `json:"team_standings"`
 }
+
+// TeamByManagerGUID returns the team managed by the given Yahoo user, or nil.
+func (s *Standings) TeamByManagerGUID(guid string) *StandingsTeam {
+	for i := range s.Teams {
+		for _, m := range s.Teams[i].Managers {
+			if m.GUID == guid {
+				return &s.Teams[i]
+			}
+		}
+	}
+	return nil
+}
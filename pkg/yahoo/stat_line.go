@@ -0,0 +1,112 @@
+package yahoo
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ToMap returns the stats keyed by their display names in the registry,
+// e.g. "PTS" or "3PTM". Stats the registry does not know fall back to the
+// display name set by Enrich and are left out if neither has one.
+// Non-numeric values, such as "-" or the compound "7/15", are left out too.
+func (sh *StatHelper) ToMap(registry *StatRegistry) map[string]float64 {
+	m := make(map[string]float64, len(sh.stats))
+	for _, stat := range sh.stats {
+		name := stat.Display
+		if registry != nil {
+			if display := registry.DisplayName(stat.StatID); display != "" {
+				name = display
+			}
+		}
+		if name == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(stat.Value, 64)
+		if err != nil {
+			continue
+		}
+		m[name] = value
+	}
+	return m
+}
+
+// StatLine is a set of stat values keyed by display name, as returned by
+// StatHelper.ToMap. Counting stats such as "PTS" add up; rate stats, whose
+// names contain "%" or "/" (e.g. "FG%", "A/T"), do not, so the arithmetic
+// helpers treat them separately.
+type StatLine map[string]float64
+
+// rateComponents lists the counting stats each rate stat is computed from.
+var rateComponents = map[string][2]string{
+	"FG%":  {"FGM", "FGA"},
+	"FT%":  {"FTM", "FTA"},
+	"3P%":  {"3PM", "3PA"},
+	"3PT%": {"3PTM", "3PTA"},
+	"A/T":  {"AST", "TO"},
+}
+
+func isRateStat(name string) bool {
+	return strings.ContainsAny(name, "%/")
+}
+
+// Add returns the sum of the two lines. Rate stats are recomputed from their
+// made and attempted stats when the result has both, and left out otherwise.
+func (l StatLine) Add(other StatLine) StatLine {
+	return l.combine(other, 1)
+}
+
+// Sub returns l minus other, handling rate stats like Add.
+func (l StatLine) Sub(other StatLine) StatLine {
+	return l.combine(other, -1)
+}
+
+func (l StatLine) combine(other StatLine, sign float64) StatLine {
+	result := make(StatLine, len(l))
+	for name, value := range l {
+		if !isRateStat(name) {
+			result[name] = value
+		}
+	}
+	for name, value := range other {
+		if !isRateStat(name) {
+			result[name] += sign * value
+		}
+	}
+	for name := range l {
+		result.recomputeRate(name)
+	}
+	for name := range other {
+		result.recomputeRate(name)
+	}
+	return result
+}
+
+func (l StatLine) recomputeRate(name string) {
+	parts, ok := rateComponents[name]
+	if !ok {
+		return
+	}
+	num, hasNum := l[parts[0]]
+	den, hasDen := l[parts[1]]
+	if !hasNum || !hasDen {
+		return
+	}
+	if den == 0 {
+		l[name] = 0
+		return
+	}
+	l[name] = num / den
+}
+
+// PerGame divides the counting stats by games, leaving rate stats as they
+// are. A games count of zero or less returns an unchanged copy.
+func (l StatLine) PerGame(games int) StatLine {
+	result := make(StatLine, len(l))
+	for name, value := range l {
+		if games > 0 && !isRateStat(name) {
+			value /= float64(games)
+		}
+		result[name] = value
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+package yahoo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStatHelperToMap(t *testing.T) {
+	registry := NewStatRegistry([]StatCategory{
+		{StatID: 12, DisplayName: "PTS"},
+		{StatID: 5, DisplayName: "FG%"},
+		{StatID: 9004003, DisplayName: "FGM/A"},
+	})
+	stats := []Stat{
+		{StatID: 12, Value: "31"},
+		{StatID: 5, Value: ".500"},
+		{StatID: 9004003, Value: "10/20"},
+		{StatID: 15, Value: "8"},
+		{StatID: 18, Value: "2", Display: "BLK"},
+	}
+
+	got := NewStatHelper(stats).ToMap(registry)
+	want := map[string]float64{"PTS": 31, "FG%": 0.5, "BLK": 2}
+	if len(got) != len(want) {
+		t.Fatalf("ToMap() = %v, want %v", got, want)
+	}
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("ToMap()[%q] = %v, want %v", name, got[name], value)
+		}
+	}
+}
+
+func TestStatLineArithmetic(t *testing.T) {
+	week1 := StatLine{"PTS": 100, "FGM": 40, "FGA": 80, "FG%": 0.5, "BLK": 5, "A/T": 2}
+	week2 := StatLine{"PTS": 80, "FGM": 20, "FGA": 60, "FG%": 0.333, "BLK": 3}
+
+	sum := week1.Add(week2)
+	if sum["PTS"] != 180 || sum["FGM"] != 60 || sum["FGA"] != 140 || sum["BLK"] != 8 {
+		t.Errorf("Add() = %v", sum)
+	}
+	if math.Abs(sum["FG%"]-60.0/140) > 1e-9 {
+		t.Errorf("Add() FG%% = %v, want %v", sum["FG%"], 60.0/140)
+	}
+	if _, ok := sum["A/T"]; ok {
+		t.Errorf("Add() kept A/T without AST and TO: %v", sum)
+	}
+
+	diff := sum.Sub(week2)
+	if diff["PTS"] != 100 || diff["FG%"] != 0.5 {
+		t.Errorf("Sub() = %v", diff)
+	}
+
+	perGame := sum.PerGame(4)
+	if perGame["PTS"] != 45 || perGame["BLK"] != 2 || perGame["FG%"] != sum["FG%"] {
+		t.Errorf("PerGame(4) = %v", perGame)
+	}
+	if zero := sum.PerGame(0); zero["PTS"] != 180 {
+		t.Errorf("PerGame(0) = %v", zero)
+	}
+
+	if week1["PTS"] != 100 || week1["FG%"] != 0.5 {
+		t.Errorf("arithmetic modified its receiver: %v", week1)
+	}
+}
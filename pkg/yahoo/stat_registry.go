@@ -0,0 +1,136 @@
+package yahoo
+
+import (
+	"context"
+	"strings"
+)
+
+// StatRegistry maps stat names to the stat IDs a league or game actually
+// uses. Stat IDs differ between sports, and the same abbreviation can mean
+// different stats in different games, so names are resolved against the
+// categories Yahoo returns rather than a hardcoded table.
+type StatRegistry struct {
+	categories []StatCategory
+	byID       map[int]StatCategory
+	byName     map[string][]int
+}
+
+// statNameAliases lists spellings that refer to the same stat, so "3PA"
+// finds a category Yahoo displays as "3PTA" and vice versa.
+var statNameAliases = [][]string{
+	{"3PA", "3PTA"},
+	{"3PM", "3PTM"},
+	{"3P%", "3PT%"},
+	{"STL", "ST"},
+	{"TO", "TOV"},
+	{"REB", "TREB"},
+	{"A/T", "AST/TO"},
+}
+
+// NewStatRegistry indexes categories by display name and full name. When
+// several categories share a name, Lookup returns the first.
+func NewStatRegistry(categories []StatCategory) *StatRegistry {
+	r := &StatRegistry{
+		categories: categories,
+		byID:       make(map[int]StatCategory, len(categories)),
+		byName:     make(map[string][]int),
+	}
+	for _, cat := range categories {
+		r.byID[cat.StatID] = cat
+		r.add(cat.DisplayName, cat.StatID)
+		if !strings.EqualFold(cat.Name, cat.DisplayName) {
+			r.add(cat.Name, cat.StatID)
+		}
+	}
+	return r
+}
+
+func (r *StatRegistry) add(name string, statID int) {
+	if name == "" {
+		return
+	}
+	key := strings.ToUpper(name)
+	r.byName[key] = append(r.byName[key], statID)
+}
+
+// GetLeagueStatRegistry returns a registry of the league's stat categories.
+func (c *Client) GetLeagueStatRegistry(ctx context.Context, leagueKey string) (*StatRegistry, error) {
+	categories, err := c.GetLeagueStatCategories(ctx, leagueKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewStatRegistry(categories), nil
+}
+
+// Lookup returns the stat ID for a display name (e.g. "3PA"), full name
+// (e.g. "3-point Shots Attempted") or common alternative spelling, ignoring
+// case.
+func (r *StatRegistry) Lookup(name string) (int, bool) {
+	ids := r.lookup(name)
+	if len(ids) == 0 {
+		return 0, false
+	}
+	return ids[0], true
+}
+
+// LookupPositionType is like Lookup but only matches categories for the
+// given position type, e.g. "DT" to find the defensive "Int" in NFL
+// leagues rather than the quarterback's.
+func (r *StatRegistry) LookupPositionType(name, positionType string) (int, bool) {
+	for _, id := range r.lookup(name) {
+		cat := r.byID[id]
+		if cat.PositionType == positionType {
+			return id, true
+		}
+		for _, pt := range cat.PositionTypes {
+			if pt == positionType {
+				return id, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (r *StatRegistry) lookup(name string) []int {
+	key := strings.ToUpper(strings.TrimSpace(name))
+	if ids, ok := r.byName[key]; ok {
+		return ids
+	}
+	for _, aliases := range statNameAliases {
+		if !containsFold(aliases, key) {
+			continue
+		}
+		for _, alias := range aliases {
+			if ids, ok := r.byName[alias]; ok {
+				return ids
+			}
+		}
+	}
+	return nil
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Category returns the category with the given stat ID.
+func (r *StatRegistry) Category(statID int) (StatCategory, bool) {
+	cat, ok := r.byID[statID]
+	return cat, ok
+}
+
+// DisplayName returns the stat's display name, or "" if the registry does
+// not know it.
+func (r *StatRegistry) DisplayName(statID int) string {
+	return r.byID[statID].DisplayName
+}
+
+// Categories returns the categories the registry was built from.
+func (r *StatRegistry) Categories() []StatCategory {
+	return r.categories
+}
@@ -0,0 +1,76 @@
+package yahoo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetLeagueStatRegistry(t *testing.T) {
+	registry, err := newFixtureClient(t, "settings.json").GetLeagueStatRegistry(context.Background(), "454.l.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		wantID int
+		wantOK bool
+	}{
+		{"FG%", 5, true},
+		{"fg%", 5, true},
+		{"3PTM", 10, true},
+		{"3PM", 10, true},
+		{"TO", 19, true},
+		{"BLK", 0, false},
+	}
+	for _, tt := range tests {
+		id, ok := registry.Lookup(tt.name)
+		if id != tt.wantID || ok != tt.wantOK {
+			t.Errorf("Lookup(%q) = %d, %v, want %d, %v", tt.name, id, ok, tt.wantID, tt.wantOK)
+		}
+	}
+
+	if got := registry.DisplayName(10); got != "3PTM" {
+		t.Errorf("DisplayName(10) = %q, want 3PTM", got)
+	}
+}
+
+func TestStatRegistryLookupPositionType(t *testing.T) {
+	registry := NewStatRegistry([]StatCategory{
+		{StatID: 6, Name: "Interceptions", DisplayName: "Int", PositionType: "O"},
+		{StatID: 33, Name: "Interception", DisplayName: "Int", PositionType: "DT"},
+	})
+
+	if id, _ := registry.Lookup("Int"); id != 6 {
+		t.Errorf("Lookup(Int) = %d, want 6", id)
+	}
+	if id, ok := registry.LookupPositionType("Int", "DT"); !ok || id != 33 {
+		t.Errorf("LookupPositionType(Int, DT) = %d, %v, want 33, true", id, ok)
+	}
+	if _, ok := registry.LookupPositionType("Int", "K"); ok {
+		t.Error("LookupPositionType(Int, K) found a stat")
+	}
+}
+
+func TestStatHelperWithRegistry(t *testing.T) {
+	// A league whose 3-pointers made category uses a non-standard ID.
+	registry := NewStatRegistry([]StatCategory{
+		{StatID: 1010, Name: "3-pointers Made", DisplayName: "3PTM"},
+	})
+	stats := []Stat{{StatID: 10, Value: "2"}, {StatID: 1010, Value: "5"}}
+
+	if got, _ := NewStatHelper(stats).GetByName("3PM"); got != "2" {
+		t.Errorf("without registry GetByName(3PM) = %q, want 2", got)
+	}
+
+	sh := NewStatHelper(stats).WithRegistry(registry)
+	if got, _ := sh.GetByName("3PM"); got != "5" {
+		t.Errorf("GetByName(3PM) = %q, want 5", got)
+	}
+	if got, _ := sh.GetByName("3-pointers made"); got != "5" {
+		t.Errorf("GetByName(3-pointers made) = %q, want 5", got)
+	}
+	if _, ok := sh.GetByName("STL"); ok {
+		t.Error("GetByName(STL) found a stat the league does not track")
+	}
+}
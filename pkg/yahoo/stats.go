@@ -1,5 +1,9 @@
 package yahoo
 
+// Stat is a single stat value. Display, Name and Order are only set once the
+// stats are enriched with the league's categories (see StatHelper.Enrich);
+// Order is the category's sort order, 1 if higher values are better and 0 if
+// lower values are.
 type Stat struct {
 	StatID  int     `json:"stat_id"`
 	Value   string  `json:"value"`
@@ -14,6 +18,15 @@ type StatCategory struct {
 	DisplayName string `json:"display_name"`
 	SortOrder   int    `json:"sort_order"`
 	PositionType string `json:"position_type"`
+	// Enabled is false for categories a league has switched off, and
+	// IsOnlyDisplayStat marks stats shown but not scored, such as FGM/A.
+	Enabled           bool `json:"enabled"`
+	IsOnlyDisplayStat bool `json:"is_only_display_stat,omitempty"`
+	// PositionTypes, IsCompositeStat and BaseStatIDs are only set for game
+	// stat categories (see GetGameStatCategories).
+	PositionTypes   []string `json:"position_types,omitempty"`
+	IsCompositeStat bool     `json:"is_composite_stat,omitempty"`
+	BaseStatIDs     []int    `json:"base_stat_ids,omitempty"`
 }
 
 type Player struct {
@@ -37,14 +50,20 @@ type Player struct {
 	ImageURL              string                 `json:"image_url,omitempty"`
 	Headshot              map[string]string      `json:"headshot,omitempty"`
 	ByeWeeks              map[string]int         `json:"bye_weeks,omitempty"`
+
+	// HasPlayerNotes is set when Yahoo has news or notes on the player;
+	// PlayerNotesLastTimestamp is when they were last updated, in Unix
+	// seconds.
+	HasPlayerNotes           bool  `json:"has_player_notes,omitempty"`
+	PlayerNotesLastTimestamp int64 `json:"player_notes_last_timestamp,omitempty"`
 }
 
 type PlayerName struct {
-	Full       string `json:"full"`
-	First      string `json:"first"`
-	Last       string `json:"last"`
-	ASCIIFirst string `json:"ascii_first"`
-	ASCIILast  string `json:"ascii_last"`
+	Full       string `json:"full" xml:"full"`
+	First      string `json:"first" xml:"first"`
+	Last       string `json:"last" xml:"last"`
+	ASCIIFirst string `json:"ascii_first" xml:"ascii_first"`
+	ASCIILast  string `json:"ascii_last" xml:"ascii_last"`
 }
 
 type SelectedPosition struct {
@@ -95,11 +114,11 @@ const (
 
 type yahooPlayerResponse struct {
 	FantasyContent struct {
-		League struct {
-			Players []struct {
-				Player yahooPlayerData `json:"player"`
-			} `json:"players"`
-		} `json:"league"`
+		League yahooObject[struct {
+			Players yahooList[struct {
+				Player yahooObject[yahooPlayerData] `json:"player"`
+			}] `json:"players"`
+		}] `json:"league"`
 	} `json:"fantasy_content"`
 }
 
@@ -127,9 +146,9 @@ type yahooPlayerData struct {
 	EditorialTeamFullName string `json:"editorial_team_full_name"`
 	EditorialTeamAbbr     string `json:"editorial_team_abbr"`
 	DisplayPosition       string `json:"display_position"`
-	EligiblePositions     []struct {
+	EligiblePositions     yahooList[struct {
 		Position string `json:"position"`
-	} `json:"eligible_positions"`
+	}] `json:"eligible_positions"`
 	SelectedPosition *struct {
 		Position string `json:"position"`
 	} `json:"selected_position,omitempty"`
@@ -137,10 +156,10 @@ type yahooPlayerData struct {
 		CoverageType string `json:"coverage_type"`
 		Week         string `json:"week,omitempty"`
 		Stats        struct {
-			Stat []struct {
+			Stat yahooList[struct {
 				StatID int    `json:"stat_id"`
 				Value  string `json:"value"`
-			} `json:"stat"`
+			}] `json:"stat"`
 		} `json:"stats"`
 	} `json:"player_stats,omitempty"`
 	PlayerPoints *struct {
@@ -148,4 +167,42 @@ type yahooPlayerData struct {
 		Week         string `json:"week,omitempty"`
 		Total        string `json:"total"`
 	} `json:"player_points,omitempty"`
+	Ownership     *Ownership                           `json:"ownership,omitempty"`
+	PercentOwned  *yahooObject[yahooPercentOwnedData] `json:"percent_owned,omitempty"`
+	Status        string                               `json:"status,omitempty"`
+	StatusFull    string                               `json:"status_full,omitempty"`
+	InjuryNote    string                               `json:"injury_note,omitempty"`
+	UniformNumber yahooNumber                          `json:"uniform_number,omitempty"`
+	ImageURL      string                               `json:"image_url,omitempty"`
+	Headshot      *struct {
+		URL  string `json:"url"`
+		Size string `json:"size"`
+	} `json:"headshot,omitempty"`
+	ByeWeeks *struct {
+		Week yahooNumber `json:"week"`
+	} `json:"bye_weeks,omitempty"`
+	HasPlayerNotes           yahooNumber `json:"has_player_notes"`
+	PlayerNotesLastTimestamp yahooNumber `json:"player_notes_last_timestamp"`
+}
+
+type yahooPercentOwnedData struct {
+	CoverageType string      `json:"coverage_type"`
+	Week         yahooNumber `json:"week"`
+	Value        yahooNumber `json:"value"`
+	Delta        yahooNumber `json:"delta"`
 }
+
+type yahooPlayerResourceResponse struct {
+	FantasyContent struct {
+		Player yahooObject[yahooPlayerData] `json:"player"`
+	} `json:"fantasy_content"`
+}
+
+// PlayerSubresource names a sub-resource that GetPlayer can include.
+type PlayerSubresource string
+
+const (
+	PlayerSubresourceStats        PlayerSubresource = "stats"
+	PlayerSubresourceOwnership    PlayerSubresource = "ownership"
+	PlayerSubresourcePercentOwned PlayerSubresource = "percent_owned"
+)
@@ -3,10 +3,12 @@ package yahoo
 import (
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 type StatHelper struct {
-	stats []Stat
+	stats    []Stat
+	registry *StatRegistry
 }
 
 func NewStatHelper(stats []Stat) *StatHelper {
@@ -42,6 +44,61 @@ func (sh *StatHelper) GetAll() []Stat {
 	return sh.stats
 }
 
+// Enrich fills in the Name, Display and Order of each stat from the league's
+// stat categories (see GetLeagueStatCategories). The stats the helper was
+// created with are updated in place.
+func (sh *StatHelper) Enrich(categories []StatCategory) *StatHelper {
+	byID := make(map[int]StatCategory, len(categories))
+	for _, cat := range categories {
+		byID[cat.StatID] = cat
+	}
+	for i := range sh.stats {
+		if cat, ok := byID[sh.stats[i].StatID]; ok {
+			sh.stats[i].Name = cat.Name
+			sh.stats[i].Display = cat.DisplayName
+			sh.stats[i].Order = cat.SortOrder
+		}
+	}
+	return sh
+}
+
+// WithRegistry makes GetByName resolve names through the registry, so they
+// map to the league's own stat IDs.
+func (sh *StatHelper) WithRegistry(registry *StatRegistry) *StatHelper {
+	sh.registry = registry
+	return sh
+}
+
+// GetByName looks a stat up by its display name (e.g. "FG%") or full name,
+// ignoring case. Names are known once the helper has been enriched or given
+// a registry; without either, common NBA abbreviations such as "3PM" or
+// "STL" fall back to the standard NBA stat IDs.
+func (sh *StatHelper) GetByName(name string) (string, bool) {
+	for _, stat := range sh.stats {
+		if strings.EqualFold(stat.Display, name) || strings.EqualFold(stat.Name, name) {
+			return stat.Value, true
+		}
+	}
+	if sh.registry != nil {
+		if statID, ok := sh.registry.Lookup(name); ok {
+			return sh.GetByID(statID)
+		}
+		return "", false
+	}
+	if statID, ok := nbaStatAbbreviations[strings.ToUpper(name)]; ok {
+		return sh.GetByID(statID)
+	}
+	return "", false
+}
+
+func (sh *StatHelper) GetFloatByName(name string) (float64, error) {
+	value, ok := sh.GetByName(name)
+	if !ok {
+		return 0, fmt.Errorf("stat %q not found", name)
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
 // GetFGMFGA attempts to get field goal made/attempted, with fallback to compound stat parsing
 func (sh *StatHelper) GetFGMFGA() (fgm, fga int, err error) {
 	fgm, err = sh.GetIntByID(StatIDFGM)
@@ -125,7 +182,9 @@ func (sh *StatHelper) Get3PM3PA() (tpm, tpa int, err error) {
 }
 
 // parseCompoundStat attempts to parse a compound stat value like "7/15" into made/attempted
-// This is a fallback for when the stat ID returns a compound value instead of individual stats
+// This is a fallback for when the stat ID returns a compound value instead of individual stats.
+// Stats decoded by the client are already split (see normalizeStats), so it only matters for
+// stats built by hand.
 func (sh *StatHelper) parseCompoundStat(statID int) (made int, attempted int, err error) {
 	value, ok := sh.GetByID(statID)
 	if !ok {
@@ -259,6 +318,37 @@ const (
 	StatID3PM3PACompound = 9010009  // 3PM/3PA as compound "made/attempted"
 )
 
+// nbaStatAbbreviations maps common NBA category abbreviations, including
+// Yahoo's own display names, to stat IDs.
+var nbaStatAbbreviations = map[string]int{
+	"GP":   StatIDGamesPlayed,
+	"GS":   StatIDGamesStarted,
+	"MIN":  StatIDMinutesPlayed,
+	"FGA":  StatIDFGA,
+	"FGM":  StatIDFGM,
+	"FG%":  StatIDFGPercent,
+	"FTA":  StatIDFTA,
+	"FTM":  StatIDFTM,
+	"FT%":  StatIDFTPercent,
+	"3PA":  StatID3PA,
+	"3PTA": StatID3PA,
+	"3PM":  StatID3PM,
+	"3PTM": StatID3PM,
+	"3P%":  StatID3PPercent,
+	"3PT%": StatID3PPercent,
+	"PTS":  StatIDPoints,
+	"OREB": StatIDOffensiveRebounds,
+	"DREB": StatIDDefensiveRebounds,
+	"REB":  StatIDRebounds,
+	"AST":  StatIDAssists,
+	"ST":   StatIDSteals,
+	"STL":  StatIDSteals,
+	"BLK":  StatIDBlocks,
+	"TO":   StatIDTurnovers,
+	"A/T":  StatIDAssistTurnoverRatio,
+	"PF":   StatIDPersonalFouls,
+}
+
 type NBAStats struct {
 	GamesPlayed       int
 	FGM               int
@@ -273,10 +363,13 @@ type NBAStats struct {
 	Points            int
 	Rebounds          int
 	OffensiveRebounds int
+	DefensiveRebounds int
 	Assists           int
 	Steals            int
 	Blocks            int
 	Turnovers         int
+	PersonalFouls     int
+	Minutes           float64
 }
 
 func ParseNBAStats(stats []Stat) (*NBAStats, error) {
@@ -349,6 +442,15 @@ func ParseNBAStats(stats []Stat) (*NBAStats, error) {
 	if val, err := sh.GetIntByID(StatIDTurnovers); err == nil {
 		nbaStats.Turnovers = val
 	}
+	if val, err := sh.GetIntByID(StatIDDefensiveRebounds); err == nil {
+		nbaStats.DefensiveRebounds = val
+	}
+	if val, err := sh.GetIntByID(StatIDPersonalFouls); err == nil {
+		nbaStats.PersonalFouls = val
+	}
+	if val, ok := sh.GetByID(StatIDMinutesPlayed); ok {
+		nbaStats.Minutes = parseMinutes(val)
+	}
 
 	if nbaStats.FGPercent == 0 && nbaStats.FGA > 0 {
 		nbaStats.FGPercent = nbaStats.CalculateFGPercent()
@@ -425,3 +425,48 @@ func TestStatHelperGetIntByID(t *testing.T) {
 		})
 	}
 }
+
+func TestStatHelperEnrichAndGetByName(t *testing.T) {
+	stats := []Stat{
+		{StatID: StatIDFGPercent, Value: ".481"},
+		{StatID: StatID3PM, Value: "42"},
+		{StatID: StatIDTurnovers, Value: "17"},
+	}
+	categories := []StatCategory{
+		{StatID: StatIDFGPercent, Name: "Field Goal Percentage", DisplayName: "FG%", SortOrder: 1},
+		{StatID: StatID3PM, Name: "3-point Shots Made", DisplayName: "3PTM", SortOrder: 1},
+		{StatID: StatIDTurnovers, Name: "Turnovers", DisplayName: "TO", SortOrder: 0},
+	}
+
+	helper := NewStatHelper(stats).Enrich(categories)
+	if stats[1].Display != "3PTM" || stats[1].Name != "3-point Shots Made" || stats[1].Order != 1 {
+		t.Errorf("enriched stat = %+v", stats[1])
+	}
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"FG%", ".481"},
+		{"3ptm", "42"},
+		{"3PM", "42"},
+		{"Turnovers", "17"},
+	}
+	for _, tt := range tests {
+		got, ok := helper.GetByName(tt.name)
+		if !ok || got != tt.want {
+			t.Errorf("GetByName(%q) = %q, %v, want %q", tt.name, got, ok, tt.want)
+		}
+	}
+	if _, ok := helper.GetByName("BLK"); ok {
+		t.Error("GetByName(BLK) found a stat that is not present")
+	}
+}
+
+func TestStatHelperGetByNameWithoutEnrich(t *testing.T) {
+	helper := NewStatHelper([]Stat{{StatID: StatIDSteals, Value: "9"}})
+	got, err := helper.GetFloatByName("STL")
+	if err != nil || got != 9 {
+		t.Errorf("GetFloatByName(STL) = %v, %v, want 9", got, err)
+	}
+}
@@ -0,0 +1,65 @@
+package yahoo
+
+// TeamMetadata holds a team's waiver position, FAAB balance, move counts,
+// division and logo, as returned with its teams and standings.
+type TeamMetadata struct {
+	FAABBalance      int    `json:"faab_balance,omitempty"`
+	WaiverPriority   int    `json:"waiver_priority,omitempty"`
+	NumberOfMoves    int    `json:"number_of_moves"`
+	NumberOfTrades   int    `json:"number_of_trades"`
+	DivisionID       int    `json:"division_id,omitempty"`
+	ClinchedPlayoffs bool   `json:"clinched_playoffs,omitempty"`
+	LogoURL          string `json:"logo_url,omitempty"`
+}
+
+type yahooTeamMetadata struct {
+	FAABBalance      yahooNumber `json:"faab_balance"`
+	WaiverPriority   yahooNumber `json:"waiver_priority"`
+	NumberOfMoves    yahooNumber `json:"number_of_moves"`
+	NumberOfTrades   yahooNumber `json:"number_of_trades"`
+	DivisionID       yahooNumber `json:"division_id"`
+	ClinchedPlayoffs yahooNumber `json:"clinched_playoffs"`
+	TeamLogos        yahooList[struct {
+		TeamLogo struct {
+			Size string `json:"size"`
+			URL  string `json:"url"`
+		} `json:"team_logo"`
+	}] `json:"team_logos"`
+}
+
+func (m yahooTeamMetadata) toMetadata() TeamMetadata {
+	meta := TeamMetadata{
+		FAABBalance:      m.FAABBalance.Int(),
+		WaiverPriority:   m.WaiverPriority.Int(),
+		NumberOfMoves:    m.NumberOfMoves.Int(),
+		NumberOfTrades:   m.NumberOfTrades.Int(),
+		DivisionID:       m.DivisionID.Int(),
+		ClinchedPlayoffs: m.ClinchedPlayoffs.Bool(),
+	}
+	if len(m.TeamLogos) > 0 {
+		meta.LogoURL = m.TeamLogos[0].TeamLogo.URL
+	}
+	return meta
+}
+
+type yahooTeamMetadataXML struct {
+	FAABBalance      int    `xml:"faab_balance"`
+	WaiverPriority   int    `xml:"waiver_priority"`
+	NumberOfMoves    int    `xml:"number_of_moves"`
+	NumberOfTrades   int    `xml:"number_of_trades"`
+	DivisionID       int    `xml:"division_id"`
+	ClinchedPlayoffs string `xml:"clinched_playoffs"`
+	TeamLogoURL      string `xml:"team_logos>team_logo>url"`
+}
+
+func (m yahooTeamMetadataXML) toMetadata() TeamMetadata {
+	return TeamMetadata{
+		FAABBalance:      m.FAABBalance,
+		WaiverPriority:   m.WaiverPriority,
+		NumberOfMoves:    m.NumberOfMoves,
+		NumberOfTrades:   m.NumberOfTrades,
+		DivisionID:       m.DivisionID,
+		ClinchedPlayoffs: m.ClinchedPlayoffs == "1",
+		LogoURL:          m.TeamLogoURL,
+	}
+}
@@ -0,0 +1,69 @@
+package yahoo
+
+import (
+	"context"
+	"testing"
+)
+
+func TestTeamMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("teams", func(t *testing.T) {
+		teams, err := newFixtureClient(t, "teams.json").GetLeagueTeams(ctx, "454.l.1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := TeamMetadata{
+			FAABBalance:    87,
+			WaiverPriority: 3,
+			NumberOfMoves:  12,
+			NumberOfTrades: 1,
+			DivisionID:     2,
+			LogoURL:        "https://s.yimg.com/logo1.png",
+		}
+		if teams[0].TeamMetadata != want {
+			t.Errorf("TeamMetadata = %+v, want %+v", teams[0].TeamMetadata, want)
+		}
+		if teams[1].TeamMetadata != (TeamMetadata{}) {
+			t.Errorf("expected empty metadata, got %+v", teams[1].TeamMetadata)
+		}
+	})
+
+	t.Run("standings", func(t *testing.T) {
+		standings, err := newFixtureClient(t, "standings.json").GetLeagueStandings(ctx, "454.l.1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		meta := standings.Teams[0].TeamMetadata
+		if meta.FAABBalance != 42 || meta.WaiverPriority != 10 || meta.NumberOfMoves != 20 || meta.NumberOfTrades != 2 || !meta.ClinchedPlayoffs {
+			t.Errorf("TeamMetadata = %+v", meta)
+		}
+	})
+
+	t.Run("xml", func(t *testing.T) {
+		data := []byte(xmlHeader + `
+<league><standings><teams count="1"><team>
+  <team_key>454.l.1.t.3</team_key><team_id>3</team_id><name>Splash</name>
+  <team_logos><team_logo><size>large</size><url>https://s.yimg.com/logo3.png</url></team_logo></team_logos>
+  <division_id>1</division_id><waiver_priority>4</waiver_priority><faab_balance>55</faab_balance>
+  <number_of_moves>9</number_of_moves><number_of_trades>0</number_of_trades><clinched_playoffs>1</clinched_playoffs>
+  <team_standings><rank>1</rank></team_standings>
+</team></teams></standings></league></fantasy_content>`)
+
+		standings, err := decodeStandingsXML(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := TeamMetadata{
+			FAABBalance:      55,
+			WaiverPriority:   4,
+			NumberOfMoves:    9,
+			DivisionID:       1,
+			ClinchedPlayoffs: true,
+			LogoURL:          "https://s.yimg.com/logo3.png",
+		}
+		if got := standings.Teams[0].TeamMetadata; got != want {
+			t.Errorf("TeamMetadata = %+v, want %+v", got, want)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package yahoo
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const encryptedTokenPrefix = "enc:v1:"
+
+// EncryptedTokenStore wraps a TokenStore and encrypts access and refresh
+// tokens with AES-GCM before they are persisted. Plaintext values written
+// before encryption was enabled are still readable and are encrypted on the
+// next save.
+type EncryptedTokenStore struct {
+	store TokenStore
+	aead  cipher.AEAD
+}
+
+// NewEncryptedTokenStore wraps store using key, which must be 16, 24 or 32
+// bytes long (AES-128, AES-192 or AES-256).
+func NewEncryptedTokenStore(store TokenStore, key []byte) (*EncryptedTokenStore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid token encryption key: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialise AES-GCM: %w", err)
+	}
+	return &EncryptedTokenStore{store: store, aead: aead}, nil
+}
+
+// NewEncryptedTokenStoreFromEnv reads a base64-encoded key from
+// YAHOO_TOKEN_ENCRYPTION_KEY.
+func NewEncryptedTokenStoreFromEnv(store TokenStore) (*EncryptedTokenStore, error) {
+	encoded := os.Getenv("YAHOO_TOKEN_ENCRYPTION_KEY")
+	if encoded == "" {
+		return nil, fmt.Errorf("YAHOO_TOKEN_ENCRYPTION_KEY is not set")
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode YAHOO_TOKEN_ENCRYPTION_KEY: %w", err)
+	}
+	return NewEncryptedTokenStore(store, key)
+}
+
+func (s *EncryptedTokenStore) Load(ctx context.Context, userGUID string) (*Token, error) {
+	token, err := s.store.Load(ctx, userGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted := *token
+	if decrypted.AccessToken, err = s.decrypt(token.AccessToken, userGUID); err != nil {
+		return nil, fmt.Errorf("failed to decrypt access token: %w", err)
+	}
+	if decrypted.RefreshToken, err = s.decrypt(token.RefreshToken, userGUID); err != nil {
+		return nil, fmt.Errorf("failed to decrypt refresh token: %w", err)
+	}
+	return &decrypted, nil
+}
+
+func (s *EncryptedTokenStore) Save(ctx context.Context, userGUID string, token *Token) error {
+	encrypted := *token
+
+	var err error
+	if encrypted.AccessToken, err = s.encrypt(token.AccessToken, userGUID); err != nil {
+		return fmt.Errorf("failed to encrypt access token: %w", err)
+	}
+	if encrypted.RefreshToken, err = s.encrypt(token.RefreshToken, userGUID); err != nil {
+		return fmt.Errorf("failed to encrypt refresh token: %w", err)
+	}
+	return s.store.Save(ctx, userGUID, &encrypted)
+}
+
+func (s *EncryptedTokenStore) Delete(ctx context.Context, userGUID string) error {
+	return s.store.Delete(ctx, userGUID)
+}
+
+// encrypt binds the ciphertext to the user GUID so a row copied to another
+// user fails to decrypt.
+func (s *EncryptedTokenStore) encrypt(plaintext, userGUID string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := s.aead.Seal(nonce, nonce, []byte(plaintext), []byte(userGUID))
+	return encryptedTokenPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (s *EncryptedTokenStore) decrypt(value, userGUID string) (string, error) {
+	if !strings.HasPrefix(value, encryptedTokenPrefix) {
+		return value, nil
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedTokenPrefix))
+	if err != nil {
+		return "", err
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+
+	plaintext, err := s.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(userGUID))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,71 @@
+package yahoo
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEncryptedTokenStoreRoundTrip(t *testing.T) {
+	backing := newMemoryTokenStore()
+	store, err := NewEncryptedTokenStore(backing, bytes.Repeat([]byte{7}, 32))
+	if err != nil {
+		t.Fatalf("NewEncryptedTokenStore() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := store.Save(ctx, "guid", &Token{AccessToken: "access", RefreshToken: "refresh"}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	raw, _ := backing.Load(ctx, "guid")
+	if !strings.HasPrefix(raw.AccessToken, encryptedTokenPrefix) || strings.Contains(raw.AccessToken, "access") {
+		t.Errorf("access token stored in plaintext: %q", raw.AccessToken)
+	}
+	if !strings.HasPrefix(raw.RefreshToken, encryptedTokenPrefix) {
+		t.Errorf("refresh token stored in plaintext: %q", raw.RefreshToken)
+	}
+
+	token, err := store.Load(ctx, "guid")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if token.AccessToken != "access" || token.RefreshToken != "refresh" {
+		t.Errorf("Load() = %+v, want decrypted tokens", token)
+	}
+}
+
+func TestEncryptedTokenStoreReadsLegacyPlaintext(t *testing.T) {
+	backing := newMemoryTokenStore()
+	backing.Save(context.Background(), "guid", &Token{AccessToken: "plain", RefreshToken: "plain-refresh"})
+
+	store, _ := NewEncryptedTokenStore(backing, bytes.Repeat([]byte{1}, 16))
+	token, err := store.Load(context.Background(), "guid")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if token.AccessToken != "plain" {
+		t.Errorf("AccessToken = %v, want %v", token.AccessToken, "plain")
+	}
+}
+
+func TestEncryptedTokenStoreRejectsSwappedRows(t *testing.T) {
+	backing := newMemoryTokenStore()
+	store, _ := NewEncryptedTokenStore(backing, bytes.Repeat([]byte{3}, 32))
+
+	ctx := context.Background()
+	store.Save(ctx, "guid-a", &Token{AccessToken: "a", RefreshToken: "a"})
+	raw, _ := backing.Load(ctx, "guid-a")
+	backing.Save(ctx, "guid-b", raw)
+
+	if _, err := store.Load(ctx, "guid-b"); err == nil {
+		t.Error("expected decryption to fail for a row copied to another user")
+	}
+}
+
+func TestNewEncryptedTokenStoreInvalidKey(t *testing.T) {
+	if _, err := NewEncryptedTokenStore(newMemoryTokenStore(), []byte("short")); err == nil {
+		t.Error("expected error for invalid key length")
+	}
+}
@@ -0,0 +1,94 @@
+package yahoo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/n-ae/yahoo-fantasy-sports-api-go/pkg/dialect"
+)
+
+var ErrTokenNotFound = errors.New("yahoo token not found")
+
+// TokenStore persists OAuth token sets keyed by Yahoo user GUID.
+type TokenStore interface {
+	Load(ctx context.Context, userGUID string) (*Token, error)
+	Save(ctx context.Context, userGUID string, token *Token) error
+	Delete(ctx context.Context, userGUID string) error
+}
+
+// SQLTokenStore stores tokens in the yahoo_tokens table:
+//
+//	CREATE TABLE yahoo_tokens (
+//		user_guid     TEXT PRIMARY KEY,
+//		access_token  TEXT NOT NULL,
+//		refresh_token TEXT NOT NULL,
+//		token_type    TEXT,
+//		expires_at    DATETIME,
+//		updated_at    DATETIME NOT NULL
+//	);
+type SQLTokenStore struct {
+	db *sql.DB
+}
+
+func NewSQLTokenStore(db *sql.DB) *SQLTokenStore {
+	return &SQLTokenStore{db: db}
+}
+
+func (s *SQLTokenStore) Load(ctx context.Context, userGUID string) (*Token, error) {
+	query := `
+		SELECT access_token, refresh_token, token_type, expires_at
+		FROM yahoo_tokens
+		WHERE user_guid = ?
+	`
+
+	token := &Token{}
+	var tokenType sql.NullString
+	var expiresAt sql.NullTime
+	err := s.db.QueryRowContext(ctx, query, userGUID).Scan(
+		&token.AccessToken, &token.RefreshToken, &tokenType, &expiresAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load token: %w", err)
+	}
+
+	token.TokenType = tokenType.String
+	if expiresAt.Valid {
+		token.Expiry = expiresAt.Time
+	}
+
+	return token, nil
+}
+
+func (s *SQLTokenStore) Save(ctx context.Context, userGUID string, token *Token) error {
+	query := dialect.For(s.db).Upsert("yahoo_tokens",
+		[]string{"user_guid", "access_token", "refresh_token", "token_type", "expires_at", "updated_at"},
+		[]string{"user_guid"},
+		[]string{"access_token", "refresh_token", "token_type", "expires_at", "updated_at"},
+	)
+
+	var expiresAt *time.Time
+	if !token.Expiry.IsZero() {
+		expiresAt = &token.Expiry
+	}
+
+	_, err := s.db.ExecContext(ctx, query,
+		userGUID, token.AccessToken, token.RefreshToken, token.TokenType,
+		expiresAt, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save token: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLTokenStore) Delete(ctx context.Context, userGUID string) error {
+	query := `DELETE FROM yahoo_tokens WHERE user_guid = ?`
+	_, err := s.db.ExecContext(ctx, query, userGUID)
+	return err
+}
@@ -0,0 +1,55 @@
+package yahoo
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// TracerName is the instrumentation scope used for spans emitted by the SDK.
+const TracerName = "github.com/n-ae/yahoo-fantasy-sports-api-go"
+
+// WithTracerProvider records OpenTelemetry spans for API requests and cache
+// lookups using tp. Tracing is disabled by default.
+func WithTracerProvider(tp trace.TracerProvider) ClientOption {
+	return func(c *Client) {
+		if tp == nil {
+			tp = noop.NewTracerProvider()
+		}
+		c.tracer = tp.Tracer(TracerName)
+	}
+}
+
+func defaultTracer() trace.Tracer {
+	return noop.NewTracerProvider().Tracer(TracerName)
+}
+
+// endSpan records err on span, if any, and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// endpointAttributes describes a request path such as
+// "league/nba.l.123/standings" for span attributes.
+func endpointAttributes(endpoint string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.String("yahoo.endpoint", endpoint)}
+
+	for _, prefix := range []string{"league/", "leagues;league_keys="} {
+		if i := strings.Index(endpoint, prefix); i >= 0 {
+			key := endpoint[i+len(prefix):]
+			if end := strings.IndexAny(key, "/;,?"); end >= 0 {
+				key = key[:end]
+			}
+			attrs = append(attrs, attribute.String("yahoo.league_key", key))
+			break
+		}
+	}
+	return attrs
+}
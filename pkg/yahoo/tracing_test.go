@@ -0,0 +1,70 @@
+package yahoo
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestMakeRequestRecordsSpan(t *testing.T) {
+	server := newTokenTestServer(t, "valid")
+	defer server.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	client := NewClient("key", "secret", nil,
+		WithTracerProvider(provider),
+		WithInitialToken(Token{AccessToken: "valid"}),
+	)
+	client.baseURL = server.URL
+
+	if _, err := client.makeRequest(context.Background(), "league/nba.l.123/standings"); err != nil {
+		t.Fatalf("makeRequest() error = %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name() != "yahoo.request" {
+		t.Errorf("span name = %v, want %v", spans[0].Name(), "yahoo.request")
+	}
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, kv := range spans[0].Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	if got := attrs["yahoo.league_key"].AsString(); got != "nba.l.123" {
+		t.Errorf("yahoo.league_key = %q, want %q", got, "nba.l.123")
+	}
+	if got := attrs["http.response.status_code"].AsInt64(); got != 200 {
+		t.Errorf("http.response.status_code = %d, want 200", got)
+	}
+}
+
+func TestEndpointAttributes(t *testing.T) {
+	tests := []struct {
+		endpoint  string
+		leagueKey string
+	}{
+		{"league/nba.l.1/teams", "nba.l.1"},
+		{"league/nba.l.2;out=settings", "nba.l.2"},
+		{"leagues;league_keys=nba.l.3,nba.l.4", "nba.l.3"},
+		{"users;use_login=1/games;game_keys=nba/leagues", ""},
+	}
+	for _, tt := range tests {
+		var got string
+		for _, kv := range endpointAttributes(tt.endpoint) {
+			if kv.Key == "yahoo.league_key" {
+				got = kv.Value.AsString()
+			}
+		}
+		if got != tt.leagueKey {
+			t.Errorf("endpointAttributes(%q) league key = %q, want %q", tt.endpoint, got, tt.leagueKey)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package yahoo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// TradeProposal is a trade offer from the trader team to the tradee team.
+type TradeProposal struct {
+	TraderTeamKey string
+	TradeeTeamKey string
+	// SendPlayerKeys are the trader's players going to the tradee, and
+	// ReceivePlayerKeys the tradee's players going to the trader.
+	SendPlayerKeys    []string
+	ReceivePlayerKeys []string
+	Note              string
+}
+
+func (p TradeProposal) validate() error {
+	switch {
+	case p.TraderTeamKey == "" || p.TradeeTeamKey == "":
+		return fmt.Errorf("trade proposal requires both team keys")
+	case p.TraderTeamKey == p.TradeeTeamKey:
+		return fmt.Errorf("trade proposal cannot trade %s with itself", p.TraderTeamKey)
+	case len(p.SendPlayerKeys) == 0 || len(p.ReceivePlayerKeys) == 0:
+		return fmt.Errorf("trade proposal requires players on both sides")
+	}
+	return nil
+}
+
+func tradePayloadPlayer(playerKey, from, to string) transactionPayloadPlayer {
+	p := transactionPayloadPlayer{PlayerKey: playerKey}
+	p.TransactionData.Type = TransactionPendingTrade
+	p.TransactionData.SourceTeamKey = from
+	p.TransactionData.DestinationTeamKey = to
+	return p
+}
+
+// ProposeTrade offers a trade and returns the pending trade Yahoo created.
+// Its TransactionKey identifies the offer to AcceptTrade, RejectTrade and
+// CancelTrade.
+func (c *Client) ProposeTrade(ctx context.Context, leagueKey string, proposal TradeProposal) (*Transaction, error) {
+	if err := proposal.validate(); err != nil {
+		return nil, err
+	}
+
+	var payload transactionPayload
+	payload.Transaction.Type = TransactionPendingTrade
+	payload.Transaction.TraderTeamKey = proposal.TraderTeamKey
+	payload.Transaction.TradeeTeamKey = proposal.TradeeTeamKey
+	payload.Transaction.TradeNote = proposal.Note
+
+	players := &transactionPayloadPlayers{}
+	for _, key := range proposal.SendPlayerKeys {
+		players.Player = append(players.Player, tradePayloadPlayer(key, proposal.TraderTeamKey, proposal.TradeeTeamKey))
+	}
+	for _, key := range proposal.ReceivePlayerKeys {
+		players.Player = append(players.Player, tradePayloadPlayer(key, proposal.TradeeTeamKey, proposal.TraderTeamKey))
+	}
+	payload.Transaction.Players = players
+
+	return c.submitTransaction(ctx, http.MethodPost, fmt.Sprintf("league/%s/transactions", leagueKey), &payload,
+		fmt.Sprintf("league:%s:transactions", leagueKey))
+}
+
+// AcceptTrade accepts a trade offered to one of the user's teams.
+func (c *Client) AcceptTrade(ctx context.Context, transactionKey, note string) (*Transaction, error) {
+	return c.respondToTrade(ctx, transactionKey, "accept", note)
+}
+
+// RejectTrade rejects a trade offered to one of the user's teams.
+func (c *Client) RejectTrade(ctx context.Context, transactionKey, note string) (*Transaction, error) {
+	return c.respondToTrade(ctx, transactionKey, "reject", note)
+}
+
+// CancelTrade withdraws a trade one of the user's teams proposed.
+func (c *Client) CancelTrade(ctx context.Context, transactionKey string) error {
+	_, err := c.submitTransaction(ctx, http.MethodDelete, "transaction/"+transactionKey, nil,
+		transactionInvalidations(transactionKey)...)
+	return err
+}
+
+func (c *Client) respondToTrade(ctx context.Context, transactionKey, action, note string) (*Transaction, error) {
+	payload := tradeActionPayload(transactionKey, action)
+	payload.Transaction.TradeNote = note
+
+	return c.submitTransaction(ctx, http.MethodPut, "transaction/"+transactionKey, &payload,
+		transactionInvalidations(transactionKey)...)
+}
+
+func tradeActionPayload(transactionKey, action string) transactionPayload {
+	var payload transactionPayload
+	payload.Transaction.TransactionKey = transactionKey
+	payload.Transaction.Type = TransactionPendingTrade
+	payload.Transaction.Action = action
+	return payload
+}
+
+// transactionInvalidations returns the cache prefixes a change to an
+// existing transaction makes stale: the league's transactions and the
+// rosters of all its teams.
+func transactionInvalidations(transactionKey string) []string {
+	leagueKey := leagueKeyOfTransaction(transactionKey)
+	if leagueKey == "" {
+		return nil
+	}
+	return []string{
+		fmt.Sprintf("league:%s:transactions", leagueKey),
+		fmt.Sprintf("team:%s.t.", leagueKey),
+	}
+}
+
+// leagueKeyOfTransaction returns the league part of a transaction key such
+// as "454.l.1.pt.3", or "" if the key has none.
+func leagueKeyOfTransaction(transactionKey string) string {
+	parts := strings.SplitN(transactionKey, ".", 4)
+	if len(parts) < 4 || parts[1] != "l" {
+		return ""
+	}
+	return strings.Join(parts[:3], ".")
+}
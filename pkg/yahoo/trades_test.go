@@ -0,0 +1,110 @@
+package yahoo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type recordedRequest struct {
+	method, path, body string
+}
+
+// newWriteTestClient returns a client whose writes are answered with
+// response and recorded in the returned slice.
+func newWriteTestClient(t *testing.T, response string) (*Client, *[]recordedRequest) {
+	t.Helper()
+	var requests []recordedRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests = append(requests, recordedRequest{r.Method, r.URL.Path, string(body)})
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+	return client, &requests
+}
+
+func TestProposeTrade(t *testing.T) {
+	client, requests := newWriteTestClient(t, `<fantasy_content><transaction><transaction_key>454.l.1.pt.3</transaction_key><type>pending_trade</type><status>proposed</status><trader_team_key>454.l.1.t.1</trader_team_key><tradee_team_key>454.l.1.t.2</tradee_team_key></transaction></fantasy_content>`)
+
+	trans, err := client.ProposeTrade(context.Background(), "454.l.1", TradeProposal{
+		TraderTeamKey:     "454.l.1.t.1",
+		TradeeTeamKey:     "454.l.1.t.2",
+		SendPlayerKeys:    []string{"454.p.10"},
+		ReceivePlayerKeys: []string{"454.p.20", "454.p.21"},
+		Note:              "2 for 1",
+	})
+	if err != nil {
+		t.Fatalf("ProposeTrade() error = %v", err)
+	}
+	if trans.TransactionKey != "454.l.1.pt.3" || trans.Status != "proposed" {
+		t.Errorf("transaction = %+v", trans)
+	}
+
+	req := (*requests)[0]
+	if req.method != http.MethodPost || req.path != "/league/454.l.1/transactions" {
+		t.Errorf("request = %s %s", req.method, req.path)
+	}
+	for _, want := range []string{
+		"<type>pending_trade</type><trader_team_key>454.l.1.t.1</trader_team_key><tradee_team_key>454.l.1.t.2</tradee_team_key><trade_note>2 for 1</trade_note>",
+		"<player><player_key>454.p.10</player_key><transaction_data><type>pending_trade</type><source_team_key>454.l.1.t.1</source_team_key><destination_team_key>454.l.1.t.2</destination_team_key></transaction_data></player>",
+		"<player><player_key>454.p.21</player_key><transaction_data><type>pending_trade</type><source_team_key>454.l.1.t.2</source_team_key><destination_team_key>454.l.1.t.1</destination_team_key></transaction_data></player>",
+	} {
+		if !strings.Contains(req.body, want) {
+			t.Errorf("payload = %s, want it to contain %s", req.body, want)
+		}
+	}
+}
+
+func TestProposeTradeValidation(t *testing.T) {
+	client, requests := newWriteTestClient(t, "")
+	for _, proposal := range []TradeProposal{
+		{TraderTeamKey: "454.l.1.t.1", SendPlayerKeys: []string{"a"}, ReceivePlayerKeys: []string{"b"}},
+		{TraderTeamKey: "454.l.1.t.1", TradeeTeamKey: "454.l.1.t.1", SendPlayerKeys: []string{"a"}, ReceivePlayerKeys: []string{"b"}},
+		{TraderTeamKey: "454.l.1.t.1", TradeeTeamKey: "454.l.1.t.2", SendPlayerKeys: []string{"a"}},
+	} {
+		if _, err := client.ProposeTrade(context.Background(), "454.l.1", proposal); err == nil {
+			t.Errorf("ProposeTrade(%+v) succeeded, want error", proposal)
+		}
+	}
+	if len(*requests) != 0 {
+		t.Errorf("invalid proposals reached Yahoo: %v", *requests)
+	}
+}
+
+func TestRespondToTrade(t *testing.T) {
+	client, requests := newWriteTestClient(t, `<fantasy_content><transaction><transaction_key>454.l.1.pt.3</transaction_key><type>pending_trade</type><status>accepted</status></transaction></fantasy_content>`)
+	ctx := context.Background()
+
+	if _, err := client.AcceptTrade(ctx, "454.l.1.pt.3", "deal"); err != nil {
+		t.Fatalf("AcceptTrade() error = %v", err)
+	}
+	if _, err := client.RejectTrade(ctx, "454.l.1.pt.3", ""); err != nil {
+		t.Fatalf("RejectTrade() error = %v", err)
+	}
+	if err := client.CancelTrade(ctx, "454.l.1.pt.3"); err != nil {
+		t.Fatalf("CancelTrade() error = %v", err)
+	}
+
+	got := *requests
+	if len(got) != 3 {
+		t.Fatalf("requests = %v", got)
+	}
+	if got[0].method != http.MethodPut || got[0].path != "/transaction/454.l.1.pt.3" ||
+		got[0].body != "<fantasy_content><transaction><transaction_key>454.l.1.pt.3</transaction_key><type>pending_trade</type><action>accept</action><trade_note>deal</trade_note></transaction></fantasy_content>" {
+		t.Errorf("accept request = %+v", got[0])
+	}
+	if !strings.Contains(got[1].body, "<action>reject</action>") {
+		t.Errorf("reject request = %+v", got[1])
+	}
+	if got[2].method != http.MethodDelete || got[2].path != "/transaction/454.l.1.pt.3" || got[2].body != "" {
+		t.Errorf("cancel request = %+v", got[2])
+	}
+}
@@ -1,13 +1,103 @@
 package yahoo
 
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
 type Transaction struct {
 	TransactionKey string               `json:"transaction_key"`
 	TransactionID  string               `json:"transaction_id"`
-	Type           string               `json:"type"`
+	Type           TransactionType      `json:"type"`
 	Status         string               `json:"status"`
 	Timestamp      int64                `json:"timestamp"`
 	FAABBid        int                  `json:"faab_bid,omitempty"`
 	Players        []TransactionPlayer  `json:"players"`
+
+	// Pending waiver claims name the claiming team, when the claim is
+	// processed and its priority.
+	WaiverTeamKey  string `json:"waiver_team_key,omitempty"`
+	WaiverDate     string `json:"waiver_date,omitempty"`
+	WaiverPriority int    `json:"waiver_priority,omitempty"`
+
+	// Pending trades name the proposing (trader) and receiving (tradee)
+	// teams.
+	TraderTeamKey     string `json:"trader_team_key,omitempty"`
+	TraderTeamName    string `json:"trader_team_name,omitempty"`
+	TradeeTeamKey     string `json:"tradee_team_key,omitempty"`
+	TradeeTeamName    string `json:"tradee_team_name,omitempty"`
+	TradeProposedTime int64  `json:"trade_proposed_time,omitempty"`
+	TradeNote         string `json:"trade_note,omitempty"`
+}
+
+// TransactionType is the kind of a league transaction. It is also used to
+// filter GetLeagueTransactions.
+type TransactionType string
+
+const (
+	TransactionAdd          TransactionType = "add"
+	TransactionDrop         TransactionType = "drop"
+	TransactionAddDrop      TransactionType = "add/drop"
+	TransactionTrade        TransactionType = "trade"
+	TransactionCommish      TransactionType = "commish"
+	TransactionWaiver       TransactionType = "waiver"
+	TransactionPendingTrade TransactionType = "pending_trade"
+)
+
+// TransactionOption narrows the transactions GetLeagueTransactions returns.
+type TransactionOption func(*transactionQuery)
+
+type transactionQuery struct {
+	types   []TransactionType
+	teamKey string
+	start   int
+	count   int
+}
+
+// WithTransactionTypes returns only transactions of the given types.
+func WithTransactionTypes(types ...TransactionType) TransactionOption {
+	return func(q *transactionQuery) {
+		q.types = append(q.types, types...)
+	}
+}
+
+// WithTransactionTeam returns only transactions involving the given team.
+// Yahoo requires it for the waiver and pending_trade types.
+func WithTransactionTeam(teamKey string) TransactionOption {
+	return func(q *transactionQuery) {
+		q.teamKey = teamKey
+	}
+}
+
+// WithTransactionPage returns count transactions, newest first, skipping the
+// first start. A count of zero or less leaves Yahoo's default in place.
+func WithTransactionPage(start, count int) TransactionOption {
+	return func(q *transactionQuery) {
+		q.start = start
+		q.count = count
+	}
+}
+
+// params renders the query as Yahoo matrix parameters, e.g.
+// ";types=add,drop;team_key=454.l.1.t.1;start=0;count=10".
+func (q transactionQuery) params() string {
+	var b strings.Builder
+	if len(q.types) > 0 {
+		types := make([]string, len(q.types))
+		for i, t := range q.types {
+			types[i] = string(t)
+		}
+		b.WriteString(";types=" + strings.Join(types, ","))
+	}
+	if q.teamKey != "" {
+		b.WriteString(";team_key=" + q.teamKey)
+	}
+	if q.count > 0 {
+		fmt.Fprintf(&b, ";start=%d;count=%d", q.start, q.count)
+	}
+	return b.String()
 }
 
 type TransactionPlayer struct {
@@ -18,34 +108,45 @@ type TransactionPlayer struct {
 }
 
 type TransactionData struct {
-	Type               string `json:"type"`
-	SourceType         string `json:"source_type"`
-	SourceTeamKey      string `json:"source_team_key,omitempty"`
-	SourceTeamName     string `json:"source_team_name,omitempty"`
-	DestinationType    string `json:"destination_type"`
-	DestinationTeamKey string `json:"destination_team_key,omitempty"`
-	DestinationTeamName string `json:"destination_team_name,omitempty"`
+	Type               string `json:"type" xml:"type"`
+	SourceType         string `json:"source_type" xml:"source_type"`
+	SourceTeamKey      string `json:"source_team_key,omitempty" xml:"source_team_key,omitempty"`
+	SourceTeamName     string `json:"source_team_name,omitempty" xml:"source_team_name,omitempty"`
+	DestinationType    string `json:"destination_type" xml:"destination_type"`
+	DestinationTeamKey string `json:"destination_team_key,omitempty" xml:"destination_team_key,omitempty"`
+	DestinationTeamName string `json:"destination_team_name,omitempty" xml:"destination_team_name,omitempty"`
 }
 
 type yahooTransactionsResponse struct {
 	FantasyContent struct {
-		League struct {
-			Transactions []struct {
-				Transaction yahooTransactionData `json:"transaction"`
-			} `json:"transactions"`
-		} `json:"league"`
+		League yahooObject[struct {
+			Transactions yahooList[struct {
+				Transaction yahooObject[yahooTransactionData] `json:"transaction"`
+			}] `json:"transactions"`
+		}] `json:"league"`
 	} `json:"fantasy_content"`
 }
 
 type yahooTransactionData struct {
-	TransactionKey string `json:"transaction_key"`
-	TransactionID  string `json:"transaction_id"`
-	Type           string `json:"type"`
-	Status         string `json:"status"`
-	Timestamp      string `json:"timestamp"`
-	FAABBid        string `json:"faab_bid,omitempty"`
-	Players        []struct {
-		Player struct {
+	TransactionKey string      `json:"transaction_key"`
+	TransactionID  string      `json:"transaction_id"`
+	Type           string      `json:"type"`
+	Status         string      `json:"status"`
+	Timestamp      string      `json:"timestamp"`
+	FAABBid        yahooNumber `json:"faab_bid,omitempty"`
+
+	WaiverTeamKey     string      `json:"waiver_team_key"`
+	WaiverDate        string      `json:"waiver_date"`
+	WaiverPriority    yahooNumber `json:"waiver_priority"`
+	TraderTeamKey     string      `json:"trader_team_key"`
+	TraderTeamName    string      `json:"trader_team_name"`
+	TradeeTeamKey     string      `json:"tradee_team_key"`
+	TradeeTeamName    string      `json:"tradee_team_name"`
+	TradeProposedTime yahooNumber `json:"trade_proposed_time"`
+	TradeNote         string      `json:"trade_note"`
+
+	Players yahooList[struct {
+		Player yahooObject[struct {
 			PlayerKey string `json:"player_key"`
 			PlayerID  string `json:"player_id"`
 			Name      struct {
@@ -64,6 +165,71 @@ type yahooTransactionData struct {
 				DestinationTeamKey  string `json:"destination_team_key,omitempty"`
 				DestinationTeamName string `json:"destination_team_name,omitempty"`
 			} `json:"transaction_data"`
-		} `json:"player"`
-	} `json:"players"`
+		}] `json:"player"`
+	}] `json:"players"`
+}
+
+// transactionPayload is the XML body Yahoo expects for transaction writes.
+// Only the fields a given write needs are set.
+type transactionPayload struct {
+	XMLName     xml.Name `xml:"fantasy_content"`
+	Transaction struct {
+		TransactionKey string                     `xml:"transaction_key,omitempty"`
+		Type           TransactionType            `xml:"type"`
+		Action         string                     `xml:"action,omitempty"`
+		FAABBid        *int                       `xml:"faab_bid,omitempty"`
+		TraderTeamKey  string                     `xml:"trader_team_key,omitempty"`
+		TradeeTeamKey  string                     `xml:"tradee_team_key,omitempty"`
+		VoterTeamKey   string                     `xml:"voter_team_key,omitempty"`
+		TradeNote      string                     `xml:"trade_note,omitempty"`
+		Player         *transactionPayloadPlayer  `xml:"player,omitempty"`
+		Players        *transactionPayloadPlayers `xml:"players,omitempty"`
+	} `xml:"transaction"`
+}
+
+type transactionPayloadPlayers struct {
+	Player []transactionPayloadPlayer `xml:"player"`
+}
+
+type transactionPayloadPlayer struct {
+	PlayerKey       string `xml:"player_key"`
+	TransactionData struct {
+		Type               TransactionType `xml:"type"`
+		SourceTeamKey      string          `xml:"source_team_key,omitempty"`
+		DestinationTeamKey string          `xml:"destination_team_key,omitempty"`
+	} `xml:"transaction_data"`
+}
+
+type yahooTransactionResultXML struct {
+	Transaction yahooTransactionXML `xml:"transaction"`
+}
+
+// submitTransaction sends payload, or no body if it is nil, to endpoint and
+// returns the transaction in Yahoo's response. On success it invalidates the
+// cache entries under the given key prefixes. Rejections come back as a
+// *YahooAPIError matching ErrPlayerNotAvailable, ErrRosterFull or
+// ErrTransactionLimit where Yahoo's description allows.
+func (c *Client) submitTransaction(ctx context.Context, method, endpoint string, payload *transactionPayload, invalidate ...string) (*Transaction, error) {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = xml.Marshal(payload); err != nil {
+			return nil, fmt.Errorf("failed to encode transaction: %w", err)
+		}
+	}
+
+	data, err := c.makeWriteRequest(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, fmt.Errorf("transaction %s failed: %w", strings.ToLower(method), err)
+	}
+	c.invalidateCache(invalidate...)
+
+	var resp yahooTransactionResultXML
+	if len(strings.TrimSpace(string(data))) > 0 {
+		if err := xml.Unmarshal(data, &resp); err != nil {
+			return nil, fmt.Errorf("failed to parse transaction response: %w", err)
+		}
+	}
+	trans := convertYahooTransactionXML(resp.Transaction)
+	return &trans, nil
 }
@@ -0,0 +1,98 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetLeagueTransactionsFilters(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "transactions.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		opts []TransactionOption
+		want string
+	}{
+		{"none", nil, "/league/454.l.1/transactions"},
+		{"types", []TransactionOption{WithTransactionTypes(TransactionAdd, TransactionTrade)}, "/league/454.l.1/transactions;types=add,trade"},
+		{"team and page", []TransactionOption{
+			WithTransactionTypes(TransactionWaiver),
+			WithTransactionTeam("454.l.1.t.3"),
+			WithTransactionPage(10, 5),
+		}, "/league/454.l.1/transactions;types=waiver;team_key=454.l.1.t.3;start=10;count=5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths = nil
+			transactions, err := client.GetLeagueTransactions(ctx, "454.l.1", tt.opts...)
+			if err != nil {
+				t.Fatalf("GetLeagueTransactions() error = %v", err)
+			}
+			if len(paths) != 1 || !strings.HasSuffix(paths[0], tt.want) {
+				t.Errorf("request paths = %v, want suffix %s", paths, tt.want)
+			}
+			if len(transactions) == 0 || transactions[0].Type == "" {
+				t.Errorf("transactions = %+v", transactions)
+			}
+		})
+	}
+}
+
+func TestGetPendingTransactions(t *testing.T) {
+	body, err := os.ReadFile(filepath.Join("testdata", "pending_transactions.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		w.Write(body)
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	pending, err := client.GetPendingTransactions(context.Background(), "454.l.1", "454.l.1.t.3")
+	if err != nil {
+		t.Fatalf("GetPendingTransactions() error = %v", err)
+	}
+
+	if want := "/league/454.l.1/transactions;types=waiver,pending_trade;team_key=454.l.1.t.3"; !strings.HasSuffix(gotPath, want) {
+		t.Errorf("request path = %s, want suffix %s", gotPath, want)
+	}
+	if len(pending) != 2 {
+		t.Fatalf("pending = %+v", pending)
+	}
+
+	claim := pending[0]
+	if claim.Type != TransactionWaiver || claim.WaiverTeamKey != "454.l.1.t.3" || claim.WaiverPriority != 4 || claim.FAABBid != 12 {
+		t.Errorf("claim = %+v", claim)
+	}
+	if len(claim.Players) != 1 || claim.Players[0].Name.Full != "Claimed Guy" {
+		t.Errorf("claim players = %+v", claim.Players)
+	}
+
+	trade := pending[1]
+	if trade.Type != TransactionPendingTrade || trade.TradeeTeamKey != "454.l.1.t.5" || trade.TradeProposedTime != 1737000000 || trade.TradeNote != "Fair?" {
+		t.Errorf("trade = %+v", trade)
+	}
+}
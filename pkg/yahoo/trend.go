@@ -0,0 +1,70 @@
+package yahoo
+
+import (
+	"math"
+	"sort"
+)
+
+// TrendDirection says whether a stat is rising or falling. It says nothing
+// about whether that is good: a rising "TO" is a falling player.
+type TrendDirection string
+
+const (
+	TrendUp   TrendDirection = "up"
+	TrendDown TrendDirection = "down"
+	TrendFlat TrendDirection = "flat"
+)
+
+// StatTrend compares recent production in one stat with the season to date.
+type StatTrend struct {
+	Stat   string  `json:"stat"`
+	Season float64 `json:"season"`
+	Recent float64 `json:"recent"`
+	Change float64 `json:"change"`
+	// PercentChange is Change relative to Season, e.g. 0.25 for 25% higher.
+	// It is 0 when Season is 0.
+	PercentChange float64        `json:"percent_change"`
+	Direction     TrendDirection `json:"direction"`
+}
+
+// CompareTrend compares a season-to-date line with a line covering the last
+// few weeks, stat by stat, for the stats both lines have. Pass per-game
+// lines (see NBAStats.PerGame and StatLine.PerGame) so the comparison is
+// not skewed by the number of games. Changes within threshold of the season
+// value, as a fraction such as 0.1 for 10%, are reported as TrendFlat.
+// Trends are sorted by the size of the relative change, largest first.
+func CompareTrend(seasonToDate, lastNWeeks StatLine, threshold float64) []StatTrend {
+	var trends []StatTrend
+	for name, season := range seasonToDate {
+		recent, ok := lastNWeeks[name]
+		if !ok || name == "GP" {
+			continue
+		}
+		trend := StatTrend{
+			Stat:      name,
+			Season:    season,
+			Recent:    recent,
+			Change:    recent - season,
+			Direction: TrendFlat,
+		}
+		if season != 0 {
+			trend.PercentChange = trend.Change / math.Abs(season)
+		}
+		switch {
+		case season == 0 && recent > 0, season != 0 && trend.PercentChange > threshold:
+			trend.Direction = TrendUp
+		case season == 0 && recent < 0, season != 0 && trend.PercentChange < -threshold:
+			trend.Direction = TrendDown
+		}
+		trends = append(trends, trend)
+	}
+
+	sort.Slice(trends, func(i, j int) bool {
+		ci, cj := math.Abs(trends[i].PercentChange), math.Abs(trends[j].PercentChange)
+		if ci != cj {
+			return ci > cj
+		}
+		return trends[i].Stat < trends[j].Stat
+	})
+	return trends
+}
@@ -0,0 +1,57 @@
+package yahoo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPerGame(t *testing.T) {
+	nba := &NBAStats{GamesPlayed: 10, Points: 250, Rebounds: 80, FGPercent: 0.5}
+	perGame := nba.PerGame()
+	if perGame["PTS"] != 25 || perGame["REB"] != 8 || perGame["FG%"] != 0.5 {
+		t.Errorf("NBAStats.PerGame() = %v", perGame)
+	}
+	if got := (&NBAStats{Points: 10}).PerGame(); len(got) != 0 {
+		t.Errorf("PerGame() without games = %v, want empty", got)
+	}
+
+	nfl := &NFLStats{GamesPlayed: 4, PassYards: 1100, PassTD: 8, DefenseInterceptions: 2}
+	nflPerGame := nfl.PerGame()
+	if nflPerGame["Pass Yds"] != 275 || nflPerGame["Pass TD"] != 2 || nflPerGame["DEF Int"] != 0.5 {
+		t.Errorf("NFLStats.PerGame() = %v", nflPerGame)
+	}
+	if got := (&NFLStats{PassYards: 300}).PerGame(); len(got) != 0 {
+		t.Errorf("PerGame() without games = %v, want empty", got)
+	}
+}
+
+func TestCompareTrend(t *testing.T) {
+	season := StatLine{"GP": 40, "PTS": 20, "REB": 10, "AST": 5, "BLK": 0, "ST": 1}
+	recent := StatLine{"GP": 8, "PTS": 26, "REB": 9.5, "AST": 3, "BLK": 0.5}
+
+	trends := CompareTrend(season, recent, 0.1)
+	if len(trends) != 4 {
+		t.Fatalf("CompareTrend() = %+v", trends)
+	}
+
+	byStat := make(map[string]StatTrend)
+	for _, tr := range trends {
+		byStat[tr.Stat] = tr
+	}
+	if tr := byStat["PTS"]; tr.Direction != TrendUp || tr.Change != 6 || math.Abs(tr.PercentChange-0.3) > 1e-9 {
+		t.Errorf("PTS trend = %+v", tr)
+	}
+	if tr := byStat["REB"]; tr.Direction != TrendFlat {
+		t.Errorf("REB trend = %+v", tr)
+	}
+	if tr := byStat["AST"]; tr.Direction != TrendDown {
+		t.Errorf("AST trend = %+v", tr)
+	}
+	if tr := byStat["BLK"]; tr.Direction != TrendUp || tr.PercentChange != 0 {
+		t.Errorf("BLK trend = %+v", tr)
+	}
+
+	if trends[0].Stat != "AST" || trends[1].Stat != "PTS" {
+		t.Errorf("trends not sorted by relative change: %+v", trends)
+	}
+}
@@ -0,0 +1,79 @@
+package yahoo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const defaultUserInfoURL = "https://api.login.yahoo.com/openid/v1/userinfo"
+
+// User identifies the Yahoo account the client is authenticated as.
+type User struct {
+	GUID            string `json:"guid"`
+	Nickname        string `json:"nickname,omitempty"`
+	Name            string `json:"name,omitempty"`
+	Email           string `json:"email,omitempty"`
+	ProfileImageURL string `json:"profile_image_url,omitempty"`
+}
+
+type yahooUserInfoResponse struct {
+	Sub      string `json:"sub"`
+	Nickname string `json:"nickname"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Picture  string `json:"picture"`
+}
+
+type yahooCurrentUserResponse struct {
+	FantasyContent struct {
+		Users yahooList[struct {
+			User yahooList[struct {
+				GUID string `json:"guid"`
+			}] `json:"user"`
+		}] `json:"users"`
+	} `json:"fantasy_content"`
+}
+
+// GetCurrentUser returns the logged-in user's profile from Yahoo's OpenID
+// userinfo endpoint. Tokens issued without the openid scope cannot read the
+// profile, in which case only the GUID is resolved via the fantasy API.
+func (c *Client) GetCurrentUser(ctx context.Context) (*User, error) {
+	data, err := c.doAuthorizedGet(ctx, c.userInfoURL)
+	if err == nil {
+		var resp yahooUserInfoResponse
+		if err := json.Unmarshal(data, &resp); err == nil && resp.Sub != "" {
+			return &User{
+				GUID:            resp.Sub,
+				Nickname:        resp.Nickname,
+				Name:            resp.Name,
+				Email:           resp.Email,
+				ProfileImageURL: resp.Picture,
+			}, nil
+		}
+	}
+
+	return c.fetchCurrentUserGUID(ctx)
+}
+
+func (c *Client) fetchCurrentUserGUID(ctx context.Context) (*User, error) {
+	data, err := c.makeRequest(ctx, "users;use_login=1")
+	if err != nil {
+		return nil, err
+	}
+
+	var resp yahooCurrentUserResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse user response: %w", err)
+	}
+
+	for _, user := range resp.FantasyContent.Users {
+		for _, userItem := range user.User {
+			if userItem.GUID != "" {
+				return &User{GUID: userItem.GUID}, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("user response did not contain a GUID")
+}
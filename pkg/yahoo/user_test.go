@@ -0,0 +1,69 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetCurrentUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"sub":"ABCDEF","nickname":"hoops","name":"Jordan Smith","picture":"https://img.example.com/p.png"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.userInfoURL = server.URL
+
+	user, err := client.GetCurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentUser() error = %v", err)
+	}
+	if user.GUID != "ABCDEF" {
+		t.Errorf("GUID = %v, want %v", user.GUID, "ABCDEF")
+	}
+	if user.Nickname != "hoops" {
+		t.Errorf("Nickname = %v, want %v", user.Nickname, "hoops")
+	}
+	if user.ProfileImageURL != "https://img.example.com/p.png" {
+		t.Errorf("ProfileImageURL = %v, want %v", user.ProfileImageURL, "https://img.example.com/p.png")
+	}
+}
+
+func TestGetCurrentUserFallsBackToFantasyAPI(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/userinfo" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"fantasy_content":{"users":[{"user":[{"guid":"GUID123"}]}]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithInitialToken(Token{AccessToken: "token"}))
+	client.userInfoURL = server.URL + "/userinfo"
+	client.baseURL = server.URL
+
+	user, err := client.GetCurrentUser(context.Background())
+	if err != nil {
+		t.Fatalf("GetCurrentUser() error = %v", err)
+	}
+	if user.GUID != "GUID123" {
+		t.Errorf("GUID = %v, want %v", user.GUID, "GUID123")
+	}
+}
+
+func TestStandingsTeamByManagerGUID(t *testing.T) {
+	standings := &Standings{Teams: []StandingsTeam{
+		{TeamKey: "t.1", Managers: []Manager{{GUID: "A"}}},
+		{TeamKey: "t.2", Managers: []Manager{{GUID: "B"}}},
+	}}
+
+	if team := standings.TeamByManagerGUID("B"); team == nil || team.TeamKey != "t.2" {
+		t.Errorf("TeamByManagerGUID(B) = %v, want t.2", team)
+	}
+	if team := standings.TeamByManagerGUID("C"); team != nil {
+		t.Errorf("TeamByManagerGUID(C) = %v, want nil", team)
+	}
+}
@@ -0,0 +1,322 @@
+package yahoo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+// ResponseFormat selects the representation requested from Yahoo with the
+// format query parameter.
+type ResponseFormat string
+
+const (
+	FormatJSON ResponseFormat = "json"
+	FormatXML  ResponseFormat = "xml"
+)
+
+// Yahoo's XML documents are rooted at <fantasy_content>; the paths below are
+// relative to it.
+
+type yahooLeaguesXML struct {
+	Leagues []struct {
+		LeagueID    string `xml:"league_id"`
+		Name        string `xml:"name"`
+		Season      string `xml:"season"`
+		ScoringType string `xml:"scoring_type"`
+		NumTeams    int    `xml:"num_teams"`
+		CurrentWeek int    `xml:"current_week"`
+		Renew       string `xml:"renew"`
+		Renewed     string `xml:"renewed"`
+	} `xml:"users>user>games>game>leagues>league"`
+}
+
+type yahooPlayersXML struct {
+	Players []yahooPlayerXML `xml:"league>players>player"`
+}
+
+type yahooPlayerXML struct {
+	PlayerKey             string     `xml:"player_key"`
+	PlayerID              string     `xml:"player_id"`
+	Name                  PlayerName `xml:"name"`
+	EditorialTeamKey      string     `xml:"editorial_team_key"`
+	EditorialTeamFullName string     `xml:"editorial_team_full_name"`
+	EditorialTeamAbbr     string     `xml:"editorial_team_abbr"`
+	DisplayPosition       string     `xml:"display_position"`
+	EligiblePositions     []string   `xml:"eligible_positions>position"`
+	SelectedPosition      *struct {
+		Position string `xml:"position"`
+	} `xml:"selected_position"`
+	PlayerStats *struct {
+		CoverageType string `xml:"coverage_type"`
+		Week         string `xml:"week"`
+		Stats        []struct {
+			StatID int    `xml:"stat_id"`
+			Value  string `xml:"value"`
+		} `xml:"stats>stat"`
+	} `xml:"player_stats"`
+	PlayerPoints *struct {
+		CoverageType string `xml:"coverage_type"`
+		Week         string `xml:"week"`
+		Total        string `xml:"total"`
+	} `xml:"player_points"`
+
+	Status                   string `xml:"status"`
+	StatusFull               string `xml:"status_full"`
+	InjuryNote               string `xml:"injury_note"`
+	HasPlayerNotes           string `xml:"has_player_notes"`
+	PlayerNotesLastTimestamp int64  `xml:"player_notes_last_timestamp"`
+}
+
+type yahooStandingsXML struct {
+	Teams []struct {
+		TeamKey  string `xml:"team_key"`
+		TeamID   string `xml:"team_id"`
+		Name     string `xml:"name"`
+		Managers []struct {
+			ManagerID      string `xml:"manager_id"`
+			Nickname       string `xml:"nickname"`
+			GUID           string `xml:"guid"`
+			IsCommissioner string `xml:"is_commissioner"`
+			IsCurrentLogin string `xml:"is_current_login"`
+		} `xml:"managers>manager"`
+		yahooTeamMetadataXML
+		TeamStandings struct {
+			Rank          int    `xml:"rank"`
+			PlayoffSeed   int    `xml:"playoff_seed"`
+			PointsFor     string `xml:"points_for"`
+			PointsAgainst string `xml:"points_against"`
+			GamesBack     string `xml:"games_back"`
+			OutcomeTotals struct {
+				Wins       int    `xml:"wins"`
+				Losses     int    `xml:"losses"`
+				Ties       int    `xml:"ties"`
+				Percentage string `xml:"percentage"`
+			} `xml:"outcome_totals"`
+			DivisionalOutcomeTotals *struct {
+				Wins   int `xml:"wins"`
+				Losses int `xml:"losses"`
+				Ties   int `xml:"ties"`
+			} `xml:"divisional_outcome_totals"`
+			Streak *struct {
+				Type  string `xml:"type"`
+				Value int    `xml:"value"`
+			} `xml:"streak"`
+		} `xml:"team_standings"`
+	} `xml:"league>standings>teams>team"`
+}
+
+type yahooTransactionsXML struct {
+	Transactions []yahooTransactionXML `xml:"league>transactions>transaction"`
+}
+
+type yahooTransactionXML struct {
+	TransactionKey string `xml:"transaction_key"`
+	TransactionID  string `xml:"transaction_id"`
+	Type           string `xml:"type"`
+	Status         string `xml:"status"`
+	Timestamp      int64  `xml:"timestamp"`
+	FAABBid        int    `xml:"faab_bid"`
+
+	WaiverTeamKey     string `xml:"waiver_team_key"`
+	WaiverDate        string `xml:"waiver_date"`
+	WaiverPriority    int    `xml:"waiver_priority"`
+	TraderTeamKey     string `xml:"trader_team_key"`
+	TraderTeamName    string `xml:"trader_team_name"`
+	TradeeTeamKey     string `xml:"tradee_team_key"`
+	TradeeTeamName    string `xml:"tradee_team_name"`
+	TradeProposedTime int64  `xml:"trade_proposed_time"`
+	TradeNote         string `xml:"trade_note"`
+
+	Players []struct {
+		PlayerKey       string          `xml:"player_key"`
+		PlayerID        string          `xml:"player_id"`
+		Name            PlayerName      `xml:"name"`
+		TransactionData TransactionData `xml:"transaction_data"`
+	} `xml:"players>player"`
+}
+
+func decodeLeaguesXML(data []byte, gameKey string) ([]League, error) {
+	var resp yahooLeaguesXML
+	if err := xml.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse leagues XML response: %w", err)
+	}
+
+	var leagues []League
+	for _, l := range resp.Leagues {
+		season, _ := strconv.Atoi(l.Season)
+		leagues = append(leagues, League{
+			YahooLeagueID: l.LeagueID,
+			YahooGameKey:  gameKey,
+			LeagueName:    l.Name,
+			SeasonYear:    season,
+			ScoringType:   l.ScoringType,
+			NumTeams:      l.NumTeams,
+			CurrentWeek:   l.CurrentWeek,
+			Renew:         renewLeagueKey(l.Renew),
+			Renewed:       renewLeagueKey(l.Renewed),
+		})
+	}
+	return leagues, nil
+}
+
+func decodePlayersXML(data []byte) ([]Player, error) {
+	var resp yahooPlayersXML
+	if err := xml.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse players XML response: %w", err)
+	}
+
+	var players []Player
+	for _, p := range resp.Players {
+		players = append(players, p.toPlayer())
+	}
+	return players, nil
+}
+
+func (p yahooPlayerXML) toPlayer() Player {
+	player := Player{
+		PlayerKey:             p.PlayerKey,
+		PlayerID:              p.PlayerID,
+		Name:                  p.Name,
+		EditorialTeamKey:      p.EditorialTeamKey,
+		EditorialTeamFullName: p.EditorialTeamFullName,
+		EditorialTeamAbbr:     p.EditorialTeamAbbr,
+		DisplayPosition:       p.DisplayPosition,
+		EligiblePositions:     p.EligiblePositions,
+
+		Status:                   p.Status,
+		StatusFull:               p.StatusFull,
+		InjuryNote:               p.InjuryNote,
+		HasPlayerNotes:           p.HasPlayerNotes == "1",
+		PlayerNotesLastTimestamp: p.PlayerNotesLastTimestamp,
+	}
+
+	if p.SelectedPosition != nil {
+		player.SelectedPosition = SelectedPosition{Position: p.SelectedPosition.Position}
+	}
+
+	if p.PlayerStats != nil {
+		week, _ := strconv.Atoi(p.PlayerStats.Week)
+		var stats []Stat
+		for _, s := range p.PlayerStats.Stats {
+			stats = append(stats, Stat{StatID: s.StatID, Value: s.Value})
+		}
+		player.PlayerStats = &PlayerStats{
+			CoverageType: p.PlayerStats.CoverageType,
+			Week:         week,
+			Stats:        normalizeStats(stats),
+		}
+	}
+
+	if p.PlayerPoints != nil {
+		week, _ := strconv.Atoi(p.PlayerPoints.Week)
+		total, _ := strconv.ParseFloat(p.PlayerPoints.Total, 64)
+		player.PlayerPoints = &PlayerPoints{
+			CoverageType: p.PlayerPoints.CoverageType,
+			Week:         week,
+			Total:        total,
+		}
+	}
+
+	return player
+}
+
+func decodeStandingsXML(data []byte) (*Standings, error) {
+	var resp yahooStandingsXML
+	if err := xml.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse standings XML response: %w", err)
+	}
+
+	var teams []StandingsTeam
+	for _, t := range resp.Teams {
+		ts := t.TeamStandings
+		percentage, _ := strconv.ParseFloat(ts.OutcomeTotals.Percentage, 64)
+		pointsFor, _ := strconv.ParseFloat(ts.PointsFor, 64)
+		pointsAgainst, _ := strconv.ParseFloat(ts.PointsAgainst, 64)
+
+		team := StandingsTeam{
+			TeamKey:      t.TeamKey,
+			TeamID:       t.TeamID,
+			Name:         t.Name,
+			TeamMetadata: t.toMetadata(),
+			TeamStandings: TeamStandings{
+				Rank:        ts.Rank,
+				PlayoffSeed: ts.PlayoffSeed,
+				OutcomeTotals: OutcomeTotals{
+					Wins:       ts.OutcomeTotals.Wins,
+					Losses:     ts.OutcomeTotals.Losses,
+					Ties:       ts.OutcomeTotals.Ties,
+					Percentage: percentage,
+				},
+				PointsFor:     pointsFor,
+				PointsAgainst: pointsAgainst,
+				GamesBack:     ts.GamesBack,
+			},
+		}
+		if d := ts.DivisionalOutcomeTotals; d != nil {
+			team.TeamStandings.DivisionalOutcomeTotals = newOutcomeTotals(d.Wins, d.Losses, d.Ties)
+		}
+		if ts.Streak != nil {
+			team.TeamStandings.Streak = &Streak{Type: ts.Streak.Type, Value: ts.Streak.Value}
+		}
+
+		for _, m := range t.Managers {
+			team.Managers = append(team.Managers, Manager{
+				ManagerID:      m.ManagerID,
+				Nickname:       m.Nickname,
+				GUID:           m.GUID,
+				IsCommissioner: m.IsCommissioner == "1",
+				IsCurrentLogin: m.IsCurrentLogin == "1",
+			})
+		}
+		if len(team.Managers) > 0 {
+			team.ManagerNickname = team.Managers[0].Nickname
+		}
+
+		teams = append(teams, team)
+	}
+	return &Standings{Teams: teams}, nil
+}
+
+func decodeTransactionsXML(data []byte) ([]Transaction, error) {
+	var resp yahooTransactionsXML
+	if err := xml.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse transactions XML response: %w", err)
+	}
+
+	var transactions []Transaction
+	for _, t := range resp.Transactions {
+		transactions = append(transactions, convertYahooTransactionXML(t))
+	}
+	return transactions, nil
+}
+
+func convertYahooTransactionXML(t yahooTransactionXML) Transaction {
+	trans := Transaction{
+		TransactionKey: t.TransactionKey,
+		TransactionID:  t.TransactionID,
+		Type:           TransactionType(t.Type),
+		Status:         t.Status,
+		Timestamp:      t.Timestamp,
+		FAABBid:        t.FAABBid,
+
+		WaiverTeamKey:     t.WaiverTeamKey,
+		WaiverDate:        t.WaiverDate,
+		WaiverPriority:    t.WaiverPriority,
+		TraderTeamKey:     t.TraderTeamKey,
+		TraderTeamName:    t.TraderTeamName,
+		TradeeTeamKey:     t.TradeeTeamKey,
+		TradeeTeamName:    t.TradeeTeamName,
+		TradeProposedTime: t.TradeProposedTime,
+		TradeNote:         t.TradeNote,
+	}
+	for _, p := range t.Players {
+		trans.Players = append(trans.Players, TransactionPlayer{
+			PlayerKey:       p.PlayerKey,
+			PlayerID:        p.PlayerID,
+			Name:            p.Name,
+			TransactionData: p.TransactionData,
+		})
+	}
+	return trans
+}
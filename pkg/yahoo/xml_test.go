@@ -0,0 +1,156 @@
+package yahoo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8"?>
+<fantasy_content xmlns="http://fantasysports.yahooapis.com/fantasy/v2/base.rng" xml:lang="en-US">`
+
+func TestDecodeLeaguesXML(t *testing.T) {
+	data := []byte(xmlHeader + `
+<users count="1"><user><guid>ABC</guid><games count="2">
+  <game><game_key>454</game_key><leagues count="1">
+    <league><league_key>454.l.1</league_key><league_id>1</league_id><name>Hoops</name><season>2024</season><scoring_type>head</scoring_type><num_teams>12</num_teams><current_week>5</current_week></league>
+  </leagues></game>
+  <game><game_key>466</game_key><leagues count="1">
+    <league><league_key>466.l.2</league_key><league_id>2</league_id><name>Dynasty</name><season>2025</season><scoring_type>point</scoring_type><num_teams>10</num_teams><current_week>1</current_week></league>
+  </leagues></game>
+</games></user></users></fantasy_content>`)
+
+	leagues, err := decodeLeaguesXML(data, "nba")
+	if err != nil {
+		t.Fatalf("decodeLeaguesXML() error = %v", err)
+	}
+	if len(leagues) != 2 {
+		t.Fatalf("expected 2 leagues, got %d", len(leagues))
+	}
+	want := League{YahooLeagueID: "2", YahooGameKey: "nba", LeagueName: "Dynasty", SeasonYear: 2025, ScoringType: "point", NumTeams: 10, CurrentWeek: 1}
+	if leagues[1] != want {
+		t.Errorf("leagues[1] = %+v, want %+v", leagues[1], want)
+	}
+}
+
+func TestDecodePlayersXML(t *testing.T) {
+	data := []byte(xmlHeader + `
+<league><players count="1"><player>
+  <player_key>454.p.5352</player_key><player_id>5352</player_id>
+  <name><full>Stephen Curry</full><first>Stephen</first><last>Curry</last><ascii_first>Stephen</ascii_first><ascii_last>Curry</ascii_last></name>
+  <editorial_team_abbr>GS</editorial_team_abbr><display_position>PG</display_position>
+  <eligible_positions><position>PG</position><position>G</position><position>Util</position></eligible_positions>
+  <selected_position><coverage_type>date</coverage_type><position>BN</position></selected_position>
+  <player_stats><coverage_type>week</coverage_type><week>3</week><stats>
+    <stat><stat_id>12</stat_id><value>130</value></stat>
+    <stat><stat_id>10</stat_id><value>21</value></stat>
+  </stats></player_stats>
+</player></players></league></fantasy_content>`)
+
+	players, err := decodePlayersXML(data)
+	if err != nil {
+		t.Fatalf("decodePlayersXML() error = %v", err)
+	}
+	if len(players) != 1 {
+		t.Fatalf("expected 1 player, got %d", len(players))
+	}
+	p := players[0]
+	if p.Name.Full != "Stephen Curry" || p.Name.ASCIILast != "Curry" {
+		t.Errorf("Name = %+v", p.Name)
+	}
+	if len(p.EligiblePositions) != 3 || p.EligiblePositions[2] != "Util" {
+		t.Errorf("EligiblePositions = %v", p.EligiblePositions)
+	}
+	if p.SelectedPosition.Position != "BN" {
+		t.Errorf("SelectedPosition = %v, want BN", p.SelectedPosition.Position)
+	}
+	if p.PlayerStats == nil || p.PlayerStats.Week != 3 || len(p.PlayerStats.Stats) != 2 {
+		t.Fatalf("PlayerStats = %+v", p.PlayerStats)
+	}
+	if s := p.PlayerStats.Stats[1]; s.StatID != 10 || s.Value != "21" {
+		t.Errorf("Stats[1] = %+v", s)
+	}
+}
+
+func TestDecodeStandingsXML(t *testing.T) {
+	data := []byte(xmlHeader + `
+<league><standings><teams count="1"><team>
+  <team_key>454.l.1.t.3</team_key><team_id>3</team_id><name>Splash</name>
+  <managers><manager><manager_id>3</manager_id><nickname>Sam</nickname><guid>GUID3</guid><is_commissioner>1</is_commissioner></manager></managers>
+  <team_standings><rank>1</rank><playoff_seed></playoff_seed>
+    <outcome_totals><wins>8</wins><losses>2</losses><ties>0</ties><percentage>.800</percentage></outcome_totals>
+    <streak><type>win</type><value>4</value></streak>
+    <points_for>1020.5</points_for><points_against>900.25</points_against><games_back>-</games_back>
+  </team_standings>
+</team></teams></standings></league></fantasy_content>`)
+
+	standings, err := decodeStandingsXML(data)
+	if err != nil {
+		t.Fatalf("decodeStandingsXML() error = %v", err)
+	}
+	if len(standings.Teams) != 1 {
+		t.Fatalf("expected 1 team, got %d", len(standings.Teams))
+	}
+	team := standings.Teams[0]
+	if team.TeamStandings.OutcomeTotals.Wins != 8 || team.TeamStandings.OutcomeTotals.Percentage != 0.8 {
+		t.Errorf("OutcomeTotals = %+v", team.TeamStandings.OutcomeTotals)
+	}
+	if team.TeamStandings.PointsFor != 1020.5 {
+		t.Errorf("PointsFor = %v, want 1020.5", team.TeamStandings.PointsFor)
+	}
+	if team.TeamStandings.Streak == nil || team.TeamStandings.Streak.Value != 4 {
+		t.Errorf("Streak = %+v", team.TeamStandings.Streak)
+	}
+	if team.ManagerNickname != "Sam" || !team.Managers[0].IsCommissioner {
+		t.Errorf("Managers = %+v", team.Managers)
+	}
+}
+
+func TestDecodeTransactionsXML(t *testing.T) {
+	data := []byte(xmlHeader + `
+<league><transactions count="1"><transaction>
+  <transaction_key>454.l.1.tr.7</transaction_key><transaction_id>7</transaction_id><type>add/drop</type><status>successful</status><timestamp>1700000000</timestamp><faab_bid>12</faab_bid>
+  <players count="2">
+    <player><player_key>454.p.1</player_key><player_id>1</player_id><name><full>Added Guy</full></name>
+      <transaction_data><type>add</type><source_type>freeagents</source_type><destination_type>team</destination_type><destination_team_key>454.l.1.t.3</destination_team_key></transaction_data></player>
+    <player><player_key>454.p.2</player_key><player_id>2</player_id><name><full>Dropped Guy</full></name>
+      <transaction_data><type>drop</type><source_type>team</source_type><source_team_key>454.l.1.t.3</source_team_key><destination_type>waivers</destination_type></transaction_data></player>
+  </players>
+</transaction></transactions></league></fantasy_content>`)
+
+	transactions, err := decodeTransactionsXML(data)
+	if err != nil {
+		t.Fatalf("decodeTransactionsXML() error = %v", err)
+	}
+	if len(transactions) != 1 {
+		t.Fatalf("expected 1 transaction, got %d", len(transactions))
+	}
+	tr := transactions[0]
+	if tr.Timestamp != 1700000000 || tr.FAABBid != 12 || len(tr.Players) != 2 {
+		t.Fatalf("transaction = %+v", tr)
+	}
+	if d := tr.Players[1].TransactionData; d.Type != "drop" || d.SourceTeamKey != "454.l.1.t.3" {
+		t.Errorf("Players[1].TransactionData = %+v", d)
+	}
+}
+
+func TestWithResponseFormatRequestsXML(t *testing.T) {
+	var gotFormat, gotAccept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotFormat = r.URL.Query().Get("format")
+		gotAccept = r.Header.Get("Accept")
+		w.Write([]byte(xmlHeader + `<league><standings><teams count="0"></teams></standings></league></fantasy_content>`))
+	}))
+	defer server.Close()
+
+	client := NewClient("key", "secret", nil, WithResponseFormat(FormatXML), WithInitialToken(Token{AccessToken: "token"}))
+	client.baseURL = server.URL
+
+	if _, err := client.GetLeagueStandings(context.Background(), "454.l.1"); err != nil {
+		t.Fatalf("GetLeagueStandings() error = %v", err)
+	}
+	if gotFormat != "xml" || gotAccept != "application/xml" {
+		t.Errorf("format = %q, Accept = %q; want xml, application/xml", gotFormat, gotAccept)
+	}
+}